@@ -4,10 +4,12 @@ import (
 	"log"
 
 	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/authority"
 )
 
 func main() {
+	ctlruntime.AddFlags()
 	// Set kubeconfig to be used to create clientsets
 	bootstrap.SetKubeConfig()
 	clientset, err := bootstrap.CreateClientSet()
@@ -0,0 +1,237 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The edgenet-manager hosts any subset of the EdgeNet controllers in a single process.
+// Several replicas can run at the same time, a lease elects the one that runs the controllers.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1/nodelabeler"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/acceptableusepolicy"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/authority"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/authorityrequest"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/emailverification"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/nodecontribution"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/selectivedeployment"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/slice"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/team"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/totalresourcequota"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/user"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/userregistrationrequest"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// The controllers that the manager is able to host, named after their former binaries
+var controllers = map[string]func(manager *ctlruntime.Manager){
+	"acceptableusepolicy":     acceptableusepolicy.Register,
+	"authority":               authority.Register,
+	"authorityrequest":        authorityrequest.Register,
+	"emailverification":       emailverification.Register,
+	"nodecontribution":        nodecontribution.Register,
+	"nodelabeler":             nodelabeler.Register,
+	"selectivedeployment":     selectivedeployment.Register,
+	"slice":                   slice.Register,
+	"team":                    team.Register,
+	"totalresourcequota":      totalresourcequota.Register,
+	"user":                    user.Register,
+	"userregistrationrequest": userregistrationrequest.Register,
+}
+
+func main() {
+	var enabled string
+	var leaderElect bool
+	var leaseName, leaseNamespace string
+	var workloadKinds string
+	flag.StringVar(&enabled, "controllers", "*", fmt.Sprintf("comma-separated list of controllers to run, * for all of them: %s", strings.Join(controllerNames(), ",")))
+	flag.BoolVar(&leaderElect, "leader-elect", true, "elect a leader among the replicas before running the controllers")
+	flag.StringVar(&leaseName, "leader-election-id", "edgenet-manager", "name of the lease used for the leader election")
+	flag.StringVar(&leaseNamespace, "leader-election-namespace", "kube-system", "namespace of the lease used for the leader election")
+	ctlruntime.AddFlags()
+	flag.StringVar(&workloadKinds, "workload-kinds", "", "path of a file declaring the kinds of workloads that selective deployments manage besides the built-in ones")
+	node.SetGeolocationFlags()
+	// Set kubeconfig to be used to create clientsets
+	bootstrap.SetKubeConfig()
+	registrations, err := selectControllers(enabled)
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	if workloadKinds != "" {
+		if err := selectivedeployment.LoadWorkloadKinds(workloadKinds); err != nil {
+			log.Println(err.Error())
+			panic(err.Error())
+		}
+	}
+	clientset, err := bootstrap.CreateClientSet()
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	edgenetClientset, err := bootstrap.CreateEdgeNetClientSet()
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	dynamicClientset, err := bootstrap.CreateDynamicClientSet()
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+
+	stopCh := ctlruntime.SetupSignalHandler()
+	// The probes are served from the start, whereas a standby replica remains unready as it runs no controllers
+	var leading atomic.Value
+	if ctlruntime.MetricsAddress != "" {
+		go ctlruntime.Serve(ctlruntime.MetricsAddress, func() bool {
+			manager, ok := leading.Load().(*ctlruntime.Manager)
+			return ok && manager.Ready()
+		}, stopCh)
+	}
+	// client-go runs the callback of the leadership in a goroutine of its own, so done tells main
+	// when the manager has drained its workers and procedures. Once main stops waiting for the
+	// leadership, a callback that comes late no longer starts the controllers.
+	var lifecycle sync.Mutex
+	var started, stopping bool
+	done := make(chan struct{})
+	// The controllers are registered only once the leadership is acquired, so that the standby
+	// replicas neither create roles nor fill their caches
+	run := func(ctx context.Context) {
+		lifecycle.Lock()
+		if stopping {
+			lifecycle.Unlock()
+			return
+		}
+		started = true
+		lifecycle.Unlock()
+		defer close(done)
+		manager := ctlruntime.NewManager(clientset, edgenetClientset)
+		manager.MetricsAddress = ""
+		manager.UseDynamicClientset(dynamicClientset)
+		for _, register := range registrations {
+			register(manager)
+		}
+		leading.Store(manager)
+		manager.Run(ctx.Done())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+	if !leaderElect {
+		run(ctx)
+		return
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaseName,
+			Namespace: leaseNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: run,
+			OnStoppedLeading: func() {
+				// The controllers cannot be stopped cleanly in the middle of their work, a restart brings the replica back as a standby
+				log.Printf("%s lost the leadership", identity)
+				if ctx.Err() == nil {
+					os.Exit(1)
+				}
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					log.Printf("%s is the leader", leader)
+				}
+			},
+		},
+	})
+	lifecycle.Lock()
+	stopping = true
+	leader := started
+	lifecycle.Unlock()
+	if leader {
+		<-done
+	}
+}
+
+// selectControllers turns the value of the controllers flag into the registration functions
+func selectControllers(enabled string) ([]func(manager *ctlruntime.Manager), error) {
+	registrations := []func(manager *ctlruntime.Manager){}
+	if enabled == "*" {
+		for _, name := range controllerNames() {
+			registrations = append(registrations, controllers[name])
+		}
+		return registrations, nil
+	}
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(enabled, ",") {
+		name = strings.TrimSpace(name)
+		register, ok := controllers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown controller %q, the controllers are %s", name, strings.Join(controllerNames(), ","))
+		}
+		// A controller registered twice would process each object twice
+		if !selected[name] {
+			selected[name] = true
+			registrations = append(registrations, register)
+		}
+	}
+	return registrations, nil
+}
+
+// controllerNames lists the controllers in alphabetical order
+func controllerNames() []string {
+	names := []string{}
+	for name := range controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
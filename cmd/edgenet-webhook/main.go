@@ -0,0 +1,47 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The edgenet-webhook validates and defaults the apps.edgenet.io objects on behalf of the API server.
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/EdgeNet-project/edgenet/pkg/admission"
+	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
+)
+
+func main() {
+	var address, certFile, keyFile string
+	flag.StringVar(&address, "address", ":8443", "address to serve the admission webhooks on")
+	flag.StringVar(&certFile, "tls-cert-file", "../../configs/webhook/tls.crt", "certificate that the API server trusts for the webhooks")
+	flag.StringVar(&keyFile, "tls-private-key-file", "../../configs/webhook/tls.key", "private key of the certificate")
+	// Set kubeconfig to be used to create clientsets
+	bootstrap.SetKubeConfig()
+	edgenetClientset, err := bootstrap.CreateEdgeNetClientSet()
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	webhook := admission.New(edgenetClientset)
+	if err := webhook.Serve(address, certFile, keyFile, ctlruntime.SetupSignalHandler()); err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+}
@@ -2,11 +2,13 @@ package main
 
 import (
 	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/emailverification"
 	"log"
 )
 
 func main() {
+	ctlruntime.AddFlags()
 	// Set kubeconfig to be used to create clientsets
 	bootstrap.SetKubeConfig()
 	clientset, err := bootstrap.CreateClientSet()
@@ -23,10 +23,14 @@ import (
 	"log"
 
 	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1/nodelabeler"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
 )
 
 func main() {
+	ctlruntime.AddFlags()
+	node.SetGeolocationFlags()
 	// Set kubeconfig to be used to create clientsets
 	bootstrap.SetKubeConfig()
 	clientset, err := bootstrap.CreateClientSet()
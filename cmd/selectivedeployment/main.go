@@ -17,14 +17,25 @@ limitations under the License.
 package main
 
 import (
+	"flag"
 	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/selectivedeployment"
 	"log"
 )
 
 func main() {
+	var workloadKinds string
+	ctlruntime.AddFlags()
+	flag.StringVar(&workloadKinds, "workload-kinds", "", "path of a file declaring the kinds of workloads to manage besides the built-in ones")
 	// Set kubeconfig to be used to create clientsets
 	bootstrap.SetKubeConfig()
+	if workloadKinds != "" {
+		if err := selectivedeployment.LoadWorkloadKinds(workloadKinds); err != nil {
+			log.Println(err.Error())
+			panic(err.Error())
+		}
+	}
 	clientset, err := bootstrap.CreateClientSet()
 	if err != nil {
 		log.Println(err.Error())
@@ -35,6 +46,11 @@ func main() {
 		log.Println(err.Error())
 		panic(err.Error())
 	}
+	dynamicClientset, err := bootstrap.CreateDynamicClientSet()
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
 	// Start the controller to provide the functionalities of selectivedeployment resource
-	selectivedeployment.Start(clientset, edgenetClientset)
+	selectivedeployment.Start(clientset, edgenetClientset, dynamicClientset)
 }
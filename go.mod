@@ -5,8 +5,10 @@ go 1.15
 require (
 	github.com/billputer/go-namecheap v0.0.0-20191113012015-80fb801c9a11
 	github.com/oschwald/geoip2-golang v1.4.0
+	github.com/prometheus/client_golang v1.7.1
 	github.com/sirupsen/logrus v1.7.0
 	golang.org/x/crypto v0.0.0-20201002170205-7f63de1d35b0
+	golang.org/x/text v0.3.3
 	gopkg.in/yaml.v2 v2.3.0
 	k8s.io/api v0.19.2
 	k8s.io/apimachinery v0.19.2
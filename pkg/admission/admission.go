@@ -0,0 +1,278 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admission implements the validating and mutating admission webhooks of the apps.edgenet.io resources,
+// along with the webhook that converts them between the served versions.
+// The mutating webhook defaults the optional fields, then the validating webhook rejects the objects that the
+// controllers would fail on, so that the users get the error at once instead of a status message later.
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Webhook serves the admission reviews that the API server sends
+type Webhook struct {
+	edgenetClientset versioned.Interface
+}
+
+// New returns a webhook that looks the related objects up by the clientset
+func New(edgenetClientset versioned.Interface) *Webhook {
+	return &Webhook{edgenetClientset: edgenetClientset}
+}
+
+// Serve exposes the validation at /validate, the defaulting at /mutate, the version conversion at /convert,
+// and the liveness at /healthz over TLS.
+// It blocks until the stop channel is closed.
+func (w *Webhook) Serve(address, certFile, keyFile string, stopCh <-chan struct{}) error {
+	server := &http.Server{Addr: address, Handler: w.newServeMux()}
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+	log.Infof("serving admission webhooks on %s", address)
+	if err := server.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// newServeMux routes the webhooks
+func (w *Webhook) newServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate", serveReview(w.Validate))
+	mux.HandleFunc("/mutate", serveReview(w.Mutate))
+	mux.HandleFunc("/convert", serveConversion)
+	mux.HandleFunc("/healthz", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("ok"))
+	})
+	return mux
+}
+
+// serveReview decodes the admission review, answers its request by the review function, and sends it back.
+// The review gets the context of the HTTP request, so that its lookups stop when the API server drops the request.
+func serveReview(review func(context.Context, *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		admissionReview := admissionv1.AdmissionReview{}
+		if err := json.Unmarshal(body, &admissionReview); err != nil || admissionReview.Request == nil {
+			http.Error(rw, "malformed admission review", http.StatusBadRequest)
+			return
+		}
+		response := review(r.Context(), admissionReview.Request)
+		response.UID = admissionReview.Request.UID
+		admissionReview.Request = nil
+		admissionReview.Response = response
+		responseBody, err := json.Marshal(admissionReview)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write(responseBody)
+	}
+}
+
+// Validate admits the request if the object is valid
+func (w *Webhook) Validate(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	allErrs, err := w.validate(ctx, request)
+	if err != nil {
+		return deny(errors.NewBadRequest(err.Error()))
+	}
+	if len(allErrs) > 0 {
+		log.Infof("%s %s/%s rejected: %s", request.Kind.Kind, request.Namespace, request.Name, allErrs.ToAggregate())
+		return deny(errors.NewInvalid(schema.GroupKind{Group: request.Kind.Group, Kind: request.Kind.Kind}, request.Name, allErrs))
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// validate picks the validation that fits the kind of the object and the operation
+func (w *Webhook) validate(ctx context.Context, request *admissionv1.AdmissionRequest) (field.ErrorList, error) {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return nil, nil
+	}
+	// The controllers own the statuses, except the acceptable use policy flag of the users that they follow
+	if request.SubResource == "status" {
+		if request.Kind.Kind != "User" || request.Operation != admissionv1.Update {
+			return nil, nil
+		}
+		user, oldUser := &apps_v1alpha.User{}, &apps_v1alpha.User{}
+		if err := decode(request, user, oldUser); err != nil {
+			return nil, err
+		}
+		AUP, err := w.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(request.Namespace).Get(ctx, user.GetName(), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			AUP = nil
+		} else if err != nil {
+			return nil, err
+		}
+		return ValidateUserStatusUpdate(user, oldUser, AUP), nil
+	}
+
+	switch request.Kind.Kind {
+	case "AcceptableUsePolicy":
+		AUP := &apps_v1alpha.AcceptableUsePolicy{}
+		var oldAUP *apps_v1alpha.AcceptableUsePolicy
+		var err error
+		if request.Operation == admissionv1.Update {
+			oldAUP = &apps_v1alpha.AcceptableUsePolicy{}
+			err = decode(request, AUP, oldAUP)
+		} else {
+			err = decode(request, AUP, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+		// The policy takes the name of the user it belongs to
+		user, err := w.edgenetClientset.AppsV1alpha().Users(request.Namespace).Get(ctx, AUP.GetName(), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			user = nil
+		} else if err != nil {
+			return nil, err
+		}
+		if oldAUP != nil {
+			return ValidateAcceptableUsePolicyUpdate(AUP, oldAUP, user), nil
+		}
+		return ValidateAcceptableUsePolicy(AUP, user), nil
+	case "Authority":
+		authority := &apps_v1alpha.Authority{}
+		err := decode(request, authority, nil)
+		return ValidateAuthority(authority), err
+	case "AuthorityRequest":
+		authorityRequest := &apps_v1alpha.AuthorityRequest{}
+		err := decode(request, authorityRequest, nil)
+		return ValidateAuthorityRequest(authorityRequest), err
+	case "Team":
+		team := &apps_v1alpha.Team{}
+		err := decode(request, team, nil)
+		return ValidateTeam(team), err
+	case "Slice":
+		slice := &apps_v1alpha.Slice{}
+		err := decode(request, slice, nil)
+		return ValidateSlice(slice), err
+	case "User":
+		user := &apps_v1alpha.User{}
+		err := decode(request, user, nil)
+		return ValidateUser(user), err
+	case "UserRegistrationRequest":
+		userRequest := &apps_v1alpha.UserRegistrationRequest{}
+		err := decode(request, userRequest, nil)
+		return ValidateUserRegistrationRequest(userRequest), err
+	case "EmailVerification":
+		EV := &apps_v1alpha.EmailVerification{}
+		if request.Operation == admissionv1.Update {
+			oldEV := &apps_v1alpha.EmailVerification{}
+			err := decode(request, EV, oldEV)
+			return ValidateEmailVerificationUpdate(EV, oldEV), err
+		}
+		err := decode(request, EV, nil)
+		return ValidateEmailVerification(EV), err
+	case "NodeContribution":
+		NC := &apps_v1alpha.NodeContribution{}
+		err := decode(request, NC, nil)
+		return ValidateNodeContribution(NC), err
+	case "TotalResourceQuota":
+		TRQ := &apps_v1alpha.TotalResourceQuota{}
+		err := decode(request, TRQ, nil)
+		return ValidateTotalResourceQuota(TRQ), err
+	case "SelectiveDeployment":
+		SD := &apps_v1alpha.SelectiveDeployment{}
+		err := decode(request, SD, nil)
+		return ValidateSelectiveDeployment(SD), err
+	}
+	return nil, nil
+}
+
+// Mutate admits the request along with the patch that defaults the object
+func (w *Webhook) Mutate(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	patch, err := w.mutate(request)
+	if err != nil {
+		return deny(errors.NewBadRequest(err.Error()))
+	}
+	response := &admissionv1.AdmissionResponse{Allowed: true}
+	if len(patch) > 0 {
+		response.Patch, err = json.Marshal(patch)
+		if err != nil {
+			return deny(errors.NewInternalError(err))
+		}
+		patchType := admissionv1.PatchTypeJSONPatch
+		response.PatchType = &patchType
+	}
+	return response
+}
+
+// mutate picks the defaulting that fits the kind of the object
+func (w *Webhook) mutate(request *admissionv1.AdmissionRequest) ([]patchOperation, error) {
+	if (request.Operation != admissionv1.Create && request.Operation != admissionv1.Update) || request.SubResource != "" {
+		return nil, nil
+	}
+	switch request.Kind.Kind {
+	case "Slice":
+		slice := &apps_v1alpha.Slice{}
+		err := decode(request, slice, nil)
+		return defaultSlice(slice), err
+	case "NodeContribution":
+		NC := &apps_v1alpha.NodeContribution{}
+		err := decode(request, NC, nil)
+		return defaultNodeContribution(NC), err
+	case "SelectiveDeployment":
+		SD := &apps_v1alpha.SelectiveDeployment{}
+		err := decode(request, SD, nil)
+		return defaultSelectiveDeployment(SD), err
+	}
+	return nil, nil
+}
+
+// decode unmarshals the object of the request, and the old object as well if asked
+func decode(request *admissionv1.AdmissionRequest, obj, oldObj runtime.Object) error {
+	if err := json.Unmarshal(request.Object.Raw, obj); err != nil {
+		return fmt.Errorf("cannot decode the %s: %s", request.Kind.Kind, err)
+	}
+	if oldObj != nil {
+		if err := json.Unmarshal(request.OldObject.Raw, oldObj); err != nil {
+			return fmt.Errorf("cannot decode the former %s: %s", request.Kind.Kind, err)
+		}
+	}
+	return nil
+}
+
+// deny builds the response that rejects the request with the status of the error
+func deny(err *errors.StatusError) *admissionv1.AdmissionResponse {
+	status := err.ErrStatus
+	return &admissionv1.AdmissionResponse{Allowed: false, Result: &status}
+}
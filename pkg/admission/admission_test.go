@@ -0,0 +1,375 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newRequest(t *testing.T, kind string, operation admissionv1.Operation, obj, oldObj interface{}) *admissionv1.AdmissionRequest {
+	request := &admissionv1.AdmissionRequest{
+		UID:       types.UID("request"),
+		Kind:      metav1.GroupVersionKind{Group: "apps.edgenet.io", Version: "v1alpha", Kind: kind},
+		Operation: operation,
+		Namespace: "authority-edgenet",
+	}
+	raw, err := json.Marshal(obj)
+	util.OK(t, err)
+	request.Object = runtime.RawExtension{Raw: raw}
+	if oldObj != nil {
+		raw, err = json.Marshal(oldObj)
+		util.OK(t, err)
+		request.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return request
+}
+
+func TestValidateSlice(t *testing.T) {
+	slice := apps_v1alpha.Slice{
+		ObjectMeta: metav1.ObjectMeta{Name: "slice", Namespace: "authority-edgenet"},
+		Spec: apps_v1alpha.SliceSpec{
+			Type:    "Classroom",
+			Profile: "Medium",
+			Users:   []apps_v1alpha.SliceUsers{{Authority: "edgenet", Username: "johndoe"}},
+		},
+	}
+	invalidProfile := slice
+	invalidProfile.Spec.Profile = "Huge"
+	noUser := slice
+	noUser.Spec.Users = nil
+	invalidUser := slice
+	invalidUser.Spec.Users = []apps_v1alpha.SliceUsers{{Authority: "edgenet", Username: "John Doe"}}
+
+	cases := map[string]struct {
+		slice    apps_v1alpha.Slice
+		expected int
+	}{
+		"valid":           {slice, 0},
+		"profile/invalid": {invalidProfile, 1},
+		"users/missing":   {noUser, 1},
+		"users/invalid":   {invalidUser, 1},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			util.Equals(t, tc.expected, len(ValidateSlice(&tc.slice)))
+		})
+	}
+}
+
+func TestValidateSelectiveDeployment(t *testing.T) {
+	polygon := "[[2.2150567, 48.8947616], [2.2040704, 48.8084639], [2.4807885, 48.8574024]]"
+	cases := map[string]struct {
+		selector apps_v1alpha.Selector
+		expected int
+	}{
+		"city":                 {apps_v1alpha.Selector{Name: "City", Value: []string{"Paris"}, Operator: "In", Quantity: 1}, 0},
+		"polygon":              {apps_v1alpha.Selector{Name: "Polygon", Value: []string{polygon}, Operator: "NotIn"}, 0},
+		"polygon/json":         {apps_v1alpha.Selector{Name: "Polygon", Value: []string{"[[2.2150567, 48.8947616"}, Operator: "In"}, 1},
+		"polygon/points":       {apps_v1alpha.Selector{Name: "Polygon", Value: []string{"[[2.2150567, 48.8947616]]"}, Operator: "In"}, 1},
+		"polygon/range":        {apps_v1alpha.Selector{Name: "Polygon", Value: []string{"[[200, 48], [2, 48], [2, 49]]"}, Operator: "In"}, 1},
+		"polygon/geojson":      {apps_v1alpha.Selector{Name: "Polygon", Value: []string{`{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[2.2, 48.8], [2.5, 48.8], [2.5, 48.9], [2.2, 48.8]]]}}`}, Operator: "In"}, 0},
+		"polygon/geojson/open": {apps_v1alpha.Selector{Name: "Polygon", Value: []string{`{"type": "Polygon", "coordinates": [[[2.2, 48.8], [2.5, 48.8], [2.5, 48.9], [2.2, 48.9]]]}`}, Operator: "In"}, 1},
+		"radius":               {apps_v1alpha.Selector{Name: "Radius", Value: []string{"48.8566,2.3522,300km"}, Operator: "In"}, 0},
+		"radius/distance":      {apps_v1alpha.Selector{Name: "Radius", Value: []string{"48.8566,2.3522,far"}, Operator: "In"}, 1},
+		"nearest":              {apps_v1alpha.Selector{Name: "Nearest", Value: []string{"48.8566,2.3522,5"}, Operator: "In"}, 0},
+		"nearest/point":        {apps_v1alpha.Selector{Name: "Nearest", Value: []string{"Paris,5"}, Operator: "In"}, 1},
+		"asn":                  {apps_v1alpha.Selector{Name: "ASN", Value: []string{"2200", "AS1307"}, Operator: "In"}, 0},
+		"asn/name":             {apps_v1alpha.Selector{Name: "ASN", Value: []string{"RENATER"}, Operator: "In"}, 1},
+		"preferred":            {apps_v1alpha.Selector{Name: "Continent", Value: []string{"Europe"}, Operator: "In", Preferred: true, Weight: 80}, 0},
+		"preferred/weight":     {apps_v1alpha.Selector{Name: "Continent", Value: []string{"Europe"}, Operator: "In", Preferred: true, Weight: 101}, 1},
+		"required/weight":      {apps_v1alpha.Selector{Name: "Continent", Value: []string{"Europe"}, Operator: "In", Weight: 80}, 1},
+		"name/unknown":         {apps_v1alpha.Selector{Name: "Planet", Value: []string{"Earth"}, Operator: "In"}, 1},
+		"operator/unknown":     {apps_v1alpha.Selector{Name: "City", Value: []string{"Paris"}, Operator: "Exists"}, 1},
+		"value/missing":        {apps_v1alpha.Selector{Name: "City", Operator: "In"}, 1},
+		"quantity/negative":    {apps_v1alpha.Selector{Name: "City", Value: []string{"Paris"}, Operator: "In", Quantity: -1}, 1},
+		"operator/name/value":  {apps_v1alpha.Selector{}, 3},
+		"windows":              {apps_v1alpha.Selector{Name: "City", Value: []string{"Paris"}, Operator: "In", Windows: []apps_v1alpha.TimeWindow{{Start: "22:00", End: "06:00", Days: []string{"Sat", "Sun"}}}}, 0},
+		"windows/clock":        {apps_v1alpha.Selector{Name: "City", Value: []string{"Paris"}, Operator: "In", Windows: []apps_v1alpha.TimeWindow{{Start: "10pm", End: "24:00"}}}, 2},
+		"windows/days":         {apps_v1alpha.Selector{Name: "City", Value: []string{"Paris"}, Operator: "In", Windows: []apps_v1alpha.TimeWindow{{Start: "22:00", End: "06:00", Days: []string{"Weekend"}}}}, 1},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			SD := apps_v1alpha.SelectiveDeployment{}
+			SD.Spec.Workloads.Deployment = make([]appsv1.Deployment, 1)
+			SD.Spec.Selector = []apps_v1alpha.Selector{tc.selector}
+			util.Equals(t, tc.expected, len(ValidateSelectiveDeployment(&SD)))
+		})
+	}
+	t.Run("empty", func(t *testing.T) {
+		util.Equals(t, 2, len(ValidateSelectiveDeployment(&apps_v1alpha.SelectiveDeployment{})))
+	})
+	t.Run("objects", func(t *testing.T) {
+		SD := apps_v1alpha.SelectiveDeployment{}
+		SD.Spec.Selector = []apps_v1alpha.Selector{{Name: "City", Value: []string{"Paris"}, Operator: "In"}}
+		vmi := unstructured.Unstructured{}
+		vmi.SetAPIVersion("kubevirt.io/v1")
+		vmi.SetKind("VirtualMachineInstance")
+		vmi.SetName("fedora")
+		SD.Spec.Workloads.Objects = []unstructured.Unstructured{vmi, {}}
+		util.Equals(t, 2, len(ValidateSelectiveDeployment(&SD)))
+	})
+
+	country := apps_v1alpha.Selector{Name: "Country", Value: []string{"FR", "US"}, Operator: "In"}
+	distributionCases := map[string]struct {
+		distribution apps_v1alpha.Distribution
+		selector     apps_v1alpha.Selector
+		expected     int
+	}{
+		"per value":          {apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2}, country, 0},
+		"per value/replicas": {apps_v1alpha.Distribution{Mode: "PerValue"}, country, 1},
+		"per value/topology": {apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2, Topology: "City", MaxSkew: 1}, country, 2},
+		"per value/regions":  {apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2}, apps_v1alpha.Selector{Name: "Country", Value: []string{"FR"}, Operator: "NotIn"}, 1},
+		"spread":             {apps_v1alpha.Distribution{Mode: "Spread", Topology: "Country", MaxSkew: 1}, country, 0},
+		"spread/topology":    {apps_v1alpha.Distribution{Mode: "Spread", Topology: "Polygon", MaxSkew: 1}, country, 1},
+		"spread/skew":        {apps_v1alpha.Distribution{Mode: "Spread", Topology: "City"}, country, 1},
+		"spread/replicas":    {apps_v1alpha.Distribution{Mode: "Spread", Topology: "City", MaxSkew: 1, Replicas: 2}, country, 1},
+		"mode/unknown":       {apps_v1alpha.Distribution{Mode: "Random"}, country, 1},
+	}
+	for k, tc := range distributionCases {
+		t.Run("distribution/"+k, func(t *testing.T) {
+			SD := apps_v1alpha.SelectiveDeployment{}
+			SD.Spec.Workloads.Deployment = make([]appsv1.Deployment, 1)
+			SD.Spec.Selector = []apps_v1alpha.Selector{tc.selector}
+			SD.Spec.Distribution = &tc.distribution
+			util.Equals(t, tc.expected, len(ValidateSelectiveDeployment(&SD)))
+		})
+	}
+
+	canary := apps_v1alpha.RolloutStage{Name: "canary", Regions: []string{"FR"}}
+	rolloutCases := map[string]struct {
+		rollout      apps_v1alpha.Rollout
+		distribution *apps_v1alpha.Distribution
+		expected     int
+	}{
+		"stages":       {apps_v1alpha.Rollout{Stages: []apps_v1alpha.RolloutStage{canary}, Promote: "remaining"}, &apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2}, 0},
+		"stages/empty": {apps_v1alpha.Rollout{}, &apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2}, 1},
+		"stages/names": {apps_v1alpha.Rollout{Stages: []apps_v1alpha.RolloutStage{canary, canary, {Name: "remaining", Regions: []string{"US"}}}}, &apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2}, 2},
+		"promote":      {apps_v1alpha.Rollout{Stages: []apps_v1alpha.RolloutStage{canary}, Promote: "europe"}, &apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2}, 1},
+		"bake time":    {apps_v1alpha.Rollout{Stages: []apps_v1alpha.RolloutStage{canary}, BakeTime: metav1.Duration{Duration: -time.Minute}}, &apps_v1alpha.Distribution{Mode: "PerValue", Replicas: 2}, 1},
+		"distribution": {apps_v1alpha.Rollout{Stages: []apps_v1alpha.RolloutStage{canary}}, nil, 1},
+		"spread":       {apps_v1alpha.Rollout{Stages: []apps_v1alpha.RolloutStage{canary}}, &apps_v1alpha.Distribution{Mode: "Spread", Topology: "Country", MaxSkew: 1}, 1},
+	}
+	for k, tc := range rolloutCases {
+		t.Run("rollout/"+k, func(t *testing.T) {
+			SD := apps_v1alpha.SelectiveDeployment{}
+			SD.Spec.Workloads.Deployment = make([]appsv1.Deployment, 1)
+			SD.Spec.Selector = []apps_v1alpha.Selector{country}
+			SD.Spec.Distribution = tc.distribution
+			SD.Spec.Rollout = &tc.rollout
+			util.Equals(t, tc.expected, len(ValidateSelectiveDeployment(&SD)))
+		})
+	}
+}
+
+func TestValidateNodeContribution(t *testing.T) {
+	cases := map[string]struct {
+		spec     apps_v1alpha.NodeContributionSpec
+		expected int
+	}{
+		"ipv4":              {apps_v1alpha.NodeContributionSpec{Host: "132.227.123.49", Port: 22}, 0},
+		"ipv6":              {apps_v1alpha.NodeContributionSpec{Host: "2001:db8::1", Port: 2222}, 0},
+		"hostname":          {apps_v1alpha.NodeContributionSpec{Host: "example-test.com", Port: 22}, 1},
+		"port":              {apps_v1alpha.NodeContributionSpec{Host: "132.227.123.49", Port: 70000}, 1},
+		"limitations/empty": {apps_v1alpha.NodeContributionSpec{Host: "132.227.123.49", Port: 22, Limitations: []apps_v1alpha.Limitations{{Team: "lab"}}}, 1},
+		"location":          {apps_v1alpha.NodeContributionSpec{Host: "132.227.123.49", Port: 22, Location: &apps_v1alpha.DeclaredLocation{Latitude: 48.8607, Longitude: 2.3281, City: "Paris", Country: "FR"}}, 0},
+		"location/range":    {apps_v1alpha.NodeContributionSpec{Host: "132.227.123.49", Port: 22, Location: &apps_v1alpha.DeclaredLocation{Latitude: 91, Longitude: -181}}, 2},
+		"location/country":  {apps_v1alpha.NodeContributionSpec{Host: "132.227.123.49", Port: 22, Location: &apps_v1alpha.DeclaredLocation{Country: "France"}}, 1},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			NC := apps_v1alpha.NodeContribution{Spec: tc.spec}
+			util.Equals(t, tc.expected, len(ValidateNodeContribution(&NC)))
+		})
+	}
+}
+
+func TestValidateTotalResourceQuota(t *testing.T) {
+	TRQ := apps_v1alpha.TotalResourceQuota{}
+	TRQ.Spec.Claim = []apps_v1alpha.TotalResourceDetails{{Name: "Default", CPU: "12000m", Memory: "12Gi"}}
+	TRQ.Spec.Drop = []apps_v1alpha.TotalResourceDetails{{Name: "Temporary", CPU: "2", Memory: "1Gi"}}
+	util.Equals(t, 0, len(ValidateTotalResourceQuota(&TRQ)))
+	TRQ.Spec.Claim[0].CPU = "twelve"
+	TRQ.Spec.Drop[0].Name = "Default"
+	TRQ.Spec.Drop[0].Memory = "-1Gi"
+	util.Equals(t, 3, len(ValidateTotalResourceQuota(&TRQ)))
+}
+
+func TestValidatePerson(t *testing.T) {
+	user := apps_v1alpha.User{}
+	user.Spec.FirstName = "John"
+	user.Spec.LastName = "Doe"
+	user.Spec.Email = "john.doe@edge-net.org"
+	user.Spec.URL = "https://www.edge-net.org"
+	util.Equals(t, 0, len(ValidateUser(&user)))
+	user.Spec.Email = "John Doe <john.doe@edge-net.org>"
+	user.Spec.URL = "www.edge-net.org"
+	util.Equals(t, 2, len(ValidateUser(&user)))
+
+	userRequest := apps_v1alpha.UserRegistrationRequest{}
+	userRequest.SetName("John_Doe")
+	userRequest.Spec.Email = "john.doe"
+	util.Equals(t, 4, len(ValidateUserRegistrationRequest(&userRequest)))
+}
+
+func TestValidateEmailVerificationUpdate(t *testing.T) {
+	EV := apps_v1alpha.EmailVerification{}
+	EV.Spec.Kind = "User"
+	EV.Spec.Identifier = "johndoe"
+	updated := EV
+	updated.Spec.Verified = true
+	util.Equals(t, 0, len(ValidateEmailVerificationUpdate(&updated, &EV)))
+	updated.Spec.Kind = "Authority"
+	updated.Spec.Identifier = "edgenet"
+	util.Equals(t, 2, len(ValidateEmailVerificationUpdate(&updated, &EV)))
+}
+
+func TestValidate(t *testing.T) {
+	edgenetClient := edgenettestclient.NewSimpleClientset()
+	webhook := New(edgenetClient)
+	EV := apps_v1alpha.EmailVerification{ObjectMeta: metav1.ObjectMeta{Name: "verification", Namespace: "authority-edgenet"}}
+	EV.Spec.Kind = "Email"
+	EV.Spec.Identifier = "johndoe"
+	manipulated := EV
+	manipulated.Spec.Identifier = "janedoe"
+
+	t.Run("allowed", func(t *testing.T) {
+		response := webhook.Validate(context.TODO(), newRequest(t, "EmailVerification", admissionv1.Create, EV, nil))
+		util.Equals(t, true, response.Allowed)
+	})
+	t.Run("denied", func(t *testing.T) {
+		response := webhook.Validate(context.TODO(), newRequest(t, "EmailVerification", admissionv1.Update, manipulated, EV))
+		util.Equals(t, false, response.Allowed)
+		util.Equals(t, metav1.StatusReasonInvalid, response.Result.Reason)
+		util.Equals(t, 1, len(response.Result.Details.Causes))
+		util.Equals(t, "spec.identifier", response.Result.Details.Causes[0].Field)
+	})
+	t.Run("delete", func(t *testing.T) {
+		response := webhook.Validate(context.TODO(), newRequest(t, "EmailVerification", admissionv1.Delete, manipulated, nil))
+		util.Equals(t, true, response.Allowed)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		request := newRequest(t, "EmailVerification", admissionv1.Create, EV, nil)
+		request.Object.Raw = []byte("{\"spec\": [")
+		response := webhook.Validate(context.TODO(), request)
+		util.Equals(t, false, response.Allowed)
+		util.Equals(t, metav1.StatusReasonBadRequest, response.Result.Reason)
+	})
+
+	t.Run("acceptable use policy", func(t *testing.T) {
+		user := apps_v1alpha.User{ObjectMeta: metav1.ObjectMeta{Name: "johndoe", Namespace: "authority-edgenet"}}
+		agreed := user
+		agreed.Status.AUP = true
+		request := newRequest(t, "User", admissionv1.Update, agreed, user)
+		request.SubResource = "status"
+		util.Equals(t, false, webhook.Validate(context.TODO(), request).Allowed)
+
+		AUP := apps_v1alpha.AcceptableUsePolicy{ObjectMeta: metav1.ObjectMeta{Name: "johndoe", Namespace: "authority-edgenet"}}
+		AUP.Spec.Accepted = true
+		_, err := edgenetClient.AppsV1alpha().AcceptableUsePolicies(AUP.GetNamespace()).Create(context.TODO(), AUP.DeepCopy(), metav1.CreateOptions{})
+		util.OK(t, err)
+		util.Equals(t, true, webhook.Validate(context.TODO(), request).Allowed)
+	})
+	t.Run("acceptable use policy changes", func(t *testing.T) {
+		AUP := apps_v1alpha.AcceptableUsePolicy{ObjectMeta: metav1.ObjectMeta{Name: "janedoe", Namespace: "authority-edgenet"}}
+		accepted := AUP
+		accepted.Spec.Accepted = true
+		// The policy of a user who does not exist
+		response := webhook.Validate(context.TODO(), newRequest(t, "AcceptableUsePolicy", admissionv1.Create, AUP, nil))
+		util.Equals(t, false, response.Allowed)
+		util.Equals(t, "metadata.name", response.Result.Details.Causes[0].Field)
+
+		user := apps_v1alpha.User{ObjectMeta: metav1.ObjectMeta{Name: "janedoe", Namespace: "authority-edgenet"}}
+		_, err := edgenetClient.AppsV1alpha().Users(user.GetNamespace()).Create(context.TODO(), user.DeepCopy(), metav1.CreateOptions{})
+		util.OK(t, err)
+		util.Equals(t, true, webhook.Validate(context.TODO(), newRequest(t, "AcceptableUsePolicy", admissionv1.Create, AUP, nil)).Allowed)
+		// An inactive user cannot accept the policy
+		response = webhook.Validate(context.TODO(), newRequest(t, "AcceptableUsePolicy", admissionv1.Update, accepted, AUP))
+		util.Equals(t, false, response.Allowed)
+		util.Equals(t, "spec.accepted", response.Result.Details.Causes[0].Field)
+
+		user.Spec.Active = true
+		_, err = edgenetClient.AppsV1alpha().Users(user.GetNamespace()).Update(context.TODO(), user.DeepCopy(), metav1.UpdateOptions{})
+		util.OK(t, err)
+		util.Equals(t, true, webhook.Validate(context.TODO(), newRequest(t, "AcceptableUsePolicy", admissionv1.Update, accepted, AUP)).Allowed)
+	})
+}
+
+func TestMutate(t *testing.T) {
+	webhook := New(edgenettestclient.NewSimpleClientset())
+	t.Run("slice", func(t *testing.T) {
+		slice := apps_v1alpha.Slice{}
+		response := webhook.Mutate(context.TODO(), newRequest(t, "Slice", admissionv1.Create, slice, nil))
+		util.Equals(t, true, response.Allowed)
+		util.Equals(t, admissionv1.PatchTypeJSONPatch, *response.PatchType)
+		util.Equals(t, `[{"op":"add","path":"/spec/profile","value":"Low"}]`, string(response.Patch))
+
+		slice.Spec.Profile = "High"
+		response = webhook.Mutate(context.TODO(), newRequest(t, "Slice", admissionv1.Create, slice, nil))
+		util.Equals(t, true, response.Allowed)
+		util.Equals(t, 0, len(response.Patch))
+	})
+	t.Run("selective deployment", func(t *testing.T) {
+		SD := apps_v1alpha.SelectiveDeployment{}
+		SD.Spec.Selector = []apps_v1alpha.Selector{{Name: "City", Operator: "NotIn"}, {Name: "Country"}}
+		response := webhook.Mutate(context.TODO(), newRequest(t, "SelectiveDeployment", admissionv1.Create, SD, nil))
+		util.Equals(t, `[{"op":"add","path":"/spec/selector/1/operator","value":"In"}]`, string(response.Patch))
+
+		SD.Spec.Selector = []apps_v1alpha.Selector{{Name: "Continent", Operator: "In", Preferred: true}}
+		response = webhook.Mutate(context.TODO(), newRequest(t, "SelectiveDeployment", admissionv1.Create, SD, nil))
+		util.Equals(t, `[{"op":"add","path":"/spec/selector/0/weight","value":100}]`, string(response.Patch))
+
+		SD.Spec.Selector[0].Weight = 100
+		SD.Spec.Distribution = &apps_v1alpha.Distribution{Mode: "Spread", Topology: "Country"}
+		response = webhook.Mutate(context.TODO(), newRequest(t, "SelectiveDeployment", admissionv1.Create, SD, nil))
+		util.Equals(t, `[{"op":"add","path":"/spec/distribution/maxSkew","value":1}]`, string(response.Patch))
+	})
+	t.Run("node contribution", func(t *testing.T) {
+		response := webhook.Mutate(context.TODO(), newRequest(t, "NodeContribution", admissionv1.Create, apps_v1alpha.NodeContribution{}, nil))
+		util.Equals(t, `[{"op":"add","path":"/spec/port","value":22}]`, string(response.Patch))
+	})
+}
+
+func TestServeReview(t *testing.T) {
+	webhook := New(edgenettestclient.NewSimpleClientset())
+	server := httptest.NewServer(webhook.newServeMux())
+	defer server.Close()
+
+	slice := apps_v1alpha.Slice{}
+	slice.Spec.Type = "Testing"
+	review := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  newRequest(t, "Slice", admissionv1.Create, slice, nil),
+	}
+	body, err := json.Marshal(review)
+	util.OK(t, err)
+	resp, err := http.Post(server.URL+"/validate", "application/json", bytes.NewReader(body))
+	util.OK(t, err)
+	defer resp.Body.Close()
+	util.Equals(t, http.StatusOK, resp.StatusCode)
+	response := admissionv1.AdmissionReview{}
+	util.OK(t, json.NewDecoder(resp.Body).Decode(&response))
+	util.Equals(t, "AdmissionReview", response.Kind)
+	util.Equals(t, review.Request.UID, response.Response.UID)
+	// The profile is left to the mutating webhook, and the users are missing
+	util.Equals(t, false, response.Response.Allowed)
+
+	resp, err = http.Post(server.URL+"/validate", "application/json", bytes.NewReader([]byte("{}")))
+	util.OK(t, err)
+	resp.Body.Close()
+	util.Equals(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	apps_v1beta1 "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1beta1"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+// ConversionReview is the body of the requests that the API server sends to convert custom resources,
+// it mirrors the one of apiextensions.k8s.io/v1
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *ConversionRequest  `json:"request,omitempty"`
+	Response        *ConversionResponse `json:"response,omitempty"`
+}
+
+// ConversionRequest holds the objects to convert to the desired version
+type ConversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+// ConversionResponse holds the converted objects in the order of the request
+type ConversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// conversionScheme knows every served version of the apps.edgenet.io kinds and the conversions between them
+var conversionScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(apps_v1alpha.AddToScheme(conversionScheme))
+	utilruntime.Must(apps_v1beta1.AddToScheme(conversionScheme))
+}
+
+// serveConversion decodes the conversion review, converts its objects, and sends it back
+func serveConversion(rw http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	conversionReview := ConversionReview{}
+	if err := json.Unmarshal(body, &conversionReview); err != nil || conversionReview.Request == nil {
+		http.Error(rw, "malformed conversion review", http.StatusBadRequest)
+		return
+	}
+	response := Convert(conversionReview.Request)
+	conversionReview.Request = nil
+	conversionReview.Response = response
+	responseBody, err := json.Marshal(conversionReview)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Write(responseBody)
+}
+
+// Convert converts the objects of the request to the desired version, it fails as a whole if any object fails
+func Convert(request *ConversionRequest) *ConversionResponse {
+	response := &ConversionResponse{UID: request.UID}
+	desired, err := schema.ParseGroupVersion(request.DesiredAPIVersion)
+	if err != nil {
+		response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+		return response
+	}
+	for _, obj := range request.Objects {
+		converted, err := convertObject(obj.Raw, desired)
+		if err != nil {
+			log.Infof("conversion to %s failed: %s", request.DesiredAPIVersion, err)
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			return response
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+// convertObject converts a JSON object to the same kind in the desired group version
+func convertObject(raw []byte, desired schema.GroupVersion) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("cannot decode the object: %s", err)
+	}
+	gvk := typeMeta.GroupVersionKind()
+	if gvk.GroupVersion() == desired {
+		return raw, nil
+	}
+	in, err := conversionScheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, in); err != nil {
+		return nil, fmt.Errorf("cannot decode the %s: %s", gvk.Kind, err)
+	}
+	out, err := conversionScheme.New(desired.WithKind(gvk.Kind))
+	if err != nil {
+		return nil, err
+	}
+	if err := conversionScheme.Convert(in, out, nil); err != nil {
+		return nil, err
+	}
+	out.GetObjectKind().SetGroupVersionKind(desired.WithKind(gvk.Kind))
+	return json.Marshal(out)
+}
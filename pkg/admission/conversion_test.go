@@ -0,0 +1,186 @@
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	apps_v1beta1 "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1beta1"
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newConversionRequest(t *testing.T, desiredAPIVersion string, objs ...interface{}) *ConversionRequest {
+	request := &ConversionRequest{UID: types.UID("request"), DesiredAPIVersion: desiredAPIVersion}
+	for _, obj := range objs {
+		raw, err := json.Marshal(obj)
+		util.OK(t, err)
+		request.Objects = append(request.Objects, runtime.RawExtension{Raw: raw})
+	}
+	return request
+}
+
+// roundTrip converts the object to the desired version, then back to the version it has
+func roundTrip(t *testing.T, obj interface{}, desiredAPIVersion string, converted, restored interface{}) {
+	response := Convert(newConversionRequest(t, desiredAPIVersion, obj))
+	util.Equals(t, metav1.StatusSuccess, response.Result.Status)
+	util.OK(t, json.Unmarshal(response.ConvertedObjects[0].Raw, converted))
+	typeMeta := metav1.TypeMeta{}
+	raw, _ := json.Marshal(obj)
+	util.OK(t, json.Unmarshal(raw, &typeMeta))
+	response = Convert(newConversionRequest(t, typeMeta.APIVersion, converted))
+	util.Equals(t, metav1.StatusSuccess, response.Result.Status)
+	util.OK(t, json.Unmarshal(response.ConvertedObjects[0].Raw, restored))
+}
+
+func TestConvertSelectiveDeployment(t *testing.T) {
+	polygon := "[[2.2150567,48.8947616],[2.2040704,48.8084639],[2.4807885,48.8574024]]"
+	region := `{"type": "Polygon", "coordinates": [[[2.2, 48.8], [2.5, 48.8], [2.5, 48.9], [2.2, 48.8]]]}`
+	SD := apps_v1alpha.SelectiveDeployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps.edgenet.io/v1alpha", Kind: "SelectiveDeployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "sd", Namespace: "default"},
+		Spec: apps_v1alpha.SelectiveDeploymentSpec{
+			Selector: []apps_v1alpha.Selector{
+				{Name: "City", Value: []string{"Paris"}, Operator: "In", Quantity: 1},
+				{Name: "Polygon", Value: []string{polygon}, Operator: "NotIn"},
+				{Name: "Polygon", Value: []string{region}, Operator: "In"},
+			},
+		},
+	}
+	converted, restored := apps_v1beta1.SelectiveDeployment{}, apps_v1alpha.SelectiveDeployment{}
+	roundTrip(t, SD, "apps.edgenet.io/v1beta1", &converted, &restored)
+	util.Equals(t, "apps.edgenet.io/v1beta1", converted.APIVersion)
+	util.Equals(t, []string{"Paris"}, converted.Spec.Selector[0].Values)
+	util.Equals(t, 1, converted.Spec.Selector[0].Quantity)
+	util.Equals(t, []apps_v1beta1.Polygon{{{2.2150567, 48.8947616}, {2.2040704, 48.8084639}, {2.4807885, 48.8574024}}}, converted.Spec.Selector[1].Polygons)
+	util.Equals(t, []string{region}, converted.Spec.Selector[2].Values)
+	util.Equals(t, SD.Spec, restored.Spec)
+
+	invalid := SD.DeepCopy()
+	invalid.Spec.Selector[1].Value = []string{"[[2.2150567, 48.8947616"}
+	response := Convert(newConversionRequest(t, "apps.edgenet.io/v1beta1", SD, invalid))
+	util.Equals(t, metav1.StatusFailure, response.Result.Status)
+	util.Equals(t, 0, len(response.ConvertedObjects))
+}
+
+func TestConvertNodeContribution(t *testing.T) {
+	NC := apps_v1alpha.NodeContribution{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps.edgenet.io/v1alpha", Kind: "NodeContribution"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nc", Namespace: "authority-edgenet"},
+		Spec:       apps_v1alpha.NodeContributionSpec{Host: "192.168.0.1", Port: 22, User: "edgenet", Password: "secret", Enabled: true},
+	}
+	t.Run("password", func(t *testing.T) {
+		converted, restored := apps_v1beta1.NodeContribution{}, apps_v1alpha.NodeContribution{}
+		roundTrip(t, NC, "apps.edgenet.io/v1beta1", &converted, &restored)
+		util.Equals(t, "192.168.0.1", converted.Spec.Host)
+		util.Equals(t, true, converted.Spec.PasswordSecretRef == nil)
+		// The password is not carried through the annotations, where anyone who reads the object would see it
+		for key, value := range converted.GetAnnotations() {
+			util.Equals(t, false, strings.Contains(key+value, NC.Spec.Password))
+		}
+		spec := NC.Spec
+		spec.Password = ""
+		util.Equals(t, spec, restored.Spec)
+		util.Equals(t, 0, len(restored.GetAnnotations()))
+	})
+	t.Run("secret reference", func(t *testing.T) {
+		NC := apps_v1beta1.NodeContribution{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps.edgenet.io/v1beta1", Kind: "NodeContribution"},
+			ObjectMeta: metav1.ObjectMeta{Name: "nc", Namespace: "authority-edgenet"},
+			Spec: apps_v1beta1.NodeContributionSpec{
+				Host:              "192.168.0.1",
+				PasswordSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "nc"}, Key: "password"},
+			},
+		}
+		converted, restored := apps_v1alpha.NodeContribution{}, apps_v1beta1.NodeContribution{}
+		roundTrip(t, NC, "apps.edgenet.io/v1alpha", &converted, &restored)
+		util.Equals(t, "", converted.Spec.Password)
+		util.Equals(t, NC.Spec.PasswordSecretRef, apps_v1beta1.PasswordSecretRef(&converted))
+		util.Equals(t, NC.Spec, restored.Spec)
+	})
+}
+
+func TestConvertTotalResourceQuota(t *testing.T) {
+	TRQ := apps_v1alpha.TotalResourceQuota{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps.edgenet.io/v1alpha", Kind: "TotalResourceQuota"},
+		ObjectMeta: metav1.ObjectMeta{Name: "edgenet"},
+		Spec: apps_v1alpha.TotalResourceQuotaSpec{
+			Enabled: true,
+			Claim:   []apps_v1alpha.TotalResourceDetails{{Name: "Default", CPU: "12000m", Memory: "12Gi"}},
+		},
+	}
+	converted, restored := apps_v1beta1.TotalResourceQuota{}, apps_v1alpha.TotalResourceQuota{}
+	roundTrip(t, TRQ, "apps.edgenet.io/v1beta1", &converted, &restored)
+	util.Equals(t, int64(12), converted.Spec.Claim[0].CPU.Value())
+	memory := resource.MustParse("12Gi")
+	util.Equals(t, memory.Value(), converted.Spec.Claim[0].Memory.Value())
+	util.Equals(t, "12", restored.Spec.Claim[0].CPU)
+	util.Equals(t, "12Gi", restored.Spec.Claim[0].Memory)
+
+	invalid := TRQ.DeepCopy()
+	invalid.Spec.Claim[0].CPU = "twelve"
+	response := Convert(newConversionRequest(t, "apps.edgenet.io/v1beta1", invalid))
+	util.Equals(t, metav1.StatusFailure, response.Result.Status)
+}
+
+func TestConvertTeam(t *testing.T) {
+	team := apps_v1alpha.Team{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps.edgenet.io/v1alpha", Kind: "Team"},
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "authority-edgenet"},
+		Spec: apps_v1alpha.TeamSpec{
+			Description: "team",
+			Users:       []apps_v1alpha.TeamUsers{{Authority: "edgenet", Username: "johndoe"}},
+			Enabled:     true,
+		},
+	}
+	converted, restored := apps_v1beta1.Team{}, apps_v1alpha.Team{}
+	roundTrip(t, team, "apps.edgenet.io/v1beta1", &converted, &restored)
+	util.Equals(t, []apps_v1beta1.Participant{{Authority: "edgenet", Username: "johndoe"}}, converted.Spec.Users)
+	util.Equals(t, team.Spec, restored.Spec)
+}
+
+func TestServeConversion(t *testing.T) {
+	webhook := New(edgenettestclient.NewSimpleClientset())
+	server := httptest.NewServer(webhook.newServeMux())
+	defer server.Close()
+
+	authority := apps_v1alpha.Authority{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps.edgenet.io/v1alpha", Kind: "Authority"},
+		ObjectMeta: metav1.ObjectMeta{Name: "edgenet"},
+		Spec:       apps_v1alpha.AuthoritySpec{FullName: "EdgeNet", ShortName: "EdgeNet", URL: "https://www.edge-net.org"},
+	}
+	review := ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request:  newConversionRequest(t, "apps.edgenet.io/v1beta1", authority),
+	}
+	body, err := json.Marshal(review)
+	util.OK(t, err)
+	resp, err := http.Post(server.URL+"/convert", "application/json", bytes.NewReader(body))
+	util.OK(t, err)
+	defer resp.Body.Close()
+	util.Equals(t, http.StatusOK, resp.StatusCode)
+	response := ConversionReview{}
+	util.OK(t, json.NewDecoder(resp.Body).Decode(&response))
+	util.Equals(t, "ConversionReview", response.Kind)
+	util.Equals(t, review.Request.UID, response.Response.UID)
+	util.Equals(t, metav1.StatusSuccess, response.Response.Result.Status)
+	converted := apps_v1beta1.Authority{}
+	util.OK(t, json.Unmarshal(response.Response.ConvertedObjects[0].Raw, &converted))
+	util.Equals(t, "apps.edgenet.io/v1beta1", converted.APIVersion)
+	util.Equals(t, authority.Spec.FullName, converted.Spec.FullName)
+
+	resp, err = http.Post(server.URL+"/convert", "application/json", bytes.NewReader([]byte("{}")))
+	util.OK(t, err)
+	resp.Body.Close()
+	util.Equals(t, http.StatusBadRequest, resp.StatusCode)
+}
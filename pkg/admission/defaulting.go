@@ -0,0 +1,82 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The default values of the optional fields
+const (
+	defaultSliceProfile     = "Low"
+	defaultSSHPort          = 22
+	defaultSelectorOperator = corev1.NodeSelectorOpIn
+	defaultSelectorWeight   = 100
+	defaultMaxSkew          = 1
+)
+
+// patchOperation is an operation of the JSON patch that the mutating webhook returns
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// defaultSlice sets the profile of a slice to the lowest one, as a slice without a profile gets no quota otherwise
+func defaultSlice(slice *apps_v1alpha.Slice) []patchOperation {
+	var patch []patchOperation
+	if slice.Spec.Profile == "" {
+		slice.Spec.Profile = defaultSliceProfile
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/profile", Value: defaultSliceProfile})
+	}
+	return patch
+}
+
+// defaultNodeContribution sets the port of a node contribution to the one of SSH
+func defaultNodeContribution(NC *apps_v1alpha.NodeContribution) []patchOperation {
+	var patch []patchOperation
+	if NC.Spec.Port == 0 {
+		NC.Spec.Port = defaultSSHPort
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/port", Value: defaultSSHPort})
+	}
+	return patch
+}
+
+// defaultSelectiveDeployment sets the operator of the selectors that lack one to In,
+// the weight of the preferred selectors to the highest, and the skew of the spread to the lowest
+func defaultSelectiveDeployment(SD *apps_v1alpha.SelectiveDeployment) []patchOperation {
+	var patch []patchOperation
+	for i := range SD.Spec.Selector {
+		if SD.Spec.Selector[i].Operator == "" {
+			SD.Spec.Selector[i].Operator = defaultSelectorOperator
+			patch = append(patch, patchOperation{Op: "add", Path: fmt.Sprintf("/spec/selector/%d/operator", i), Value: defaultSelectorOperator})
+		}
+		if SD.Spec.Selector[i].Preferred && SD.Spec.Selector[i].Weight == 0 {
+			SD.Spec.Selector[i].Weight = defaultSelectorWeight
+			patch = append(patch, patchOperation{Op: "add", Path: fmt.Sprintf("/spec/selector/%d/weight", i), Value: defaultSelectorWeight})
+		}
+	}
+	if distribution := SD.Spec.Distribution; distribution != nil && distribution.Mode == apps_v1alpha.DistributionSpread && distribution.MaxSkew == 0 {
+		distribution.MaxSkew = defaultMaxSkew
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/distribution/maxSkew", Value: defaultMaxSkew})
+	}
+	return patch
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/geo"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// The values that the enumerated fields accept, as declared in the custom resource definitions
+var (
+	sliceTypes        = []string{"Classroom", "Experiment", "Testing", "Development"}
+	sliceProfiles     = []string{"Low", "Medium", "High"}
+	verificationKinds = []string{"Authority", "User", "Email"}
+	selectorNames     = []string{"City", "State", "Country", "Continent", "ASN", "Polygon", "Radius", "Nearest"}
+	selectorOperators = []string{"In", "NotIn"}
+	distributionModes = []string{apps_v1alpha.DistributionPerValue, apps_v1alpha.DistributionSpread}
+	topologies        = []string{"City", "State", "Country", "Continent"}
+	claimNames        = []string{"Default", "Privilege", "Reward"}
+	dropNames         = []string{"Equilibrate", "Temporary"}
+	weekDays          = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+)
+
+const immutableFieldText = "field is immutable"
+
+// validateAuthoritySpec checks the fields that an authority and an authority request share
+func validateAuthoritySpec(fullName, shortName, URL string, address apps_v1alpha.Address, contact apps_v1alpha.Contact, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateRequired(fullName, path.Child("fullname"))...)
+	allErrs = append(allErrs, validateRequired(shortName, path.Child("shortname"))...)
+	allErrs = append(allErrs, validateURL(URL, true, path.Child("url"))...)
+	allErrs = append(allErrs, validateRequired(address.City, path.Child("address", "city"))...)
+	allErrs = append(allErrs, validateRequired(address.Country, path.Child("address", "country"))...)
+	allErrs = append(allErrs, validateContact(contact, path.Child("contact"))...)
+	return allErrs
+}
+
+// validateContact checks the contact person of an authority who becomes its first user
+func validateContact(contact apps_v1alpha.Contact, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateName(contact.Username, path.Child("username"))...)
+	allErrs = append(allErrs, validateRequired(contact.FirstName, path.Child("firstname"))...)
+	allErrs = append(allErrs, validateRequired(contact.LastName, path.Child("lastname"))...)
+	allErrs = append(allErrs, validateEmail(contact.Email, path.Child("email"))...)
+	return allErrs
+}
+
+// ValidateAuthority checks the spec of an authority
+func ValidateAuthority(authority *apps_v1alpha.Authority) field.ErrorList {
+	spec := authority.Spec
+	return validateAuthoritySpec(spec.FullName, spec.ShortName, spec.URL, spec.Address, spec.Contact, field.NewPath("spec"))
+}
+
+// ValidateAuthorityRequest checks the spec of an authority request
+func ValidateAuthorityRequest(authorityRequest *apps_v1alpha.AuthorityRequest) field.ErrorList {
+	spec := authorityRequest.Spec
+	return validateAuthoritySpec(spec.FullName, spec.ShortName, spec.URL, spec.Address, spec.Contact, field.NewPath("spec"))
+}
+
+// ValidateTeam checks the spec of a team
+func ValidateTeam(team *apps_v1alpha.Team) field.ErrorList {
+	allErrs := field.ErrorList{}
+	usersPath := field.NewPath("spec", "users")
+	for i, user := range team.Spec.Users {
+		allErrs = append(allErrs, validateParticipant(user.Authority, user.Username, usersPath.Index(i))...)
+	}
+	return allErrs
+}
+
+// ValidateSlice checks the spec of a slice, the profile is expected to be defaulted beforehand
+func ValidateSlice(slice *apps_v1alpha.Slice) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateEnum(slice.Spec.Type, sliceTypes, specPath.Child("type"))...)
+	allErrs = append(allErrs, validateEnum(slice.Spec.Profile, sliceProfiles, specPath.Child("profile"))...)
+	if len(slice.Spec.Users) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("users"), "a slice needs at least one user"))
+	}
+	for i, user := range slice.Spec.Users {
+		allErrs = append(allErrs, validateParticipant(user.Authority, user.Username, specPath.Child("users").Index(i))...)
+	}
+	return allErrs
+}
+
+// ValidateUser checks the spec of a user
+func ValidateUser(user *apps_v1alpha.User) field.ErrorList {
+	spec := user.Spec
+	return validatePerson(spec.FirstName, spec.LastName, spec.Email, spec.URL, field.NewPath("spec"))
+}
+
+// ValidateUserRegistrationRequest checks the spec of a user registration request
+func ValidateUserRegistrationRequest(userRequest *apps_v1alpha.UserRegistrationRequest) field.ErrorList {
+	allErrs := validateName(userRequest.GetName(), field.NewPath("metadata", "name"))
+	spec := userRequest.Spec
+	return append(allErrs, validatePerson(spec.FirstName, spec.LastName, spec.Email, spec.URL, field.NewPath("spec"))...)
+}
+
+// ValidateUserStatusUpdate rejects the changes to the acceptable use policy of a user that the policy object does not approve.
+// The user controller reverts these changes anyway, the webhook saves the round trip.
+func ValidateUserStatusUpdate(user, oldUser *apps_v1alpha.User, AUP *apps_v1alpha.AcceptableUsePolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if user.Status.AUP != oldUser.Status.AUP && (AUP == nil || AUP.Spec.Accepted != user.Status.AUP) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("status", "aup"), "must follow the acceptable use policy of the user"))
+	}
+	return allErrs
+}
+
+// ValidateAcceptableUsePolicy checks that the acceptable use policy belongs to a user of the namespace, whose name it takes
+func ValidateAcceptableUsePolicy(AUP *apps_v1alpha.AcceptableUsePolicy, user *apps_v1alpha.User) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if user == nil {
+		allErrs = append(allErrs, field.NotFound(field.NewPath("metadata", "name"), AUP.GetName()))
+	}
+	return allErrs
+}
+
+// ValidateAcceptableUsePolicyUpdate checks the acceptable use policy and rejects its acceptance by a user who is not active
+func ValidateAcceptableUsePolicyUpdate(AUP, oldAUP *apps_v1alpha.AcceptableUsePolicy, user *apps_v1alpha.User) field.ErrorList {
+	allErrs := ValidateAcceptableUsePolicy(AUP, user)
+	if user != nil && AUP.Spec.Accepted && !oldAUP.Spec.Accepted && !user.Spec.Active {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "accepted"), "the user must be active to accept the acceptable use policy"))
+	}
+	return allErrs
+}
+
+// ValidateEmailVerification checks the spec of an email verification
+func ValidateEmailVerification(EV *apps_v1alpha.EmailVerification) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateEnum(EV.Spec.Kind, verificationKinds, specPath.Child("kind"))...)
+	allErrs = append(allErrs, validateRequired(EV.Spec.Identifier, specPath.Child("identifier"))...)
+	return allErrs
+}
+
+// ValidateEmailVerificationUpdate checks the email verification and rejects the changes of what it verifies
+func ValidateEmailVerificationUpdate(EV, oldEV *apps_v1alpha.EmailVerification) field.ErrorList {
+	allErrs := ValidateEmailVerification(EV)
+	specPath := field.NewPath("spec")
+	if EV.Spec.Kind != oldEV.Spec.Kind {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("kind"), EV.Spec.Kind, immutableFieldText))
+	}
+	if EV.Spec.Identifier != oldEV.Spec.Identifier {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("identifier"), EV.Spec.Identifier, immutableFieldText))
+	}
+	return allErrs
+}
+
+// ValidateNodeContribution checks the spec of a node contribution
+func ValidateNodeContribution(NC *apps_v1alpha.NodeContribution) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	if net.ParseIP(NC.Spec.Host) == nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("host"), NC.Spec.Host, "must be an IPv4 or IPv6 address"))
+	}
+	for _, msg := range validation.IsValidPortNum(NC.Spec.Port) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("port"), NC.Spec.Port, msg))
+	}
+	for i, limitation := range NC.Spec.Limitations {
+		allErrs = append(allErrs, validateName(limitation.Authority, specPath.Child("limitations").Index(i).Child("authority"))...)
+	}
+	if location := NC.Spec.Location; location != nil {
+		locationPath := specPath.Child("location")
+		if location.Latitude < -90 || location.Latitude > 90 {
+			allErrs = append(allErrs, field.Invalid(locationPath.Child("latitude"), location.Latitude, "must be within [-90, 90]"))
+		}
+		if location.Longitude < -180 || location.Longitude > 180 {
+			allErrs = append(allErrs, field.Invalid(locationPath.Child("longitude"), location.Longitude, "must be within [-180, 180]"))
+		}
+		if location.Country != "" && !countryCode.MatchString(location.Country) {
+			allErrs = append(allErrs, field.Invalid(locationPath.Child("country"), location.Country, "must be an ISO 3166-1 alpha-2 code"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateTotalResourceQuota checks the claims and drops of a total resource quota
+func ValidateTotalResourceQuota(TRQ *apps_v1alpha.TotalResourceQuota) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	for i, claim := range TRQ.Spec.Claim {
+		allErrs = append(allErrs, validateResourceDetails(claim, claimNames, specPath.Child("claim").Index(i))...)
+	}
+	for i, drop := range TRQ.Spec.Drop {
+		allErrs = append(allErrs, validateResourceDetails(drop, dropNames, specPath.Child("drop").Index(i))...)
+	}
+	return allErrs
+}
+
+// validateResourceDetails checks that a claim or a drop has a known name and quantities of resources
+func validateResourceDetails(details apps_v1alpha.TotalResourceDetails, names []string, path *field.Path) field.ErrorList {
+	allErrs := validateEnum(details.Name, names, path.Child("name"))
+	allErrs = append(allErrs, validateQuantity(details.CPU, path.Child("cpu"))...)
+	allErrs = append(allErrs, validateQuantity(details.Memory, path.Child("memory"))...)
+	return allErrs
+}
+
+// ValidateSelectiveDeployment checks the workloads and the selectors of a selective deployment
+func ValidateSelectiveDeployment(SD *apps_v1alpha.SelectiveDeployment) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	workloads := SD.Spec.Workloads
+	if len(workloads.Deployment)+len(workloads.DaemonSet)+len(workloads.StatefulSet)+len(workloads.Job)+len(workloads.CronJob)+len(workloads.Objects) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("workloads"), "a selective deployment needs at least one workload"))
+	}
+	for i, workloadObj := range workloads.Objects {
+		objectPath := specPath.Child("workloads", "objects").Index(i)
+		if workloadObj.GetAPIVersion() == "" || workloadObj.GetKind() == "" {
+			allErrs = append(allErrs, field.Required(objectPath, "a workload needs its apiVersion and kind"))
+		}
+		if workloadObj.GetName() == "" {
+			allErrs = append(allErrs, field.Required(objectPath.Child("metadata", "name"), "a workload needs a name"))
+		}
+	}
+	if len(SD.Spec.Selector) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("selector"), "a selective deployment needs at least one selector"))
+	}
+	for i, selector := range SD.Spec.Selector {
+		allErrs = append(allErrs, validateSelector(selector, specPath.Child("selector").Index(i))...)
+	}
+	if SD.Spec.Distribution != nil {
+		allErrs = append(allErrs, validateDistribution(*SD.Spec.Distribution, SD.Spec.Selector, specPath.Child("distribution"))...)
+	}
+	if SD.Spec.Rollout != nil {
+		// The stages of a rollout are made of the regions that the PerValue mode runs a copy of the workloads in
+		if SD.Spec.Distribution == nil || SD.Spec.Distribution.Mode != apps_v1alpha.DistributionPerValue {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("rollout"), "", "needs the PerValue distribution"))
+		}
+		allErrs = append(allErrs, validateRollout(*SD.Spec.Rollout, specPath.Child("rollout"))...)
+	}
+	return allErrs
+}
+
+// validateRollout checks that the stages of a rollout have distinct names and that the stage to promote is one of them
+func validateRollout(rollout apps_v1alpha.Rollout, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(rollout.Stages) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("stages"), "a rollout needs at least one stage"))
+	}
+	names := map[string]bool{apps_v1alpha.RolloutRemaining: true}
+	for i, stage := range rollout.Stages {
+		stagePath := path.Child("stages").Index(i)
+		allErrs = append(allErrs, validateRequired(stage.Name, stagePath.Child("name"))...)
+		if stage.Name == apps_v1alpha.RolloutRemaining {
+			allErrs = append(allErrs, field.Invalid(stagePath.Child("name"), stage.Name, "is the name of the stage of the regions that no stage lists"))
+		} else if names[stage.Name] {
+			allErrs = append(allErrs, field.Duplicate(stagePath.Child("name"), stage.Name))
+		}
+		names[stage.Name] = true
+		if len(stage.Regions) == 0 {
+			allErrs = append(allErrs, field.Required(stagePath.Child("regions"), "a stage needs at least one region"))
+		}
+	}
+	if rollout.BakeTime.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("bakeTime"), rollout.BakeTime.String(), "must be greater than or equal to 0"))
+	}
+	if rollout.Promote != "" && !names[rollout.Promote] {
+		allErrs = append(allErrs, field.NotFound(path.Child("promote"), rollout.Promote))
+	}
+	return allErrs
+}
+
+// validateDistribution checks that a distribution has the fields of its mode only
+func validateDistribution(distribution apps_v1alpha.Distribution, selectors []apps_v1alpha.Selector, path *field.Path) field.ErrorList {
+	allErrs := validateEnum(distribution.Mode, distributionModes, path.Child("mode"))
+	switch distribution.Mode {
+	case apps_v1alpha.DistributionPerValue:
+		if distribution.Replicas < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("replicas"), distribution.Replicas, "must be greater than 0"))
+		}
+		if distribution.Topology != "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("topology"), distribution.Topology, "only applies to the Spread mode"))
+		}
+		if distribution.MaxSkew != 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxSkew"), distribution.MaxSkew, "only applies to the Spread mode"))
+		}
+		// The regions are the values of the selectors that require the nodes in them
+		regions := false
+		for _, selector := range selectors {
+			if selector.Operator == "In" && !selector.Preferred {
+				regions = true
+			}
+		}
+		if !regions {
+			allErrs = append(allErrs, field.Invalid(path.Child("mode"), distribution.Mode, "needs a selector with the In operator that is not preferred"))
+		}
+	case apps_v1alpha.DistributionSpread:
+		allErrs = append(allErrs, validateEnum(distribution.Topology, topologies, path.Child("topology"))...)
+		if distribution.MaxSkew < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxSkew"), distribution.MaxSkew, "must be greater than 0"))
+		}
+		if distribution.Replicas != 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("replicas"), distribution.Replicas, "only applies to the PerValue mode"))
+		}
+	}
+	return allErrs
+}
+
+// validateSelector checks a selector, including the polygons that the handler would otherwise fail to parse
+func validateSelector(selector apps_v1alpha.Selector, path *field.Path) field.ErrorList {
+	allErrs := validateEnum(selector.Name, selectorNames, path.Child("name"))
+	allErrs = append(allErrs, validateEnum(string(selector.Operator), selectorOperators, path.Child("operator"))...)
+	if selector.Quantity < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("quantity"), selector.Quantity, "must be greater than or equal to 0"))
+	}
+	if len(selector.Value) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("value"), "a selector needs at least one value"))
+	}
+	if selector.Preferred && (selector.Weight < 1 || selector.Weight > 100) {
+		allErrs = append(allErrs, field.Invalid(path.Child("weight"), selector.Weight, "must be within [1, 100]"))
+	} else if !selector.Preferred && selector.Weight != 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("weight"), selector.Weight, "only applies to the preferred selectors"))
+	}
+	for i, value := range selector.Value {
+		valuePath := path.Child("value").Index(i)
+		var err error
+		switch selector.Name {
+		case "Polygon":
+			// The value is either GeoJSON or an array of [longitude, latitude] points
+			_, err = geo.Parse([]byte(value))
+		case "Radius":
+			_, _, err = geo.ParseRadius(value)
+		case "Nearest":
+			_, _, err = geo.ParseNearest(value)
+		case "ASN":
+			if !asNumber.MatchString(value) {
+				allErrs = append(allErrs, field.Invalid(valuePath, value, "must be an autonomous system number, such as 2200 or AS2200"))
+			}
+		default:
+			allErrs = append(allErrs, validateRequired(value, valuePath)...)
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(valuePath, value, err.Error()))
+		}
+	}
+	for i, window := range selector.Windows {
+		windowPath := path.Child("windows").Index(i)
+		if _, err := time.Parse("15:04", window.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("start"), window.Start, "must be in the HH:MM form"))
+		}
+		if _, err := time.Parse("15:04", window.End); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("end"), window.End, "must be in the HH:MM form"))
+		}
+		for j, day := range window.Days {
+			allErrs = append(allErrs, validateEnum(day, weekDays, windowPath.Child("days").Index(j))...)
+		}
+	}
+	return allErrs
+}
+
+// asNumber matches the autonomous system numbers, with or without the AS prefix
+var asNumber = regexp.MustCompile("^(?i:AS)?[0-9]{1,10}$")
+
+// countryCode matches the ISO 3166-1 alpha-2 codes of the countries, which GeoIP gives as well
+var countryCode = regexp.MustCompile("^[A-Z]{2}$")
+
+// validateParticipant checks a user who participates in a team or a slice
+func validateParticipant(authority, username string, path *field.Path) field.ErrorList {
+	allErrs := validateName(authority, path.Child("authority"))
+	return append(allErrs, validateName(username, path.Child("username"))...)
+}
+
+// validatePerson checks the identity of a user
+func validatePerson(firstName, lastName, email, URL string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateRequired(firstName, path.Child("firstname"))...)
+	allErrs = append(allErrs, validateRequired(lastName, path.Child("lastname"))...)
+	allErrs = append(allErrs, validateEmail(email, path.Child("email"))...)
+	allErrs = append(allErrs, validateURL(URL, false, path.Child("url"))...)
+	return allErrs
+}
+
+// validateName checks that the value can name an object, as authorities and users give their names to namespaces and role bindings
+func validateName(value string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Label(value) {
+		allErrs = append(allErrs, field.Invalid(path, value, msg))
+	}
+	return allErrs
+}
+
+func validateRequired(value string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	return nil
+}
+
+func validateEnum(value string, values []string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	for _, supported := range values {
+		if value == supported {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(path, value, values)}
+}
+
+func validateEmail(value string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+		return field.ErrorList{field.Invalid(path, value, "must be an email address")}
+	}
+	return nil
+}
+
+func validateURL(value string, required bool, path *field.Path) field.ErrorList {
+	if value == "" {
+		if required {
+			return field.ErrorList{field.Required(path, "")}
+		}
+		return nil
+	}
+	if parsed, err := url.ParseRequestURI(value); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return field.ErrorList{field.Invalid(path, value, "must be an http or https URL")}
+	}
+	return nil
+}
+
+func validateQuantity(value string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	if quantity.Sign() < 0 {
+		return field.ErrorList{field.Invalid(path, value, "must be greater than or equal to 0")}
+	}
+	return nil
+}
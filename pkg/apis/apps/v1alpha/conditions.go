@@ -0,0 +1,146 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionReady is the condition type that every EdgeNet resource reports. It is true once the
+// controller has brought the object to the desired state, and its reason tells what went wrong otherwise.
+const ConditionReady = "Ready"
+
+// The condition types specific to the selective deployments
+const (
+	// ConditionWorkloadsCreated is false when a workload cannot be created or is owned by another selective deployment
+	ConditionWorkloadsCreated = "WorkloadsCreated"
+	// ConditionNodesSelected is false when the selectors do not find as many nodes as requested
+	ConditionNodesSelected = "NodesSelected"
+)
+
+// The reasons of the conditions. Unlike the messages, they are stable and meant to be read by programs.
+const (
+	// Common to several resources
+	ReasonReconciled              = "Reconciled"
+	ReasonAuthorityDisabled       = "AuthorityDisabled"
+	ReasonNamespaceCreationFailed = "NamespaceCreationFailed"
+	ReasonUserCreationFailed      = "UserCreationFailed"
+	ReasonEmailInUse              = "EmailInUse"
+	ReasonVerificationEmailSent   = "VerificationEmailSent"
+	ReasonVerificationEmailFailed = "VerificationEmailFailed"
+	ReasonExpired                 = "Expired"
+	// Email verifications
+	ReasonAwaitingVerification = "AwaitingVerification"
+	// Authority requests
+	ReasonAuthorityNameTaken      = "AuthorityNameTaken"
+	ReasonAuthorityCreationFailed = "AuthorityCreationFailed"
+	// User registration requests
+	ReasonUsernameTaken = "UsernameTaken"
+	// Teams
+	ReasonTeamDisabled = "TeamDisabled"
+	// Users
+	ReasonCertificateFailed = "CertificateFailed"
+	ReasonKubeconfigFailed  = "KubeconfigFailed"
+	// Acceptable use policies
+	ReasonAccepted        = "Accepted"
+	ReasonNotAccepted     = "NotAccepted"
+	ReasonExpirySetFailed = "ExpirySetFailed"
+	// Total resource quotas
+	ReasonQuotaApplied      = "QuotaApplied"
+	ReasonQuotaExceeded     = "QuotaExceeded"
+	ReasonQuotaDisabled     = "QuotaDisabled"
+	ReasonQuotaUpdateFailed = "QuotaUpdateFailed"
+	// Selective deployments
+	ReasonWorkloadsRunning       = "WorkloadsRunning"
+	ReasonWorkloadCreationFailed = "WorkloadCreationFailed"
+	ReasonWorkloadInUse          = "WorkloadInUse"
+	ReasonWorkloadKindUnknown    = "WorkloadKindUnknown"
+	ReasonFewerNodes             = "FewerNodes"
+	ReasonGeoJSONError           = "GeoJSONError"
+	ReasonInvalidSelectorValue   = "InvalidSelectorValue"
+	ReasonNoWorkloads            = "NoWorkloads"
+	ReasonDryRun                 = "DryRun"
+	// Node contributions
+	ReasonInvalidHost            = "InvalidHost"
+	ReasonQueued                 = "Queued"
+	ReasonInstalling             = "Installing"
+	ReasonInstallationFailed     = "InstallationFailed"
+	ReasonDNSConfigurationFailed = "DNSConfigurationFailed"
+	ReasonSchedulingFailed       = "SchedulingFailed"
+	ReasonOwnerReferenceFailed   = "OwnerReferenceFailed"
+	ReasonLimitationFailed       = "LimitationFailed"
+	ReasonConnectionFailed       = "ConnectionFailed"
+	ReasonRecovering             = "Recovering"
+	ReasonRecoveryFailed         = "RecoveryFailed"
+	ReasonTimeout                = "Timeout"
+	ReasonNodeRunning            = "NodeRunning"
+	ReasonNodeNotReady           = "NodeNotReady"
+)
+
+// The reasons that only show up in the events, as they tell what the controllers did rather than how the objects are
+const (
+	// Common to several resources
+	ReasonCleanedUp = "CleanedUp"
+	ReasonDeleted   = "Deleted"
+	// Email verifications
+	ReasonVerified = "Verified"
+	ReasonTampered = "Tampered"
+	// Slices
+	ReasonRenewed             = "Renewed"
+	ReasonExpiryReminder      = "ExpiryReminder"
+	ReasonParticipantsChanged = "ParticipantsChanged"
+	// Total resource quotas
+	ReasonSliceEvicted = "SliceEvicted"
+	// Node contributions
+	ReasonNodeRemoved      = "NodeRemoved"
+	ReasonLocationRejected = "LocationRejected"
+)
+
+// ConditionedStatus holds the standard conditions of a resource along with the generation of the
+// object that the controller observed last
+type ConditionedStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates the condition of the given type as observed at the generation of the object.
+// The transition time only changes along with the status of the condition.
+func (s *ConditionedStatus) SetCondition(generation int64, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	s.ObservedGeneration = generation
+	meta.SetStatusCondition(&s.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// SetReady sets the ready condition, as true if ready and false otherwise
+func (s *ConditionedStatus) SetReady(generation int64, ready bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	s.SetCondition(generation, ConditionReady, status, reason, message)
+}
+
+// GetCondition returns the condition of the given type, nil if there is none
+func (s *ConditionedStatus) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(s.Conditions, conditionType)
+}
@@ -22,6 +22,7 @@ import (
 	batchv1beta "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // +genclient
@@ -45,11 +46,70 @@ type SelectiveDeploymentSpec struct {
 	// The controller indicates the name and type of controller desired to configure
 	// Workloads: deployment, daemonset, and statefulsets
 	// The type is for defining which kind of selectivedeployment it is, you could find the list of active types below.
-	// Types of selector: city, state, country, continent, and polygon
+	// Types of selector: city, state, country, continent, polygon, radius, and nearest
 	// The value represents the desired filter and it must be compatible with the type of selectivedeployment
 	Workloads Workloads  `json:"workloads"`
 	Selector  []Selector `json:"selector"`
 	Recovery  bool       `json:"recovery"`
+	// Distribution spreads the replicas of the deployments and statefulsets over the regions that the selectors pick
+	Distribution *Distribution `json:"distribution,omitempty"`
+	// DryRun resolves the selectors into the status without creating or updating any workload
+	DryRun bool `json:"dryRun,omitempty"`
+	// Rollout brings the changes of the workloads to the regions of the PerValue distribution stage by stage
+	Rollout *Rollout `json:"rollout,omitempty"`
+}
+
+// The modes of distribution
+const (
+	// DistributionPerValue runs a copy of the workload with a fixed number of replicas for each selector value
+	DistributionPerValue = "PerValue"
+	// DistributionSpread spreads the replicas of the workload evenly across the cities, states, countries, or continents
+	DistributionSpread = "Spread"
+)
+
+// Distribution defines how the replicas of a workload spread over the regions
+type Distribution struct {
+	// Mode is either PerValue or Spread
+	Mode string `json:"mode"`
+	// Replicas is the number of replicas for each selector value in the PerValue mode
+	Replicas int32 `json:"replicas,omitempty"`
+	// Topology is the level that the Spread mode balances the replicas across: City, State, Country, or Continent
+	Topology string `json:"topology,omitempty"`
+	// MaxSkew is the largest difference allowed between the replicas of two regions in the Spread mode, 1 by default
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+}
+
+// RolloutRemaining is the name of the last stage of a rollout, which has the regions that no stage lists
+const RolloutRemaining = "remaining"
+
+// The phases of a rollout
+const (
+	RolloutProgressing = "Progressing"
+	RolloutBaking      = "Baking"
+	RolloutPaused      = "Paused"
+	RolloutCompleted   = "Completed"
+	RolloutRolledBack  = "RolledBack"
+)
+
+// Rollout defines the stages that a change of the workloads goes through. A stage starts once the replicas
+// of the stage before are all ready and have run for the bake time.
+type Rollout struct {
+	Stages []RolloutStage `json:"stages"`
+	// BakeTime is how long the replicas of a stage run ready before the next stage starts
+	BakeTime metav1.Duration `json:"bakeTime,omitempty"`
+	// Paused keeps the rollout at its current stage
+	Paused bool `json:"paused,omitempty"`
+	// Promote is the name of a stage that the rollout reaches without waiting for the stages before it
+	Promote string `json:"promote,omitempty"`
+	// Rollback brings the regions that the change reached back to the workloads they ran before
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// RolloutStage is a group of regions that get a change at once
+type RolloutStage struct {
+	Name string `json:"name"`
+	// Regions are the selector values that make the regions, such as Europe or FR
+	Regions []string `json:"regions"`
 }
 
 // Workloads indicates deployments, daemonsets or statefulsets
@@ -59,6 +119,9 @@ type Workloads struct {
 	StatefulSet []appsv1.StatefulSet  `json:"statefulset"`
 	Job         []batchv1.Job         `json:"job"`
 	CronJob     []batchv1beta.CronJob `json:"cronjob"`
+	// Objects are the workloads of the other kinds, which the controller knows the pod spec path of
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Objects []unstructured.Unstructured `json:"objects,omitempty"`
 }
 
 // Selector to define desired node filtering parameters
@@ -67,6 +130,22 @@ type Selector struct {
 	Value    []string                    `json:"value"`
 	Operator corev1.NodeSelectorOperator `json:"operator"`
 	Quantity int                         `json:"quantity"`
+	// Preferred makes the scheduler favor the nodes selected, weighted within [1, 100], rather than require them.
+	// The workloads then run on the other nodes as well while the selected ones are unavailable.
+	Preferred bool  `json:"preferred,omitempty"`
+	Weight    int32 `json:"weight,omitempty"`
+	// Windows limit the nodes selected to the ones whose local time is in one of the windows, the nodes
+	// join and leave the selection as the windows open and close
+	Windows []TimeWindow `json:"windows,omitempty"`
+}
+
+// TimeWindow is a daily period in the local time of the nodes, such as 22:00 to 06:00
+type TimeWindow struct {
+	// Start and End are in the HH:MM form, a window whose end comes before its start runs past midnight
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Days are the days of the week that the window starts on, such as Sat and Sun, every day by default
+	Days []string `json:"days,omitempty"`
 }
 
 // SelectiveDeploymentStatus is the status for a SelectiveDeployment resource
@@ -74,6 +153,57 @@ type SelectiveDeploymentStatus struct {
 	Ready   string   `json:"ready"`
 	State   string   `json:"state"`
 	Message []string `json:"message"`
+	// Nodes are the nodes that the radius and nearest selectors picked, along with their distances to the points given
+	Nodes []SelectedNode `json:"nodes,omitempty"`
+	// Regions are the readiness of the replicas in each region when the spec has a distribution
+	Regions []RegionStatus `json:"regions,omitempty"`
+	// Preview is the outcome of each selector in the dry run mode
+	Preview []SelectorPreview `json:"preview,omitempty"`
+	// Rollout is the progress of the change of the workloads across the stages
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	ConditionedStatus `json:",inline"`
+}
+
+// RegionStatus is the readiness of the replicas of a workload in a region
+type RegionStatus struct {
+	Workload string `json:"workload"`
+	Region   string `json:"region"`
+	// Ready is the number of ready replicas out of the replicas, e.g. 2/3
+	Ready string `json:"ready"`
+	// Revision is the revision of the workloads that the region runs during a rollout
+	Revision string `json:"revision,omitempty"`
+}
+
+// RolloutStatus is the stage that the rollout of a revision has reached
+type RolloutStatus struct {
+	// Revision is the hash of the workloads that the rollout brings to the regions
+	Revision string `json:"revision"`
+	// Stage is the index of the current stage, the remaining stage comes after the stages of the spec
+	Stage     int    `json:"stage"`
+	StageName string `json:"stageName,omitempty"`
+	Phase     string `json:"phase"`
+	// BakeStarted is when the replicas of the current stage became ready
+	BakeStarted *metav1.Time `json:"bakeStarted,omitempty"`
+}
+
+// SelectorPreview is the nodes that a selector picks, and the shortfall if they are fewer than the quantity
+type SelectorPreview struct {
+	// Selector is the index of the selector in the spec
+	Selector  int      `json:"selector"`
+	Name      string   `json:"name"`
+	Nodes     []string `json:"nodes"`
+	Count     int      `json:"count"`
+	Shortfall int      `json:"shortfall,omitempty"`
+}
+
+// SelectedNode is a node that a distance-based selector picked
+type SelectedNode struct {
+	// Selector is the index of the selector in the spec
+	Selector int    `json:"selector"`
+	Name     string `json:"name"`
+	// Distance is the great-circle distance in meters between the node and the point of the selector
+	Distance int64 `json:"distance"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -135,6 +265,8 @@ type Address struct {
 type AuthorityStatus struct {
 	State   string   `json:"state"`
 	Message []string `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -180,6 +312,8 @@ type AuthorityRequestStatus struct {
 	Expires       *metav1.Time `json:"expires"`
 	State         string       `json:"state"`
 	Message       []string     `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -224,6 +358,8 @@ type TeamUsers struct {
 type TeamStatus struct {
 	State   string   `json:"state"`
 	Message []string `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -271,6 +407,8 @@ type SliceStatus struct {
 	Expires *metav1.Time `json:"expires"`
 	State   string       `json:"state"`
 	Message []string     `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -315,6 +453,8 @@ type UserStatus struct {
 	AUP     bool     `json:"aup"`
 	State   string   `json:"state"`
 	Message []string `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -359,6 +499,8 @@ type UserRegistrationRequestStatus struct {
 	Expires       *metav1.Time `json:"expires"`
 	State         string       `json:"state"`
 	Message       []string     `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -398,6 +540,8 @@ type AcceptableUsePolicyStatus struct {
 	Expires *metav1.Time `json:"expires"`
 	State   string       `json:"state"`
 	Message []string     `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -438,6 +582,8 @@ type EmailVerificationStatus struct {
 	Expires *metav1.Time `json:"expires"`
 	State   string       `json:"state"`
 	Message []string     `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -474,6 +620,18 @@ type NodeContributionSpec struct {
 	Password    string        `json:"password"`
 	Enabled     bool          `json:"enabled"`
 	Limitations []Limitations `json:"limitations"`
+	// Location is where the contributor declares the node to be, which the node is labeled with instead of
+	// its GeoIP location unless GeoIP places the host in another country
+	Location *DeclaredLocation `json:"location,omitempty"`
+}
+
+// DeclaredLocation is the location of a contributed node as its contributor declares it
+type DeclaredLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	City      string  `json:"city,omitempty"`
+	// Country is the ISO 3166-1 alpha-2 code of the country
+	Country string `json:"country,omitempty"`
 }
 
 type Limitations struct {
@@ -486,6 +644,8 @@ type Limitations struct {
 type NodeContributionStatus struct {
 	State   string   `json:"state"`
 	Message []string `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -536,6 +696,8 @@ type TotalResourceQuotaStatus struct {
 	Used     TotalResourceUsed `json:"used"`
 	State    string            `json:"state"`
 	Message  []string          `json:"message"`
+
+	ConditionedStatus `json:",inline"`
 }
 
 // TotalResourceUsed presents the usage of total resource quota
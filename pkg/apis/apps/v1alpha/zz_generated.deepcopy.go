@@ -22,6 +22,10 @@ package v1alpha
 
 import (
 	v1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -114,6 +118,7 @@ func (in *AcceptableUsePolicyStatus) DeepCopyInto(out *AcceptableUsePolicyStatus
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -295,6 +300,7 @@ func (in *AuthorityRequestStatus) DeepCopyInto(out *AuthorityRequestStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -334,6 +340,7 @@ func (in *AuthorityStatus) DeepCopyInto(out *AuthorityStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -347,6 +354,29 @@ func (in *AuthorityStatus) DeepCopy() *AuthorityStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionedStatus) DeepCopyInto(out *ConditionedStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionedStatus.
+func (in *ConditionedStatus) DeepCopy() *ConditionedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Contact) DeepCopyInto(out *Contact) {
 	*out = *in
@@ -363,6 +393,38 @@ func (in *Contact) DeepCopy() *Contact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeclaredLocation) DeepCopyInto(out *DeclaredLocation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeclaredLocation.
+func (in *DeclaredLocation) DeepCopy() *DeclaredLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(DeclaredLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Distribution) DeepCopyInto(out *Distribution) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Distribution.
+func (in *Distribution) DeepCopy() *Distribution {
+	if in == nil {
+		return nil
+	}
+	out := new(Distribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmailVerification) DeepCopyInto(out *EmailVerification) {
 	*out = *in
@@ -452,6 +514,7 @@ func (in *EmailVerificationStatus) DeepCopyInto(out *EmailVerificationStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -550,6 +613,11 @@ func (in *NodeContributionSpec) DeepCopyInto(out *NodeContributionSpec) {
 		*out = make([]Limitations, len(*in))
 		copy(*out, *in)
 	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(DeclaredLocation)
+		**out = **in
+	}
 	return
 }
 
@@ -571,6 +639,7 @@ func (in *NodeContributionStatus) DeepCopyInto(out *NodeContributionStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -584,6 +653,124 @@ func (in *NodeContributionStatus) DeepCopy() *NodeContributionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionStatus) DeepCopyInto(out *RegionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionStatus.
+func (in *RegionStatus) DeepCopy() *RegionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rollout) DeepCopyInto(out *Rollout) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]RolloutStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.BakeTime = in.BakeTime
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
+func (in *Rollout) DeepCopy() *Rollout {
+	if in == nil {
+		return nil
+	}
+	out := new(Rollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStage) DeepCopyInto(out *RolloutStage) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStage.
+func (in *RolloutStage) DeepCopy() *RolloutStage {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.BakeStarted != nil {
+		in, out := &in.BakeStarted, &out.BakeStarted
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorPreview) DeepCopyInto(out *SelectorPreview) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorPreview.
+func (in *SelectorPreview) DeepCopy() *SelectorPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectedNode) DeepCopyInto(out *SelectedNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectedNode.
+func (in *SelectedNode) DeepCopy() *SelectedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectiveDeployment) DeepCopyInto(out *SelectiveDeployment) {
 	*out = *in
@@ -656,6 +843,16 @@ func (in *SelectiveDeploymentSpec) DeepCopyInto(out *SelectiveDeploymentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Distribution != nil {
+		in, out := &in.Distribution, &out.Distribution
+		*out = new(Distribution)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -677,6 +874,29 @@ func (in *SelectiveDeploymentStatus) DeepCopyInto(out *SelectiveDeploymentStatus
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]SelectedNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]RegionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = make([]SelectorPreview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -698,6 +918,13 @@ func (in *Selector) DeepCopyInto(out *Selector) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]TimeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -805,6 +1032,7 @@ func (in *SliceStatus) DeepCopyInto(out *SliceStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -924,6 +1152,7 @@ func (in *TeamStatus) DeepCopyInto(out *TeamStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -953,6 +1182,27 @@ func (in *TeamUsers) DeepCopy() *TeamUsers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TotalResourceDetails) DeepCopyInto(out *TotalResourceDetails) {
 	*out = *in
@@ -1073,6 +1323,7 @@ func (in *TotalResourceQuotaStatus) DeepCopyInto(out *TotalResourceQuotaStatus)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -1252,6 +1503,7 @@ func (in *UserRegistrationRequestStatus) DeepCopyInto(out *UserRegistrationReque
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -1289,6 +1541,7 @@ func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
 	return
 }
 
@@ -1326,6 +1579,27 @@ func (in *Workloads) DeepCopyInto(out *Workloads) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = make([]batchv1.Job, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CronJob != nil {
+		in, out := &in.CronJob, &out.CronJob
+		*out = make([]v1beta1.CronJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]unstructured.Unstructured, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -0,0 +1,378 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConversionDataAnnotation keeps the fields that one version has and the other lacks,
+// so that an object does not lose them on its way through the other version
+const ConversionDataAnnotation = "apps.edgenet.io/conversion-data"
+
+// polygonSelector is the name of the selector whose values are polygons
+const polygonSelector = "Polygon"
+
+// nodeContributionData holds the fields of node contributions that do not exist in both versions. The plaintext
+// password is not among them, as the annotations are shown to anyone who reads the object.
+type nodeContributionData struct {
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// RegisterConversions adds the conversions between v1alpha and v1beta1 to the scheme
+func RegisterConversions(s *runtime.Scheme) error {
+	// The kinds whose schema stays the same share their JSON form across the versions
+	unchanged := []struct{ alpha, beta interface{} }{
+		{(*v1alpha.Authority)(nil), (*Authority)(nil)},
+		{(*v1alpha.AuthorityRequest)(nil), (*AuthorityRequest)(nil)},
+		{(*v1alpha.User)(nil), (*User)(nil)},
+		{(*v1alpha.UserRegistrationRequest)(nil), (*UserRegistrationRequest)(nil)},
+		{(*v1alpha.AcceptableUsePolicy)(nil), (*AcceptableUsePolicy)(nil)},
+		{(*v1alpha.EmailVerification)(nil), (*EmailVerification)(nil)},
+	}
+	for _, kind := range unchanged {
+		if err := s.AddConversionFunc(kind.alpha, kind.beta, convertThroughJSON); err != nil {
+			return err
+		}
+		if err := s.AddConversionFunc(kind.beta, kind.alpha, convertThroughJSON); err != nil {
+			return err
+		}
+	}
+	funcs := []struct {
+		in, out interface{}
+		fn      conversion.ConversionFunc
+	}{
+		{(*v1alpha.SelectiveDeployment)(nil), (*SelectiveDeployment)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1alpha_SelectiveDeployment_To_v1beta1_SelectiveDeployment(a.(*v1alpha.SelectiveDeployment), b.(*SelectiveDeployment))
+		}},
+		{(*SelectiveDeployment)(nil), (*v1alpha.SelectiveDeployment)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1beta1_SelectiveDeployment_To_v1alpha_SelectiveDeployment(a.(*SelectiveDeployment), b.(*v1alpha.SelectiveDeployment))
+		}},
+		{(*v1alpha.Team)(nil), (*Team)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1alpha_Team_To_v1beta1_Team(a.(*v1alpha.Team), b.(*Team))
+		}},
+		{(*Team)(nil), (*v1alpha.Team)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1beta1_Team_To_v1alpha_Team(a.(*Team), b.(*v1alpha.Team))
+		}},
+		{(*v1alpha.Slice)(nil), (*Slice)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1alpha_Slice_To_v1beta1_Slice(a.(*v1alpha.Slice), b.(*Slice))
+		}},
+		{(*Slice)(nil), (*v1alpha.Slice)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1beta1_Slice_To_v1alpha_Slice(a.(*Slice), b.(*v1alpha.Slice))
+		}},
+		{(*v1alpha.NodeContribution)(nil), (*NodeContribution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1alpha_NodeContribution_To_v1beta1_NodeContribution(a.(*v1alpha.NodeContribution), b.(*NodeContribution))
+		}},
+		{(*NodeContribution)(nil), (*v1alpha.NodeContribution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1beta1_NodeContribution_To_v1alpha_NodeContribution(a.(*NodeContribution), b.(*v1alpha.NodeContribution))
+		}},
+		{(*v1alpha.TotalResourceQuota)(nil), (*TotalResourceQuota)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1alpha_TotalResourceQuota_To_v1beta1_TotalResourceQuota(a.(*v1alpha.TotalResourceQuota), b.(*TotalResourceQuota))
+		}},
+		{(*TotalResourceQuota)(nil), (*v1alpha.TotalResourceQuota)(nil), func(a, b interface{}, scope conversion.Scope) error {
+			return Convert_v1beta1_TotalResourceQuota_To_v1alpha_TotalResourceQuota(a.(*TotalResourceQuota), b.(*v1alpha.TotalResourceQuota))
+		}},
+	}
+	for _, f := range funcs {
+		if err := s.AddConversionFunc(f.in, f.out, f.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertThroughJSON converts an object to the other version of its kind when both have the same JSON form
+func convertThroughJSON(in, out interface{}, scope conversion.Scope) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// Convert_v1alpha_SelectiveDeployment_To_v1beta1_SelectiveDeployment parses the polygons that v1alpha holds as JSON strings.
+// The regions given as GeoJSON objects remain strings among the values.
+func Convert_v1alpha_SelectiveDeployment_To_v1beta1_SelectiveDeployment(in *v1alpha.SelectiveDeployment, out *SelectiveDeployment) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.Workloads.DeepCopyInto((*v1alpha.Workloads)(&out.Spec.Workloads))
+	out.Spec.Recovery = in.Spec.Recovery
+	out.Spec.Distribution = in.Spec.Distribution.DeepCopy()
+	out.Spec.DryRun = in.Spec.DryRun
+	out.Spec.Rollout = in.Spec.Rollout.DeepCopy()
+	out.Spec.Selector = nil
+	for _, selector := range in.Spec.Selector {
+		converted := Selector{Name: selector.Name, Operator: selector.Operator, Quantity: selector.Quantity, Preferred: selector.Preferred, Weight: selector.Weight}
+		for _, window := range selector.Windows {
+			converted.Windows = append(converted.Windows, *window.DeepCopy())
+		}
+		if selector.Name != polygonSelector {
+			converted.Values = append([]string(nil), selector.Value...)
+		} else {
+			for _, value := range selector.Value {
+				if trimmed := strings.TrimSpace(value); !strings.HasPrefix(trimmed, "[") {
+					converted.Values = append(converted.Values, value)
+					continue
+				}
+				var polygon Polygon
+				if err := json.Unmarshal([]byte(value), &polygon); err != nil {
+					return fmt.Errorf("polygon of selective deployment %s is not valid JSON: %s", in.GetName(), err)
+				}
+				converted.Polygons = append(converted.Polygons, polygon)
+			}
+		}
+		out.Spec.Selector = append(out.Spec.Selector, converted)
+	}
+	in.Status.DeepCopyInto((*v1alpha.SelectiveDeploymentStatus)(&out.Status))
+	return nil
+}
+
+// Convert_v1beta1_SelectiveDeployment_To_v1alpha_SelectiveDeployment encodes the polygons as JSON strings
+func Convert_v1beta1_SelectiveDeployment_To_v1alpha_SelectiveDeployment(in *SelectiveDeployment, out *v1alpha.SelectiveDeployment) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	(*v1alpha.Workloads)(&in.Spec.Workloads).DeepCopyInto(&out.Spec.Workloads)
+	out.Spec.Recovery = in.Spec.Recovery
+	out.Spec.Distribution = in.Spec.Distribution.DeepCopy()
+	out.Spec.DryRun = in.Spec.DryRun
+	out.Spec.Rollout = in.Spec.Rollout.DeepCopy()
+	out.Spec.Selector = nil
+	for _, selector := range in.Spec.Selector {
+		converted := v1alpha.Selector{Name: selector.Name, Operator: selector.Operator, Quantity: selector.Quantity, Preferred: selector.Preferred, Weight: selector.Weight}
+		for _, window := range selector.Windows {
+			converted.Windows = append(converted.Windows, *window.DeepCopy())
+		}
+		converted.Value = append([]string(nil), selector.Values...)
+		for _, polygon := range selector.Polygons {
+			value, err := json.Marshal(polygon)
+			if err != nil {
+				return err
+			}
+			converted.Value = append(converted.Value, string(value))
+		}
+		out.Spec.Selector = append(out.Spec.Selector, converted)
+	}
+	(*v1alpha.SelectiveDeploymentStatus)(&in.Status).DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v1alpha_Team_To_v1beta1_Team converts the team users to participants
+func Convert_v1alpha_Team_To_v1beta1_Team(in *v1alpha.Team, out *Team) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Description = in.Spec.Description
+	out.Spec.Enabled = in.Spec.Enabled
+	out.Spec.Users = nil
+	for _, user := range in.Spec.Users {
+		out.Spec.Users = append(out.Spec.Users, Participant(user))
+	}
+	in.Status.DeepCopyInto((*v1alpha.TeamStatus)(&out.Status))
+	return nil
+}
+
+// Convert_v1beta1_Team_To_v1alpha_Team converts the participants to team users
+func Convert_v1beta1_Team_To_v1alpha_Team(in *Team, out *v1alpha.Team) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Description = in.Spec.Description
+	out.Spec.Enabled = in.Spec.Enabled
+	out.Spec.Users = nil
+	for _, user := range in.Spec.Users {
+		out.Spec.Users = append(out.Spec.Users, v1alpha.TeamUsers(user))
+	}
+	(*v1alpha.TeamStatus)(&in.Status).DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v1alpha_Slice_To_v1beta1_Slice converts the slice users to participants
+func Convert_v1alpha_Slice_To_v1beta1_Slice(in *v1alpha.Slice, out *Slice) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Type = in.Spec.Type
+	out.Spec.Profile = in.Spec.Profile
+	out.Spec.Description = in.Spec.Description
+	out.Spec.Renew = in.Spec.Renew
+	out.Spec.Users = nil
+	for _, user := range in.Spec.Users {
+		out.Spec.Users = append(out.Spec.Users, Participant(user))
+	}
+	in.Status.DeepCopyInto((*v1alpha.SliceStatus)(&out.Status))
+	return nil
+}
+
+// Convert_v1beta1_Slice_To_v1alpha_Slice converts the participants to slice users
+func Convert_v1beta1_Slice_To_v1alpha_Slice(in *Slice, out *v1alpha.Slice) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Type = in.Spec.Type
+	out.Spec.Profile = in.Spec.Profile
+	out.Spec.Description = in.Spec.Description
+	out.Spec.Renew = in.Spec.Renew
+	out.Spec.Users = nil
+	for _, user := range in.Spec.Users {
+		out.Spec.Users = append(out.Spec.Users, v1alpha.SliceUsers(user))
+	}
+	(*v1alpha.SliceStatus)(&in.Status).DeepCopyInto(&out.Status)
+	return nil
+}
+
+// Convert_v1alpha_NodeContribution_To_v1beta1_NodeContribution drops the plaintext password, which v1beta1 lacks,
+// and restores the secret reference from the conversion data. The clients of v1beta1 set the secret reference instead.
+func Convert_v1alpha_NodeContribution_To_v1beta1_NodeContribution(in *v1alpha.NodeContribution, out *NodeContribution) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	data := nodeContributionData{}
+	if err := popConversionData(&out.ObjectMeta.Annotations, &data); err != nil {
+		return err
+	}
+	out.Spec = NodeContributionSpec{
+		Host:              in.Spec.Host,
+		Port:              in.Spec.Port,
+		User:              in.Spec.User,
+		PasswordSecretRef: data.PasswordSecretRef,
+		Enabled:           in.Spec.Enabled,
+		Location:          in.Spec.Location.DeepCopy(),
+	}
+	for _, limitation := range in.Spec.Limitations {
+		out.Spec.Limitations = append(out.Spec.Limitations, Limitations(limitation))
+	}
+	in.Status.DeepCopyInto((*v1alpha.NodeContributionStatus)(&out.Status))
+	return nil
+}
+
+// Convert_v1beta1_NodeContribution_To_v1alpha_NodeContribution moves the secret reference, which v1alpha lacks,
+// to the conversion data
+func Convert_v1beta1_NodeContribution_To_v1alpha_NodeContribution(in *NodeContribution, out *v1alpha.NodeContribution) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if err := popConversionData(&out.ObjectMeta.Annotations, &nodeContributionData{}); err != nil {
+		return err
+	}
+	out.Spec = v1alpha.NodeContributionSpec{
+		Host:     in.Spec.Host,
+		Port:     in.Spec.Port,
+		User:     in.Spec.User,
+		Enabled:  in.Spec.Enabled,
+		Location: in.Spec.Location.DeepCopy(),
+	}
+	for _, limitation := range in.Spec.Limitations {
+		out.Spec.Limitations = append(out.Spec.Limitations, v1alpha.Limitations(limitation))
+	}
+	(*v1alpha.NodeContributionStatus)(&in.Status).DeepCopyInto(&out.Status)
+	var secretRef *corev1.SecretKeySelector
+	if in.Spec.PasswordSecretRef != nil {
+		secretRef = in.Spec.PasswordSecretRef.DeepCopy()
+	}
+	return pushConversionData(&out.ObjectMeta.Annotations, nodeContributionData{PasswordSecretRef: secretRef})
+}
+
+// PasswordSecretRef returns the secret that holds the SSH password of a node contribution created through v1beta1, nil otherwise
+func PasswordSecretRef(NC *v1alpha.NodeContribution) *corev1.SecretKeySelector {
+	data := nodeContributionData{}
+	if raw, ok := NC.GetAnnotations()[ConversionDataAnnotation]; ok && json.Unmarshal([]byte(raw), &data) == nil {
+		return data.PasswordSecretRef
+	}
+	return nil
+}
+
+// Convert_v1alpha_TotalResourceQuota_To_v1beta1_TotalResourceQuota parses the quantities of the claims and drops
+func Convert_v1alpha_TotalResourceQuota_To_v1beta1_TotalResourceQuota(in *v1alpha.TotalResourceQuota, out *TotalResourceQuota) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Enabled = in.Spec.Enabled
+	var err error
+	if out.Spec.Claim, err = parseResourceDetails(in.Spec.Claim); err != nil {
+		return fmt.Errorf("claim of total resource quota %s: %s", in.GetName(), err)
+	}
+	if out.Spec.Drop, err = parseResourceDetails(in.Spec.Drop); err != nil {
+		return fmt.Errorf("drop of total resource quota %s: %s", in.GetName(), err)
+	}
+	out.Status = TotalResourceQuotaStatus{
+		Exceeded:          in.Status.Exceeded,
+		Used:              TotalResourceUsed(in.Status.Used),
+		State:             in.Status.State,
+		Message:           append([]string(nil), in.Status.Message...),
+		ConditionedStatus: *in.Status.ConditionedStatus.DeepCopy(),
+	}
+	return nil
+}
+
+// Convert_v1beta1_TotalResourceQuota_To_v1alpha_TotalResourceQuota formats the quantities of the claims and drops
+func Convert_v1beta1_TotalResourceQuota_To_v1alpha_TotalResourceQuota(in *TotalResourceQuota, out *v1alpha.TotalResourceQuota) error {
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec.Enabled = in.Spec.Enabled
+	out.Spec.Claim = formatResourceDetails(in.Spec.Claim)
+	out.Spec.Drop = formatResourceDetails(in.Spec.Drop)
+	out.Status = v1alpha.TotalResourceQuotaStatus{
+		Exceeded:          in.Status.Exceeded,
+		Used:              v1alpha.TotalResourceUsed(in.Status.Used),
+		State:             in.Status.State,
+		Message:           append([]string(nil), in.Status.Message...),
+		ConditionedStatus: *in.Status.ConditionedStatus.DeepCopy(),
+	}
+	return nil
+}
+
+func parseResourceDetails(in []v1alpha.TotalResourceDetails) ([]TotalResourceDetails, error) {
+	var out []TotalResourceDetails
+	for _, details := range in {
+		CPU, err := resource.ParseQuantity(details.CPU)
+		if err != nil {
+			return nil, fmt.Errorf("cpu of %s: %s", details.Name, err)
+		}
+		memory, err := resource.ParseQuantity(details.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("memory of %s: %s", details.Name, err)
+		}
+		out = append(out, TotalResourceDetails{Name: details.Name, CPU: CPU, Memory: memory, Expires: details.Expires.DeepCopy()})
+	}
+	return out, nil
+}
+
+func formatResourceDetails(in []TotalResourceDetails) []v1alpha.TotalResourceDetails {
+	var out []v1alpha.TotalResourceDetails
+	for _, details := range in {
+		out = append(out, v1alpha.TotalResourceDetails{Name: details.Name, CPU: details.CPU.String(), Memory: details.Memory.String(), Expires: details.Expires.DeepCopy()})
+	}
+	return out
+}
+
+// popConversionData reads the conversion data into data and removes the annotation
+func popConversionData(annotations *map[string]string, data interface{}) error {
+	raw, ok := (*annotations)[ConversionDataAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(*annotations, ConversionDataAnnotation)
+	if len(*annotations) == 0 {
+		*annotations = nil
+	}
+	return json.Unmarshal([]byte(raw), data)
+}
+
+// pushConversionData stores the data in the annotation unless there is nothing to keep
+func pushConversionData(annotations *map[string]string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil || string(raw) == "{}" {
+		return err
+	}
+	if *annotations == nil {
+		*annotations = make(map[string]string)
+	}
+	(*annotations)[ConversionDataAnnotation] = string(raw)
+	return nil
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2015 The Kubernetes Authors.
+Copyright 2020 Sorbonne Université
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,6 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package types contains go type information, packaged in a way that makes
-// auto-generation convenient, whether by template or straight go functions.
-package types // import "k8s.io/gengo/types"
+// +k8s:deepcopy-gen=package
+// +groupName=apps.edgenet.io
+
+package v1beta1 // import "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1beta1"
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Old Credits:
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/EdgeNet-project/edgenet/pkg/apis/apps"
+)
+
+// SchemeGroupVersion is group version used to register these objects
+var SchemeGroupVersion = schema.GroupVersion{Group: apps.GroupName, Version: "v1beta1"}
+
+// Kind takes an unqualified kind and returns back a Group qualified GroupKind
+func Kind(kind string) schema.GroupKind {
+	return SchemeGroupVersion.WithKind(kind).GroupKind()
+}
+
+// Resource takes an unqualified resource and returns a Group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+var (
+	// SchemeBuilder initializes a scheme builder
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes, RegisterConversions)
+	// AddToScheme is a global function that registers this API group & version to a scheme
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// Adds the list of known types to Scheme.
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&SelectiveDeployment{},
+		&SelectiveDeploymentList{},
+		&Authority{},
+		&AuthorityList{},
+		&AuthorityRequest{},
+		&AuthorityRequestList{},
+		&User{},
+		&UserList{},
+		&UserRegistrationRequest{},
+		&UserRegistrationRequestList{},
+		&AcceptableUsePolicy{},
+		&AcceptableUsePolicyList{},
+		&EmailVerification{},
+		&EmailVerificationList{},
+		&Slice{},
+		&SliceList{},
+		&Team{},
+		&TeamList{},
+		&NodeContribution{},
+		&NodeContributionList{},
+		&TotalResourceQuota{},
+		&TotalResourceQuotaList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
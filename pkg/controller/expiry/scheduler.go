@@ -0,0 +1,351 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expiry provides a scheduler that takes the time-based actions of the EdgeNet controllers,
+// such as sending a reminder before a slice expires or deleting a request nobody approved in time.
+// A single priority queue holds the deadlines of all objects. The informers feed it, so the deadlines
+// are recomputed from the objects, typically from Status.Expires, whenever the controllers restart.
+package expiry
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Action tells what a handler does once a deadline passes
+type Action string
+
+// The actions taken on the objects
+const (
+	Remind Action = "remind"
+	Expire Action = "expire"
+)
+
+// Default values of the scheduler
+const (
+	DefaultTimeout     = time.Minute
+	DefaultRetryPeriod = time.Minute
+)
+
+// Entry is an action to take on an object of a kind, identified by its key, at the deadline
+type Entry struct {
+	Kind     string
+	Key      string
+	Deadline time.Time
+	Action   Action
+}
+
+// DeadlinesFunc returns when the actions are due for an object, it returns none if nothing is due
+type DeadlinesFunc func(obj interface{}) map[Action]time.Time
+
+// Callback takes the action on the object, which is the latest state in the informer cache.
+// The entry is retried after a while if the callback fails.
+type Callback func(ctx context.Context, obj interface{}, action Action) error
+
+// kind holds what the scheduler needs to know about a registered kind
+type kind struct {
+	indexer   cache.Indexer
+	deadlines DeadlinesFunc
+	callback  Callback
+}
+
+// entryID identifies an entry regardless of its deadline, an object has at most one entry per action
+type entryID struct {
+	kind   string
+	key    string
+	action Action
+}
+
+// item is an entry in the queue, which runs at the deadline or at the next retry.
+// The entries whose actions are taken stay in the index without being in the queue,
+// so that they are not scheduled again until the deadline changes.
+type item struct {
+	Entry
+	at      time.Time
+	index   int
+	running bool
+}
+
+// Scheduler keeps the entries in a queue ordered by deadline and calls back the handlers as the deadlines pass
+type Scheduler struct {
+	// Timeout bounds a callback along with the API calls it makes
+	Timeout time.Duration
+	// RetryPeriod is the time to wait before the action is taken again after a callback fails
+	RetryPeriod time.Duration
+	mutex       sync.Mutex
+	queue       entryQueue
+	entries     map[entryID]*item
+	kinds       map[string]kind
+	hasSynced   []cache.InformerSynced
+	wakeUp      chan struct{}
+}
+
+// New creates an empty scheduler
+func New() *Scheduler {
+	return &Scheduler{
+		Timeout:     DefaultTimeout,
+		RetryPeriod: DefaultRetryPeriod,
+		entries:     map[entryID]*item{},
+		kinds:       map[string]kind{},
+		wakeUp:      make(chan struct{}, 1),
+	}
+}
+
+// Register feeds the scheduler with the objects of the informer. The deadlines of an object are computed
+// every time it gets added or updated, and the callback is called for the actions that are due.
+func (s *Scheduler) Register(kindName string, informer cache.SharedIndexInformer, deadlines DeadlinesFunc, callback Callback) {
+	s.mutex.Lock()
+	s.kinds[kindName] = kind{indexer: informer.GetIndexer(), deadlines: deadlines, callback: callback}
+	s.hasSynced = append(s.hasSynced, informer.HasSynced)
+	s.mutex.Unlock()
+	set := func(obj interface{}) {
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		s.Set(kindName, key, computeDeadlines(deadlines, obj))
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: set,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			set(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				utilruntime.HandleError(err)
+				return
+			}
+			s.Remove(kindName, key)
+		},
+	})
+}
+
+// computeDeadlines returns the deadlines of the object, the objects being deleted have none
+func computeDeadlines(deadlines DeadlinesFunc, obj interface{}) map[Action]time.Time {
+	if object, err := meta.Accessor(obj); err != nil || object.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	return deadlines(obj)
+}
+
+// Set replaces the entries of an object with the deadlines given.
+// An entry whose action is taken already stays as is as long as its deadline remains the same.
+func (s *Scheduler) Set(kindName, key string, deadlines map[Action]time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, action := range []Action{Remind, Expire} {
+		id := entryID{kind: kindName, key: key, action: action}
+		deadline, due := deadlines[action]
+		current, exists := s.entries[id]
+		switch {
+		case !due && exists:
+			s.remove(id)
+		case due && !exists:
+			s.push(&item{Entry: Entry{Kind: kindName, Key: key, Deadline: deadline, Action: action}, at: deadline})
+		case due && !current.Deadline.Equal(deadline):
+			current.Deadline, current.at = deadline, deadline
+			// An entry whose callback is running gets back into the queue once the callback returns
+			if current.running {
+				continue
+			}
+			if current.index < 0 {
+				s.push(current)
+			} else {
+				heap.Fix(&s.queue, current.index)
+				s.notify()
+			}
+		}
+	}
+}
+
+// Remove drops the entries of an object
+func (s *Scheduler) Remove(kindName, key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, action := range []Action{Remind, Expire} {
+		s.remove(entryID{kind: kindName, key: key, action: action})
+	}
+}
+
+// Len returns the number of entries waiting for their deadlines
+func (s *Scheduler) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.queue.Len()
+}
+
+// Next returns the entry that runs first along with the time it runs
+func (s *Scheduler) Next() (Entry, time.Time, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.queue.Len() == 0 {
+		return Entry{}, time.Time{}, false
+	}
+	return s.queue[0].Entry, s.queue[0].at, true
+}
+
+func (s *Scheduler) push(entry *item) {
+	s.entries[entryID{kind: entry.Kind, key: entry.Key, action: entry.Action}] = entry
+	heap.Push(&s.queue, entry)
+	s.notify()
+}
+
+func (s *Scheduler) remove(id entryID) {
+	entry, exists := s.entries[id]
+	if !exists {
+		return
+	}
+	delete(s.entries, id)
+	if entry.index >= 0 {
+		heap.Remove(&s.queue, entry.index)
+	}
+}
+
+// notify wakes the loop up to look at the closest deadline again
+func (s *Scheduler) notify() {
+	select {
+	case s.wakeUp <- struct{}{}:
+	default:
+	}
+}
+
+// Run waits for the caches of the informers to be synced, then takes the actions as their deadlines pass.
+// It blocks until the stop channel is closed, the ongoing callback gets cancelled on the way out.
+func (s *Scheduler) Run(stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+	s.mutex.Lock()
+	hasSynced := s.hasSynced
+	s.mutex.Unlock()
+	if !cache.WaitForCacheSync(stopCh, hasSynced...) {
+		utilruntime.HandleError(fmt.Errorf("Error syncing cache of the expiry scheduler"))
+		return
+	}
+	log.Infof("expiry: scheduler started with %d entries", s.Len())
+	for {
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if _, at, ok := s.Next(); ok {
+			timer = time.NewTimer(time.Until(at))
+			timeout = timer.C
+		}
+		select {
+		case <-stopCh:
+			if timer != nil {
+				timer.Stop()
+			}
+			log.Info("expiry: scheduler stopped")
+			return
+		case <-s.wakeUp:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-timeout:
+			s.runDue(ctx, time.Now())
+		}
+	}
+}
+
+// runDue takes the actions whose deadlines passed by now
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	for {
+		s.mutex.Lock()
+		if s.queue.Len() == 0 || s.queue[0].at.After(now) || ctx.Err() != nil {
+			s.mutex.Unlock()
+			return
+		}
+		entry := heap.Pop(&s.queue).(*item)
+		entry.running = true
+		kind, deadline := s.kinds[entry.Kind], entry.Deadline
+		s.mutex.Unlock()
+		s.run(ctx, kind, entry, deadline, now)
+	}
+}
+
+// run calls back the handler if the entry is still due according to the latest state of the object.
+// The informer updates the entry in the meantime if the object changes, the deadline tells whether it did.
+func (s *Scheduler) run(ctx context.Context, kind kind, entry *item, deadline, now time.Time) {
+	var err error
+	obj, exists, _ := kind.indexer.GetByKey(entry.Key)
+	if exists {
+		if current, due := computeDeadlines(kind.deadlines, obj)[entry.Action]; due && !current.After(now) {
+			log.Infof("expiry: %s %s %s", entry.Action, entry.Kind, entry.Key)
+			callbackCtx, cancel := context.WithTimeout(ctx, s.Timeout)
+			err = kind.callback(callbackCtx, obj, entry.Action)
+			cancel()
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entry.running = false
+	id := entryID{kind: entry.Kind, key: entry.Key, action: entry.Action}
+	switch {
+	case s.entries[id] != entry:
+		// The entry is removed along with the object
+	case !exists:
+		s.remove(id)
+	case !entry.Deadline.Equal(deadline):
+		s.push(entry)
+	case err != nil:
+		log.Errorf("expiry: failed to %s %s %s with error %v, retrying", entry.Action, entry.Kind, entry.Key, err)
+		entry.at = now.Add(s.RetryPeriod)
+		s.push(entry)
+	}
+}
+
+// entryQueue implements heap.Interface, the entry with the closest deadline comes first
+type entryQueue []*item
+
+func (q entryQueue) Len() int { return len(q) }
+
+func (q entryQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+
+func (q entryQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *entryQueue) Push(x interface{}) {
+	entry := x.(*item)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *entryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*q = old[:n-1]
+	return entry
+}
@@ -0,0 +1,145 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+// The expiry date of a namespace is kept in an annotation in these tests
+const expiresAnnotation = "edge-net.io/expires"
+
+func getDeadlines(obj interface{}) map[Action]time.Time {
+	namespace := obj.(*corev1.Namespace)
+	expires, err := time.Parse(time.RFC3339Nano, namespace.GetAnnotations()[expiresAnnotation])
+	if err != nil {
+		return nil
+	}
+	return map[Action]time.Time{Remind: expires.Add(-100 * time.Millisecond), Expire: expires}
+}
+
+// recorder is a thread-safe callback that keeps the actions it takes
+type recorder struct {
+	mutex    sync.Mutex
+	actions  []string
+	failures int
+}
+
+func (r *recorder) callback(ctx context.Context, obj interface{}, action Action) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.actions = append(r.actions, string(action)+" "+obj.(*corev1.Namespace).GetName())
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("action failed")
+	}
+	return nil
+}
+
+func (r *recorder) taken() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.actions...)
+}
+
+func newNamespace(name string, expires time.Time) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Annotations: map[string]string{expiresAnnotation: expires.Format(time.RFC3339Nano)},
+	}}
+}
+
+func TestQueue(t *testing.T) {
+	s := New()
+	now := time.Now()
+	s.Set("Namespace", "late", map[Action]time.Time{Expire: now.Add(time.Hour)})
+	s.Set("Namespace", "early", map[Action]time.Time{Remind: now, Expire: now.Add(time.Minute)})
+	util.Equals(t, 3, s.Len())
+	next, at, ok := s.Next()
+	util.Equals(t, true, ok)
+	util.Equals(t, Entry{Kind: "Namespace", Key: "early", Deadline: now, Action: Remind}, next)
+	util.Equals(t, now, at)
+
+	t.Run("postpone", func(t *testing.T) {
+		s.Set("Namespace", "early", map[Action]time.Time{Expire: now.Add(2 * time.Hour)})
+		util.Equals(t, 2, s.Len())
+		next, _, _ := s.Next()
+		util.Equals(t, "late", next.Key)
+	})
+	t.Run("remove", func(t *testing.T) {
+		s.Remove("Namespace", "late")
+		util.Equals(t, 1, s.Len())
+		next, _, _ := s.Next()
+		util.Equals(t, Entry{Kind: "Namespace", Key: "early", Deadline: now.Add(2 * time.Hour), Action: Expire}, next)
+	})
+}
+
+func TestRun(t *testing.T) {
+	client := testclient.NewSimpleClientset(
+		newNamespace("expired", time.Now().Add(-time.Hour)),
+		newNamespace("later", time.Now().Add(time.Hour)),
+	)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	informer := factory.Core().V1().Namespaces().Informer()
+	r := &recorder{}
+	s := New()
+	s.RetryPeriod = 50 * time.Millisecond
+	s.Register("Namespace", informer, getDeadlines, r.callback)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+	go s.Run(stopCh)
+
+	// The deadlines that passed while the scheduler was down are recomputed from the objects
+	time.Sleep(300 * time.Millisecond)
+	util.Equals(t, []string{"remind expired", "expire expired"}, r.taken())
+	util.Equals(t, 2, s.Len())
+
+	t.Run("deadline", func(t *testing.T) {
+		_, err := client.CoreV1().Namespaces().Create(context.TODO(), newNamespace("soon", time.Now().Add(150*time.Millisecond)), metav1.CreateOptions{})
+		util.OK(t, err)
+		time.Sleep(100 * time.Millisecond)
+		util.Equals(t, []string{"remind expired", "expire expired", "remind soon"}, r.taken()[:3])
+		time.Sleep(150 * time.Millisecond)
+		util.Equals(t, []string{"remind expired", "expire expired", "remind soon", "expire soon"}, r.taken())
+	})
+	t.Run("update", func(t *testing.T) {
+		// An update leaving the expiry date as is schedules nothing again
+		namespace, _ := client.CoreV1().Namespaces().Get(context.TODO(), "soon", metav1.GetOptions{})
+		namespace.Labels = map[string]string{"updated": "true"}
+		_, err := client.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+		util.OK(t, err)
+		time.Sleep(100 * time.Millisecond)
+		util.Equals(t, 4, len(r.taken()))
+		// Renewing the expiry date schedules the actions again
+		namespace.Annotations[expiresAnnotation] = time.Now().Add(50 * time.Millisecond).Format(time.RFC3339Nano)
+		_, err = client.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+		util.OK(t, err)
+		time.Sleep(150 * time.Millisecond)
+		util.Equals(t, []string{"remind soon", "expire soon"}, r.taken()[4:])
+	})
+	t.Run("delete", func(t *testing.T) {
+		err := client.CoreV1().Namespaces().Delete(context.TODO(), "later", metav1.DeleteOptions{})
+		util.OK(t, err)
+		time.Sleep(100 * time.Millisecond)
+		util.Equals(t, 0, s.Len())
+	})
+	t.Run("retry", func(t *testing.T) {
+		r.mutex.Lock()
+		r.failures = 1
+		r.mutex.Unlock()
+		_, err := client.CoreV1().Namespaces().Create(context.TODO(), newNamespace("failing", time.Now().Add(-time.Hour)), metav1.CreateOptions{})
+		util.OK(t, err)
+		time.Sleep(150 * time.Millisecond)
+		util.Equals(t, []string{"remind failing", "expire failing", "remind failing"}, r.taken()[6:])
+	})
+}
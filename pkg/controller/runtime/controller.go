@@ -0,0 +1,355 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runtime provides the controller loop shared by the EdgeNet controllers.
+// It watches a resource through an informer, puts the requests into a rate-limited
+// work queue, and runs a configurable number of workers that hand the requests over to a Reconciler.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Constant variables for events
+const (
+	Create = "create"
+	Update = "update"
+	Delete = "delete"
+	Resync = "resync"
+)
+
+// Default values of the options
+const (
+	DefaultWorkers    = 1
+	DefaultMaxRetries = 5
+	DefaultTimeout    = time.Minute
+)
+
+// Request is the item that goes through the queue. Change carries the fields that a controller
+// finds updated in its update filter, and Object keeps the last known state of a deleted object.
+// Both must be comparable since the queue deduplicates requests. Requests that differ by their
+// function or change are separate items, yet the controller hands over the requests of a key one at a time.
+type Request struct {
+	Key      string
+	Function string
+	Change   interface{}
+	Object   interface{}
+}
+
+// Options to configure the controller
+type Options struct {
+	// Name of the resource, used in the logs
+	Name string
+	// Workers is the number of requests processed concurrently, the workers flag applies if it is not set.
+	// The requests of a key wait for one another, whichever worker takes them.
+	Workers int
+	// MaxRetries is the number of times a request is requeued after its reconciliation fails
+	MaxRetries int
+	// Timeout bounds a reconciliation along with the API calls it makes
+	Timeout time.Duration
+	// ResyncPeriod enqueues all objects in the cache periodically if it is greater than zero
+	ResyncPeriod time.Duration
+	// AddFilter decides whether an object added to the cache is enqueued
+	AddFilter func(obj interface{}) bool
+	// UpdateFilter decides whether an update is enqueued and returns the change to be carried by the request
+	UpdateFilter func(oldObj, newObj interface{}) (interface{}, bool)
+}
+
+// Controller is the main structure of the controller runtime
+type Controller struct {
+	logger     *log.Entry
+	queue      workqueue.RateLimitingInterface
+	informer   cache.SharedIndexInformer
+	informers  []cache.SharedIndexInformer
+	reconciler Reconciler
+	options    Options
+	// ctx lasts until the controller shuts down, the reconciliations and the procedures derive their contexts from it
+	ctx        context.Context
+	cancel     context.CancelFunc
+	procedures *procedures
+	keys       *keys
+	// synced is set once the caches of the informers are synced
+	synced int32
+}
+
+// New creates a controller that feeds the reconciler with the events of the informer
+func New(informer cache.SharedIndexInformer, reconciler Reconciler, options Options) *Controller {
+	if options.Workers <= 0 {
+		options.Workers = Workers
+	}
+	if options.Workers <= 0 {
+		options.Workers = DefaultWorkers
+	}
+	if options.MaxRetries <= 0 {
+		options.MaxRetries = DefaultMaxRetries
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Controller{
+		logger:     log.WithField("controller", options.Name),
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), options.Name),
+		informer:   informer,
+		reconciler: reconciler,
+		options:    options,
+		ctx:        ctx,
+		cancel:     cancel,
+		procedures: newProcedures(ctx),
+		keys:       newKeys(),
+	}
+	// Each event creates its own request, so nothing is shared between the handler functions below
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.options.AddFilter != nil && !c.options.AddFilter(obj) {
+				return
+			}
+			key, err := cache.MetaNamespaceKeyFunc(obj)
+			if err != nil {
+				utilruntime.HandleError(err)
+				return
+			}
+			c.logger.Infof("Add %s: %s", c.options.Name, key)
+			c.Enqueue(Request{Key: key, Function: Create})
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if finalizerAdded(oldObj, newObj) {
+				return
+			}
+			// The procedures of an object stop as soon as its deletion starts
+			if object, err := meta.Accessor(newObj); err == nil && object.GetDeletionTimestamp() != nil {
+				c.procedures.release(newObj)
+			}
+			var change interface{}
+			// The deletion of an object that holds the finalizer reaches the reconciler whatever the filter says
+			if c.options.UpdateFilter != nil && !beingFinalized(newObj) {
+				var enqueue bool
+				if change, enqueue = c.options.UpdateFilter(oldObj, newObj); !enqueue {
+					return
+				}
+			}
+			key, err := cache.MetaNamespaceKeyFunc(newObj)
+			if err != nil {
+				utilruntime.HandleError(err)
+				return
+			}
+			c.logger.Infof("Update %s: %s", c.options.Name, key)
+			c.Enqueue(Request{Key: key, Function: Update, Change: change})
+		},
+		DeleteFunc: func(obj interface{}) {
+			// DeletionHandlingMetaNamespaceKeyFunc helps to check the existence of the object while it is still contained in the index.
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				utilruntime.HandleError(err)
+				return
+			}
+			// The final state of the object is unknown if the watch missed the deletion
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.procedures.release(obj)
+			c.logger.Infof("Delete %s: %s", c.options.Name, key)
+			c.Enqueue(Request{Key: key, Function: Delete, Object: obj})
+		},
+	})
+	return c
+}
+
+// AddInformer registers a secondary informer whose cache must be synced before the controller starts
+func (c *Controller) AddInformer(informer cache.SharedIndexInformer) {
+	c.informers = append(c.informers, informer)
+}
+
+// Enqueue adds a request to the queue, which is the way secondary informers trigger a reconciliation
+func (c *Controller) Enqueue(request Request) {
+	c.queue.Add(request)
+}
+
+// EnqueueAfter adds a request to the queue once the duration passes, such as to check an object again at the end of a wait
+func (c *Controller) EnqueueAfter(request Request, duration time.Duration) {
+	c.queue.AddAfter(request, duration)
+}
+
+// EnqueueObject adds a request of the given function for the object
+func (c *Controller) EnqueueObject(obj interface{}, function string) error {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return err
+	}
+	c.Enqueue(Request{Key: key, Function: function})
+	return nil
+}
+
+// Len returns the number of requests waiting in the queue
+func (c *Controller) Len() int {
+	return c.queue.Len()
+}
+
+// Context returns a context that is cancelled once the controller shuts down,
+// it serves the API calls made outside of the reconciliations such as in the event handlers of secondary informers
+func (c *Controller) Context() context.Context {
+	return c.ctx
+}
+
+// HasSynced tells whether the caches of the informers are synced and the workers are running
+func (c *Controller) HasSynced() bool {
+	return atomic.LoadInt32(&c.synced) == 1
+}
+
+// Run waits for the caches of the informers to be synced, starts the workers, and blocks until the stop channel is closed.
+// The informers come from the factories of the manager, which is responsible for starting them.
+// On the way out, it cancels the ongoing reconciliations and procedures, and waits for them to return.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	// A Go panic which includes logging and terminating
+	defer utilruntime.HandleCrash()
+	defer c.cancel()
+	c.logger.Info("run: initiating")
+	hasSynced := []cache.InformerSynced{c.informer.HasSynced}
+	for _, informer := range c.informers {
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+	// Synchronization to settle resources one
+	if !cache.WaitForCacheSync(stopCh, hasSynced...) {
+		c.queue.ShutDown()
+		utilruntime.HandleError(fmt.Errorf("Error syncing cache of %s", c.options.Name))
+		return
+	}
+	c.logger.Info("run: cache sync complete")
+	atomic.StoreInt32(&c.synced, 1)
+	if c.options.ResyncPeriod > 0 {
+		go wait.Until(c.resync, c.options.ResyncPeriod, stopCh)
+	}
+	// Operate the workers
+	var workers sync.WaitGroup
+	for i := 0; i < c.options.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			wait.Until(c.runWorker, time.Second, stopCh)
+		}()
+	}
+
+	<-stopCh
+	c.logger.Info("run: shutting down")
+	c.cancel()
+	// Shutdown after all goroutines have done
+	c.queue.ShutDown()
+	workers.Wait()
+	c.procedures.wait()
+	c.logger.Info("run: stopped")
+}
+
+// resync puts all objects in the cache into the queue
+func (c *Controller) resync() {
+	for _, key := range c.informer.GetIndexer().ListKeys() {
+		c.Enqueue(Request{Key: key, Function: Resync})
+	}
+}
+
+// To process new requests added to the queue
+func (c *Controller) runWorker() {
+	c.logger.Info("runWorker: starting")
+	// Run processNextItem for all the changes
+	for c.processNextItem() {
+	}
+
+	c.logger.Info("runWorker: completed")
+}
+
+// processNextItem takes a request from the queue and hands it over to the reconciler.
+// The request is requeued with a rate limit as long as the reconciler fails and the retries are not exhausted.
+func (c *Controller) processNextItem() bool {
+	item, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(item)
+	request := item.(Request)
+	// Another worker is handling the key, the request goes back to the queue once that worker is done
+	if !c.keys.acquire(request) {
+		return true
+	}
+	defer c.release(request.Key)
+	c.logger.Infof("processNextItem: %s %s", request.Function, request.Key)
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.WithValue(c.ctx, proceduresKey{}, c.procedures), c.options.Timeout)
+	err := c.reconciler.Reconcile(ctx, request)
+	cancel()
+	reconcileDuration.WithLabelValues(c.options.Name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(c.options.Name).Inc()
+		if c.queue.NumRequeues(item) < c.options.MaxRetries {
+			c.logger.Errorf("processNextItem: failed processing %s %s with error %v, retrying", request.Function, request.Key, err)
+			c.queue.AddRateLimited(item)
+			return true
+		}
+		c.logger.Errorf("processNextItem: failed processing %s %s with error %v, no more retries", request.Function, request.Key, err)
+		utilruntime.HandleError(err)
+	}
+	c.queue.Forget(item)
+	return true
+}
+
+// release lets the key go and puts back the requests of the key that arrived in the meantime
+func (c *Controller) release(key string) {
+	for _, request := range c.keys.release(key) {
+		c.queue.Add(request)
+	}
+}
+
+// keys tracks the keys in process along with the requests that wait for them
+type keys struct {
+	mutex   sync.Mutex
+	active  map[string]bool
+	waiting map[string][]Request
+}
+
+func newKeys() *keys {
+	return &keys{active: make(map[string]bool), waiting: make(map[string][]Request)}
+}
+
+// acquire marks the key of the request as in process, or parks the request if the key is already in process
+func (k *keys) acquire(request Request) bool {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if k.active[request.Key] {
+		k.waiting[request.Key] = append(k.waiting[request.Key], request)
+		return false
+	}
+	k.active[request.Key] = true
+	return true
+}
+
+// release marks the key as no longer in process and returns the requests parked meanwhile
+func (k *keys) release(key string) []Request {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	waiting := k.waiting[key]
+	delete(k.waiting, key)
+	delete(k.active, key)
+	return waiting
+}
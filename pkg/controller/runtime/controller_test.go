@@ -0,0 +1,313 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+// recorder is a thread-safe reconciler that keeps the requests it receives
+type recorder struct {
+	mutex    sync.Mutex
+	requests []Request
+	failures int
+}
+
+func (r *recorder) Reconcile(ctx context.Context, request Request) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.requests = append(r.requests, request)
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("reconciliation failed")
+	}
+	return nil
+}
+
+func (r *recorder) functions() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	functions := []string{}
+	for _, request := range r.requests {
+		functions = append(functions, request.Function)
+	}
+	return functions
+}
+
+// newManager creates a manager on top of the fake clientsets
+func newManager(objects ...runtime.Object) (*Manager, kubernetes.Interface) {
+	client := testclient.NewSimpleClientset(objects...)
+	return NewManager(client, edgenettestclient.NewSimpleClientset()), client
+}
+
+func TestStartController(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	manager, client := newManager()
+	reconciler := &recorder{}
+	controller := New(manager.InformerFactory.Core().V1().Nodes().Informer(), reconciler, Options{
+		Name: "node",
+		UpdateFilter: func(oldObj, newObj interface{}) (interface{}, bool) {
+			return nil, oldObj.(*corev1.Node).Spec.Unschedulable != newObj.(*corev1.Node).Spec.Unschedulable
+		},
+	})
+	manager.Add(controller)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.Run(stopCh)
+
+	node, err := client.CoreV1().Nodes().Create(context.TODO(), g.nodeObj.DeepCopy(), metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, []string{Create}, reconciler.functions())
+	// The update filter drops the updates that leave the scheduling as is
+	node.Labels = map[string]string{"edge-net.io/city": "paris"}
+	node, err = client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, []string{Create}, reconciler.functions())
+	node.Spec.Unschedulable = true
+	_, err = client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, []string{Create, Update}, reconciler.functions())
+	err = client.CoreV1().Nodes().Delete(context.TODO(), node.GetName(), metav1.DeleteOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, []string{Create, Update, Delete}, reconciler.functions())
+	// The last known state of the deleted object goes along with the request
+	reconciler.mutex.Lock()
+	deleted := reconciler.requests[2].Object.(*corev1.Node)
+	reconciler.mutex.Unlock()
+	util.Equals(t, g.nodeObj.GetName(), deleted.GetName())
+}
+
+func TestControllerFinalizer(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	manager, client := newManager()
+	reconciler := &recorder{}
+	controller := New(manager.InformerFactory.Core().V1().Nodes().Informer(), reconciler, Options{
+		Name: "node",
+		UpdateFilter: func(oldObj, newObj interface{}) (interface{}, bool) {
+			return nil, false
+		},
+	})
+	manager.Add(controller)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.Run(stopCh)
+
+	node, err := client.CoreV1().Nodes().Create(context.TODO(), g.nodeObj.DeepCopy(), metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	// Putting the finalizer on the object triggers nothing
+	AddFinalizer(node)
+	node, err = client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, []string{Create}, reconciler.functions())
+	// The deletion gets through the update filter
+	node.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	_, err = client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	util.Equals(t, []string{Create, Update}, reconciler.functions())
+}
+
+func TestControllerRetry(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	manager, client := newManager()
+	reconciler := &recorder{failures: 2}
+	controller := New(manager.InformerFactory.Core().V1().Nodes().Informer(), reconciler, Options{Name: "node"})
+	manager.Add(controller)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.Run(stopCh)
+
+	_, err := client.CoreV1().Nodes().Create(context.TODO(), g.nodeObj.DeepCopy(), metav1.CreateOptions{})
+	util.OK(t, err)
+	time.Sleep(time.Millisecond * 500)
+	// The request is put back into the queue until it succeeds
+	util.Equals(t, []string{Create, Create, Create}, reconciler.functions())
+	util.Equals(t, 0, controller.Len())
+}
+
+// overlap is a reconciler that counts the reconciliations of a key running at the same time
+type overlap struct {
+	mutex   sync.Mutex
+	running map[string]int
+	peak    int
+	done    int
+}
+
+func (o *overlap) Reconcile(ctx context.Context, request Request) error {
+	o.mutex.Lock()
+	o.running[request.Key]++
+	if o.running[request.Key] > o.peak {
+		o.peak = o.running[request.Key]
+	}
+	o.mutex.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	o.mutex.Lock()
+	o.running[request.Key]--
+	o.done++
+	o.mutex.Unlock()
+	return nil
+}
+
+func TestControllerWorkersPerKey(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	manager, _ := newManager(g.nodeObj.DeepCopy())
+	reconciler := &overlap{running: make(map[string]int)}
+	controller := New(manager.InformerFactory.Core().V1().Nodes().Informer(), reconciler, Options{Name: "node", Workers: 4})
+	manager.Add(controller)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.Run(stopCh)
+
+	time.Sleep(time.Millisecond * 200)
+	// The requests differ, so the queue keeps them apart, but they share the key
+	for _, change := range []string{"a", "b", "c"} {
+		controller.Enqueue(Request{Key: g.nodeObj.GetName(), Function: Update, Change: change})
+	}
+	controller.Enqueue(Request{Key: g.nodeObj.GetName(), Function: Resync})
+	time.Sleep(time.Millisecond * 500)
+	reconciler.mutex.Lock()
+	defer reconciler.mutex.Unlock()
+	util.Equals(t, 5, reconciler.done)
+	util.Equals(t, 1, reconciler.peak)
+}
+
+func TestControllerResync(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	manager, _ := newManager(g.nodeObj.DeepCopy())
+	reconciler := &recorder{}
+	controller := New(manager.InformerFactory.Core().V1().Nodes().Informer(), reconciler, Options{Name: "node", ResyncPeriod: 200 * time.Millisecond})
+	manager.Add(controller)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.Run(stopCh)
+
+	time.Sleep(time.Millisecond * 500)
+	functions := reconciler.functions()
+	util.Equals(t, Create, functions[0])
+	util.Equals(t, Resync, functions[len(functions)-1])
+}
+
+func TestManagerProbes(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	manager, _ := newManager(g.nodeObj.DeepCopy())
+	reconciler := &recorder{failures: 1}
+	controller := New(manager.InformerFactory.Core().V1().Nodes().Informer(), reconciler, Options{Name: "probe"})
+	manager.Add(controller)
+	mux := newServeMux(manager.Ready)
+	probe := func(path string) (int, string) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+		return recorder.Code, recorder.Body.String()
+	}
+	code, _ := probe("/healthz")
+	util.Equals(t, 200, code)
+	// The manager is not ready until the caches are synced
+	code, _ = probe("/readyz")
+	util.Equals(t, 503, code)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go manager.Run(stopCh)
+	time.Sleep(time.Millisecond * 500)
+	code, _ = probe("/readyz")
+	util.Equals(t, 200, code)
+	code, body := probe("/metrics")
+	util.Equals(t, 200, code)
+	for _, line := range []string{
+		"edgenet_controller_reconcile_duration_seconds_count{controller=\"probe\"} 2\n",
+		"edgenet_controller_reconcile_errors_total{controller=\"probe\"} 1\n",
+		"workqueue_retries_total{name=\"probe\"} 1\n",
+		"workqueue_depth{name=\"probe\"} 0\n",
+	} {
+		util.Equals(t, true, strings.Contains(body, line))
+	}
+}
+
+func TestControllerProcedures(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	manager, client := newManager()
+	deadlines := make(chan bool, 2)
+	stopped := make(chan string, 2)
+	reconciler := ReconcilerFunc(func(ctx context.Context, request Request) error {
+		_, hasDeadline := ctx.Deadline()
+		deadlines <- hasDeadline
+		if request.Function != Create {
+			return nil
+		}
+		obj, _, _ := manager.InformerFactory.Core().V1().Nodes().Informer().GetIndexer().GetByKey(request.Key)
+		Go(ctx, obj, func(ctx context.Context) {
+			<-ctx.Done()
+			stopped <- request.Key
+		})
+		return nil
+	})
+	controller := New(manager.InformerFactory.Core().V1().Nodes().Informer(), reconciler, Options{Name: "node"})
+	manager.Add(controller)
+	stopCh := make(chan struct{})
+	managerStopped := make(chan bool)
+	go func() {
+		manager.Run(stopCh)
+		managerStopped <- true
+	}()
+
+	g.nodeObj.SetUID("deleted")
+	_, err := client.CoreV1().Nodes().Create(context.TODO(), g.nodeObj.DeepCopy(), metav1.CreateOptions{})
+	util.OK(t, err)
+	remaining := g.nodeObj.DeepCopy()
+	remaining.SetName("remaining")
+	remaining.SetUID("remaining")
+	_, err = client.CoreV1().Nodes().Create(context.TODO(), remaining, metav1.CreateOptions{})
+	util.OK(t, err)
+	util.Equals(t, true, <-deadlines)
+	util.Equals(t, true, <-deadlines)
+	t.Run("deletion", func(t *testing.T) {
+		util.OK(t, client.CoreV1().Nodes().Delete(context.TODO(), g.nodeObj.GetName(), metav1.DeleteOptions{}))
+		select {
+		case key := <-stopped:
+			util.Equals(t, g.nodeObj.GetName(), key)
+		case <-time.After(5 * time.Second):
+			t.Fatal("procedure not cancelled on deletion")
+		}
+	})
+	t.Run("shutdown", func(t *testing.T) {
+		close(stopCh)
+		select {
+		case key := <-stopped:
+			util.Equals(t, remaining.GetName(), key)
+		case <-time.After(5 * time.Second):
+			t.Fatal("procedure not cancelled on shutdown")
+		}
+		select {
+		case <-managerStopped:
+		case <-time.After(5 * time.Second):
+			t.Fatal("manager not stopped")
+		}
+		util.Equals(t, context.Canceled, controller.Context().Err())
+	})
+}
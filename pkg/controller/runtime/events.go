@@ -0,0 +1,59 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"sync"
+
+	edgenetscheme "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/scheme"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// eventScheme knows the EdgeNet kinds besides the built-in ones, so that an event can refer to any object
+var eventScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(scheme.AddToScheme(eventScheme))
+	utilruntime.Must(edgenetscheme.AddToScheme(eventScheme))
+}
+
+// The broadcasters send the events to the API server, there is one per clientset
+var (
+	broadcastersMutex sync.Mutex
+	broadcasters      = map[kubernetes.Interface]record.EventBroadcaster{}
+)
+
+// NewEventRecorder returns a recorder that publishes the events of the component on the objects they concern.
+// The recorders of a clientset share a broadcaster, so a handler can ask for one every time it gets initialized.
+func NewEventRecorder(clientset kubernetes.Interface, component string) record.EventRecorder {
+	broadcastersMutex.Lock()
+	defer broadcastersMutex.Unlock()
+	broadcaster, ok := broadcasters[clientset]
+	if !ok {
+		broadcaster = record.NewBroadcaster()
+		broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+		broadcasters[clientset] = broadcaster
+	}
+	return broadcaster.NewRecorder(eventScheme, corev1.EventSource{Component: component})
+}
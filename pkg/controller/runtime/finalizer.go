@@ -0,0 +1,85 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Finalizer holds the deletion of an object until its controller cleans up after it
+const Finalizer = "apps.edgenet.io/cleanup"
+
+// HasFinalizer tells whether the finalizer is on the object
+func HasFinalizer(obj metav1.Object) bool {
+	for _, finalizer := range obj.GetFinalizers() {
+		if finalizer == Finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFinalizer puts the finalizer on the object, it returns false if the finalizer is already there
+func AddFinalizer(obj metav1.Object) bool {
+	if HasFinalizer(obj) {
+		return false
+	}
+	obj.SetFinalizers(append(obj.GetFinalizers(), Finalizer))
+	return true
+}
+
+// RemoveFinalizer takes the finalizer off the object, it returns false if the finalizer is not there
+func RemoveFinalizer(obj metav1.Object) bool {
+	if !HasFinalizer(obj) {
+		return false
+	}
+	var finalizers []string
+	for _, finalizer := range obj.GetFinalizers() {
+		if finalizer != Finalizer {
+			finalizers = append(finalizers, finalizer)
+		}
+	}
+	obj.SetFinalizers(finalizers)
+	return true
+}
+
+// beingFinalized tells whether the object is deleted and waits for the cleanup of its controller
+func beingFinalized(obj interface{}) bool {
+	object, err := meta.Accessor(obj)
+	return err == nil && object.GetDeletionTimestamp() != nil && HasFinalizer(object)
+}
+
+// holdsFinalizer tells whether the finalizer is on the object
+func holdsFinalizer(obj interface{}) bool {
+	object, err := meta.Accessor(obj)
+	return err == nil && HasFinalizer(object)
+}
+
+// finalizerAdded tells whether the update only puts the finalizer on the object, which is nothing to reconcile
+func finalizerAdded(oldObj, newObj interface{}) bool {
+	oldObject, err := meta.Accessor(oldObj)
+	if err != nil {
+		return false
+	}
+	newObject, err := meta.Accessor(newObj)
+	if err != nil {
+		return false
+	}
+	return !HasFinalizer(oldObject) && HasFinalizer(newObject) && newObject.GetDeletionTimestamp() == nil &&
+		oldObject.GetGeneration() == newObject.GetGeneration()
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
+	edgenetinformers "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Manager hosts a set of controllers in a single process. The controllers share the clientsets
+// and take their informers from the same factories, so a resource is watched only once no matter
+// how many controllers are interested in it.
+type Manager struct {
+	Clientset              kubernetes.Interface
+	EdgeNetClientset       versioned.Interface
+	InformerFactory        informers.SharedInformerFactory
+	EdgeNetInformerFactory edgenetinformers.SharedInformerFactory
+	// DynamicClientset and DynamicInformerFactory serve the controllers dealing with the objects of any kind,
+	// they are nil unless UseDynamicClientset is called
+	DynamicClientset       dynamic.Interface
+	DynamicInformerFactory dynamicinformer.DynamicSharedInformerFactory
+	// Scheduler takes the time-based actions of the controllers, such as the expiries
+	Scheduler *expiry.Scheduler
+	// MetricsAddress is the address to serve the metrics and the health probes on, none if empty
+	MetricsAddress string
+	controllers    []*Controller
+}
+
+// NewManager creates a manager along with the informer factories of the clientsets
+func NewManager(clientset kubernetes.Interface, edgenetClientset versioned.Interface) *Manager {
+	return &Manager{
+		Clientset:              clientset,
+		EdgeNetClientset:       edgenetClientset,
+		InformerFactory:        informers.NewSharedInformerFactory(clientset, 0),
+		EdgeNetInformerFactory: edgenetinformers.NewSharedInformerFactory(edgenetClientset, 0),
+		Scheduler:              expiry.New(),
+		MetricsAddress:         MetricsAddress,
+	}
+}
+
+// UseDynamicClientset sets the dynamic clientset along with its informer factory
+func (m *Manager) UseDynamicClientset(dynamicClientset dynamic.Interface) {
+	m.DynamicClientset = dynamicClientset
+	m.DynamicInformerFactory = dynamicinformer.NewDynamicSharedInformerFactory(dynamicClientset, 0)
+}
+
+// Add registers a controller to be run by the manager
+func (m *Manager) Add(controller *Controller) {
+	m.controllers = append(m.controllers, controller)
+}
+
+// Run starts the informers requested by the controllers, the controllers themselves, and the scheduler.
+// It blocks until the stop channel is closed and the controllers stop.
+func (m *Manager) Run(stopCh <-chan struct{}) {
+	log.Infof("manager: starting %d controller(s)", len(m.controllers))
+	if m.MetricsAddress != "" {
+		go Serve(m.MetricsAddress, m.Ready, stopCh)
+	}
+	// The factories start only the informers obtained from them so far
+	m.InformerFactory.Start(stopCh)
+	m.EdgeNetInformerFactory.Start(stopCh)
+	if m.DynamicInformerFactory != nil {
+		m.DynamicInformerFactory.Start(stopCh)
+	}
+	var controllers sync.WaitGroup
+	controllers.Add(1)
+	go func() {
+		defer controllers.Done()
+		m.Scheduler.Run(stopCh)
+	}()
+	for _, controller := range m.controllers {
+		controllers.Add(1)
+		go func(controller *Controller) {
+			defer controllers.Done()
+			controller.Run(stopCh)
+		}(controller)
+	}
+	<-stopCh
+	log.Info("manager: shutting down")
+	controllers.Wait()
+	log.Info("manager: stopped")
+}
+
+// Ready tells whether all controllers have their caches synced
+func (m *Manager) Ready() bool {
+	for _, controller := range m.controllers {
+		if !controller.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupSignalHandler returns a channel that is closed on SIGTERM or SIGINT for a smooth shut down
+func SetupSignalHandler() <-chan struct{} {
+	stopCh := make(chan struct{})
+	sigTerm := make(chan os.Signal, 1)
+	signal.Notify(sigTerm, syscall.SIGTERM)
+	signal.Notify(sigTerm, syscall.SIGINT)
+	go func() {
+		<-sigTerm
+		close(stopCh)
+	}()
+	return stopCh
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/EdgeNet-project/edgenet/pkg/metrics"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// MetricsAddress is the address that the managers serve the metrics and the health probes on, an empty address disables them.
+// The binaries bind it to the metrics-address flag through AddFlags.
+var MetricsAddress string
+
+// Workers is the number of workers of the controllers that do not set their own, bound to the workers flag through AddFlags
+var Workers = DefaultWorkers
+
+// AddFlags defines the flags of the controller runtime, to be called before the flags are parsed
+func AddFlags() {
+	flag.StringVar(&MetricsAddress, "metrics-address", ":8080", "address to serve the metrics and the health probes on, empty to disable")
+	flag.IntVar(&Workers, "workers", DefaultWorkers, "number of requests each controller processes concurrently")
+}
+
+// The metrics of the reconciliations, labeled by the name of the controller
+var (
+	reconcileDuration = metrics.NewHistogramVec("edgenet_controller_reconcile_duration_seconds",
+		"How long the reconciliation of a request takes", metrics.DefBuckets, "controller")
+	reconcileErrors = metrics.NewCounterVec("edgenet_controller_reconcile_errors_total",
+		"Total number of failed reconciliations", "controller")
+)
+
+func init() {
+	// The queues created from now on report their depth, latency, and retries
+	workqueue.SetProvider(metrics.WorkqueueProvider{})
+}
+
+// Serve exposes the metrics at /metrics, the liveness at /healthz, and the readiness at /readyz on the address.
+// The readiness follows the ready function, which tells whether the caches are synced. It blocks until the stop channel is closed.
+func Serve(address string, ready func() bool, stopCh <-chan struct{}) {
+	server := &http.Server{Addr: address, Handler: newServeMux(ready)}
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+	log.Infof("serving metrics and health probes on %s", address)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Errorf("metrics server: %v", err)
+	}
+}
+
+// newServeMux routes the metrics and the health probes
+func newServeMux(ready func() bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !ready() {
+			http.Error(w, "caches not synced", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
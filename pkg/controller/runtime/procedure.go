@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// procedures keeps track of the background procedures of a controller, such as the timeouts and the installations over SSH.
+// Each object gets a context that lasts until the object is deleted or the controller shuts down.
+type procedures struct {
+	ctx     context.Context
+	mutex   sync.Mutex
+	objects map[types.UID]objectContext
+	running sync.WaitGroup
+}
+
+type objectContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type proceduresKey struct{}
+
+func newProcedures(ctx context.Context) *procedures {
+	return &procedures{ctx: ctx, objects: map[types.UID]objectContext{}}
+}
+
+// objectContext returns the context of the object, it creates one if the object has none yet
+func (p *procedures) objectContext(uid types.UID) context.Context {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	object, ok := p.objects[uid]
+	if !ok {
+		object.ctx, object.cancel = context.WithCancel(p.ctx)
+		p.objects[uid] = object
+	}
+	return object.ctx
+}
+
+// release cancels the context of the object, which stops its procedures
+func (p *procedures) release(obj interface{}) {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if objectCtx, ok := p.objects[object.GetUID()]; ok {
+		objectCtx.cancel()
+		delete(p.objects, object.GetUID())
+	}
+}
+
+// wait blocks until all procedures return
+func (p *procedures) wait() {
+	p.running.Wait()
+}
+
+// Go runs the procedure in the background with a context that is cancelled once the object is deleted or the controller shuts down.
+// The controller waits for its procedures to return before it stops, so they must return soon after the context is done.
+// Outside of a reconciliation, as in the tests calling the handlers directly, the procedure gets the context as is.
+func Go(ctx context.Context, obj interface{}, procedure func(ctx context.Context)) {
+	p, ok := ctx.Value(proceduresKey{}).(*procedures)
+	object, err := meta.Accessor(obj)
+	if !ok || err != nil {
+		go procedure(ctx)
+		return
+	}
+	objectCtx := p.objectContext(object.GetUID())
+	p.running.Add(1)
+	go func() {
+		defer p.running.Done()
+		procedure(objectCtx)
+	}()
+}
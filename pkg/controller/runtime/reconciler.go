@@ -0,0 +1,89 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// Reconciler is the contract every EdgeNet resource implements to be driven by the controller runtime.
+// Returning an error puts the request back into the queue with a rate-limited delay.
+type Reconciler interface {
+	Reconcile(ctx context.Context, request Request) error
+}
+
+// ReconcilerFunc allows a plain function to be used as a Reconciler
+type ReconcilerFunc func(ctx context.Context, request Request) error
+
+// Reconcile calls the function itself
+func (f ReconcilerFunc) Reconcile(ctx context.Context, request Request) error {
+	return f(ctx, request)
+}
+
+// HandlerFuncs adapts the ObjectCreated, ObjectUpdated, and ObjectDeleted methods of the handlers to the Reconciler.
+// It looks the object up in the indexer and dispatches the request according to the event that triggered it.
+// A nil function means that the controller ignores the corresponding event.
+// The functions receive the context of the reconciliation, which is done once it times out or the controller shuts down.
+type HandlerFuncs struct {
+	Indexer    cache.Indexer
+	CreateFunc func(ctx context.Context, obj interface{}) error
+	UpdateFunc func(ctx context.Context, obj, change interface{}) error
+	DeleteFunc func(ctx context.Context, obj interface{}) error
+	ResyncFunc func(ctx context.Context, obj interface{}) error
+	// FinalizeFunc receives the deleted objects that still hold the finalizer instead of the functions above.
+	// It cleans up after the object and takes the finalizer off, so that the object disappears.
+	FinalizeFunc func(ctx context.Context, obj interface{}) error
+}
+
+// Reconcile dispatches the request to the handler function that matches the event
+func (h HandlerFuncs) Reconcile(ctx context.Context, request Request) error {
+	item, exists, err := h.Indexer.GetByKey(request.Key)
+	if err != nil {
+		return err
+	}
+	if exists && h.FinalizeFunc != nil && beingFinalized(item) {
+		return h.FinalizeFunc(ctx, item)
+	}
+	// The object may have gone in the meantime, or may have come back after a deletion.
+	// In both cases, the request is outdated and another one is already on its way.
+	switch request.Function {
+	case Create:
+		if exists && h.CreateFunc != nil {
+			return h.CreateFunc(ctx, item)
+		}
+	case Update:
+		if exists && h.UpdateFunc != nil {
+			return h.UpdateFunc(ctx, item, request.Change)
+		}
+	case Resync:
+		if exists && h.ResyncFunc != nil {
+			return h.ResyncFunc(ctx, item)
+		}
+	case Delete:
+		// An object that disappears with the finalizer on has skipped its cleanup,
+		// for instance when someone takes the finalizer off by hand
+		if !exists && h.FinalizeFunc != nil && holdsFinalizer(request.Object) {
+			return h.FinalizeFunc(ctx, request.Object)
+		}
+		if !exists && h.DeleteFunc != nil {
+			return h.DeleteFunc(ctx, request.Object)
+		}
+	}
+	return nil
+}
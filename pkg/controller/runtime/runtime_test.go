@@ -0,0 +1,207 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+// The main structure of test group
+type TestGroup struct {
+	nodeObj corev1.Node
+	indexer cache.Indexer
+	calls   []string
+}
+
+func TestMain(m *testing.M) {
+	logrus.SetOutput(ioutil.Discard)
+	os.Exit(m.Run())
+}
+
+// Init syncs the test group
+func (g *TestGroup) Init() {
+	g.nodeObj = corev1.Node{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Node",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "edgenet.planet-lab.eu",
+		},
+	}
+	g.indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	g.calls = []string{}
+}
+
+// handlerFuncs records the calls made by the reconciler
+func (g *TestGroup) handlerFuncs() HandlerFuncs {
+	return HandlerFuncs{
+		Indexer: g.indexer,
+		CreateFunc: func(ctx context.Context, obj interface{}) error {
+			g.calls = append(g.calls, Create)
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, obj, change interface{}) error {
+			g.calls = append(g.calls, Update)
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, obj interface{}) error {
+			g.calls = append(g.calls, Delete)
+			return nil
+		},
+	}
+}
+
+func TestHandlerFuncsDispatch(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.indexer.Add(g.nodeObj.DeepCopy())
+	reconciler := g.handlerFuncs()
+
+	cases := map[string]struct {
+		function string
+		expected []string
+	}{
+		"create":                  {Create, []string{Create}},
+		"update":                  {Update, []string{Update}},
+		"delete of existing":      {Delete, []string{}},
+		"resync without function": {Resync, []string{}},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			g.calls = []string{}
+			err := reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: tc.function})
+			util.OK(t, err)
+			util.Equals(t, tc.expected, g.calls)
+		})
+	}
+}
+
+func TestHandlerFuncsMissingObject(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	reconciler := g.handlerFuncs()
+
+	err := reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Create})
+	util.OK(t, err)
+	util.Equals(t, []string{}, g.calls)
+	err = reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Delete, Object: g.nodeObj.DeepCopy()})
+	util.OK(t, err)
+	util.Equals(t, []string{Delete}, g.calls)
+}
+
+func TestHandlerFuncsError(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.indexer.Add(g.nodeObj.DeepCopy())
+	reconciler := g.handlerFuncs()
+	reconciler.CreateFunc = func(ctx context.Context, obj interface{}) error {
+		return errors.New("creation failed")
+	}
+
+	err := reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Create})
+	util.Equals(t, "creation failed", err.Error())
+}
+
+func TestHandlerFuncsFinalize(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	reconciler := g.handlerFuncs()
+	reconciler.FinalizeFunc = func(ctx context.Context, obj interface{}) error {
+		g.calls = append(g.calls, "finalize")
+		return nil
+	}
+	nodeCopy := g.nodeObj.DeepCopy()
+	util.Equals(t, true, AddFinalizer(nodeCopy))
+	util.Equals(t, false, AddFinalizer(nodeCopy))
+	g.indexer.Add(nodeCopy)
+
+	err := reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Update})
+	util.OK(t, err)
+	util.Equals(t, []string{Update}, g.calls)
+	// The deleted object goes to the cleanup as long as it holds the finalizer
+	nodeCopy = nodeCopy.DeepCopy()
+	nodeCopy.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	g.indexer.Update(nodeCopy)
+	g.calls = []string{}
+	err = reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Update})
+	util.OK(t, err)
+	util.Equals(t, []string{"finalize"}, g.calls)
+	nodeCopy = nodeCopy.DeepCopy()
+	util.Equals(t, true, RemoveFinalizer(nodeCopy))
+	util.Equals(t, false, RemoveFinalizer(nodeCopy))
+	util.Equals(t, 0, len(nodeCopy.GetFinalizers()))
+	g.indexer.Update(nodeCopy)
+	g.calls = []string{}
+	err = reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Update})
+	util.OK(t, err)
+	util.Equals(t, []string{Update}, g.calls)
+}
+
+func TestHandlerFuncsDeletedWithFinalizer(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	reconciler := g.handlerFuncs()
+	reconciler.FinalizeFunc = func(ctx context.Context, obj interface{}) error {
+		g.calls = append(g.calls, "finalize")
+		return nil
+	}
+	// The object is gone without its cleanup
+	nodeCopy := g.nodeObj.DeepCopy()
+	AddFinalizer(nodeCopy)
+	err := reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Delete, Object: nodeCopy})
+	util.OK(t, err)
+	util.Equals(t, []string{"finalize"}, g.calls)
+	g.calls = []string{}
+	err = reconciler.Reconcile(context.TODO(), Request{Key: g.nodeObj.GetName(), Function: Delete, Object: g.nodeObj.DeepCopy()})
+	util.OK(t, err)
+	util.Equals(t, []string{Delete}, g.calls)
+}
+
+func TestEventRecorder(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	client := testclient.NewSimpleClientset()
+	authorityRecorder := NewEventRecorder(client, "authority-controller")
+	nodeRecorder := NewEventRecorder(client, "node-controller")
+	_, shared := broadcasters[client]
+	util.Equals(t, true, shared)
+	// The events go through the broadcaster that the recorders of the clientset share
+	events := make(chan *corev1.Event, 2)
+	watcher := broadcasters[client].StartEventWatcher(func(event *corev1.Event) { events <- event })
+	defer watcher.Stop()
+
+	authority := apps_v1alpha.Authority{ObjectMeta: metav1.ObjectMeta{Name: "edgenet"}}
+	authorityRecorder.Event(&authority, corev1.EventTypeNormal, apps_v1alpha.ReasonReconciled, "Authority established")
+	nodeRecorder.Event(&g.nodeObj, corev1.EventTypeWarning, apps_v1alpha.ReasonNodeNotReady, "Node is not ready")
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-events:
+			switch event.InvolvedObject.Kind {
+			case "Authority":
+				util.Equals(t, "apps.edgenet.io/v1alpha", event.InvolvedObject.APIVersion)
+				util.Equals(t, "authority-controller", event.Source.Component)
+				util.Equals(t, apps_v1alpha.ReasonReconciled, event.Reason)
+			case "Node":
+				util.Equals(t, "node-controller", event.Source.Component)
+				util.Equals(t, corev1.EventTypeWarning, event.Type)
+			default:
+				t.Errorf("unexpected event on %s", event.InvolvedObject.Kind)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("event not recorded")
+		}
+	}
+}
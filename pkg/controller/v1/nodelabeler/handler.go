@@ -1,22 +1,33 @@
 package nodelabeler
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
 	"github.com/EdgeNet-project/edgenet/pkg/node"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface)
-	SetNodeGeolocation(obj interface{})
+	SetNodeGeolocation(ctx context.Context, obj interface{}) error
+	ReloadDatabases(ctx context.Context, nodes []interface{})
 }
 
 // Handler is a sample implementation of Handler
 type Handler struct {
 	clientset kubernetes.Interface
+	// provider is the chain of the sources that the nodes are located by
+	provider node.GeolocationProvider
+	// asnProvider is the database that the autonomous systems of the nodes are looked up in
+	asnProvider *node.ASNProvider
 }
 
 // Init handles any handler initialization
@@ -24,23 +35,90 @@ func (t *Handler) Init(kubernetes kubernetes.Interface) {
 	log.Info("Handler.Init")
 	t.clientset = kubernetes
 	node.Clientset = t.clientset
+	t.provider = node.DefaultGeolocationProvider()
+	t.asnProvider = node.DefaultASNProvider()
 }
 
 // SetNodeGeolocation is called when an object is created or updated
-func (t *Handler) SetNodeGeolocation(obj interface{}) {
+func (t *Handler) SetNodeGeolocation(ctx context.Context, obj interface{}) error {
 	log.Info("Handler.ObjectCreated")
-	// Get internal and external IP addresses of the node
-	internalIP, externalIP := node.GetNodeIPAddresses(obj.(*corev1.Node))
-	result := false
-	// Check if the external IP exists to use it in the first place
-	if externalIP != "" {
-		log.Infof("External IP: %s", externalIP)
-		result = node.GetGeolocationByIP(obj.(*corev1.Node).Name, externalIP)
-	}
-	// Check if the internal IP exists and
-	// the result of detecting geolocation by external IP is false
-	if internalIP != "" && result == false {
-		log.Infof("Internal IP: %s", internalIP)
-		node.GetGeolocationByIP(obj.(*corev1.Node).Name, internalIP)
+	nodeObj := obj.(*corev1.Node)
+	// A node whose autonomous system is unknown still gets the other network labels
+	labels, err := node.NetworkLabels(t.asnProvider, nodeObj)
+	if err != nil {
+		log.Println(err.Error())
+	}
+	if err := node.SetNetworkLabels(ctx, nodeObj.GetName(), labels); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	// The external IP address is looked up in the first place, then the internal one
+	geolocation, err := node.Geolocate(ctx, t.provider, nodeObj)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	log.Infof("Node %s located by %s", nodeObj.GetName(), geolocation.Source)
+	return nil
+}
+
+// ReloadDatabases reloads the databases whose files are replaced, and relabels the nodes if any of them is
+func (t *Handler) ReloadDatabases(ctx context.Context, nodes []interface{}) {
+	reloaded := false
+	for _, provider := range []interface{}{t.provider, t.asnProvider} {
+		if reloadable, ok := provider.(node.Reloadable); ok {
+			providerReloaded, err := reloadable.Reload()
+			if err != nil {
+				log.Println(err.Error())
+			}
+			reloaded = reloaded || providerReloaded
+		}
+	}
+	if !reloaded {
+		return
+	}
+	log.Info("Handler.ReloadDatabases: a database is reloaded, relabeling the nodes")
+	t.Relabel(ctx, nodes)
+}
+
+// Relabel labels the nodes again, a node is updated only if its labels change. It logs a summary of the nodes
+// that move to another place, and returns their names.
+func (t *Handler) Relabel(ctx context.Context, nodes []interface{}) []string {
+	var moved, moves []string
+	for _, obj := range nodes {
+		nodeObj := obj.(*corev1.Node)
+		// A failure leaves the node to the next event or reload
+		t.SetNodeGeolocation(ctx, nodeObj)
+		nodeCopy, err := t.clientset.CoreV1().Nodes().Get(ctx, nodeObj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if from, to := place(nodeObj.GetLabels()), place(nodeCopy.GetLabels()); from != to {
+			moved = append(moved, nodeObj.GetName())
+			moves = append(moves, fmt.Sprintf("%s from %s to %s", nodeObj.GetName(), from, to))
+		}
+	}
+	if len(moved) == 0 {
+		log.Infof("Relabel: none of %d node(s) moved", len(nodes))
+	} else {
+		log.Infof("Relabel: %d of %d node(s) moved: %s", len(moved), len(nodes), strings.Join(moves, ", "))
+	}
+	return moved
+}
+
+// place describes where the labels put the node, by its city, state, country, and autonomous system
+func place(labels map[string]string) string {
+	var parts []string
+	for _, key := range []string{geolabel.City, geolabel.State, geolabel.Country} {
+		if value := labels[key]; value != "" {
+			parts = append(parts, value)
+		}
+	}
+	if asn := labels[node.ASNLabel]; asn != "" {
+		parts = append(parts, "AS"+asn)
+	}
+	if len(parts) == 0 {
+		return "nowhere"
 	}
+	return strings.Join(parts, "/")
 }
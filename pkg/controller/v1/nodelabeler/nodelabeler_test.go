@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/EdgeNet-project/edgenet/pkg/node"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	"github.com/sirupsen/logrus"
@@ -136,7 +137,7 @@ func TestAssigningGeoLabels(t *testing.T) {
 	for k, tc := range cases {
 		t.Run(fmt.Sprintf("%s", k), func(t *testing.T) {
 			g.client.CoreV1().Nodes().Create(context.TODO(), tc.Node.DeepCopy(), metav1.CreateOptions{})
-			g.handler.SetNodeGeolocation(tc.Node.DeepCopy())
+			g.handler.SetNodeGeolocation(context.TODO(), tc.Node.DeepCopy())
 			node, _ := g.client.CoreV1().Nodes().Get(context.TODO(), tc.Node.GetName(), metav1.GetOptions{})
 			if !reflect.DeepEqual(node.Labels, tc.Expected) {
 				for actualKey, actualValue := range node.Labels {
@@ -150,3 +151,32 @@ func TestAssigningGeoLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestRelabel(t *testing.T) {
+	g := testGroup{}
+	g.Init()
+	g.handler.Init(g.client)
+	g.handler.provider = node.ProviderChain{node.AnnotationProvider{}}
+	g.handler.asnProvider = node.NewASNProvider("missing.mmdb")
+	// The node in France is labeled as in the United States, as if the database had placed it there
+	nodeFR := g.nodeObj
+	nodeFR.ObjectMeta = metav1.ObjectMeta{
+		Name:        "fr.edge-net.io",
+		Labels:      map[string]string{"edge-net.io/country-iso": "US", "edge-net.io/state-iso": "US"},
+		Annotations: map[string]string{node.LocationAnnotation: `{"country":"FR","city":"Paris"}`},
+	}
+	nodeUS := g.nodeObj
+	nodeUS.ObjectMeta = metav1.ObjectMeta{
+		Name:        "us.edge-net.io",
+		Labels:      map[string]string{"edge-net.io/country-iso": "US", "edge-net.io/state-iso": "US"},
+		Annotations: map[string]string{node.LocationAnnotation: `{"country":"US"}`},
+	}
+	var nodes []interface{}
+	for _, nodeObj := range []corev1.Node{nodeFR, nodeUS} {
+		g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj.DeepCopy(), metav1.CreateOptions{})
+		nodes = append(nodes, nodeObj.DeepCopy())
+	}
+	util.Equals(t, []string{"fr.edge-net.io"}, g.handler.Relabel(context.TODO(), nodes))
+	nodeCopy, _ := g.client.CoreV1().Nodes().Get(context.TODO(), nodeFR.GetName(), metav1.GetOptions{})
+	util.Equals(t, "Paris", nodeCopy.Labels["edge-net.io/city"])
+}
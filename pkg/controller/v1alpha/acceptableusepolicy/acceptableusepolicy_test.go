@@ -12,6 +12,7 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
@@ -107,7 +108,7 @@ func (g *TestGroup) Init() {
 	user.Status.AUP = false
 	user.Status.Type = "admin"
 	g.edgenetClient.AppsV1alpha().Users(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Create(context.TODO(), user.DeepCopy(), metav1.CreateOptions{})
-	// authorityHandler.ObjectCreated(g.authorityObj.DeepCopy())
+	// authorityHandler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 }
 
 func TestHandlerInit(t *testing.T) {
@@ -145,7 +146,7 @@ func TestCreate(t *testing.T) {
 	t.Run("regular", func(t *testing.T) {
 		g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(regular.GetNamespace()).Create(context.TODO(), regular.DeepCopy(), metav1.CreateOptions{})
 		defer g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(regular.GetNamespace()).Delete(context.TODO(), regular.GetName(), metav1.DeleteOptions{})
-		g.handler.ObjectCreated(regular.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), regular.DeepCopy())
 		AUP, err := g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(regular.GetNamespace()).Get(context.TODO(), regular.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
 		util.Equals(t, success, AUP.Status.State)
@@ -163,7 +164,7 @@ func TestCreate(t *testing.T) {
 
 		g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(accepted.GetNamespace()).Create(context.TODO(), accepted.DeepCopy(), metav1.CreateOptions{})
 		defer g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(accepted.GetNamespace()).Delete(context.TODO(), accepted.GetName(), metav1.DeleteOptions{})
-		g.handler.ObjectCreated(accepted.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), accepted.DeepCopy())
 		AUP, err := g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(accepted.GetNamespace()).Get(context.TODO(), accepted.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
 		util.Equals(t, success, AUP.Status.State)
@@ -181,7 +182,7 @@ func TestCreate(t *testing.T) {
 
 		g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(recreation.GetNamespace()).Create(context.TODO(), recreation.DeepCopy(), metav1.CreateOptions{})
 		defer g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(recreation.GetNamespace()).Delete(context.TODO(), recreation.GetName(), metav1.DeleteOptions{})
-		g.handler.ObjectCreated(recreation.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), recreation.DeepCopy())
 		AUP, err := g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(recreation.GetNamespace()).Get(context.TODO(), recreation.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
 		util.Equals(t, "", AUP.Status.State)
@@ -199,7 +200,7 @@ func TestCreate(t *testing.T) {
 
 		g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(recreationExpired.GetNamespace()).Create(context.TODO(), recreationExpired.DeepCopy(), metav1.CreateOptions{})
 		defer g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(recreationExpired.GetNamespace()).Delete(context.TODO(), recreationExpired.GetName(), metav1.DeleteOptions{})
-		g.handler.ObjectCreated(recreationExpired.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), recreationExpired.DeepCopy())
 		AUP, err := g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(recreationExpired.GetNamespace()).Get(context.TODO(), recreationExpired.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
 		util.Equals(t, failure, AUP.Status.State)
@@ -218,7 +219,7 @@ func TestAccept(t *testing.T) {
 	// Create AUP to update later
 	g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(g.AUPObj.GetNamespace()).Create(context.TODO(), g.AUPObj.DeepCopy(), metav1.CreateOptions{})
 	// Invoke ObjectCreated func to create a AUP
-	g.handler.ObjectCreated(g.AUPObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.AUPObj.DeepCopy())
 	AUP, err := g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(g.AUPObj.GetNamespace()).Get(context.TODO(), g.AUPObj.GetName(), metav1.GetOptions{})
 	util.OK(t, err)
 	// Update of AUP status
@@ -226,7 +227,7 @@ func TestAccept(t *testing.T) {
 	var field fields
 	field.accepted = true
 	AUP.Spec.Accepted = true
-	g.handler.ObjectUpdated(AUP.DeepCopy(), field)
+	g.handler.ObjectUpdated(context.TODO(), AUP.DeepCopy(), field)
 	time.Sleep(time.Millisecond * 100)
 
 	AUP, err = g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(g.AUPObj.GetNamespace()).Get(context.TODO(), g.AUPObj.GetName(), metav1.GetOptions{})
@@ -248,13 +249,13 @@ func TestAccept(t *testing.T) {
 		util.Equals(t, true, user.Status.AUP)
 	})
 	t.Run("timeout", func(t *testing.T) {
-		go g.handler.runApprovalTimeout(AUP)
 		AUP.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(10 * time.Millisecond),
 		}
 		_, err := g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(AUP.GetNamespace()).Update(context.TODO(), AUP.DeepCopy(), metav1.UpdateOptions{})
 		util.OK(t, err)
-		time.Sleep(100 * time.Millisecond)
+		util.Equals(t, AUP.Status.Expires.Time, getDeadlines(AUP)[expiry.Expire])
+		util.OK(t, g.handler.ObjectExpired(context.TODO(), AUP, expiry.Expire))
 		t.Run("expired", func(t *testing.T) {
 			AUP, err = g.edgenetClient.AppsV1alpha().AcceptableUsePolicies(AUP.GetNamespace()).Get(context.TODO(), AUP.GetName(), metav1.GetOptions{})
 			util.OK(t, err)
@@ -17,49 +17,23 @@ limitations under the License.
 package acceptableusepolicy
 
 import (
-	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
-	appsinformer_v1 "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/apps/v1alpha"
 
-	log "github.com/sirupsen/logrus"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 )
 
-// The main structure of controller
-type controller struct {
-	logger   *log.Entry
-	queue    workqueue.RateLimitingInterface
-	informer cache.SharedIndexInformer
-	handler  HandlerInterface
-}
-
-// The main structure of informerevent
-type informerevent struct {
-	key      string
-	function string
-	updated  fields
-}
-
 // This contains the fields to check whether they are updated
 type fields struct {
 	accepted bool
 }
 
 // Constant variables for events
-const create = "create"
-const update = "update"
-const delete = "delete"
 const failure = "Failure"
 const success = "Successful"
 
@@ -69,154 +43,59 @@ var statusDict = map[string]string{
 	"aup-set-fail":       "Expiry date couldn't be set",
 	"aup-expired":        "Acceptable use policy expired",
 	"aup-agreed":         "Acceptable Use Policy Agreed and Renewed",
+	"aup-not-accepted":   "Acceptable use policy not accepted yet",
 	"authority-disabled": "Authority disabled",
 }
 
 // Start function is entry point of the controller
 func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
-	var err error
-	clientset := kubernetes
-	edgenetClientset := edgenet
+	manager := ctlruntime.NewManager(kubernetes, edgenet)
+	Register(manager)
+	// Run the controller loop until a signal to terminate elegantly arrives
+	manager.Run(ctlruntime.SetupSignalHandler())
+}
 
+// Register adds the acceptable use policy controller to the manager, which can host other controllers alongside
+func Register(manager *ctlruntime.Manager) {
+	clientset := manager.Clientset
+	edgenetClientset := manager.EdgeNetClientset
 	AUPHandler := &Handler{}
-	// Create the acceptableusepolicy informer which was generated by the code generator to list and watch acceptableusepolicy resources
-	informer := appsinformer_v1.NewAcceptableUsePolicyInformer(
-		edgenetClientset,
-		metav1.NamespaceAll,
-		0,
-		cache.Indexers{},
-	)
-	// Create a work queue which contains a key of the resource to be handled by the handler
-	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	var event informerevent
-	// Event handlers deal with events of resources. Here, there are three types of events as Add, Update, and Delete
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			// Put the resource object into a key
-			event.key, err = cache.MetaNamespaceKeyFunc(obj)
-			event.function = create
-			log.Infof("Add acceptableusepolicy: %s", event.key)
-			if err == nil {
-				// Add the key to the queue
-				queue.Add(event)
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			event.key, err = cache.MetaNamespaceKeyFunc(newObj)
-			event.function = update
-			// Find out whether the `accepted` field updated
-			event.updated.accepted = false
-			if oldObj.(*apps_v1alpha.AcceptableUsePolicy).Spec.Accepted != newObj.(*apps_v1alpha.AcceptableUsePolicy).Spec.Accepted {
-				event.updated.accepted = true
-			}
-			log.Infof("Update acceptableusepolicy: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			// DeletionHandlingMetaNamsespaceKeyFunc helps to check the existence of the object while it is still contained in the index.
-			// Put the resource object into a key
-			event.key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			event.function = delete
-			log.Infof("Delete acceptableusepolicy: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-	})
-	controller := controller{
-		logger:   log.NewEntry(log.New()),
-		informer: informer,
-		queue:    queue,
-		handler:  AUPHandler,
-	}
-
-	// A channel to terminate elegantly
-	stopCh := make(chan struct{})
-	defer close(stopCh)
-	// Run the controller loop as a background task to start processing resources
-	go controller.run(stopCh, clientset, edgenetClientset)
-	// A channel to observe OS signals for smooth shut down
-	sigTerm := make(chan os.Signal, 1)
-	signal.Notify(sigTerm, syscall.SIGTERM)
-	signal.Notify(sigTerm, syscall.SIGINT)
-	<-sigTerm
+	AUPHandler.Init(clientset, edgenetClientset)
+	informer := manager.EdgeNetInformerFactory.Apps().V1alpha().AcceptableUsePolicies().Informer()
+	controller := newController(informer, AUPHandler)
+	manager.Scheduler.Register("AcceptableUsePolicy", informer, getDeadlines, AUPHandler.ObjectExpired)
+	manager.Add(controller)
 }
 
-// Run starts the controller loop
-func (c *controller) run(stopCh <-chan struct{}, clientset kubernetes.Interface, edgenetClientset versioned.Interface) {
-	// A Go panic which includes logging and terminating
-	defer utilruntime.HandleCrash()
-	// Shutdown after all goroutines have done
-	defer c.queue.ShutDown()
-	c.logger.Info("run: initiating")
-	c.handler.Init(clientset, edgenetClientset)
-	// Run the informer to list and watch resources
-	go c.informer.Run(stopCh)
-
-	// Synchronization to settle resources one
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
-		utilruntime.HandleError(fmt.Errorf("Error syncing cache"))
-		return
+// newController plugs the acceptableusepolicy handler into the controller runtime
+func newController(informer cache.SharedIndexInformer, handler HandlerInterface) *ctlruntime.Controller {
+	reconciler := ctlruntime.HandlerFuncs{
+		Indexer:    informer.GetIndexer(),
+		CreateFunc: handler.ObjectCreated,
+		UpdateFunc: handler.ObjectUpdated,
+		DeleteFunc: handler.ObjectDeleted,
 	}
-	c.logger.Info("run: cache sync complete")
-	// Operate the runWorker
-	go wait.Until(c.runWorker, time.Second, stopCh)
-
-	<-stopCh
+	return ctlruntime.New(informer, reconciler, ctlruntime.Options{
+		Name:         "acceptableusepolicy",
+		UpdateFilter: getChange,
+	})
 }
 
-// To process new objects added to the queue
-func (c *controller) runWorker() {
-	log.Info("runWorker: starting")
-	// Run processNextItem for all the changes
-	for c.processNextItem() {
-		log.Info("runWorker: processing next item")
+// getChange finds out which fields of the acceptableusepolicy updated
+func getChange(oldObj, newObj interface{}) (interface{}, bool) {
+	var change fields
+	// Find out whether the `accepted` field updated
+	if oldObj.(*apps_v1alpha.AcceptableUsePolicy).Spec.Accepted != newObj.(*apps_v1alpha.AcceptableUsePolicy).Spec.Accepted {
+		change.accepted = true
 	}
-
-	log.Info("runWorker: completed")
+	return change, true
 }
 
-// This function deals with the queue and sends each item in it to the specified handler to be processed.
-func (c *controller) processNextItem() bool {
-	log.Info("processNextItem: start")
-	// Fetch the next item of the queue
-	event, quit := c.queue.Get()
-	if quit {
-		return false
+// getDeadlines schedules the expiry of an accepted acceptable use policy
+func getDeadlines(obj interface{}) map[expiry.Action]time.Time {
+	AUPObj := obj.(*apps_v1alpha.AcceptableUsePolicy)
+	if !AUPObj.Spec.Accepted || AUPObj.Status.Expires == nil {
+		return nil
 	}
-	defer c.queue.Done(event)
-	// Get the key string
-	keyRaw := event.(informerevent).key
-	// Use the string key to get the object from the indexer
-	item, exists, err := c.informer.GetIndexer().GetByKey(keyRaw)
-	if err != nil {
-		if c.queue.NumRequeues(event.(informerevent).key) < 5 {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, retrying", event.(informerevent).key, err)
-			c.queue.AddRateLimited(event.(informerevent).key)
-		} else {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, no more retries", event.(informerevent).key, err)
-			c.queue.Forget(event.(informerevent).key)
-			utilruntime.HandleError(err)
-		}
-	}
-
-	if !exists {
-		if event.(informerevent).function == delete {
-			c.logger.Infof("Controller.processNextItem: object deleted detected: %s", keyRaw)
-			c.handler.ObjectDeleted(item)
-		}
-	} else {
-		if event.(informerevent).function == create {
-			c.logger.Infof("Controller.processNextItem: object created detected: %s", keyRaw)
-			c.handler.ObjectCreated(item)
-		} else if event.(informerevent).function == update {
-			c.logger.Infof("Controller.processNextItem: object updated detected: %s", keyRaw)
-			c.handler.ObjectUpdated(item, event.(informerevent).updated)
-		}
-	}
-	c.queue.Forget(event.(informerevent).key)
-
-	return true
+	return map[expiry.Action]time.Time{expiry.Expire: AUPObj.Status.Expires.Time}
 }
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/mailer"
 
@@ -33,9 +34,10 @@ import (
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj, updated interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj, updated interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
+	ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error
 }
 
 // Handler implementation
@@ -52,98 +54,105 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("AUPHandler.ObjectCreated")
 	// Create a copy of the acceptable use policy object to make changes on it
 	AUPCopy := obj.(*apps_v1alpha.AcceptableUsePolicy).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	AUPOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), AUPCopy.GetNamespace(), metav1.GetOptions{})
-	AUPOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), AUPOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	AUPOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, AUPCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	AUPOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, AUPOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	// Check if the authority is active
 	if AUPOwnerAuthority.Spec.Enabled {
 		// If the service restarts, it creates all objects again
 		// Because of that, this section covers a variety of possibilities
 		if AUPCopy.Spec.Accepted && AUPCopy.Status.Expires == nil {
-			// Run timeout goroutine
-			go t.runApprovalTimeout(AUPCopy)
 			// Set a timeout cycle which makes the acceptable use policy expires every 6 months
 			AUPCopy.Status.Expires = &metav1.Time{
 				Time: time.Now().Add(4382 * time.Hour),
 			}
 			AUPCopy.Status.State = success
 			AUPCopy.Status.Message = []string{statusDict["aup-ok"]}
-			_, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(context.TODO(), AUPCopy, metav1.UpdateOptions{})
+			AUPCopy.Status.SetReady(AUPCopy.GetGeneration(), true, apps_v1alpha.ReasonAccepted, statusDict["aup-ok"])
+			_, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(ctx, AUPCopy, metav1.UpdateOptions{})
 			if err != nil {
 				AUPCopy.Status.State = failure
 				AUPCopy.Status.Message = []string{statusDict["aup-ok"], statusDict["aup-set-fail"]}
-				t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(context.TODO(), AUPCopy, metav1.UpdateOptions{})
+				AUPCopy.Status.SetReady(AUPCopy.GetGeneration(), false, apps_v1alpha.ReasonExpirySetFailed, statusDict["aup-set-fail"])
+				t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(ctx, AUPCopy, metav1.UpdateOptions{})
 			} else {
-				user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(context.TODO(), AUPCopy.GetName(), metav1.GetOptions{})
+				user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(ctx, AUPCopy.GetName(), metav1.GetOptions{})
 				if !user.Status.AUP {
 					user.Status.AUP = true
-					t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(context.TODO(), user, metav1.UpdateOptions{})
+					t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(ctx, user, metav1.UpdateOptions{})
 				}
 			}
 		} else if AUPCopy.Spec.Accepted && AUPCopy.Status.Expires != nil {
 			// Check if the 6 months cycle expired
 			if AUPCopy.Status.Expires.Time.Sub(time.Now()) >= 0 {
-				go t.runApprovalTimeout(AUPCopy)
-				user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(context.TODO(), AUPCopy.GetName(), metav1.GetOptions{})
+				user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(ctx, AUPCopy.GetName(), metav1.GetOptions{})
 				if !user.Status.AUP {
 					user.Status.AUP = true
-					t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(context.TODO(), user, metav1.UpdateOptions{})
+					t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(ctx, user, metav1.UpdateOptions{})
 				}
 			} else {
 				AUPCopy.Spec.Accepted = false
-				AUPUpdated, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).Update(context.TODO(), AUPCopy, metav1.UpdateOptions{})
+				AUPUpdated, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).Update(ctx, AUPCopy, metav1.UpdateOptions{})
 				if err == nil {
 					AUPCopy = AUPUpdated
 				}
 				AUPCopy.Status.State = failure
 				AUPCopy.Status.Message = []string{statusDict["aup-expired"]}
-				t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(context.TODO(), AUPCopy, metav1.UpdateOptions{})
-				user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(context.TODO(), AUPCopy.GetName(), metav1.GetOptions{})
+				AUPCopy.Status.SetReady(AUPCopy.GetGeneration(), false, apps_v1alpha.ReasonExpired, statusDict["aup-expired"])
+				t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(ctx, AUPCopy, metav1.UpdateOptions{})
+				user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(ctx, AUPCopy.GetName(), metav1.GetOptions{})
 				if user.Status.AUP {
 					user.Status.AUP = false
-					t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(context.TODO(), user, metav1.UpdateOptions{})
+					t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(ctx, user, metav1.UpdateOptions{})
 				}
 			}
 		} else if !AUPCopy.Spec.Accepted && AUPCopy.Status.Expires == nil {
 			AUPCopy.Status.State = success
 			AUPCopy.Status.Message = []string{statusDict["aup-ok"]}
-			t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(context.TODO(), AUPCopy, metav1.UpdateOptions{})
-			user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(context.TODO(), AUPCopy.GetName(), metav1.GetOptions{})
+			AUPCopy.Status.SetReady(AUPCopy.GetGeneration(), false, apps_v1alpha.ReasonNotAccepted, statusDict["aup-not-accepted"])
+			t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(ctx, AUPCopy, metav1.UpdateOptions{})
+			user, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(ctx, AUPCopy.GetName(), metav1.GetOptions{})
 			if user.Status.AUP {
 				user.Status.AUP = false
-				t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(context.TODO(), user, metav1.UpdateOptions{})
+				t.edgenetClientset.AppsV1alpha().Users(user.GetNamespace()).Update(ctx, user, metav1.UpdateOptions{})
 			}
 		}
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj, updated interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj, updated interface{}) error {
 	log.Info("AUPHandler.ObjectUpdated")
 	// Create a copy of the acceptable use policy object to make changes on it
 	AUPCopy := obj.(*apps_v1alpha.AcceptableUsePolicy).DeepCopy()
-	AUPOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), AUPCopy.GetNamespace(), metav1.GetOptions{})
-	AUPOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), AUPOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	AUPOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, AUPCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	AUPOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, AUPOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	fieldUpdated := updated.(fields)
 
 	if AUPOwnerAuthority.Spec.Enabled {
 		// To manipulate user object according to the changes of acceptable use policy
 		if fieldUpdated.accepted {
 			defer func() {
-				AUPUpdated, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(context.TODO(), AUPCopy, metav1.UpdateOptions{})
+				AUPUpdated, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(ctx, AUPCopy, metav1.UpdateOptions{})
 				if err == nil {
 					AUPCopy = AUPUpdated
 				}
 			}()
 			// Get the user who owns this acceptable use policy object
-			AUPUser, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(context.TODO(), AUPCopy.GetName(), metav1.GetOptions{})
+			AUPUser, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(ctx, AUPCopy.GetName(), metav1.GetOptions{})
 			if AUPCopy.Spec.Accepted {
 				AUPUser.Status.AUP = true
-				go t.runApprovalTimeout(AUPCopy)
 				// Set the expiration date according to the 6-month cycle
 				AUPCopy.Status.Expires = &metav1.Time{
 					Time: time.Now().Add(4382 * time.Hour),
@@ -155,111 +164,55 @@ func (t *Handler) ObjectUpdated(obj, updated interface{}) {
 				contentData.CommonData.Name = fmt.Sprintf("%s %s", AUPUser.Spec.FirstName, AUPUser.Spec.LastName)
 				contentData.CommonData.Email = []string{AUPUser.Spec.Email}
 				mailer.Send("acceptable-use-policy-accepted", contentData)
+				AUPCopy.Status.SetReady(AUPCopy.GetGeneration(), true, apps_v1alpha.ReasonAccepted, statusDict["aup-agreed"])
 			} else {
 				AUPUser.Status.AUP = false
+				AUPCopy.Status.SetReady(AUPCopy.GetGeneration(), false, apps_v1alpha.ReasonNotAccepted, statusDict["aup-not-accepted"])
 			}
-			go t.edgenetClientset.AppsV1alpha().Users(AUPUser.GetNamespace()).UpdateStatus(context.TODO(), AUPUser, metav1.UpdateOptions{})
+			t.edgenetClientset.AppsV1alpha().Users(AUPUser.GetNamespace()).UpdateStatus(ctx, AUPUser, metav1.UpdateOptions{})
 		}
 	} else {
 		AUPCopy.Spec.Accepted = false
-		AUPUpdated, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).Update(context.TODO(), AUPCopy, metav1.UpdateOptions{})
+		AUPUpdated, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).Update(ctx, AUPCopy, metav1.UpdateOptions{})
 		if err == nil {
 			AUPCopy = AUPUpdated
 		}
 		AUPCopy.Status.State = failure
 		AUPCopy.Status.Message = []string{statusDict["authority-disabled"]}
-		t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(context.TODO(), AUPCopy, metav1.UpdateOptions{})
+		AUPCopy.Status.SetReady(AUPCopy.GetGeneration(), false, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"])
+		t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).UpdateStatus(ctx, AUPCopy, metav1.UpdateOptions{})
 	}
+	return nil
 }
 
 // ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("AUPHandler.ObjectDeleted")
 	// Mail notification, TBD
+	return nil
 }
 
-// runApprovalTimeout puts a procedure in place to remove requests by approval or timeout
-func (t *Handler) runApprovalTimeout(AUPCopy *apps_v1alpha.AcceptableUsePolicy) {
-	timeoutRenewed := make(chan bool, 1)
-	terminated := make(chan bool, 1)
-	var timeout <-chan time.Time
-	if AUPCopy.Status.Expires != nil {
-		timeout = time.After(time.Until(AUPCopy.Status.Expires.Time))
-	}
-	closeChannels := func() {
-		close(timeoutRenewed)
-		close(terminated)
-	}
-
-	// Watch the events of acceptable use policy object
-	watchAUP, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).Watch(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", AUPCopy.GetName())})
-	if err == nil {
-		go func() {
-			// Get events from watch interface
-			for AUPEvent := range watchAUP.ResultChan() {
-				// Get updated acceptable use policy object
-				updatedAUP, status := AUPEvent.Object.(*apps_v1alpha.AcceptableUsePolicy)
-				if AUPCopy.GetUID() == updatedAUP.GetUID() {
-					if status {
-						if AUPEvent.Type == "DELETED" || !updatedAUP.Spec.Accepted {
-							terminated <- true
-							continue
-						}
-
-						if updatedAUP.Status.Expires != nil {
-							// Check whether expiration date updated - TBD
-							/*if AUPCopy.Status.Expires != nil && timeout != nil {
-								if AUPCopy.Status.Expires.Time == updatedAUP.Status.Expires.Time {
-									AUPCopy = updatedAUP
-									continue
-								}
-							}*/
-							if updatedAUP.Status.Expires.Time.Sub(time.Now()) >= 0 {
-								timeout = time.After(time.Until(updatedAUP.Status.Expires.Time))
-								timeoutRenewed <- true
-							} else {
-								terminated <- true
-							}
-						}
-						AUPCopy = updatedAUP
-					}
-				}
-			}
-		}()
-	} else {
-		// In case of any malfunction of watching acceptableusepolicy resources,
-		// there is a timeout at 72 hours
-		timeout = time.After(72 * time.Hour)
+// ObjectExpired is called by the scheduler when the 6-month cycle of the acceptable use policy comes to an end
+func (t *Handler) ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error {
+	log.Info("AUPHandler.ObjectExpired")
+	AUPCopy := obj.(*apps_v1alpha.AcceptableUsePolicy).DeepCopy()
+	AUPOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, AUPCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
 	}
-
-	// Infinite loop
-timeoutLoop:
-	for {
-		// Wait on multiple channel operations
-	timeoutOptions:
-		select {
-		case <-timeoutRenewed:
-			break timeoutOptions
-		case <-timeout:
-			watchAUP.Stop()
-			AUPOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), AUPCopy.GetNamespace(), metav1.GetOptions{})
-			AUPUser, _ := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(context.TODO(), AUPCopy.GetName(), metav1.GetOptions{})
-			contentData := mailer.CommonContentData{}
-			contentData.CommonData.Authority = AUPOwnerNamespace.Labels["authority-name"]
-			contentData.CommonData.Username = AUPCopy.GetName()
-			contentData.CommonData.Name = fmt.Sprintf("%s %s", AUPUser.Spec.FirstName, AUPUser.Spec.LastName)
-			contentData.CommonData.Email = []string{AUPUser.Spec.Email}
-			mailer.Send("acceptable-use-policy-expired", contentData)
-			AUPUser.Status.AUP = false
-			t.edgenetClientset.AppsV1alpha().Users(AUPUser.GetNamespace()).Update(context.TODO(), AUPUser, metav1.UpdateOptions{})
-			AUPCopy.Spec.Accepted = false
-			t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).Update(context.TODO(), AUPCopy, metav1.UpdateOptions{})
-			closeChannels()
-			break timeoutLoop
-		case <-terminated:
-			watchAUP.Stop()
-			closeChannels()
-			break timeoutLoop
-		}
+	AUPUser, err := t.edgenetClientset.AppsV1alpha().Users(AUPCopy.GetNamespace()).Get(ctx, AUPCopy.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
 	}
+	contentData := mailer.CommonContentData{}
+	contentData.CommonData.Authority = AUPOwnerNamespace.Labels["authority-name"]
+	contentData.CommonData.Username = AUPCopy.GetName()
+	contentData.CommonData.Name = fmt.Sprintf("%s %s", AUPUser.Spec.FirstName, AUPUser.Spec.LastName)
+	contentData.CommonData.Email = []string{AUPUser.Spec.Email}
+	mailer.Send("acceptable-use-policy-expired", contentData)
+	AUPUser.Status.AUP = false
+	t.edgenetClientset.AppsV1alpha().Users(AUPUser.GetNamespace()).Update(ctx, AUPUser, metav1.UpdateOptions{})
+	AUPCopy.Spec.Accepted = false
+	_, err = t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(AUPCopy.GetNamespace()).Update(ctx, AUPCopy, metav1.UpdateOptions{})
+	return err
 }
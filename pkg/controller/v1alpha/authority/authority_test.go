@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
@@ -165,7 +166,7 @@ func TestCreate(t *testing.T) {
 	g.Init()
 	g.handler.Init(g.client, g.edgenetClient)
 	g.edgenetClient.AppsV1alpha().Authorities().Create(context.TODO(), g.authorityObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(g.authorityObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 
 	t.Run("user creation", func(t *testing.T) {
 		_, err := g.edgenetClient.AppsV1alpha().Users(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Get(context.TODO(), g.authorityObj.Spec.Contact.Username, metav1.GetOptions{})
@@ -215,14 +216,14 @@ func TestCollision(t *testing.T) {
 				_, err := g.edgenetClient.AppsV1alpha().AuthorityRequests().Create(context.TODO(), tc.request.(*apps_v1alpha.AuthorityRequest), metav1.CreateOptions{})
 				util.OK(t, err)
 				defer g.edgenetClient.AppsV1alpha().AuthorityRequests().Delete(context.TODO(), tc.request.(*apps_v1alpha.AuthorityRequest).GetName(), metav1.DeleteOptions{})
-				g.handler.checkDuplicateObject(g.authorityObj.DeepCopy())
+				g.handler.checkDuplicateObject(context.TODO(), g.authorityObj.DeepCopy())
 				_, err = g.edgenetClient.AppsV1alpha().AuthorityRequests().Get(context.TODO(), tc.request.(*apps_v1alpha.AuthorityRequest).GetName(), metav1.GetOptions{})
 				util.Equals(t, tc.expected, errors.IsNotFound(err))
 			} else if tc.kind == "User" {
 				_, err := g.edgenetClient.AppsV1alpha().Users(tc.request.(*apps_v1alpha.User).GetNamespace()).Create(context.TODO(), tc.request.(*apps_v1alpha.User).DeepCopy(), metav1.CreateOptions{})
 				util.OK(t, err)
 				defer g.edgenetClient.AppsV1alpha().Users(tc.request.(*apps_v1alpha.User).GetNamespace()).Delete(context.TODO(), tc.request.(*apps_v1alpha.User).GetName(), metav1.DeleteOptions{})
-				exists, message := g.handler.checkDuplicateObject(g.authorityObj.DeepCopy())
+				exists, message := g.handler.checkDuplicateObject(context.TODO(), g.authorityObj.DeepCopy())
 				log.Println(message)
 				util.Equals(t, tc.expected, exists)
 			}
@@ -237,20 +238,48 @@ func TestUpdate(t *testing.T) {
 	// Create an authority to update later
 	g.edgenetClient.AppsV1alpha().Authorities().Create(context.TODO(), g.authorityObj.DeepCopy(), metav1.CreateOptions{})
 	// Invoke ObjectCreated func to create a user
-	g.handler.ObjectCreated(g.authorityObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 	g.edgenetClient.AppsV1alpha().Users(g.userObj.GetNamespace()).Create(context.TODO(), g.userObj.DeepCopy(), metav1.CreateOptions{})
 	userAdmin, _ := g.edgenetClient.AppsV1alpha().Users(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Get(context.TODO(), g.authorityObj.Spec.Contact.Username, metav1.GetOptions{})
 	util.Equals(t, true, userAdmin.Spec.Active)
 	user, _ := g.edgenetClient.AppsV1alpha().Users(g.userObj.GetNamespace()).Get(context.TODO(), g.userObj.GetName(), metav1.GetOptions{})
 	util.Equals(t, true, user.Spec.Active)
 	g.authorityObj.Spec.Enabled = false
-	g.handler.ObjectUpdated(g.authorityObj.DeepCopy())
+	g.handler.ObjectUpdated(context.TODO(), g.authorityObj.DeepCopy())
 	userAdmin, _ = g.edgenetClient.AppsV1alpha().Users(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Get(context.TODO(), g.authorityObj.Spec.Contact.Username, metav1.GetOptions{})
 	util.Equals(t, false, userAdmin.Spec.Active)
 	user, _ = g.edgenetClient.AppsV1alpha().Users(g.userObj.GetNamespace()).Get(context.TODO(), g.userObj.GetName(), metav1.GetOptions{})
 	util.Equals(t, false, user.Spec.Active)
 }
 
+func TestDelete(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient)
+	g.edgenetClient.AppsV1alpha().Authorities().Create(context.TODO(), g.authorityObj.DeepCopy(), metav1.CreateOptions{})
+	g.handler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
+	authority, _ := g.edgenetClient.AppsV1alpha().Authorities().Get(context.TODO(), g.authorityObj.GetName(), metav1.GetOptions{})
+	util.Equals(t, true, ctlruntime.HasFinalizer(authority))
+
+	err := g.handler.ObjectDeleted(context.TODO(), authority.DeepCopy())
+	util.OK(t, err)
+	t.Run("finalizer", func(t *testing.T) {
+		authority, _ := g.edgenetClient.AppsV1alpha().Authorities().Get(context.TODO(), g.authorityObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, false, ctlruntime.HasFinalizer(authority))
+	})
+	t.Run("total resource quota", func(t *testing.T) {
+		_, err := g.handler.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), g.authorityObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+	t.Run("cluster role", func(t *testing.T) {
+		_, err := g.handler.clientset.RbacV1().ClusterRoles().Get(context.TODO(), fmt.Sprintf("authority-%s", g.authorityObj.GetName()), metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+	t.Run("repeated", func(t *testing.T) {
+		util.OK(t, g.handler.ObjectDeleted(context.TODO(), authority.DeepCopy()))
+	})
+}
+
 func TestAuthorityPreparation(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
@@ -261,9 +290,9 @@ func TestAuthorityPreparation(t *testing.T) {
 	for i := 1; i < 3; i++ {
 		t.Run(fmt.Sprintf("preation no %d", i), func(t *testing.T) {
 			if i == 1 {
-				authorityCopy = g.handler.authorityPreparation(g.authorityObj.DeepCopy())
+				authorityCopy = g.handler.authorityPreparation(context.TODO(), g.authorityObj.DeepCopy())
 			} else {
-				authorityCopy = g.handler.authorityPreparation(authorityCopy)
+				authorityCopy = g.handler.authorityPreparation(context.TODO(), authorityCopy)
 			}
 			util.Equals(t, g.authorityObj.Spec, authorityCopy.Spec)
 			util.Equals(t, established, authorityCopy.Status.State)
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/totalresourcequota"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/mailer"
@@ -35,14 +36,15 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
 }
 
 // Handler implementation
@@ -50,6 +52,7 @@ type Handler struct {
 	clientset        kubernetes.Interface
 	edgenetClientset versioned.Interface
 	resourceQuota    *corev1.ResourceQuota
+	recorder         record.EventRecorder
 }
 
 // Init handles any handler initialization
@@ -57,6 +60,7 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 	log.Info("AuthorityHandler.Init")
 	t.clientset = kubernetes
 	t.edgenetClientset = edgenet
+	t.recorder = ctlruntime.NewEventRecorder(kubernetes, "authority-controller")
 	t.resourceQuota = &corev1.ResourceQuota{}
 	t.resourceQuota.Name = "authority-quota"
 	t.resourceQuota.Spec = corev1.ResourceQuotaSpec{
@@ -83,65 +87,101 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("AuthorityHandler.ObjectCreated")
 	// Create a copy of the authority object to make changes on it
 	authorityCopy := obj.(*apps_v1alpha.Authority).DeepCopy()
+	// The finalizer holds the deletion of the authority until ObjectDeleted cleans up after it
+	if ctlruntime.AddFinalizer(authorityCopy) {
+		authorityUpdated, err := t.edgenetClientset.AppsV1alpha().Authorities().Update(ctx, authorityCopy, metav1.UpdateOptions{})
+		if err == nil {
+			authorityCopy = authorityUpdated
+		}
+	}
 	// Check if the email address is already taken
-	exists, message := t.checkDuplicateObject(authorityCopy)
+	exists, message := t.checkDuplicateObject(ctx, authorityCopy)
 	if exists {
 		authorityCopy.Status.State = failure
 		authorityCopy.Status.Message = []string{message}
+		authorityCopy.Status.SetReady(authorityCopy.GetGeneration(), false, apps_v1alpha.ReasonEmailInUse, message)
 		authorityCopy.Spec.Enabled = false
-		t.edgenetClientset.AppsV1alpha().Authorities().UpdateStatus(context.TODO(), authorityCopy, metav1.UpdateOptions{})
-		return
+		t.edgenetClientset.AppsV1alpha().Authorities().UpdateStatus(ctx, authorityCopy, metav1.UpdateOptions{})
+		t.recorder.Event(authorityCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonEmailInUse, message)
+		return nil
 	}
-	authorityCopy = t.authorityPreparation(authorityCopy)
+	authorityCopy = t.authorityPreparation(ctx, authorityCopy)
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj interface{}) error {
 	log.Info("AuthorityHandler.ObjectUpdated")
 	// Create a copy of the authority object to make changes on it
 	authorityCopy := obj.(*apps_v1alpha.Authority).DeepCopy()
 	// Check if the email address is already taken
-	exists, message := t.checkDuplicateObject(authorityCopy)
+	exists, message := t.checkDuplicateObject(ctx, authorityCopy)
 	if exists {
 		authorityCopy.Status.State = failure
 		authorityCopy.Status.Message = []string{message}
+		authorityCopy.Status.SetReady(authorityCopy.GetGeneration(), false, apps_v1alpha.ReasonEmailInUse, message)
 		authorityCopy.Spec.Enabled = false
-		authorityCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Authorities().UpdateStatus(context.TODO(), authorityCopy, metav1.UpdateOptions{})
+		authorityCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Authorities().UpdateStatus(ctx, authorityCopy, metav1.UpdateOptions{})
 		if err == nil {
 			authorityCopy = authorityCopyUpdated
 		}
+		t.recorder.Event(authorityCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonEmailInUse, message)
 	} else if !authorityCopy.Spec.Enabled && authorityCopy.Status.State == failure {
-		authorityCopy = t.authorityPreparation(authorityCopy)
+		authorityCopy = t.authorityPreparation(ctx, authorityCopy)
 	}
 	// Check whether the authority disabled
 	if authorityCopy.Spec.Enabled == false {
+		t.recorder.Event(authorityCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"])
 		// Delete all RoleBindings, Teams, and Slices in the namespace of authority
-		t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", authorityCopy.GetName())).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
-		t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", authorityCopy.GetName())).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
-		t.clientset.RbacV1().RoleBindings(fmt.Sprintf("authority-%s", authorityCopy.GetName())).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+		t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", authorityCopy.GetName())).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
+		t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", authorityCopy.GetName())).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
+		t.clientset.RbacV1().RoleBindings(fmt.Sprintf("authority-%s", authorityCopy.GetName())).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
 		// List all authority users to deactivate and to remove their cluster role binding to get the authority
-		usersRaw, _ := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", authorityCopy.GetName())).List(context.TODO(), metav1.ListOptions{})
+		usersRaw, _ := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", authorityCopy.GetName())).List(ctx, metav1.ListOptions{})
 		for _, user := range usersRaw.Items {
 			userCopy := user.DeepCopy()
 			userCopy.Spec.Active = false
-			t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(context.TODO(), userCopy, metav1.UpdateOptions{})
-			t.clientset.RbacV1().ClusterRoleBindings().Delete(context.TODO(), fmt.Sprintf("%s-%s-for-authority", userCopy.GetNamespace(), userCopy.GetName()), metav1.DeleteOptions{})
+			t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
+			t.clientset.RbacV1().ClusterRoleBindings().Delete(ctx, fmt.Sprintf("%s-%s-for-authority", userCopy.GetNamespace(), userCopy.GetName()), metav1.DeleteOptions{})
 		}
 	}
+	return nil
 }
 
-// ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+// ObjectDeleted is called when an object is deleted, before it disappears thanks to the finalizer
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("AuthorityHandler.ObjectDeleted")
-	// Delete or disable nodes added by authority, TBD.
+	authorityCopy := obj.(*apps_v1alpha.Authority).DeepCopy()
+	// The node contributions go first, so their finalizers remove the nodes and the DNS records of the authority
+	err := t.edgenetClientset.AppsV1alpha().NodeContributions(fmt.Sprintf("authority-%s", authorityCopy.GetName())).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err := permission.DeleteClusterRoles(ctx, authorityCopy); err != nil {
+		return err
+	}
+	err = t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Delete(ctx, authorityCopy.GetName(), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	t.recorder.Event(authorityCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonCleanedUp, statusDict["authority-cleaned"])
+	// The namespace of the authority goes with the owner references
+	if ctlruntime.RemoveFinalizer(authorityCopy) {
+		_, err := t.edgenetClientset.AppsV1alpha().Authorities().Update(ctx, authorityCopy, metav1.UpdateOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	t.sendEmail(authorityCopy, "authority-deletion")
+	return nil
 }
 
 // Create function is for being used by other resources to create an authority
-func (t *Handler) Create(obj interface{}) bool {
+func (t *Handler) Create(ctx context.Context, obj interface{}) bool {
 	failed := true
 	switch obj.(type) {
 	case *apps_v1alpha.AuthorityRequest:
@@ -155,10 +195,10 @@ func (t *Handler) Create(obj interface{}) bool {
 		authority.Spec.ShortName = authorityRequestCopy.Spec.ShortName
 		authority.Spec.URL = authorityRequestCopy.Spec.URL
 		authority.Spec.Enabled = true
-		_, err := t.edgenetClientset.AppsV1alpha().Authorities().Create(context.TODO(), authority.DeepCopy(), metav1.CreateOptions{})
+		_, err := t.edgenetClientset.AppsV1alpha().Authorities().Create(ctx, authority.DeepCopy(), metav1.CreateOptions{})
 		if err == nil {
 			failed = false
-			t.edgenetClientset.AppsV1alpha().AuthorityRequests().Delete(context.TODO(), authorityRequestCopy.GetName(), metav1.DeleteOptions{})
+			t.edgenetClientset.AppsV1alpha().AuthorityRequests().Delete(ctx, authorityRequestCopy.GetName(), metav1.DeleteOptions{})
 		}
 	}
 
@@ -166,12 +206,12 @@ func (t *Handler) Create(obj interface{}) bool {
 }
 
 // authorityPreparation basically generates a namespace and creates authority-admin
-func (t *Handler) authorityPreparation(authorityCopy *apps_v1alpha.Authority) *apps_v1alpha.Authority {
+func (t *Handler) authorityPreparation(ctx context.Context, authorityCopy *apps_v1alpha.Authority) *apps_v1alpha.Authority {
 	// If the service restarts, it creates all objects again
 	// Because of that, this section covers a variety of possibilities
-	_, err := t.clientset.CoreV1().Namespaces().Get(context.TODO(), fmt.Sprintf("authority-%s", authorityCopy.GetName()), metav1.GetOptions{})
+	_, err := t.clientset.CoreV1().Namespaces().Get(ctx, fmt.Sprintf("authority-%s", authorityCopy.GetName()), metav1.GetOptions{})
 	if err != nil {
-		permission.CreateClusterRoles(authorityCopy)
+		permission.CreateClusterRoles(ctx, authorityCopy)
 		// Automatically create a namespace to host users, slices, and teams
 		// When a authority is deleted, the owner references feature allows the namespace to be automatically removed
 		ownerReferences := SetAsOwnerReference(authorityCopy)
@@ -180,29 +220,31 @@ func (t *Handler) authorityPreparation(authorityCopy *apps_v1alpha.Authority) *a
 		// Namespace labels indicate this namespace created by a authority, not by a team or slice
 		namespaceLabels := map[string]string{"owner": "authority", "owner-name": authorityCopy.GetName(), "authority-name": authorityCopy.GetName()}
 		authorityChildNamespace.SetLabels(namespaceLabels)
-		authorityChildNamespaceCreated, err := t.clientset.CoreV1().Namespaces().Create(context.TODO(), authorityChildNamespace, metav1.CreateOptions{})
+		authorityChildNamespaceCreated, err := t.clientset.CoreV1().Namespaces().Create(ctx, authorityChildNamespace, metav1.CreateOptions{})
 		if err != nil {
 			log.Infof("Couldn't create namespace for %s: %s", authorityCopy.GetName(), err)
 			authorityCopy.Status.State = failure
 			authorityCopy.Status.Message = []string{statusDict["namespace-failure"]}
+			authorityCopy.Status.SetReady(authorityCopy.GetGeneration(), false, apps_v1alpha.ReasonNamespaceCreationFailed, statusDict["namespace-failure"])
+			t.recorder.Eventf(authorityCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonNamespaceCreationFailed, "%s: %s", statusDict["namespace-failure"], err)
 		}
 		// Create the resource quota to ban users from using this namespace for their applications
-		_, err = t.clientset.CoreV1().ResourceQuotas(authorityChildNamespaceCreated.GetName()).Create(context.TODO(), t.resourceQuota, metav1.CreateOptions{})
+		_, err = t.clientset.CoreV1().ResourceQuotas(authorityChildNamespaceCreated.GetName()).Create(ctx, t.resourceQuota, metav1.CreateOptions{})
 		if err != nil && !errors.IsAlreadyExists(err) {
 			log.Infof("Couldn't create resource quota in %s: %s", authorityCopy.GetName(), err)
 		}
 		childNamespaceOwnerReferences := ns.SetAsOwnerReference(authorityChildNamespaceCreated)
 		authorityCopy.ObjectMeta.OwnerReferences = childNamespaceOwnerReferences
-		authorityCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Authorities().Update(context.TODO(), authorityCopy, metav1.UpdateOptions{})
+		authorityCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Authorities().Update(ctx, authorityCopy, metav1.UpdateOptions{})
 		if err == nil {
 			// To manipulate the object later
 			authorityCopy = authorityCopyUpdated
 		}
 		TRQHandler := totalresourcequota.Handler{}
 		TRQHandler.Init(t.clientset, t.edgenetClientset)
-		TRQHandler.Create(authorityCopy.GetName())
+		TRQHandler.Create(ctx, authorityCopy.GetName())
 		enableAuthorityAdmin := func() {
-			t.edgenetClientset.AppsV1alpha().Authorities().UpdateStatus(context.TODO(), authorityCopy, metav1.UpdateOptions{})
+			t.edgenetClientset.AppsV1alpha().Authorities().UpdateStatus(ctx, authorityCopy, metav1.UpdateOptions{})
 			// Create a user as admin on authority
 			user := apps_v1alpha.User{}
 			user.SetName(strings.ToLower(authorityCopy.Spec.Contact.Username))
@@ -210,11 +252,13 @@ func (t *Handler) authorityPreparation(authorityCopy *apps_v1alpha.Authority) *a
 			user.Spec.FirstName = authorityCopy.Spec.Contact.FirstName
 			user.Spec.LastName = authorityCopy.Spec.Contact.LastName
 			user.Spec.Active = true
-			_, err = t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", authorityCopy.GetName())).Create(context.TODO(), user.DeepCopy(), metav1.CreateOptions{})
+			_, err = t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", authorityCopy.GetName())).Create(ctx, user.DeepCopy(), metav1.CreateOptions{})
 			if err != nil {
 				t.sendEmail(authorityCopy, "user-creation-failure")
 				authorityCopy.Status.State = failure
 				authorityCopy.Status.Message = append(authorityCopy.Status.Message, []string{statusDict["user-failed"], err.Error()}...)
+				authorityCopy.Status.SetReady(authorityCopy.GetGeneration(), false, apps_v1alpha.ReasonUserCreationFailed, fmt.Sprintf("%s: %s", statusDict["user-failed"], err))
+				t.recorder.Eventf(authorityCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonUserCreationFailed, "%s: %s", statusDict["user-failed"], err)
 			}
 		}
 		defer enableAuthorityAdmin()
@@ -222,13 +266,15 @@ func (t *Handler) authorityPreparation(authorityCopy *apps_v1alpha.Authority) *a
 			// Update authority status
 			authorityCopy.Status.State = established
 			authorityCopy.Status.Message = []string{statusDict["authority-ok"]}
+			authorityCopy.Status.SetReady(authorityCopy.GetGeneration(), true, apps_v1alpha.ReasonReconciled, statusDict["authority-ok"])
+			t.recorder.Event(authorityCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonReconciled, statusDict["authority-ok"])
 			t.sendEmail(authorityCopy, "authority-creation-successful")
 		}
 	} else if err == nil {
-		permission.CreateClusterRoles(authorityCopy)
+		permission.CreateClusterRoles(ctx, authorityCopy)
 		TRQHandler := totalresourcequota.Handler{}
 		TRQHandler.Init(t.clientset, t.edgenetClientset)
-		TRQHandler.Create(authorityCopy.GetName())
+		TRQHandler.Create(ctx, authorityCopy.GetName())
 	}
 	return authorityCopy
 }
@@ -245,11 +291,11 @@ func (t *Handler) sendEmail(authorityCopy *apps_v1alpha.Authority, subject strin
 }
 
 // checkDuplicateObject checks whether a user exists with the same email address
-func (t *Handler) checkDuplicateObject(authorityCopy *apps_v1alpha.Authority) (bool, string) {
+func (t *Handler) checkDuplicateObject(ctx context.Context, authorityCopy *apps_v1alpha.Authority) (bool, string) {
 	exists := false
 	var message string
 	// To check email address
-	userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(context.TODO(), metav1.ListOptions{})
+	userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(ctx, metav1.ListOptions{})
 	for _, userRow := range userRaw.Items {
 		if userRow.Spec.Email == authorityCopy.Spec.Contact.Email {
 			if userRow.GetNamespace() == fmt.Sprintf("authority-%s", authorityCopy.GetName()) && userRow.GetName() == strings.ToLower(authorityCopy.Spec.Contact.Username) {
@@ -262,11 +308,11 @@ func (t *Handler) checkDuplicateObject(authorityCopy *apps_v1alpha.Authority) (b
 	}
 	if !exists {
 		// Update the authority requests that have duplicate values, if any
-		authorityRequestRaw, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().List(context.TODO(), metav1.ListOptions{})
+		authorityRequestRaw, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().List(ctx, metav1.ListOptions{})
 		for _, authorityRequestRow := range authorityRequestRaw.Items {
 			if authorityRequestRow.Status.State == success {
 				if authorityRequestRow.GetName() == authorityCopy.GetName() || authorityRequestRow.Spec.Contact.Email == authorityCopy.Spec.Contact.Email {
-					t.edgenetClientset.AppsV1alpha().AuthorityRequests().Delete(context.TODO(), authorityRequestRow.GetName(), metav1.DeleteOptions{})
+					t.edgenetClientset.AppsV1alpha().AuthorityRequests().Delete(ctx, authorityRequestRow.GetName(), metav1.DeleteOptions{})
 				}
 			}
 		}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
@@ -152,7 +153,7 @@ func (g *TestGroup) Init() {
 	namespace.SetLabels(namespaceLabels)
 	g.client.CoreV1().Namespaces().Create(context.TODO(), &namespace, metav1.CreateOptions{})
 	// Invoke ObjectCreated to create namespace
-	// authorityHandler.ObjectCreated(g.authorityObj.DeepCopy())
+	// authorityHandler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 }
 
 func TestHandlerInit(t *testing.T) {
@@ -171,7 +172,7 @@ func TestCreate(t *testing.T) {
 	g.handler.Init(g.client, g.edgenetClient)
 	// Creation of Authority request
 	g.edgenetClient.AppsV1alpha().AuthorityRequests().Create(context.TODO(), g.authorityRequestObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(g.authorityRequestObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.authorityRequestObj.DeepCopy())
 	t.Run("set expiry date", func(t *testing.T) {
 		authorityRequest, _ := g.edgenetClient.AppsV1alpha().AuthorityRequests().Get(context.TODO(), g.authorityRequestObj.GetName(), metav1.GetOptions{})
 		expected := metav1.Time{
@@ -183,13 +184,13 @@ func TestCreate(t *testing.T) {
 	})
 	t.Run("timeout", func(t *testing.T) {
 		authorityRequest, _ := g.edgenetClient.AppsV1alpha().AuthorityRequests().Get(context.TODO(), g.authorityRequestObj.GetName(), metav1.GetOptions{})
-		go g.handler.runApprovalTimeout(authorityRequest)
 		authorityRequest.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(10 * time.Millisecond),
 		}
 		_, err := g.edgenetClient.AppsV1alpha().AuthorityRequests().Update(context.TODO(), authorityRequest, metav1.UpdateOptions{})
 		util.OK(t, err)
-		time.Sleep(100 * time.Millisecond)
+		util.Equals(t, authorityRequest.Status.Expires.Time, getDeadlines(authorityRequest)[expiry.Expire])
+		util.OK(t, g.handler.ObjectExpired(context.TODO(), authorityRequest, expiry.Expire))
 		_, err = g.edgenetClient.AppsV1alpha().AuthorityRequests().Get(context.TODO(), authorityRequest.GetName(), metav1.GetOptions{})
 		util.Equals(t, true, errors.IsNotFound(err))
 	})
@@ -216,20 +217,25 @@ func TestCreate(t *testing.T) {
 		cases := map[string]struct {
 			request  apps_v1alpha.AuthorityRequest
 			expected string
+			reason   string
 		}{
-			"name/authority":                {ar1, fmt.Sprintf(statusDict["authority-taken"], ar1.GetName())},
-			"email/authorityrequest":        {ar2, fmt.Sprintf(statusDict["email-used-auth"], ar2.Spec.Contact.Email)},
-			"email/user":                    {ar3, fmt.Sprintf(statusDict["email-exist"], ar3.Spec.Contact.Email)},
-			"email/userregistrationrequest": {ar4, fmt.Sprintf(statusDict["email-used-reg"], ar4.Spec.Contact.Email)},
+			"name/authority":                {ar1, fmt.Sprintf(statusDict["authority-taken"], ar1.GetName()), apps_v1alpha.ReasonAuthorityNameTaken},
+			"email/authorityrequest":        {ar2, fmt.Sprintf(statusDict["email-used-auth"], ar2.Spec.Contact.Email), apps_v1alpha.ReasonEmailInUse},
+			"email/user":                    {ar3, fmt.Sprintf(statusDict["email-exist"], ar3.Spec.Contact.Email), apps_v1alpha.ReasonEmailInUse},
+			"email/userregistrationrequest": {ar4, fmt.Sprintf(statusDict["email-used-reg"], ar4.Spec.Contact.Email), apps_v1alpha.ReasonEmailInUse},
 		}
 		for k, tc := range cases {
 			t.Run(k, func(t *testing.T) {
 				_, err := g.edgenetClient.AppsV1alpha().AuthorityRequests().Create(context.TODO(), tc.request.DeepCopy(), metav1.CreateOptions{})
 				util.OK(t, err)
-				g.handler.ObjectCreated(tc.request.DeepCopy())
+				g.handler.ObjectCreated(context.TODO(), tc.request.DeepCopy())
 				AR, err := g.edgenetClient.AppsV1alpha().AuthorityRequests().Get(context.TODO(), tc.request.GetName(), metav1.GetOptions{})
 				util.OK(t, err)
 				util.Equals(t, tc.expected, AR.Status.Message[0])
+				ready := AR.Status.GetCondition(apps_v1alpha.ConditionReady)
+				util.Equals(t, metav1.ConditionFalse, ready.Status)
+				util.Equals(t, tc.reason, ready.Reason)
+				util.Equals(t, AR.GetGeneration(), AR.Status.ObservedGeneration)
 				g.edgenetClient.AppsV1alpha().AuthorityRequests().Delete(context.TODO(), tc.request.GetName(), metav1.DeleteOptions{})
 			})
 		}
@@ -280,7 +286,7 @@ func TestUpdate(t *testing.T) {
 				tc.request.Status = status
 				_, err := g.edgenetClient.AppsV1alpha().AuthorityRequests().Update(context.TODO(), tc.request.DeepCopy(), metav1.UpdateOptions{})
 				util.OK(t, err)
-				g.handler.ObjectUpdated(tc.request.DeepCopy())
+				g.handler.ObjectUpdated(context.TODO(), tc.request.DeepCopy())
 				AR, err := g.edgenetClient.AppsV1alpha().AuthorityRequests().Get(context.TODO(), tc.request.GetName(), metav1.GetOptions{})
 				util.OK(t, err)
 				util.EqualsMultipleExp(t, tc.expected, AR.Status.State)
@@ -293,7 +299,7 @@ func TestUpdate(t *testing.T) {
 		// Updating authority request status to approved
 		g.authorityRequestObj.Spec.Approved = true
 		// Requesting server to update internal representation of authority request object and transition it to authority
-		g.handler.ObjectUpdated(g.authorityRequestObj.DeepCopy())
+		g.handler.ObjectUpdated(context.TODO(), g.authorityRequestObj.DeepCopy())
 		// Checking if handler created authority from request
 		_, err := g.edgenetClient.AppsV1alpha().Authorities().Get(context.TODO(), g.authorityRequestObj.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
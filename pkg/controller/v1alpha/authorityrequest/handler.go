@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/authority"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/emailverification"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
@@ -37,9 +39,10 @@ import (
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
+	ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error
 }
 
 // Handler implementation
@@ -56,110 +59,115 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("authorityRequestHandler.ObjectCreated")
 	// Create a copy of the authority request object to make changes on it
 	authorityRequestCopy := obj.(*apps_v1alpha.AuthorityRequest).DeepCopy()
-	defer t.edgenetClientset.AppsV1alpha().AuthorityRequests().UpdateStatus(context.TODO(), authorityRequestCopy, metav1.UpdateOptions{})
+	defer t.edgenetClientset.AppsV1alpha().AuthorityRequests().UpdateStatus(ctx, authorityRequestCopy, metav1.UpdateOptions{})
 	// Check if the email address of user or authority name is already taken
-	exists, message := t.checkDuplicateObject(authorityRequestCopy)
+	exists, reason, message := t.checkDuplicateObject(ctx, authorityRequestCopy)
 	if exists {
 		authorityRequestCopy.Status.State = failure
 		authorityRequestCopy.Status.Message = message
-		// Run timeout goroutine
-		go t.runApprovalTimeout(authorityRequestCopy)
+		authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), false, reason, strings.Join(message, "; "))
 		// Set the approval timeout which is 24 hours
 		authorityRequestCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(24 * time.Hour),
 		}
-		return
+		return nil
 	}
 	if authorityRequestCopy.Spec.Approved {
 		authorityHandler := authority.Handler{}
 		authorityHandler.Init(t.clientset, t.edgenetClientset)
-		created := !authorityHandler.Create(authorityRequestCopy)
+		created := !authorityHandler.Create(ctx, authorityRequestCopy)
 		if created {
-			return
+			return nil
 		} else {
 			t.sendEmail("authority-creation-failure", authorityRequestCopy)
 			authorityRequestCopy.Status.State = failure
 			authorityRequestCopy.Status.Message = []string{statusDict["authority-failed"]}
+			authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), false, apps_v1alpha.ReasonAuthorityCreationFailed, statusDict["authority-failed"])
 		}
 
 	}
 	// If the service restarts, it creates all objects again
 	// Because of that, this section covers a variety of possibilities
 	if authorityRequestCopy.Status.Expires == nil {
-		// Run timeout goroutine
-		go t.runApprovalTimeout(authorityRequestCopy)
 		// Set the approval timeout which is 72 hours
 		authorityRequestCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(72 * time.Hour),
 		}
 		emailVerificationHandler := emailverification.Handler{}
 		emailVerificationHandler.Init(t.clientset, t.edgenetClientset)
-		created := emailVerificationHandler.Create(authorityRequestCopy, SetAsOwnerReference(authorityRequestCopy))
+		created := emailVerificationHandler.Create(ctx, authorityRequestCopy, SetAsOwnerReference(authorityRequestCopy))
 		if created {
 			// Update the status as successful
 			authorityRequestCopy.Status.State = success
 			authorityRequestCopy.Status.Message = []string{statusDict["email-ok"]}
+			authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), true, apps_v1alpha.ReasonVerificationEmailSent, statusDict["email-ok"])
 		} else {
 			authorityRequestCopy.Status.State = issue
 			authorityRequestCopy.Status.Message = []string{statusDict["email-fail"]}
+			authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), false, apps_v1alpha.ReasonVerificationEmailFailed, statusDict["email-fail"])
 		}
 
-	} else {
-		go t.runApprovalTimeout(authorityRequestCopy)
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj interface{}) error {
 	log.Info("authorityRequestHandler.ObjectUpdated")
 	// Create a copy of the authority request object to make changes on it
 	authorityRequestCopy := obj.(*apps_v1alpha.AuthorityRequest).DeepCopy()
 	changeStatus := false
 	// Check if the email address of user or authority name is already taken
-	exists, message := t.checkDuplicateObject(authorityRequestCopy)
+	exists, reason, message := t.checkDuplicateObject(ctx, authorityRequestCopy)
 	if !exists {
 		// Check whether the request for authority creation approved
 		if authorityRequestCopy.Spec.Approved {
 			authorityHandler := authority.Handler{}
 			authorityHandler.Init(t.clientset, t.edgenetClientset)
-			changeStatus := authorityHandler.Create(authorityRequestCopy)
+			changeStatus := authorityHandler.Create(ctx, authorityRequestCopy)
 			if changeStatus {
 				t.sendEmail("authority-creation-failure", authorityRequestCopy)
 				authorityRequestCopy.Status.State = failure
 				authorityRequestCopy.Status.Message = []string{statusDict["authority-failed"]}
+				authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), false, apps_v1alpha.ReasonAuthorityCreationFailed, statusDict["authority-failed"])
 			}
 		} else if !authorityRequestCopy.Spec.Approved && authorityRequestCopy.Status.State == failure {
 			emailVerificationHandler := emailverification.Handler{}
 			emailVerificationHandler.Init(t.clientset, t.edgenetClientset)
-			created := emailVerificationHandler.Create(authorityRequestCopy, SetAsOwnerReference(authorityRequestCopy))
+			created := emailVerificationHandler.Create(ctx, authorityRequestCopy, SetAsOwnerReference(authorityRequestCopy))
 			if created {
 				// Update the status as successful
 				authorityRequestCopy.Status.State = success
 				authorityRequestCopy.Status.Message = []string{statusDict["email-ok"]}
+				authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), true, apps_v1alpha.ReasonVerificationEmailSent, statusDict["email-ok"])
 			} else {
 				authorityRequestCopy.Status.State = issue
 				authorityRequestCopy.Status.Message = []string{statusDict["email-fail"]}
+				authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), false, apps_v1alpha.ReasonVerificationEmailFailed, statusDict["email-fail"])
 			}
 			changeStatus = true
 		}
 	} else if exists && !reflect.DeepEqual(authorityRequestCopy.Status.Message, message) {
 		authorityRequestCopy.Status.State = failure
 		authorityRequestCopy.Status.Message = message
+		authorityRequestCopy.Status.SetReady(authorityRequestCopy.GetGeneration(), false, reason, strings.Join(message, "; "))
 		changeStatus = true
 	}
 	if changeStatus {
-		t.edgenetClientset.AppsV1alpha().AuthorityRequests().UpdateStatus(context.TODO(), authorityRequestCopy, metav1.UpdateOptions{})
+		t.edgenetClientset.AppsV1alpha().AuthorityRequests().UpdateStatus(ctx, authorityRequestCopy, metav1.UpdateOptions{})
 	}
+	return nil
 }
 
 // ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("authorityRequestHandler.ObjectDeleted")
 	// Mail notification, TBD
+	return nil
 }
 
 // sendEmail to send notification to participants
@@ -174,20 +182,23 @@ func (t *Handler) sendEmail(subject string, authorityRequestCopy *apps_v1alpha.A
 }
 
 // checkDuplicateObject checks whether a user exists with the same email address
-func (t *Handler) checkDuplicateObject(authorityRequestCopy *apps_v1alpha.AuthorityRequest) (bool, []string) {
+// checkDuplicateObject checks whether the authority name or the email address is already taken, and returns the reason along with the messages
+func (t *Handler) checkDuplicateObject(ctx context.Context, authorityRequestCopy *apps_v1alpha.AuthorityRequest) (bool, string, []string) {
 	exists := false
+	var reason string
 	message := []string{}
 	// To check username on the users resource
-	_, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), authorityRequestCopy.GetName(), metav1.GetOptions{})
+	_, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, authorityRequestCopy.GetName(), metav1.GetOptions{})
 	if !errors.IsNotFound(err) {
 		exists = true
+		reason = apps_v1alpha.ReasonAuthorityNameTaken
 		message = append(message, fmt.Sprintf(statusDict["authority-taken"], authorityRequestCopy.GetName()))
 		if !reflect.DeepEqual(authorityRequestCopy.Status.Message, message) {
 			t.sendEmail("authority-validation-failure-name", authorityRequestCopy)
 		}
 	} else {
 		// To check email address among users
-		userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(context.TODO(), metav1.ListOptions{})
+		userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(ctx, metav1.ListOptions{})
 		for _, userRow := range userRaw.Items {
 			if userRow.Spec.Email == authorityRequestCopy.Spec.Contact.Email {
 				exists = true
@@ -196,7 +207,7 @@ func (t *Handler) checkDuplicateObject(authorityRequestCopy *apps_v1alpha.Author
 			}
 		}
 		// To check email address among user registration requests
-		URRRaw, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests("").List(context.TODO(), metav1.ListOptions{})
+		URRRaw, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests("").List(ctx, metav1.ListOptions{})
 		for _, URRRow := range URRRaw.Items {
 			if URRRow.Spec.Email == authorityRequestCopy.Spec.Contact.Email {
 				exists = true
@@ -205,7 +216,7 @@ func (t *Handler) checkDuplicateObject(authorityRequestCopy *apps_v1alpha.Author
 			}
 		}
 		// To check email address given at authority request
-		authorityRequestRaw, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().List(context.TODO(), metav1.ListOptions{})
+		authorityRequestRaw, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().List(ctx, metav1.ListOptions{})
 		for _, authorityRequestRow := range authorityRequestRaw.Items {
 			if authorityRequestRow.Spec.Contact.Email == authorityRequestCopy.Spec.Contact.Email && authorityRequestRow.GetUID() != authorityRequestCopy.GetUID() {
 				exists = true
@@ -213,89 +224,25 @@ func (t *Handler) checkDuplicateObject(authorityRequestCopy *apps_v1alpha.Author
 				break
 			}
 		}
+		if exists {
+			reason = apps_v1alpha.ReasonEmailInUse
+		}
 		if exists && !reflect.DeepEqual(authorityRequestCopy.Status.Message, message) {
 			t.sendEmail("authority-validation-failure-email", authorityRequestCopy)
 		}
 	}
-	return exists, message
+	return exists, reason, message
 }
 
-// runApprovalTimeout puts a procedure in place to remove requests by approval or timeout
-func (t *Handler) runApprovalTimeout(authorityRequestCopy *apps_v1alpha.AuthorityRequest) {
-	registrationApproved := make(chan bool, 1)
-	timeoutRenewed := make(chan bool, 1)
-	terminated := make(chan bool, 1)
-	var timeout <-chan time.Time
-	if authorityRequestCopy.Status.Expires != nil {
-		timeout = time.After(time.Until(authorityRequestCopy.Status.Expires.Time))
-	}
-	closeChannels := func() {
-		close(registrationApproved)
-		close(timeoutRenewed)
-		close(terminated)
-	}
-
-	// Watch the events of authority request object
-	watchAuthorityRequest, err := t.edgenetClientset.AppsV1alpha().AuthorityRequests().Watch(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", authorityRequestCopy.GetName())})
-	if err == nil {
-		go func() {
-			// Get events from watch interface
-			for authorityRequestEvent := range watchAuthorityRequest.ResultChan() {
-				// Get updated authority request object
-				updatedAuthorityRequest, status := authorityRequestEvent.Object.(*apps_v1alpha.AuthorityRequest)
-				if authorityRequestCopy.GetUID() == updatedAuthorityRequest.GetUID() {
-					if status {
-						if authorityRequestEvent.Type == "DELETED" {
-							terminated <- true
-							continue
-						}
-
-						if updatedAuthorityRequest.Spec.Approved == true {
-							registrationApproved <- true
-							break
-						} else if updatedAuthorityRequest.Status.Expires != nil {
-							// Check whether expiration date updated - TBD
-							if updatedAuthorityRequest.Status.Expires.Time.Sub(time.Now()) >= 0 {
-								timeout = time.After(time.Until(updatedAuthorityRequest.Status.Expires.Time))
-								timeoutRenewed <- true
-							} else {
-								terminated <- true
-							}
-						}
-						authorityRequestCopy = updatedAuthorityRequest
-					}
-				}
-			}
-		}()
-	} else {
-		// In case of any malfunction of watching authorityrequest resources,
-		// there is a timeout at 72 hours
-		timeout = time.After(72 * time.Hour)
-	}
-
-	// Infinite loop
-timeoutLoop:
-	for {
-		// Wait on multiple channel operations
-	timeoutOptions:
-		select {
-		case <-registrationApproved:
-			watchAuthorityRequest.Stop()
-			closeChannels()
-			break timeoutLoop
-		case <-timeoutRenewed:
-			break timeoutOptions
-		case <-timeout:
-			watchAuthorityRequest.Stop()
-			closeChannels()
-			t.edgenetClientset.AppsV1alpha().AuthorityRequests().Delete(context.TODO(), authorityRequestCopy.GetName(), metav1.DeleteOptions{})
-			break timeoutLoop
-		case <-terminated:
-			watchAuthorityRequest.Stop()
-			closeChannels()
-			break timeoutLoop
-		}
+// ObjectExpired is called by the scheduler when the authority request is not approved in time
+func (t *Handler) ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error {
+	log.Info("authorityRequestHandler.ObjectExpired")
+	authorityRequestCopy := obj.(*apps_v1alpha.AuthorityRequest).DeepCopy()
+	err := t.edgenetClientset.AppsV1alpha().AuthorityRequests().Delete(ctx, authorityRequestCopy.GetName(), metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
 	}
+	return err
 }
 
 // SetAsOwnerReference put the authorityrequest as owner
@@ -17,42 +17,19 @@ limitations under the License.
 package emailverification
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
-	appsinformer_v1 "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/apps/v1alpha"
 
-	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 )
 
-// The main structure of controller
-type controller struct {
-	logger   *log.Entry
-	queue    workqueue.RateLimitingInterface
-	informer cache.SharedIndexInformer
-	handler  HandlerInterface
-}
-
-// The main structure of informerevent
-type informerevent struct {
-	key      string
-	function string
-	updated  fields
-}
-
 // This contains the fields to check whether they are updated
 type fields struct {
 	kind       bool
@@ -60,161 +37,62 @@ type fields struct {
 }
 
 // Constant variables for events
-const create = "create"
-const update = "update"
-const delete = "delete"
 
 // Start function is entry point of the controller
 func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
-	var err error
-	clientset := kubernetes
-	edgenetClientset := edgenet
-	EVHandler := &Handler{}
-	// Create the emailverification informer which was generated by the code generator to list and watch emailverification resources
-	informer := appsinformer_v1.NewEmailVerificationInformer(
-		edgenetClientset,
-		metav1.NamespaceAll,
-		0,
-		cache.Indexers{},
-	)
-	// Create a work queue which contains a key of the resource to be handled by the handler
-	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	var event informerevent
-	// Event handlers deal with events of resources. Here, there are three types of events as Add, Update, and Delete
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			// Put the resource object into a key
-			event.key, err = cache.MetaNamespaceKeyFunc(obj)
-			event.function = create
-			log.Infof("Add emailverification: %s", event.key)
-			if err == nil {
-				// Add the key to the queue
-				queue.Add(event)
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			event.key, err = cache.MetaNamespaceKeyFunc(newObj)
-			event.function = update
-			// Find out whether the fields updated
-			event.updated.kind = false
-			event.updated.identifier = false
-			if oldObj.(*apps_v1alpha.EmailVerification).Spec.Kind != newObj.(*apps_v1alpha.EmailVerification).Spec.Kind {
-				event.updated.kind = true
-			}
-			if oldObj.(*apps_v1alpha.EmailVerification).Spec.Identifier != newObj.(*apps_v1alpha.EmailVerification).Spec.Identifier {
-				event.updated.identifier = true
-			}
-			log.Infof("Update emailverification: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			// DeletionHandlingMetaNamsespaceKeyFunc helps to check the existence of the object while it is still contained in the index.
-			// Put the resource object into a key
-			event.key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			event.function = delete
-			log.Infof("Delete emailverification: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-	})
-	controller := controller{
-		logger:   log.NewEntry(log.New()),
-		informer: informer,
-		queue:    queue,
-		handler:  EVHandler,
-	}
-
-	registrationNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "registration"}}
-	clientset.CoreV1().Namespaces().Create(context.TODO(), registrationNamespace, metav1.CreateOptions{})
-
-	// A channel to terminate elegantly
-	stopCh := make(chan struct{})
-	defer close(stopCh)
-	// Run the controller loop as a background task to start processing resources
-	go controller.run(stopCh, clientset, edgenetClientset)
-	// A channel to observe OS signals for smooth shut down
-	sigTerm := make(chan os.Signal, 1)
-	signal.Notify(sigTerm, syscall.SIGTERM)
-	signal.Notify(sigTerm, syscall.SIGINT)
-	<-sigTerm
+	manager := ctlruntime.NewManager(kubernetes, edgenet)
+	Register(manager)
+	// Run the controller loop until a signal to terminate elegantly arrives
+	manager.Run(ctlruntime.SetupSignalHandler())
 }
 
-// Run starts the controller loop
-func (c *controller) run(stopCh <-chan struct{}, clientset kubernetes.Interface, edgenetClientset versioned.Interface) {
-	// A Go panic which includes logging and terminating
-	defer utilruntime.HandleCrash()
-	// Shutdown after all goroutines have done
-	defer c.queue.ShutDown()
-	c.logger.Info("run: initiating")
-	c.handler.Init(clientset, edgenetClientset)
-	// Run the informer to list and watch resources
-	go c.informer.Run(stopCh)
-
-	// Synchronization to settle resources one
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
-		utilruntime.HandleError(fmt.Errorf("Error syncing cache"))
-		return
-	}
-	c.logger.Info("run: cache sync complete")
-	// Operate the runWorker
-	go wait.Until(c.runWorker, time.Second, stopCh)
+// Register adds the email verification controller to the manager, which can host other controllers alongside
+func Register(manager *ctlruntime.Manager) {
+	clientset := manager.Clientset
+	edgenetClientset := manager.EdgeNetClientset
+	EVHandler := &Handler{}
+	EVHandler.Init(clientset, edgenetClientset)
+	informer := manager.EdgeNetInformerFactory.Apps().V1alpha().EmailVerifications().Informer()
+	controller := newController(informer, EVHandler)
+	manager.Scheduler.Register("EmailVerification", informer, getDeadlines, EVHandler.ObjectExpired)
 
-	<-stopCh
+	registrationNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "registration"}}
+	clientset.CoreV1().Namespaces().Create(controller.Context(), registrationNamespace, metav1.CreateOptions{})
+	manager.Add(controller)
 }
 
-// To process new objects added to the queue
-func (c *controller) runWorker() {
-	log.Info("runWorker: starting")
-	// Run processNextItem for all the changes
-	for c.processNextItem() {
-		log.Info("runWorker: processing next item")
+// newController plugs the emailverification handler into the controller runtime
+func newController(informer cache.SharedIndexInformer, handler HandlerInterface) *ctlruntime.Controller {
+	reconciler := ctlruntime.HandlerFuncs{
+		Indexer:    informer.GetIndexer(),
+		CreateFunc: handler.ObjectCreated,
+		UpdateFunc: handler.ObjectUpdated,
+		DeleteFunc: handler.ObjectDeleted,
 	}
-
-	log.Info("runWorker: completed")
+	return ctlruntime.New(informer, reconciler, ctlruntime.Options{
+		Name:         "emailverification",
+		UpdateFilter: getChange,
+	})
 }
 
-// This function deals with the queue and sends each item in it to the specified handler to be processed.
-func (c *controller) processNextItem() bool {
-	log.Info("processNextItem: start")
-	// Fetch the next item of the queue
-	event, quit := c.queue.Get()
-	if quit {
-		return false
+// getChange finds out which fields of the emailverification updated
+func getChange(oldObj, newObj interface{}) (interface{}, bool) {
+	var change fields
+	// Find out whether the fields updated
+	if oldObj.(*apps_v1alpha.EmailVerification).Spec.Kind != newObj.(*apps_v1alpha.EmailVerification).Spec.Kind {
+		change.kind = true
 	}
-	defer c.queue.Done(event)
-	// Get the key string
-	keyRaw := event.(informerevent).key
-	// Use the string key to get the object from the indexer
-	item, exists, err := c.informer.GetIndexer().GetByKey(keyRaw)
-	if err != nil {
-		if c.queue.NumRequeues(event.(informerevent).key) < 5 {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, retrying", event.(informerevent).key, err)
-			c.queue.AddRateLimited(event.(informerevent).key)
-		} else {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, no more retries", event.(informerevent).key, err)
-			c.queue.Forget(event.(informerevent).key)
-			utilruntime.HandleError(err)
-		}
+	if oldObj.(*apps_v1alpha.EmailVerification).Spec.Identifier != newObj.(*apps_v1alpha.EmailVerification).Spec.Identifier {
+		change.identifier = true
 	}
+	return change, true
+}
 
-	if !exists {
-		if event.(informerevent).function == delete {
-			c.logger.Infof("Controller.processNextItem: object deleted detected: %s", keyRaw)
-			c.handler.ObjectDeleted(item)
-		}
-	} else {
-		if event.(informerevent).function == create {
-			c.logger.Infof("Controller.processNextItem: object created detected: %s", keyRaw)
-			c.handler.ObjectCreated(item)
-		} else if event.(informerevent).function == update {
-			c.logger.Infof("Controller.processNextItem: object updated detected: %s", keyRaw)
-			c.handler.ObjectUpdated(item, event.(informerevent).updated)
-		}
+// getDeadlines schedules the expiry of an email verification waiting to be verified
+func getDeadlines(obj interface{}) map[expiry.Action]time.Time {
+	EVObj := obj.(*apps_v1alpha.EmailVerification)
+	if EVObj.Spec.Verified || EVObj.Status.Expires == nil {
+		return nil
 	}
-	c.queue.Forget(event.(informerevent).key)
-
-	return true
+	return map[expiry.Action]time.Time{expiry.Expire: EVObj.Status.Expires.Time}
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
@@ -170,7 +171,7 @@ func (g *TestGroup) Init() {
 	// Create a user as admin on authority
 	g.edgenetClient.AppsV1alpha().Users(g.userObj.GetNamespace()).Create(context.TODO(), g.userObj.DeepCopy(), metav1.CreateOptions{})
 	// Invoke ObjectCreated to create namespace
-	// authorityHandler.ObjectCreated(g.authorityObj.DeepCopy())
+	// authorityHandler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 }
 
 func TestHandlerInit(t *testing.T) {
@@ -191,7 +192,7 @@ func TestCreate(t *testing.T) {
 	code := "bs" + util.GenerateRandomString(16)
 	reference.SetName(code)
 	g.edgenetClient.AppsV1alpha().EmailVerifications(reference.GetNamespace()).Create(context.TODO(), reference.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(reference.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), reference.DeepCopy())
 	t.Run("set expiry date", func(t *testing.T) {
 		// Handler will update expiration time
 		EVCopy, _ := g.edgenetClient.AppsV1alpha().EmailVerifications(reference.GetNamespace()).Get(context.TODO(), reference.GetName(), metav1.GetOptions{})
@@ -204,13 +205,13 @@ func TestCreate(t *testing.T) {
 	})
 	t.Run("timeout", func(t *testing.T) {
 		EVCopy, _ := g.edgenetClient.AppsV1alpha().EmailVerifications(reference.GetNamespace()).Get(context.TODO(), reference.GetName(), metav1.GetOptions{})
-		go g.handler.runVerificationTimeout(EVCopy)
 		EVCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(10 * time.Millisecond),
 		}
 		_, err := g.edgenetClient.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Update(context.TODO(), EVCopy.DeepCopy(), metav1.UpdateOptions{})
 		util.OK(t, err)
-		time.Sleep(100 * time.Millisecond)
+		util.Equals(t, EVCopy.Status.Expires.Time, getDeadlines(EVCopy)[expiry.Expire])
+		util.OK(t, g.handler.ObjectExpired(context.TODO(), EVCopy, expiry.Expire))
 		_, err = g.edgenetClient.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Get(context.TODO(), EVCopy.GetName(), metav1.GetOptions{})
 		util.Equals(t, true, errors.IsNotFound(err))
 	})
@@ -220,7 +221,7 @@ func TestCreate(t *testing.T) {
 		recreate.SetName(code)
 		recreate.Spec.Verified = true
 		g.edgenetClient.AppsV1alpha().EmailVerifications(recreate.GetNamespace()).Create(context.TODO(), recreate.DeepCopy(), metav1.CreateOptions{})
-		g.handler.ObjectCreated(recreate.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), recreate.DeepCopy())
 		// Handler will delete EV if it is verified
 		_, err := g.edgenetClient.AppsV1alpha().EmailVerifications(recreate.GetNamespace()).Get(context.TODO(), recreate.GetName(), metav1.GetOptions{})
 		util.Equals(t, true, errors.IsNotFound(err))
@@ -256,12 +257,12 @@ func TestUpdate(t *testing.T) {
 					defer g.edgenetClient.AppsV1alpha().UserRegistrationRequests(g.URRObj.GetNamespace()).Delete(context.TODO(), g.URRObj.GetName(), metav1.DeleteOptions{})
 				}
 				g.edgenetClient.AppsV1alpha().EmailVerifications(verify.GetNamespace()).Create(context.TODO(), verify.DeepCopy(), metav1.CreateOptions{})
-				g.handler.ObjectCreated(verify.DeepCopy())
+				g.handler.ObjectCreated(context.TODO(), verify.DeepCopy())
 				EVObj, err := g.edgenetClient.AppsV1alpha().EmailVerifications(verify.GetNamespace()).Get(context.TODO(), verify.GetName(), metav1.GetOptions{})
 				util.OK(t, err)
 				EVObj.Spec.Verified = true
 				var field fields
-				g.handler.ObjectUpdated(EVObj, field)
+				g.handler.ObjectUpdated(context.TODO(), EVObj, field)
 				// Handler will delete EV if it is verified
 				_, err = g.edgenetClient.AppsV1alpha().EmailVerifications(EVObj.GetNamespace()).Get(context.TODO(), EVObj.GetName(), metav1.GetOptions{})
 				util.Equals(t, true, errors.IsNotFound(err))
@@ -297,7 +298,7 @@ func TestUpdate(t *testing.T) {
 					defer g.edgenetClient.AppsV1alpha().UserRegistrationRequests(g.URRObj.GetNamespace()).Delete(context.TODO(), g.URRObj.GetName(), metav1.DeleteOptions{})
 				}
 				g.edgenetClient.AppsV1alpha().EmailVerifications(dub.GetNamespace()).Create(context.TODO(), dub.DeepCopy(), metav1.CreateOptions{})
-				g.handler.ObjectCreated(g.EVObj.DeepCopy())
+				g.handler.ObjectCreated(context.TODO(), g.EVObj.DeepCopy())
 				EVObj, err := g.edgenetClient.AppsV1alpha().EmailVerifications(dub.GetNamespace()).Get(context.TODO(), dub.GetName(), metav1.GetOptions{})
 				util.OK(t, err)
 
@@ -309,7 +310,7 @@ func TestUpdate(t *testing.T) {
 					EVObj.Spec.Kind = tc.cheat[1]
 					field.kind = true
 				}
-				g.handler.ObjectUpdated(EVObj, field)
+				g.handler.ObjectUpdated(context.TODO(), EVObj, field)
 				// Handler deletes EV as it is no longer valid
 				_, err = g.edgenetClient.AppsV1alpha().EmailVerifications(EVObj.GetNamespace()).Get(context.TODO(), EVObj.GetName(), metav1.GetOptions{})
 				util.Equals(t, tc.expected, errors.IsNotFound(err))
@@ -334,7 +335,7 @@ func TestCreateEmailVerification(t *testing.T) {
 	}
 	for k, tc := range cases {
 		t.Run(k, func(t *testing.T) {
-			status := g.handler.Create(tc.input, []metav1.OwnerReference{})
+			status := g.handler.Create(context.TODO(), tc.input, []metav1.OwnerReference{})
 			util.Equals(t, tc.expected, status)
 		})
 	}
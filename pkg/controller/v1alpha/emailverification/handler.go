@@ -23,27 +23,35 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/mailer"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj, updated interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj, updated interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
+	ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error
 }
 
 // Handler implementation
 type Handler struct {
 	clientset        kubernetes.Interface
 	edgenetClientset versioned.Interface
+	recorder         record.EventRecorder
 }
 
 // Init handles any handler initialization
@@ -51,22 +59,26 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 	log.Info("EVHandler.Init")
 	t.clientset = kubernetes
 	t.edgenetClientset = edgenet
+	t.recorder = ctlruntime.NewEventRecorder(kubernetes, "emailverification-controller")
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("EVHandler.ObjectCreated")
 	// Create a copy of the email verification object to make changes on it
 	EVCopy := obj.(*apps_v1alpha.EmailVerification).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	EVOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), EVCopy.GetNamespace(), metav1.GetOptions{})
+	EVOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, EVCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
 	// If the object's kind is AuthorityRequest, `registration` namespace hosts the email verification object.
 	// Otherwise, the object belongs to the namespace that the authority created.
 	var authorityEnabled bool
 	if EVOwnerNamespace.GetName() == "registration" {
 		authorityEnabled = true
 	} else {
-		EVOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), EVOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+		EVOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, EVOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 		authorityEnabled = EVOwnerAuthority.Spec.Enabled
 	}
 	// Check if the authority is active
@@ -74,71 +86,80 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 		// If the service restarts, it creates all objects again
 		// Because of that, this section covers a variety of possibilities
 		if EVCopy.Spec.Verified {
-			t.objectConfiguration(EVCopy, EVOwnerNamespace.Labels["authority-name"])
+			t.objectConfiguration(ctx, EVCopy, EVOwnerNamespace.Labels["authority-name"])
 		} else if !EVCopy.Spec.Verified && EVCopy.Status.Expires == nil {
-			// Run timeout goroutine
-			go t.runVerificationTimeout(EVCopy)
-			defer t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).UpdateStatus(context.TODO(), EVCopy, metav1.UpdateOptions{})
+			defer t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).UpdateStatus(ctx, EVCopy, metav1.UpdateOptions{})
 			// Set the email verification timeout which is 24 hours
 			EVCopy.Status.Expires = &metav1.Time{
 				Time: time.Now().Add(24 * time.Hour),
 			}
+			EVCopy.Status.SetReady(EVCopy.GetGeneration(), false, apps_v1alpha.ReasonAwaitingVerification, "Waiting for the email address to be verified")
+			t.recorder.Event(EVCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonAwaitingVerification, "Waiting for the email address to be verified")
 		} else if !EVCopy.Spec.Verified && EVCopy.Status.Expires != nil {
 			// Check if the email verification expired
 			if EVCopy.Status.Expires.Time.Sub(time.Now()) >= 0 {
-				go t.runVerificationTimeout(EVCopy)
 			} else {
-				t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(context.TODO(), EVCopy.GetName(), metav1.DeleteOptions{})
+				t.conclude(ctx, EVCopy, false, apps_v1alpha.ReasonExpired, "The email verification expired")
+				t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(ctx, EVCopy.GetName(), metav1.DeleteOptions{})
 			}
 		}
 	} else {
-		t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(context.TODO(), EVCopy.GetName(), metav1.DeleteOptions{})
+		t.conclude(ctx, EVCopy, false, apps_v1alpha.ReasonAuthorityDisabled, "The authority is disabled, the email verification is deleted")
+		t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(ctx, EVCopy.GetName(), metav1.DeleteOptions{})
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj, updated interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj, updated interface{}) error {
 	log.Info("EVHandler.ObjectUpdated")
 	// Create a copy of the email verification object to make changes on it
 	EVCopy := obj.(*apps_v1alpha.EmailVerification).DeepCopy()
-	EVOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), EVCopy.GetNamespace(), metav1.GetOptions{})
+	EVOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, EVCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
 	// Security check to prevent any kind of manipulation on the email verification
 	fieldUpdated := updated.(fields)
 	if fieldUpdated.kind || fieldUpdated.identifier {
-		t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(context.TODO(), EVCopy.GetName(), metav1.DeleteOptions{})
+		t.conclude(ctx, EVCopy, false, apps_v1alpha.ReasonTampered, "The kind or the identifier of the email verification changed, it is deleted")
+		t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(ctx, EVCopy.GetName(), metav1.DeleteOptions{})
 		if strings.ToLower(EVCopy.Spec.Kind) == "authority" {
-			t.sendEmail("authority-email-verification-dubious", EVCopy.Spec.Identifier, EVCopy.GetNamespace(), "", "", "", "")
+			t.sendEmail(ctx, "authority-email-verification-dubious", EVCopy.Spec.Identifier, EVCopy.GetNamespace(), "", "", "", "")
 		} else if strings.ToLower(EVCopy.Spec.Kind) == "user" || strings.ToLower(EVCopy.Spec.Kind) == "email" {
-			t.sendEmail("user-email-verification-dubious", EVOwnerNamespace.Labels["authority-name"], EVCopy.GetNamespace(), EVCopy.Spec.Identifier, "", "", "")
+			t.sendEmail(ctx, "user-email-verification-dubious", EVOwnerNamespace.Labels["authority-name"], EVCopy.GetNamespace(), EVCopy.Spec.Identifier, "", "", "")
 		}
-		return
+		return nil
 	}
 	var authorityEnabled bool
 	if EVOwnerNamespace.GetName() == "registration" {
 		authorityEnabled = true
 	} else {
-		EVOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), EVOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+		EVOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, EVOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 		authorityEnabled = EVOwnerAuthority.Spec.Enabled
 	}
 	// Check whether the authority enabled
 	if authorityEnabled {
 		// Check whether the email verification is done
 		if EVCopy.Spec.Verified {
-			t.objectConfiguration(EVCopy, EVOwnerNamespace.Labels["authority-name"])
+			t.objectConfiguration(ctx, EVCopy, EVOwnerNamespace.Labels["authority-name"])
 		}
 	} else {
-		t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(context.TODO(), EVCopy.GetName(), metav1.DeleteOptions{})
+		t.conclude(ctx, EVCopy, false, apps_v1alpha.ReasonAuthorityDisabled, "The authority is disabled, the email verification is deleted")
+		t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(ctx, EVCopy.GetName(), metav1.DeleteOptions{})
 	}
+	return nil
 }
 
 // ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("EVHandler.ObjectDeleted")
 	// Mail notification, TBD
+	return nil
 }
 
 // Create to provide one-time code for verification
-func (t *Handler) Create(obj interface{}, ownerReferences []metav1.OwnerReference) bool {
+func (t *Handler) Create(ctx context.Context, obj interface{}, ownerReferences []metav1.OwnerReference) bool {
 	// The section below is a part of the method which provides email verification
 	// Email verification code is a security point for email verification. The user
 	// registration object creates an email verification object with a name which is
@@ -153,55 +174,62 @@ func (t *Handler) Create(obj interface{}, ownerReferences []metav1.OwnerReferenc
 		emailVerification.SetName(code)
 		emailVerification.Spec.Kind = "Authority"
 		emailVerification.Spec.Identifier = authorityRequestCopy.GetName()
-		_, err := t.edgenetClientset.AppsV1alpha().EmailVerifications("registration").Create(context.TODO(), emailVerification.DeepCopy(), metav1.CreateOptions{})
+		_, err := t.edgenetClientset.AppsV1alpha().EmailVerifications("registration").Create(ctx, emailVerification.DeepCopy(), metav1.CreateOptions{})
 		if err == nil {
 			created = true
-			t.sendEmail("authority-email-verification", authorityRequestCopy.GetName(), "", authorityRequestCopy.Spec.Contact.Username,
+			t.sendEmail(ctx, "authority-email-verification", authorityRequestCopy.GetName(), "", authorityRequestCopy.Spec.Contact.Username,
 				fmt.Sprintf("%s %s", authorityRequestCopy.Spec.Contact.FirstName, authorityRequestCopy.Spec.Contact.LastName), authorityRequestCopy.Spec.Contact.Email, code)
 		} else {
-			t.sendEmail("authority-email-verification-malfunction", authorityRequestCopy.GetName(), "", authorityRequestCopy.Spec.Contact.Username,
+			t.sendEmail(ctx, "authority-email-verification-malfunction", authorityRequestCopy.GetName(), "", authorityRequestCopy.Spec.Contact.Username,
 				fmt.Sprintf("%s %s", authorityRequestCopy.Spec.Contact.FirstName, authorityRequestCopy.Spec.Contact.LastName), authorityRequestCopy.Spec.Contact.Email, "")
 		}
 	case *apps_v1alpha.User:
 		userCopy := obj.(*apps_v1alpha.User)
-		userOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), userCopy.GetNamespace(), metav1.GetOptions{})
+		userOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(ctx, userCopy.GetNamespace(), metav1.GetOptions{})
 		code := "bs" + util.GenerateRandomString(16)
 		emailVerification := apps_v1alpha.EmailVerification{ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownerReferences}}
 		emailVerification.SetName(code)
 		emailVerification.Spec.Kind = "Email"
 		emailVerification.Spec.Identifier = userCopy.GetName()
-		_, err := t.edgenetClientset.AppsV1alpha().EmailVerifications(userCopy.GetNamespace()).Create(context.TODO(), emailVerification.DeepCopy(), metav1.CreateOptions{})
+		_, err := t.edgenetClientset.AppsV1alpha().EmailVerifications(userCopy.GetNamespace()).Create(ctx, emailVerification.DeepCopy(), metav1.CreateOptions{})
 		if err == nil {
 			created = true
-			t.sendEmail("user-email-verification-update", userOwnerNamespace.Labels["authority-name"], userCopy.GetNamespace(), userCopy.GetName(),
+			t.sendEmail(ctx, "user-email-verification-update", userOwnerNamespace.Labels["authority-name"], userCopy.GetNamespace(), userCopy.GetName(),
 				fmt.Sprintf("%s %s", userCopy.Spec.FirstName, userCopy.Spec.LastName), userCopy.Spec.Email, code)
 		} else {
-			t.sendEmail("user-email-verification-update-malfunction", userOwnerNamespace.Labels["authority-name"], userCopy.GetNamespace(), userCopy.GetName(),
+			t.sendEmail(ctx, "user-email-verification-update-malfunction", userOwnerNamespace.Labels["authority-name"], userCopy.GetNamespace(), userCopy.GetName(),
 				fmt.Sprintf("%s %s", userCopy.Spec.FirstName, userCopy.Spec.LastName), userCopy.Spec.Email, "")
 		}
 	case *apps_v1alpha.UserRegistrationRequest:
 		URRCopy := obj.(*apps_v1alpha.UserRegistrationRequest)
-		URROwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), URRCopy.GetNamespace(), metav1.GetOptions{})
+		URROwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(ctx, URRCopy.GetNamespace(), metav1.GetOptions{})
 		code := "bs" + util.GenerateRandomString(16)
 		emailVerification := apps_v1alpha.EmailVerification{ObjectMeta: metav1.ObjectMeta{OwnerReferences: ownerReferences}}
 		emailVerification.SetName(code)
 		emailVerification.Spec.Kind = "User"
 		emailVerification.Spec.Identifier = URRCopy.GetName()
-		_, err := t.edgenetClientset.AppsV1alpha().EmailVerifications(URRCopy.GetNamespace()).Create(context.TODO(), emailVerification.DeepCopy(), metav1.CreateOptions{})
+		_, err := t.edgenetClientset.AppsV1alpha().EmailVerifications(URRCopy.GetNamespace()).Create(ctx, emailVerification.DeepCopy(), metav1.CreateOptions{})
 		if err == nil {
 			created = true
-			t.sendEmail("user-email-verification", URROwnerNamespace.Labels["authority-name"], URRCopy.GetNamespace(), URRCopy.GetName(),
+			t.sendEmail(ctx, "user-email-verification", URROwnerNamespace.Labels["authority-name"], URRCopy.GetNamespace(), URRCopy.GetName(),
 				fmt.Sprintf("%s %s", URRCopy.Spec.FirstName, URRCopy.Spec.LastName), URRCopy.Spec.Email, code)
 		} else {
-			t.sendEmail("user-email-verification-malfunction", URROwnerNamespace.Labels["authority-name"], URRCopy.GetNamespace(), URRCopy.GetName(),
+			t.sendEmail(ctx, "user-email-verification-malfunction", URROwnerNamespace.Labels["authority-name"], URRCopy.GetNamespace(), URRCopy.GetName(),
 				fmt.Sprintf("%s %s", URRCopy.Spec.FirstName, URRCopy.Spec.LastName), URRCopy.Spec.Email, "")
 		}
 	}
+	if object, ok := obj.(runtime.Object); ok {
+		if created {
+			t.recorder.Event(object, corev1.EventTypeNormal, apps_v1alpha.ReasonVerificationEmailSent, "The verification code is sent to the email address")
+		} else {
+			t.recorder.Event(object, corev1.EventTypeWarning, apps_v1alpha.ReasonVerificationEmailFailed, "The email verification cannot be created")
+		}
+	}
 	return created
 }
 
 // sendEmail to send notification to authority-admins and authorized users about email verification
-func (t *Handler) sendEmail(subject, authority, namespace, username, fullname, email, code string) {
+func (t *Handler) sendEmail(ctx context.Context, subject, authority, namespace, username, fullname, email, code string) {
 	// Set the HTML template variables
 	var contentData interface{}
 
@@ -219,7 +247,7 @@ func (t *Handler) sendEmail(subject, authority, namespace, username, fullname, e
 		contentData = verifyContent
 	} else if subject == "user-email-verified-alert" {
 		// Put the email addresses of the authority-admins and authorized users in the email to be sent list
-		userRaw, _ := t.edgenetClientset.AppsV1alpha().Users(namespace).List(context.TODO(), metav1.ListOptions{})
+		userRaw, _ := t.edgenetClientset.AppsV1alpha().Users(namespace).List(ctx, metav1.ListOptions{})
 		for _, userRow := range userRaw.Items {
 			if strings.ToLower(userRow.Status.Type) == "admin" {
 				collective.CommonData.Email = append(collective.CommonData.Email, userRow.Spec.Email)
@@ -235,113 +263,64 @@ func (t *Handler) sendEmail(subject, authority, namespace, username, fullname, e
 }
 
 // objectConfiguration to update the objects that are relevant the request and send email
-func (t *Handler) objectConfiguration(EVCopy *apps_v1alpha.EmailVerification, authorityName string) {
+func (t *Handler) objectConfiguration(ctx context.Context, EVCopy *apps_v1alpha.EmailVerification, authorityName string) {
 	// Update the status of request related to email verification
 	if strings.ToLower(EVCopy.Spec.Kind) == "authority" {
-		ARObj, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().Get(context.TODO(), EVCopy.Spec.Identifier, metav1.GetOptions{})
+		ARObj, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().Get(ctx, EVCopy.Spec.Identifier, metav1.GetOptions{})
 		ARObj.Status.EmailVerified = true
-		t.edgenetClientset.AppsV1alpha().AuthorityRequests().UpdateStatus(context.TODO(), ARObj, metav1.UpdateOptions{})
+		t.edgenetClientset.AppsV1alpha().AuthorityRequests().UpdateStatus(ctx, ARObj, metav1.UpdateOptions{})
 		// Send email to inform admins of the cluster
-		t.sendEmail("authority-email-verified-alert", EVCopy.Spec.Identifier, EVCopy.GetNamespace(), ARObj.Spec.Contact.Username,
+		t.sendEmail(ctx, "authority-email-verified-alert", EVCopy.Spec.Identifier, EVCopy.GetNamespace(), ARObj.Spec.Contact.Username,
 			fmt.Sprintf("%s %s", ARObj.Spec.Contact.FirstName, ARObj.Spec.Contact.LastName), "", "")
 	} else if strings.ToLower(EVCopy.Spec.Kind) == "user" {
-		URRObj, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(EVCopy.GetNamespace()).Get(context.TODO(), EVCopy.Spec.Identifier, metav1.GetOptions{})
+		URRObj, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(EVCopy.GetNamespace()).Get(ctx, EVCopy.Spec.Identifier, metav1.GetOptions{})
 		URRObj.Status.EmailVerified = true
-		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRObj.GetNamespace()).UpdateStatus(context.TODO(), URRObj, metav1.UpdateOptions{})
+		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRObj.GetNamespace()).UpdateStatus(ctx, URRObj, metav1.UpdateOptions{})
 		// Send email to inform authority-admins and authorized users
-		t.sendEmail("user-email-verified-alert", authorityName, EVCopy.GetNamespace(), EVCopy.Spec.Identifier,
+		t.sendEmail(ctx, "user-email-verified-alert", authorityName, EVCopy.GetNamespace(), EVCopy.Spec.Identifier,
 			fmt.Sprintf("%s %s", URRObj.Spec.FirstName, URRObj.Spec.LastName), "", "")
 	} else if strings.ToLower(EVCopy.Spec.Kind) == "email" {
-		userObj, _ := t.edgenetClientset.AppsV1alpha().Users(EVCopy.GetNamespace()).Get(context.TODO(), EVCopy.Spec.Identifier, metav1.GetOptions{})
+		userObj, _ := t.edgenetClientset.AppsV1alpha().Users(EVCopy.GetNamespace()).Get(ctx, EVCopy.Spec.Identifier, metav1.GetOptions{})
 		userObj.Spec.Active = true
-		t.edgenetClientset.AppsV1alpha().Users(userObj.GetNamespace()).UpdateStatus(context.TODO(), userObj, metav1.UpdateOptions{})
+		t.edgenetClientset.AppsV1alpha().Users(userObj.GetNamespace()).UpdateStatus(ctx, userObj, metav1.UpdateOptions{})
 		if userObj.Status.Type == "admin" {
-			authorityObj, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), authorityName, metav1.GetOptions{})
+			authorityObj, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, authorityName, metav1.GetOptions{})
 			if authorityObj.Spec.Contact.Username == userObj.GetName() {
 				authorityObj.Spec.Contact.Email = userObj.Spec.Email
-				t.edgenetClientset.AppsV1alpha().Authorities().Update(context.TODO(), authorityObj, metav1.UpdateOptions{})
+				t.edgenetClientset.AppsV1alpha().Authorities().Update(ctx, authorityObj, metav1.UpdateOptions{})
 			}
 		}
 		// Send email to inform user
-		t.sendEmail("user-email-verified-notification", authorityName, EVCopy.GetNamespace(), EVCopy.Spec.Identifier,
+		t.sendEmail(ctx, "user-email-verified-notification", authorityName, EVCopy.GetNamespace(), EVCopy.Spec.Identifier,
 			fmt.Sprintf("%s %s", userObj.Spec.FirstName, userObj.Spec.LastName), userObj.Spec.Email, "")
 	}
+	t.conclude(ctx, EVCopy, true, apps_v1alpha.ReasonVerified, fmt.Sprintf("The email address of %s is verified", EVCopy.Spec.Identifier))
 	// Delete the unique email verification object as it gets verified
-	t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(context.TODO(), EVCopy.GetName(), metav1.DeleteOptions{})
+	t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(ctx, EVCopy.GetName(), metav1.DeleteOptions{})
 }
 
-// runVerificationTimeout puts a procedure in place to remove requests by verification or timeout
-func (t *Handler) runVerificationTimeout(EVCopy *apps_v1alpha.EmailVerification) {
-	timeoutRenewed := make(chan bool, 1)
-	terminated := make(chan bool, 1)
-	var timeout <-chan time.Time
-	if EVCopy.Status.Expires != nil {
-		timeout = time.After(time.Until(EVCopy.Status.Expires.Time))
-	}
-	closeChannels := func() {
-		close(timeoutRenewed)
-		close(terminated)
+// conclude records the outcome of the email verification, which is deleted right after, as its ready condition
+// and as an event. The condition stays in place if the deletion fails.
+func (t *Handler) conclude(ctx context.Context, EVCopy *apps_v1alpha.EmailVerification, verified bool, reason, message string) {
+	eventType := corev1.EventTypeWarning
+	if verified {
+		eventType = corev1.EventTypeNormal
 	}
-
-	// Watch the events of email verification object
-	watchEV, err := t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Watch(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", EVCopy.GetName())})
-	if err == nil {
-		go func() {
-			// Get events from watch interface
-			for EVEvent := range watchEV.ResultChan() {
-				// Get updated email verification object
-				updatedEV, status := EVEvent.Object.(*apps_v1alpha.EmailVerification)
-				if EVCopy.GetUID() == updatedEV.GetUID() {
-					if status {
-						if EVEvent.Type == "DELETED" {
-							terminated <- true
-							continue
-						}
-
-						if updatedEV.Status.Expires != nil {
-							// Check whether expiration date updated - TBD
-							/*if EVCopy.Status.Expires != nil && timeout != nil {
-								if EVCopy.Status.Expires.Time == updatedEV.Status.Expires.Time {
-									EVCopy = updatedEV
-									continue
-								}
-							}*/
-
-							if updatedEV.Status.Expires.Time.Sub(time.Now()) >= 0 {
-								timeout = time.After(time.Until(updatedEV.Status.Expires.Time))
-								timeoutRenewed <- true
-							} else {
-								terminated <- true
-							}
-						}
-						EVCopy = updatedEV
-					}
-				}
-			}
-		}()
-	} else {
-		// In case of any malfunction of watching emailverification resources,
-		// there is a timeout at 3 hours
-		timeout = time.After(3 * time.Hour)
+	t.recorder.Event(EVCopy, eventType, reason, message)
+	EVCopy.Status.SetReady(EVCopy.GetGeneration(), verified, reason, message)
+	if _, err := t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).UpdateStatus(ctx, EVCopy, metav1.UpdateOptions{}); err != nil && !errors.IsNotFound(err) {
+		log.Println(err.Error())
 	}
+}
 
-	// Infinite loop
-timeoutLoop:
-	for {
-		// Wait on multiple channel operations
-	timeoutOptions:
-		select {
-		case <-timeoutRenewed:
-			break timeoutOptions
-		case <-timeout:
-			watchEV.Stop()
-			t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(context.TODO(), EVCopy.GetName(), metav1.DeleteOptions{})
-			closeChannels()
-			break timeoutLoop
-		case <-terminated:
-			watchEV.Stop()
-			closeChannels()
-			break timeoutLoop
-		}
+// ObjectExpired is called by the scheduler when the email address is not verified in time
+func (t *Handler) ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error {
+	log.Info("EVHandler.ObjectExpired")
+	EVCopy := obj.(*apps_v1alpha.EmailVerification).DeepCopy()
+	t.conclude(ctx, EVCopy, false, apps_v1alpha.ReasonExpired, "The email verification expired")
+	err := t.edgenetClientset.AppsV1alpha().EmailVerifications(EVCopy.GetNamespace()).Delete(ctx, EVCopy.GetName(), metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
 	}
+	return err
 }
@@ -19,44 +19,20 @@ package nodecontribution
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
-	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
-	appsinformer_v1 "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/apps/v1alpha"
 	"github.com/EdgeNet-project/edgenet/pkg/node"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 )
 
-// The main structure of controller
-type controller struct {
-	logger       *log.Entry
-	queue        workqueue.RateLimitingInterface
-	informer     cache.SharedIndexInformer
-	nodeInformer cache.SharedIndexInformer
-	handler      HandlerInterface
-}
-
-// The main structure of informerevent
-type informerevent struct {
-	key      string
-	function string
-}
-
 // Constant variables for events
 const inqueue = "In Queue"
 const inprogress = "In Progress"
@@ -64,10 +40,8 @@ const recover = "Recovering"
 const failure = "Failure"
 const incomplete = "Halting"
 const success = "Successful"
+const removed = "Removed"
 const noSchedule = "NoSchedule"
-const create = "create"
-const update = "update"
-const delete = "delete"
 const trueStr = "True"
 const falseStr = "False"
 const unknownStr = "Unknown"
@@ -81,77 +55,22 @@ var statusDict = map[string]string{
 
 // Start function is entry point of the controller
 func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
-	var err error
-	clientset := kubernetes
-	edgenetClientset := edgenet
+	manager := ctlruntime.NewManager(kubernetes, edgenet)
+	Register(manager)
+	// Run the controller loop until a signal to terminate elegantly arrives
+	manager.Run(ctlruntime.SetupSignalHandler())
+}
 
+// Register adds the node contribution controller to the manager, which can host other controllers alongside
+func Register(manager *ctlruntime.Manager) {
+	clientset := manager.Clientset
+	edgenetClientset := manager.EdgeNetClientset
 	NCHandler := &Handler{}
-	// Create the nodecontribution informer which was generated by the code generator to list and watch nodecontribution resources
-	informer := appsinformer_v1.NewNodeContributionInformer(
-		edgenetClientset,
-		metav1.NamespaceAll,
-		0,
-		cache.Indexers{},
-	)
-	// Create a work queue which contains a key of the resource to be handled by the handler
-	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	var event informerevent
-	// Event handlers deal with events of resources. Here, there are three types of events as Add, Update, and Delete
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			if queue.Len() == 0 {
-				// Put the resource object into a key
-				event.key, err = cache.MetaNamespaceKeyFunc(obj)
-				event.function = create
-				log.Infof("Add nodecontribution: %s", event.key)
-				if err == nil {
-					// Add the key to the queue
-					queue.Add(event)
-				}
-			} else {
-				ncCopy := obj.(*apps_v1alpha.NodeContribution).DeepCopy()
-				ncCopy.Status.State = inqueue
-				edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			if reflect.DeepEqual(oldObj.(*apps_v1alpha.NodeContribution).Status, newObj.(*apps_v1alpha.NodeContribution).Status) ||
-				newObj.(*apps_v1alpha.NodeContribution).Status.State == inqueue {
-				event.key, err = cache.MetaNamespaceKeyFunc(newObj)
-				event.function = update
-				log.Infof("Update nodecontribution: %s", event.key)
-				if err == nil {
-					queue.Add(event)
-				}
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			// DeletionHandlingMetaNamsespaceKeyFunc helps to check the existence of the object while it is still contained in the index.
-			// Put the resource object into a key
-			event.key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			event.function = delete
-			log.Infof("Delete nodecontribution: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-	})
+	NCHandler.Init(clientset, edgenetClientset)
+	informer := manager.EdgeNetInformerFactory.Apps().V1alpha().NodeContributions().Informer()
+	controller := newController(informer, NCHandler, edgenetClientset)
 	// The selectivedeployment resources are reconfigured according to node events in this section
-	nodeInformer := cache.NewSharedIndexInformer(
-		&cache.ListWatch{
-			// The main purpose of listing is to attach geo labels to whole nodes at the beginning
-			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
-				return clientset.CoreV1().Nodes().List(context.TODO(), options)
-			},
-			// This function watches all changes/updates of nodes
-			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				return clientset.CoreV1().Nodes().Watch(context.TODO(), options)
-			},
-		},
-		&corev1.Node{},
-		0,
-		cache.Indexers{},
-	)
+	nodeInformer := manager.InformerFactory.Core().V1().Nodes().Informer()
 	nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			nodeObj := obj.(*corev1.Node)
@@ -162,13 +81,13 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 			}
 			for _, owner := range nodeObj.GetOwnerReferences() {
 				if owner.Kind == "Namespace" {
-					NCRaw, err := edgenetClientset.AppsV1alpha().NodeContributions(owner.Name).List(context.TODO(), metav1.ListOptions{})
+					NCRaw, err := edgenetClientset.AppsV1alpha().NodeContributions(owner.Name).List(controller.Context(), metav1.ListOptions{})
 					if err == nil {
 						if len(NCRaw.Items) == 0 {
 							log.Println("No Node Contribution Attached The Node")
-							clientset.CoreV1().Nodes().Delete(context.TODO(), nodeObj.GetName(), metav1.DeleteOptions{})
+							clientset.CoreV1().Nodes().Delete(controller.Context(), nodeObj.GetName(), metav1.DeleteOptions{})
 						} else {
-							NCOwnerNamespace, _ := clientset.CoreV1().Namespaces().Get(context.TODO(), owner.Name, metav1.GetOptions{})
+							NCOwnerNamespace, _ := clientset.CoreV1().Namespaces().Get(controller.Context(), owner.Name, metav1.GetOptions{})
 							exist := false
 							for _, NCRow := range NCRaw.Items {
 								nodeName := fmt.Sprintf("%s.%s.edge-net.io", NCOwnerNamespace.Labels["authority-name"], NCRow.GetName())
@@ -177,7 +96,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 								}
 							}
 							if !exist {
-								clientset.CoreV1().Nodes().Delete(context.TODO(), nodeObj.GetName(), metav1.DeleteOptions{})
+								clientset.CoreV1().Nodes().Delete(controller.Context(), nodeObj.GetName(), metav1.DeleteOptions{})
 							}
 						}
 					}
@@ -192,13 +111,13 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 			for _, owner := range newObj.GetOwnerReferences() {
 				for _, owner := range newObj.GetOwnerReferences() {
 					if owner.Kind == "Namespace" {
-						NCRaw, err := edgenetClientset.AppsV1alpha().NodeContributions(owner.Name).List(context.TODO(), metav1.ListOptions{})
+						NCRaw, err := edgenetClientset.AppsV1alpha().NodeContributions(owner.Name).List(controller.Context(), metav1.ListOptions{})
 						if err == nil {
 							if len(NCRaw.Items) == 0 {
 								log.Println("No Node Contribution Attached The Node")
-								clientset.CoreV1().Nodes().Delete(context.TODO(), newObj.GetName(), metav1.DeleteOptions{})
+								clientset.CoreV1().Nodes().Delete(controller.Context(), newObj.GetName(), metav1.DeleteOptions{})
 							} else {
-								NCOwnerNamespace, _ := clientset.CoreV1().Namespaces().Get(context.TODO(), owner.Name, metav1.GetOptions{})
+								NCOwnerNamespace, _ := clientset.CoreV1().Namespaces().Get(controller.Context(), owner.Name, metav1.GetOptions{})
 								for _, NCRow := range NCRaw.Items {
 									nodeName := fmt.Sprintf("%s.%s.edge-net.io", NCOwnerNamespace.Labels["authority-name"], NCRow.GetName())
 									if NCRow.GetName() == nodeName {
@@ -209,24 +128,27 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 												NCRow.Status.State = success
 												if NCRow.Status.State == recover {
 													NCRow.Status.Message = append(NCRow.Status.Message, "Node recovery successful")
+													NCRow.Status.SetReady(NCRow.GetGeneration(), true, apps_v1alpha.ReasonNodeRunning, "Node recovery successful")
 												} else {
 													NCRow.Status.Message = append(NCRow.Status.Message, "Node is ready")
+													NCRow.Status.SetReady(NCRow.GetGeneration(), true, apps_v1alpha.ReasonNodeRunning, "Node is ready")
 												}
-												edgenetClientset.AppsV1alpha().NodeContributions(NCRow.GetNamespace()).UpdateStatus(context.TODO(), NCRow, metav1.UpdateOptions{})
+												edgenetClientset.AppsV1alpha().NodeContributions(NCRow.GetNamespace()).UpdateStatus(controller.Context(), NCRow, metav1.UpdateOptions{})
 											}
 										} else if (oldReady == trueStr && newReady == falseStr) ||
 											(oldReady == trueStr && newReady == unknownStr) {
 											if NCRow.Status.State != failure {
 												NCRow.Status.State = failure
 												NCRow.Status.Message = append(NCRow.Status.Message, "Node is not ready")
-												edgenetClientset.AppsV1alpha().NodeContributions(NCRow.GetNamespace()).UpdateStatus(context.TODO(), NCRow, metav1.UpdateOptions{})
+												NCRow.Status.SetReady(NCRow.GetGeneration(), false, apps_v1alpha.ReasonNodeNotReady, "Node is not ready")
+												edgenetClientset.AppsV1alpha().NodeContributions(NCRow.GetNamespace()).UpdateStatus(controller.Context(), NCRow, metav1.UpdateOptions{})
 											}
 										}
 
 										if (oldObj.Spec.Unschedulable == true && newObj.Spec.Unschedulable == false) ||
 											(oldObj.Spec.Unschedulable == false && newObj.Spec.Unschedulable == true) {
 											if NCRow.Spec.Enabled == newObj.Spec.Unschedulable {
-												node.SetNodeScheduling(newObj.GetName(), !NCRow.Spec.Enabled)
+												node.SetNodeScheduling(controller.Context(), newObj.GetName(), !NCRow.Spec.Enabled)
 											}
 										}
 									}
@@ -238,7 +160,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 
 				if owner.Kind == "NodeContribution" {
 					NCRaw, err := edgenetClientset.AppsV1alpha().NodeContributions("").
-						List(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", owner.Name)})
+						List(controller.Context(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", owner.Name)})
 					if err == nil {
 						for _, NCRow := range NCRaw.Items {
 							if NCRow.GetUID() == owner.UID {
@@ -253,100 +175,38 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 			log.Println("Node Deleted Event")
 		},
 	})
-	controller := controller{
-		logger:       log.NewEntry(log.New()),
-		informer:     informer,
-		nodeInformer: nodeInformer,
-		queue:        queue,
-		handler:      NCHandler,
-	}
-
-	// A channel to terminate elegantly
-	stopCh := make(chan struct{})
-	defer close(stopCh)
-	// Run the controller loop as a background task to start processing resources
-	go controller.run(stopCh, clientset, edgenetClientset)
-	// A channel to observe OS signals for smooth shut down
-	sigTerm := make(chan os.Signal, 1)
-	signal.Notify(sigTerm, syscall.SIGTERM)
-	signal.Notify(sigTerm, syscall.SIGINT)
-	<-sigTerm
+	controller.AddInformer(nodeInformer)
+	manager.Add(controller)
 }
 
-// Run starts the controller loop
-func (c *controller) run(stopCh <-chan struct{}, clientset kubernetes.Interface, edgenetClientset versioned.Interface) {
-	// A Go panic which includes logging and terminating
-	defer utilruntime.HandleCrash()
-	// Shutdown after all goroutines have done
-	defer c.queue.ShutDown()
-	c.logger.Info("run: initiating")
-	c.handler.Init(clientset, edgenetClientset)
-	// Run the informer to list and watch resources
-	go c.informer.Run(stopCh)
-	go c.nodeInformer.Run(stopCh)
-
-	// Synchronization to settle resources one
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced, c.nodeInformer.HasSynced) {
-		utilruntime.HandleError(fmt.Errorf("Error syncing cache"))
-		return
-	}
-	c.logger.Info("run: cache sync complete")
-	// Operate the runWorker
-	go wait.Until(c.runWorker, time.Second, stopCh)
-
-	<-stopCh
-}
-
-// To process new objects added to the queue
-func (c *controller) runWorker() {
-	log.Info("runWorker: starting")
-	// Run processNextItem for all the changes
-	for c.processNextItem() {
-		log.Info("runWorker: processing next item")
-	}
-
-	log.Info("runWorker: completed")
-}
-
-// This function deals with the queue and sends each item in it to the specified handler to be processed.
-func (c *controller) processNextItem() bool {
-	log.Info("processNextItem: start")
-	// Fetch the next item of the queue
-	event, quit := c.queue.Get()
-	if quit {
-		return false
-	}
-	defer c.queue.Done(event)
-	// Get the key string
-	keyRaw := event.(informerevent).key
-	// Use the string key to get the object from the indexer
-	item, exists, err := c.informer.GetIndexer().GetByKey(keyRaw)
-	if err != nil {
-		if c.queue.NumRequeues(event.(informerevent).key) < 5 {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, retrying", event.(informerevent).key, err)
-			c.queue.AddRateLimited(event.(informerevent).key)
-		} else {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, no more retries", event.(informerevent).key, err)
-			c.queue.Forget(event.(informerevent).key)
-			utilruntime.HandleError(err)
-		}
-	}
-
-	if !exists {
-		if event.(informerevent).function == delete {
-			c.logger.Infof("Controller.processNextItem: object deleted detected: %s", keyRaw)
-			c.handler.ObjectDeleted(item)
-		}
-	} else {
-		if event.(informerevent).function == create {
-			c.logger.Infof("Controller.processNextItem: object created detected: %s", keyRaw)
-			c.handler.ObjectCreated(item)
-		} else if event.(informerevent).function == update {
-			c.logger.Infof("Controller.processNextItem: object updated detected: %s", keyRaw)
-			c.handler.ObjectUpdated(item)
-		}
+// newController plugs the node contribution handler into the controller runtime
+func newController(informer cache.SharedIndexInformer, handler HandlerInterface, edgenetClientset versioned.Interface) *ctlruntime.Controller {
+	reconciler := ctlruntime.HandlerFuncs{
+		Indexer:    informer.GetIndexer(),
+		CreateFunc: handler.ObjectCreated,
+		UpdateFunc: func(ctx context.Context, obj, change interface{}) error {
+			return handler.ObjectUpdated(ctx, obj)
+		},
+		FinalizeFunc: handler.ObjectDeleted,
 	}
-	c.queue.Forget(event.(informerevent).key)
-
-	return true
+	var controller *ctlruntime.Controller
+	controller = ctlruntime.New(informer, reconciler, ctlruntime.Options{
+		Name: "nodecontribution",
+		// Node contributions are installed one by one, the others wait in the queue
+		AddFilter: func(obj interface{}) bool {
+			if controller.Len() == 0 {
+				return true
+			}
+			ncCopy := obj.(*apps_v1alpha.NodeContribution).DeepCopy()
+			ncCopy.Status.State = inqueue
+			ncCopy.Status.SetReady(ncCopy.GetGeneration(), false, apps_v1alpha.ReasonQueued, "Waiting for the installation of other nodes")
+			edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(controller.Context(), ncCopy, metav1.UpdateOptions{})
+			return false
+		},
+		UpdateFilter: func(oldObj, newObj interface{}) (interface{}, bool) {
+			return nil, reflect.DeepEqual(oldObj.(*apps_v1alpha.NodeContribution).Status, newObj.(*apps_v1alpha.NodeContribution).Status) ||
+				newObj.(*apps_v1alpha.NodeContribution).Status.State == inqueue
+		},
+	})
+	return controller
 }
@@ -19,7 +19,9 @@ package nodecontribution
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -27,9 +29,12 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	apps_v1beta1 "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1beta1"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/authority"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/mailer"
+	"github.com/EdgeNet-project/edgenet/pkg/metrics"
 	ns "github.com/EdgeNet-project/edgenet/pkg/namespace"
 	"github.com/EdgeNet-project/edgenet/pkg/node"
 	"github.com/EdgeNet-project/edgenet/pkg/remoteip"
@@ -37,16 +42,18 @@ import (
 	namecheap "github.com/billputer/go-namecheap"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface) error
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
 }
 
 // Handler implementation
@@ -54,6 +61,20 @@ type Handler struct {
 	clientset        kubernetes.Interface
 	edgenetClientset versioned.Interface
 	publicKey        ssh.Signer
+	recorder         record.EventRecorder
+}
+
+// sshProcedures counts the outcomes of the setup and recovery procedures run over SSH
+var sshProcedures = metrics.NewCounterVec("edgenet_nodecontribution_ssh_procedures_total",
+	"Total number of SSH procedures by procedure and result", "procedure", "result")
+
+// recordProcedure counts the procedure according to the state the node contribution ends up in
+func recordProcedure(procedure string, ncCopy *apps_v1alpha.NodeContribution) {
+	result := "failure"
+	if ncCopy.Status.State == success {
+		result = "success"
+	}
+	sshProcedures.WithLabelValues(procedure, result).Inc()
 }
 
 // Init handles any handler initialization
@@ -61,6 +82,7 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 	log.Info("NCHandler.Init")
 	t.clientset = kubernetes
 	t.edgenetClientset = edgenet
+	t.recorder = ctlruntime.NewEventRecorder(kubernetes, "nodecontribution-controller")
 
 	// Get the SSH Public Key of the headnode
 	key, err := ioutil.ReadFile("../../.ssh/id_rsa")
@@ -78,20 +100,70 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 	return err
 }
 
+// setReady sets the ready condition and records the step as an event, a warning unless the node is running or on its way
+func (t *Handler) setReady(ncCopy *apps_v1alpha.NodeContribution, ready bool, reason, message string) {
+	ncCopy.Status.SetReady(ncCopy.GetGeneration(), ready, reason, message)
+	eventType := corev1.EventTypeWarning
+	if ready || reason == apps_v1alpha.ReasonInstalling || reason == apps_v1alpha.ReasonRecovering {
+		eventType = corev1.EventTypeNormal
+	}
+	t.recorder.Event(ncCopy, eventType, reason, message)
+}
+
+// declareLocation annotates the node with the location that the node contribution declares, which the node labeler
+// prefers to the GeoIP one. A declaration that GeoIP contradicts is rejected, and so is left to GeoIP.
+func (t *Handler) declareLocation(ctx context.Context, ncCopy *apps_v1alpha.NodeContribution, nodeName string) {
+	var geolocation *node.Geolocation
+	if ncCopy.Spec.Location != nil {
+		var err error
+		geolocation, err = node.DeclareLocation(ctx, node.DefaultGeolocationProvider(), ncCopy.Spec.Location, net.ParseIP(ncCopy.Spec.Host))
+		if err != nil {
+			message := fmt.Sprintf("Declared location rejected: %s", err)
+			ncCopy.Status.Message = append(ncCopy.Status.Message, message)
+			t.recorder.Event(ncCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonLocationRejected, message)
+		}
+	}
+	if err := node.SetLocationAnnotation(ctx, nodeName, geolocation); err != nil {
+		log.Println(err)
+	}
+}
+
+// password returns the SSH password of the node contribution, which is held by a secret
+// if the node contribution has been created through v1beta1
+func (t *Handler) password(ctx context.Context, ncCopy *apps_v1alpha.NodeContribution) string {
+	secretRef := apps_v1beta1.PasswordSecretRef(ncCopy)
+	if secretRef == nil {
+		return ncCopy.Spec.Password
+	}
+	secret, err := t.clientset.CoreV1().Secrets(ncCopy.GetNamespace()).Get(ctx, secretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("Password secret of %s not found: %s", ncCopy.GetName(), err)
+		return ""
+	}
+	return string(secret.Data[secretRef.Key])
+}
+
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("NCHandler.ObjectCreated")
 	// Create a copy of the node contribution object to make changes on it
 	ncCopy := obj.(*apps_v1alpha.NodeContribution).DeepCopy()
+	// The finalizer holds the deletion of the node contribution until ObjectDeleted removes the node
+	if ctlruntime.AddFinalizer(ncCopy) {
+		ncUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).Update(ctx, ncCopy, metav1.UpdateOptions{})
+		if err == nil {
+			ncCopy = ncUpdated
+		}
+	}
 	ncCopy.Status.Message = []string{}
 	// Find the authority from the namespace in which the object is
-	NCOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), ncCopy.GetNamespace(), metav1.GetOptions{})
+	NCOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(ctx, ncCopy.GetNamespace(), metav1.GetOptions{})
 	nodeName := fmt.Sprintf("%s.%s.edge-net.io", NCOwnerNamespace.Labels["authority-name"], ncCopy.GetName())
 	// Don't use the authority name if the node belongs to EdgeNet
 	if NCOwnerNamespace.GetName() == "authority-edgenet" {
 		nodeName = fmt.Sprintf("%s.edge-net.io", ncCopy.GetName())
 	}
-	NCOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), NCOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	NCOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, NCOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	authorityEnabled := NCOwnerAuthority.Spec.Enabled
 	log.Println("AUTHORITY CHECK")
 	// Check if the authority is active
@@ -104,63 +176,77 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 		if recordType == "" {
 			ncCopy.Status.State = failure
 			ncCopy.Status.Message = append(ncCopy.Status.Message, statusDict["invalid-host"])
-			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
-			t.sendEmail(ncCopy)
-			return
+			t.setReady(ncCopy, false, apps_v1alpha.ReasonInvalidHost, statusDict["invalid-host"])
+			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
+			t.sendEmail(ctx, ncCopy)
+			return nil
 		}
 		// Set the client config according to the node contribution,
 		// with the maximum time of 15 seconds to establist the connection.
 		config := &ssh.ClientConfig{
 			User:            ncCopy.Spec.User,
-			Auth:            []ssh.AuthMethod{ssh.PublicKeys(t.publicKey), ssh.Password(ncCopy.Spec.Password)},
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(t.publicKey), ssh.Password(t.password(ctx, ncCopy))},
 			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 			Timeout:         15 * time.Second,
 		}
 		addr := fmt.Sprintf("%s:%d", ncCopy.Spec.Host, ncCopy.Spec.Port)
-		contributedNode, err := t.clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		contributedNode, err := t.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 		if err == nil {
 			// The node corresponding to the contributed node exists in the cluster
 			log.Println("NODE FOUND")
+			if err := node.SetLimitations(ctx, nodeName, ncCopy.Spec.Limitations); err != nil {
+				log.Println(err)
+			}
+			t.declareLocation(ctx, ncCopy, nodeName)
 			if node.GetConditionReadyStatus(contributedNode.DeepCopy()) != trueStr {
-				t.balanceMultiThreading(5)
-				go t.runRecoveryProcedure(addr, config, nodeName, ncCopy, contributedNode)
+				ctlruntime.Go(ctx, ncCopy, func(ctx context.Context) {
+					if t.balanceMultiThreading(ctx, 5) == nil {
+						t.runRecoveryProcedure(ctx, addr, config, nodeName, ncCopy, contributedNode)
+					}
+				})
 			} else {
 				ncCopy.Status.State = success
 				ncCopy.Status.Message = append(ncCopy.Status.Message, statusDict["node-ok"])
-				t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+				t.setReady(ncCopy, true, apps_v1alpha.ReasonNodeRunning, statusDict["node-ok"])
+				t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 			}
 		} else {
 			// There isn't any node corresponding to the node contribution
 			log.Println("NODE NOT FOUND")
-			t.balanceMultiThreading(5)
-			go t.runSetupProcedure(NCOwnerNamespace.Labels["authority-name"], addr, nodeName, recordType, config, ncCopy)
+			ctlruntime.Go(ctx, ncCopy, func(ctx context.Context) {
+				if t.balanceMultiThreading(ctx, 5) == nil {
+					t.runSetupProcedure(ctx, NCOwnerNamespace.Labels["authority-name"], addr, nodeName, recordType, config, ncCopy)
+				}
+			})
 		}
 	} else {
 		log.Println("AUTHORITY NOT ENABLED")
 		// Disable scheduling on the node if the authority is disabled
 		ncCopy.Spec.Enabled = false
-		ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).Update(context.TODO(), ncCopy, metav1.UpdateOptions{})
+		ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).Update(ctx, ncCopy, metav1.UpdateOptions{})
 		if err == nil {
 			ncCopy = ncCopyUpdated
 			ncCopy.Status.State = failure
 			ncCopy.Status.Message = append(ncCopy.Status.Message, statusDict["authority-disabled"])
-			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+			t.setReady(ncCopy, false, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"])
+			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 		}
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj interface{}) error {
 	log.Info("NCHandler.ObjectUpdated")
 	// Create a copy of the node contribution object to make changes on it
 	ncCopy := obj.(*apps_v1alpha.NodeContribution).DeepCopy()
 	ncCopy.Status.Message = []string{}
-	NCOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), ncCopy.GetNamespace(), metav1.GetOptions{})
+	NCOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(ctx, ncCopy.GetNamespace(), metav1.GetOptions{})
 	nodeName := fmt.Sprintf("%s.%s.edge-net.io", NCOwnerNamespace.Labels["authority-name"], ncCopy.GetName())
 	if NCOwnerNamespace.GetName() == "authority-edgenet" {
 		nodeName = fmt.Sprintf("%s.edge-net.io", ncCopy.GetName())
 	}
-	NCOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), NCOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	NCOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, NCOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	authorityEnabled := NCOwnerAuthority.Spec.Enabled
 	log.Println("AUTHORITY CHECK")
 	// Check if the authority is active
@@ -170,59 +256,96 @@ func (t *Handler) ObjectUpdated(obj interface{}) {
 		if recordType == "" {
 			ncCopy.Status.State = failure
 			ncCopy.Status.Message = append(ncCopy.Status.Message, statusDict["invalid-host"])
-			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
-			t.sendEmail(ncCopy)
-			return
+			t.setReady(ncCopy, false, apps_v1alpha.ReasonInvalidHost, statusDict["invalid-host"])
+			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
+			t.sendEmail(ctx, ncCopy)
+			return nil
 		}
 		config := &ssh.ClientConfig{
 			User:            ncCopy.Spec.User,
-			Auth:            []ssh.AuthMethod{ssh.PublicKeys(t.publicKey), ssh.Password(ncCopy.Spec.Password)},
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(t.publicKey), ssh.Password(t.password(ctx, ncCopy))},
 			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 			Timeout:         15 * time.Second,
 		}
 		addr := fmt.Sprintf("%s:%d", ncCopy.Spec.Host, ncCopy.Spec.Port)
-		contributedNode, err := t.clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		contributedNode, err := t.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 		if err == nil {
 			log.Println("NODE FOUND")
 			if contributedNode.Spec.Unschedulable != !ncCopy.Spec.Enabled {
-				node.SetNodeScheduling(nodeName, !ncCopy.Spec.Enabled)
+				node.SetNodeScheduling(ctx, nodeName, !ncCopy.Spec.Enabled)
+			}
+			if err := node.SetLimitations(ctx, nodeName, ncCopy.Spec.Limitations); err != nil {
+				log.Println(err)
 			}
+			t.declareLocation(ctx, ncCopy, nodeName)
 			if node.GetConditionReadyStatus(contributedNode.DeepCopy()) != trueStr {
-				t.balanceMultiThreading(5)
-				go t.runRecoveryProcedure(addr, config, nodeName, ncCopy, contributedNode)
+				ctlruntime.Go(ctx, ncCopy, func(ctx context.Context) {
+					if t.balanceMultiThreading(ctx, 5) == nil {
+						t.runRecoveryProcedure(ctx, addr, config, nodeName, ncCopy, contributedNode)
+					}
+				})
 			} else {
 				ncCopy.Status.State = success
 				ncCopy.Status.Message = append(ncCopy.Status.Message, statusDict["node-ok"])
-				t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+				t.setReady(ncCopy, true, apps_v1alpha.ReasonNodeRunning, statusDict["node-ok"])
+				t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 			}
 		} else {
 			log.Println("NODE NOT FOUND")
-			t.balanceMultiThreading(5)
-			go t.runSetupProcedure(NCOwnerNamespace.Labels["authority-name"], addr, nodeName, recordType, config, ncCopy)
+			ctlruntime.Go(ctx, ncCopy, func(ctx context.Context) {
+				if t.balanceMultiThreading(ctx, 5) == nil {
+					t.runSetupProcedure(ctx, NCOwnerNamespace.Labels["authority-name"], addr, nodeName, recordType, config, ncCopy)
+				}
+			})
 		}
 	} else {
 		log.Println("AUTHORITY NOT ENABLED")
 		ncCopy.Spec.Enabled = false
-		ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).Update(context.TODO(), ncCopy, metav1.UpdateOptions{})
+		ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).Update(ctx, ncCopy, metav1.UpdateOptions{})
 		if err == nil {
 			ncCopy = ncCopyUpdated
 			ncCopy.Status.State = failure
 			ncCopy.Status.Message = append(ncCopy.Status.Message, "Authority disabled")
-			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+			t.setReady(ncCopy, false, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"])
+			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 		}
 	}
+	return nil
 }
 
-// ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+// ObjectDeleted is called when an object is deleted, before it disappears thanks to the finalizer
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("NCHandler.ObjectDeleted")
-	// Mail notification, TBD
+	ncCopy := obj.(*apps_v1alpha.NodeContribution).DeepCopy()
+	// The namespace may be on its way out along with the authority, so the name of the node comes from the namespace name
+	nodeName := fmt.Sprintf("%s.%s.edge-net.io", strings.TrimPrefix(ncCopy.GetNamespace(), "authority-"), ncCopy.GetName())
+	if ncCopy.GetNamespace() == "authority-edgenet" {
+		nodeName = fmt.Sprintf("%s.edge-net.io", ncCopy.GetName())
+	}
+	err := t.clientset.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	// The DNS provider may be out of reach or not configured at all, so a host record left behind does not hold the deletion
+	if removed, state := node.RemoveHostname(strings.TrimSuffix(nodeName, ".edge-net.io")); !removed {
+		t.recorder.Event(ncCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonDNSConfigurationFailed, fmt.Sprintf("Host record of %s cannot be removed: %s", nodeName, state))
+	}
+	if ctlruntime.RemoveFinalizer(ncCopy) {
+		_, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).Update(ctx, ncCopy, metav1.UpdateOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	t.recorder.Event(ncCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonNodeRemoved, fmt.Sprintf("Node %s is removed from the cluster", nodeName))
+	ncCopy.Status.State = removed
+	t.sendEmail(ctx, ncCopy)
+	return nil
 }
 
 // sendEmail to send notification to participants
-func (t *Handler) sendEmail(ncCopy *apps_v1alpha.NodeContribution) {
+func (t *Handler) sendEmail(ctx context.Context, ncCopy *apps_v1alpha.NodeContribution) {
 	// For those who are authority-admin and authorized users of the authority
-	userRaw, err := t.edgenetClientset.AppsV1alpha().Users(ncCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
+	userRaw, err := t.edgenetClientset.AppsV1alpha().Users(ncCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
 	if err == nil {
 		contentData := mailer.MultiProviderData{}
 		contentData.Name = ncCopy.GetName()
@@ -241,6 +364,8 @@ func (t *Handler) sendEmail(ncCopy *apps_v1alpha.NodeContribution) {
 						mailer.Send("node-contribution-failure", contentData)
 					} else if contentData.Status == success {
 						mailer.Send("node-contribution-successful", contentData)
+					} else if contentData.Status == removed {
+						mailer.Send("node-contribution-removal", contentData)
 					}
 				}
 			}
@@ -251,14 +376,13 @@ func (t *Handler) sendEmail(ncCopy *apps_v1alpha.NodeContribution) {
 	}
 }
 
-// balanceMultiThreading is a simple algorithm to limit concurrent threads
-func (t *Handler) balanceMultiThreading(limit int) {
+// balanceMultiThreading is a simple algorithm to limit concurrent threads, it returns an error if the context is done in the meantime
+func (t *Handler) balanceMultiThreading(ctx context.Context, limit int) error {
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
-check:
-	for ; true; <-ticker.C {
+	for {
 		var threads int
-		ncRaw, err := t.edgenetClientset.AppsV1alpha().NodeContributions("").List(context.TODO(), metav1.ListOptions{})
+		ncRaw, err := t.edgenetClientset.AppsV1alpha().NodeContributions("").List(ctx, metav1.ListOptions{})
 		if err == nil {
 			for _, ncRow := range ncRaw.Items {
 				if ncRow.Status.State == inprogress {
@@ -266,15 +390,22 @@ check:
 				}
 			}
 			if threads < limit {
-				break check
+				return nil
 			}
 		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 	}
 }
 
-// runSetupProcedure installs necessary packages from scratch and makes the node join into the cluster
-func (t *Handler) runSetupProcedure(authorityName, addr, nodeName, recordType string, config *ssh.ClientConfig,
+// runSetupProcedure installs necessary packages from scratch and makes the node join into the cluster.
+// It gives up once the context is done, closing the SSH connection on its way.
+func (t *Handler) runSetupProcedure(ctx context.Context, authorityName, addr, nodeName, recordType string, config *ssh.ClientConfig,
 	ncCopy *apps_v1alpha.NodeContribution) error {
+	defer func() { recordProcedure("setup", ncCopy) }()
 	// Steps in the procedure
 	endProcedure := make(chan bool, 1)
 	dnsConfiguration := make(chan bool, 1)
@@ -283,7 +414,8 @@ func (t *Handler) runSetupProcedure(authorityName, addr, nodeName, recordType st
 	// Set the status as recovering
 	ncCopy.Status.State = inprogress
 	ncCopy.Status.Message = append(ncCopy.Status.Message, "Installation procedure has started")
-	ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+	t.setReady(ncCopy, false, apps_v1alpha.ReasonInstalling, "Installation procedure has started")
+	ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 	if err == nil {
 		ncCopy = ncCopyUpdated
 	}
@@ -313,7 +445,8 @@ nodeInstallLoop:
 				}
 				ncCopy.Status.State = incomplete
 				ncCopy.Status.Message = append(ncCopy.Status.Message, hostnameError)
-				ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+				t.setReady(ncCopy, false, apps_v1alpha.ReasonDNSConfigurationFailed, hostnameError)
+				ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 				if err == nil {
 					ncCopy = ncCopyUpdated
 				}
@@ -325,12 +458,13 @@ nodeInstallLoop:
 			// To prevent hanging forever during establishing a connection
 			go func() {
 				// SSH into the node
-				conn, err := ssh.Dial("tcp", addr, config)
+				conn, err := dial(ctx, addr, config)
 				if err != nil {
 					log.Println(err)
 					ncCopy.Status.State = failure
 					ncCopy.Status.Message = append(ncCopy.Status.Message, "SSH handshake failed")
-					ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+					t.setReady(ncCopy, false, apps_v1alpha.ReasonConnectionFailed, "SSH handshake failed")
+					ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 					log.Println(err)
 					if err == nil {
 						ncCopy = ncCopyUpdated
@@ -339,12 +473,14 @@ nodeInstallLoop:
 					return
 				}
 				defer conn.Close()
+				defer closeOnDone(ctx, conn)()
 				// Uninstall all existing packages related, do a clean installation, and make the node join to the cluster
-				err = t.cleanInstallation(conn, nodeName, ncCopy)
+				err = t.cleanInstallation(ctx, conn, nodeName, ncCopy)
 				if err != nil {
 					ncCopy.Status.State = failure
 					ncCopy.Status.Message = append(ncCopy.Status.Message, "Node installation failed")
-					ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+					t.setReady(ncCopy, false, apps_v1alpha.ReasonInstallationFailed, "Node installation failed")
+					ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 					log.Println(err)
 					if err == nil {
 						ncCopy = ncCopyUpdated
@@ -352,71 +488,99 @@ nodeInstallLoop:
 					endProcedure <- true
 					return
 				}
-				_, err = t.clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+				_, err = t.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 				if err == nil {
 					nodePatch <- true
 				}
 			}()
 		case <-nodePatch:
 			log.Println("***************Node Patch***************")
-			// Set the node as schedulable or unschedulable according to the node contribution
-			patchStatus := true
-			err := node.SetNodeScheduling(nodeName, !ncCopy.Spec.Enabled)
-			if err != nil {
-				ncCopy.Status.State = incomplete
-				ncCopy.Status.Message = append(ncCopy.Status.Message, "Scheduling configuration failed")
-				t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
-				t.sendEmail(ncCopy)
-				patchStatus = false
-			}
-			var ownerReferences []metav1.OwnerReference
-			authorityCopy, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), authorityName, metav1.GetOptions{})
-			if err == nil {
-				ownerReferences = authority.SetAsOwnerReference(authorityCopy)
-			}
-			NCOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(context.TODO(), fmt.Sprintf("authority-%s", authorityName), metav1.GetOptions{})
-			if err == nil {
-				ownerReferences = append(ownerReferences, ns.SetAsOwnerReference(NCOwnerNamespace)...)
-			}
-			err = node.SetOwnerReferences(nodeName, ownerReferences)
-			if err != nil {
-				ncCopy.Status.State = incomplete
-				ncCopy.Status.Message = append(ncCopy.Status.Message, "Setting owner reference failed")
-				t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
-				t.sendEmail(ncCopy)
-				patchStatus = false
-			}
-			if patchStatus {
+			if !t.configureNode(ctx, authorityName, nodeName, ncCopy) {
 				break nodeInstallLoop
 			}
-			ncCopy.Status.State = success
-			ncCopy.Status.Message = append(ncCopy.Status.Message, "Node installation successful")
-			t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
 			endProcedure <- true
 		case <-endProcedure:
 			log.Println("***************Procedure Terminated***************")
-			t.sendEmail(ncCopy)
+			t.sendEmail(ctx, ncCopy)
+			break nodeInstallLoop
+		case <-ctx.Done():
+			log.Println("***************Procedure Cancelled***************")
 			break nodeInstallLoop
 		case <-time.After(25 * time.Minute):
 			log.Println("***************Timeout***************")
 			// Terminate the procedure after 25 minutes
 			ncCopy.Status.State = failure
 			ncCopy.Status.Message = append(ncCopy.Status.Message, "Node installation failed: timeout")
-			ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+			t.setReady(ncCopy, false, apps_v1alpha.ReasonTimeout, "Node installation failed: timeout")
+			ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 			log.Println(err)
 			if err == nil {
 				ncCopy = ncCopyUpdated
 			}
-			t.sendEmail(ncCopy)
+			t.sendEmail(ctx, ncCopy)
 			break nodeInstallLoop
 		}
 	}
 	return err
 }
 
-// runRecoveryProcedure applies predefined methods to recover the node
-func (t *Handler) runRecoveryProcedure(addr string, config *ssh.ClientConfig,
+// configureNode applies the scheduling, the limitations, the location, and the owners of the node contribution to the node
+// that has joined the cluster. The setup succeeds if all of them apply, otherwise the node contribution remains incomplete.
+func (t *Handler) configureNode(ctx context.Context, authorityName, nodeName string, ncCopy *apps_v1alpha.NodeContribution) bool {
+	// Set the node as schedulable or unschedulable according to the node contribution
+	patchStatus := true
+	err := node.SetNodeScheduling(ctx, nodeName, !ncCopy.Spec.Enabled)
+	if err != nil {
+		ncCopy.Status.State = incomplete
+		ncCopy.Status.Message = append(ncCopy.Status.Message, "Scheduling configuration failed")
+		t.setReady(ncCopy, false, apps_v1alpha.ReasonSchedulingFailed, "Scheduling configuration failed")
+		t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
+		t.sendEmail(ctx, ncCopy)
+		patchStatus = false
+	}
+	// Taint the node so that only the namespaces that the limitations permit run on it
+	err = node.SetLimitations(ctx, nodeName, ncCopy.Spec.Limitations)
+	if err != nil {
+		ncCopy.Status.State = incomplete
+		ncCopy.Status.Message = append(ncCopy.Status.Message, "Limitation configuration failed")
+		t.setReady(ncCopy, false, apps_v1alpha.ReasonLimitationFailed, "Limitation configuration failed")
+		t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
+		t.sendEmail(ctx, ncCopy)
+		patchStatus = false
+	}
+	t.declareLocation(ctx, ncCopy, nodeName)
+	var ownerReferences []metav1.OwnerReference
+	authorityCopy, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, authorityName, metav1.GetOptions{})
+	if err == nil {
+		ownerReferences = authority.SetAsOwnerReference(authorityCopy)
+	}
+	NCOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, fmt.Sprintf("authority-%s", authorityName), metav1.GetOptions{})
+	if err == nil {
+		ownerReferences = append(ownerReferences, ns.SetAsOwnerReference(NCOwnerNamespace)...)
+	}
+	err = node.SetOwnerReferences(ctx, nodeName, ownerReferences)
+	if err != nil {
+		ncCopy.Status.State = incomplete
+		ncCopy.Status.Message = append(ncCopy.Status.Message, "Setting owner reference failed")
+		t.setReady(ncCopy, false, apps_v1alpha.ReasonOwnerReferenceFailed, "Setting owner reference failed")
+		t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
+		t.sendEmail(ctx, ncCopy)
+		patchStatus = false
+	}
+	if !patchStatus {
+		return false
+	}
+	ncCopy.Status.State = success
+	ncCopy.Status.Message = append(ncCopy.Status.Message, "Node installation successful")
+	t.setReady(ncCopy, true, apps_v1alpha.ReasonNodeRunning, "Node installation successful")
+	t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
+	return true
+}
+
+// runRecoveryProcedure applies predefined methods to recover the node, it gives up once the context is done
+func (t *Handler) runRecoveryProcedure(ctx context.Context, addr string, config *ssh.ClientConfig,
 	nodeName string, ncCopy *apps_v1alpha.NodeContribution, contributedNode *corev1.Node) {
+	defer func() { recordProcedure("recovery", ncCopy) }()
 	// Steps in the procedure
 	endProcedure := make(chan bool, 1)
 	establishConnection := make(chan bool, 1)
@@ -425,12 +589,13 @@ func (t *Handler) runRecoveryProcedure(addr string, config *ssh.ClientConfig,
 	// Set the status as recovering
 	ncCopy.Status.State = recover
 	ncCopy.Status.Message = append(ncCopy.Status.Message, "Node recovering")
-	ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+	t.setReady(ncCopy, false, apps_v1alpha.ReasonRecovering, "Node recovering")
+	ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 	if err == nil {
 		ncCopy = ncCopyUpdated
 	}
 	// Watch the events of node object
-	watchNode, err := t.clientset.CoreV1().Nodes().Watch(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", contributedNode.GetName())})
+	watchNode, err := t.clientset.CoreV1().Nodes().Watch(ctx, metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", contributedNode.GetName())})
 	if err == nil {
 		go func() {
 			// Get events from watch interface
@@ -444,7 +609,8 @@ func (t *Handler) runRecoveryProcedure(addr string, config *ssh.ClientConfig,
 					if node.GetConditionReadyStatus(updatedNode) == trueStr {
 						ncCopy.Status.State = success
 						ncCopy.Status.Message = append([]string{}, "Node recovery successful")
-						ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+						t.setReady(ncCopy, true, apps_v1alpha.ReasonNodeRunning, "Node recovery successful")
+						ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 						log.Println(err)
 						if err == nil {
 							ncCopy = ncCopyUpdated
@@ -462,12 +628,13 @@ func (t *Handler) runRecoveryProcedure(addr string, config *ssh.ClientConfig,
 
 	var conn *ssh.Client
 	go func() {
-		conn, err = ssh.Dial("tcp", addr, config)
+		conn, err = dial(ctx, addr, config)
 		if err != nil {
 			log.Println(err)
 			ncCopy.Status.State = failure
 			ncCopy.Status.Message = append(ncCopy.Status.Message, "Node recovery failed: SSH handshake failed")
-			ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+			t.setReady(ncCopy, false, apps_v1alpha.ReasonConnectionFailed, "Node recovery failed: SSH handshake failed")
+			ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 			log.Println(err)
 			if err == nil {
 				ncCopy = ncCopyUpdated
@@ -489,17 +656,22 @@ nodeRecoveryLoop:
 			log.Printf("***************Establish Connection***************%s", nodeName)
 			go func() {
 				// SSH into the node
-				conn, err = ssh.Dial("tcp", addr, config)
+				conn, err = dial(ctx, addr, config)
 				if err != nil && connCounter < 3 {
 					log.Println(err)
 					// Wait three minutes to try establishing a connection again
-					time.Sleep(3 * time.Minute)
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(3 * time.Minute):
+					}
 					establishConnection <- true
 					connCounter++
 				} else if err != nil && connCounter >= 3 {
 					ncCopy.Status.State = failure
 					ncCopy.Status.Message = append(ncCopy.Status.Message, "Node recovery failed: SSH handshake failed")
-					ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+					t.setReady(ncCopy, false, apps_v1alpha.ReasonConnectionFailed, "Node recovery failed: SSH handshake failed")
+					ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 					log.Println(err)
 					if err == nil {
 						ncCopy = ncCopyUpdated
@@ -512,16 +684,17 @@ nodeRecoveryLoop:
 		case <-installation:
 			log.Println("***************Installation***************")
 			// Uninstall all existing packages related, do a clean installation, and make the node join to the cluster
-			err := t.cleanInstallation(conn, nodeName, ncCopy)
+			err := t.cleanInstallation(ctx, conn, nodeName, ncCopy)
 			if err != nil {
 				ncCopy.Status.State = failure
 				ncCopy.Status.Message = append(ncCopy.Status.Message, "Node recovery failed: installation step")
-				ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+				t.setReady(ncCopy, false, apps_v1alpha.ReasonRecoveryFailed, "Node recovery failed: installation step")
+				ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 				log.Println(err)
 				if err == nil {
 					ncCopy = ncCopyUpdated
 				}
-				t.sendEmail(ncCopy)
+				t.sendEmail(ctx, ncCopy)
 				watchNode.Stop()
 				break nodeRecoveryLoop
 			}
@@ -531,31 +704,43 @@ nodeRecoveryLoop:
 			err = rebootNode(conn)
 			if err != nil {
 				ncCopy.Status.Message = append(ncCopy.Status.Message, "Node recovery failed: reboot step")
-				ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+				t.setReady(ncCopy, false, apps_v1alpha.ReasonRecoveryFailed, "Node recovery failed: reboot step")
+				ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 				log.Println(err)
 				if err == nil {
 					ncCopy = ncCopyUpdated
 				}
 			}
 			conn.Close()
-			time.Sleep(3 * time.Minute)
+			select {
+			case <-ctx.Done():
+				break nodeRecoveryLoop
+			case <-time.After(3 * time.Minute):
+			}
 			establishConnection <- true
 		case <-endProcedure:
 			log.Println("***************Procedure Terminated***************")
-			t.sendEmail(ncCopy)
+			t.sendEmail(ctx, ncCopy)
 			watchNode.Stop()
 			break nodeRecoveryLoop
+		case <-ctx.Done():
+			log.Println("***************Procedure Cancelled***************")
+			if watchNode != nil {
+				watchNode.Stop()
+			}
+			break nodeRecoveryLoop
 		case <-time.After(25 * time.Minute):
 			log.Println("***************Timeout***************")
 			// Terminate the procedure after 25 minutes
 			ncCopy.Status.State = failure
 			ncCopy.Status.Message = append(ncCopy.Status.Message, "Node recovery failed: timeout")
-			ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(context.TODO(), ncCopy, metav1.UpdateOptions{})
+			t.setReady(ncCopy, false, apps_v1alpha.ReasonTimeout, "Node recovery failed: timeout")
+			ncCopyUpdated, err := t.edgenetClientset.AppsV1alpha().NodeContributions(ncCopy.GetNamespace()).UpdateStatus(ctx, ncCopy, metav1.UpdateOptions{})
 			log.Println(err)
 			if err == nil {
 				ncCopy = ncCopyUpdated
 			}
-			t.sendEmail(ncCopy)
+			t.sendEmail(ctx, ncCopy)
 			watchNode.Stop()
 			break nodeRecoveryLoop
 		}
@@ -566,13 +751,13 @@ nodeRecoveryLoop:
 }
 
 // cleanInstallation gets and runs the uninstallation and installation commands prepared
-func (t *Handler) cleanInstallation(conn *ssh.Client, nodeName string, ncCopy *apps_v1alpha.NodeContribution) error {
+func (t *Handler) cleanInstallation(ctx context.Context, conn *ssh.Client, nodeName string, ncCopy *apps_v1alpha.NodeContribution) error {
 	uninstallationCommands, err := getUninstallCommands(conn)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
-	installationCommands, err := getInstallCommands(conn, nodeName, node.GetKubeletVersion()[1:])
+	installationCommands, err := getInstallCommands(conn, nodeName, node.GetKubeletVersion(ctx)[1:])
 	if err != nil {
 		log.Println(err)
 		return err
@@ -586,6 +771,7 @@ func (t *Handler) cleanInstallation(conn *ssh.Client, nodeName string, ncCopy *a
 		return err
 	}
 	defer sess.Close()
+	defer closeOnDone(ctx, sess)()
 	// StdinPipe for commands
 	stdin, err := sess.StdinPipe()
 	if err != nil {
@@ -617,6 +803,38 @@ func (t *Handler) cleanInstallation(conn *ssh.Client, nodeName string, ncCopy *a
 	return nil
 }
 
+// dial establishes the SSH connection to the node, it gives up once the context is done
+func dial(ctx context.Context, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	dialer := net.Dialer{Timeout: config.Timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// The handshake has no context, closing the connection interrupts it
+	release := closeOnDone(ctx, netConn)
+	defer release()
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, addr, config)
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// closeOnDone closes the connection or the session once the context is done so that no procedure hangs on a shutdown,
+// the function it returns stops watching the context
+func closeOnDone(ctx context.Context, closer io.Closer) func() {
+	released := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			closer.Close()
+		case <-released:
+		}
+	}()
+	return func() { close(released) }
+}
+
 // rebootNode restarts node after a minute
 func rebootNode(conn *ssh.Client) error {
 	sess, err := startSession(conn)
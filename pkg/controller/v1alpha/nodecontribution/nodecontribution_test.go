@@ -1,12 +1,23 @@
 package nodecontribution
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"testing"
 
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 // Dictionary for error messages
@@ -30,3 +41,26 @@ func TestMain(m *testing.M) {
 	logrus.SetOutput(ioutil.Discard)
 	os.Exit(m.Run())
 }
+
+func TestSetupProcedureMetric(t *testing.T) {
+	nodeObj := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "nc.edge-net.io"}}
+	NC := &apps_v1alpha.NodeContribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "nc", Namespace: "authority-edgenet"},
+		Spec:       apps_v1alpha.NodeContributionSpec{Host: "192.168.0.1", Enabled: true},
+	}
+	clientset := testclient.NewSimpleClientset(nodeObj)
+	node.Clientset = clientset
+	handler := Handler{
+		clientset:        clientset,
+		edgenetClientset: edgenettestclient.NewSimpleClientset(NC),
+		recorder:         record.NewFakeRecorder(10),
+	}
+	successes := testutil.ToFloat64(sshProcedures.WithLabelValues("setup", "success"))
+	failures := testutil.ToFloat64(sshProcedures.WithLabelValues("setup", "failure"))
+	ncCopy := NC.DeepCopy()
+	util.Equals(t, true, handler.configureNode(context.TODO(), "edgenet", nodeObj.GetName(), ncCopy))
+	recordProcedure("setup", ncCopy)
+	util.Equals(t, success, ncCopy.Status.State)
+	util.Equals(t, successes+1, testutil.ToFloat64(sshProcedures.WithLabelValues("setup", "success")))
+	util.Equals(t, failures, testutil.ToFloat64(sshProcedures.WithLabelValues("setup", "failure")))
+}
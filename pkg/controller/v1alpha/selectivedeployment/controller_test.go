@@ -15,7 +15,7 @@ func TestStartController(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
 	// Run the controller in a goroutine
-	go Start(g.client, g.edgenetClient)
+	go Start(g.client, g.edgenetClient, g.dynamicClient)
 	// Creating nodes
 	nodeParis := g.nodeObj
 	nodeParis.SetName("edgenet.planet-lab.eu")
@@ -0,0 +1,338 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectivedeployment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// The labels of the regional copies of a workload and of their pods, which keep the selectors of the copies apart
+const (
+	workloadLabel = "edge-net.io/sd-workload"
+	regionLabel   = "edge-net.io/sd-region"
+)
+
+// defaultMaxSkew is the largest difference between the replicas of two regions when the spread mode has none
+const defaultMaxSkew = 1
+
+// region is a selector value that gets its own copy of the workloads in the PerValue mode
+type region struct {
+	// name is the value for the location selectors, and the selector name along with a sequence number otherwise
+	name string
+	// suffix is the name turned into a DNS label, which is appended to the names of the copies
+	suffix string
+	// selectors are the selector of the region followed by the ones that apply to every region
+	selectors []apps_v1alpha.Selector
+	// indices are the indices of the selectors in the spec
+	indices []int
+}
+
+// listRegions makes a region of each value of the selectors that require the nodes in their values. The selectors
+// with the NotIn operator and the preferred selectors apply to every region rather than make regions.
+func listRegions(selectors []apps_v1alpha.Selector) []region {
+	var shared []apps_v1alpha.Selector
+	var sharedIndices []int
+	for i, selector := range selectors {
+		if selector.Operator != "In" || selector.Preferred {
+			shared = append(shared, selector)
+			sharedIndices = append(sharedIndices, i)
+		}
+	}
+	regions := []region{}
+	counts := map[string]int{}
+	for i, selector := range selectors {
+		if selector.Operator != "In" || selector.Preferred {
+			continue
+		}
+		selectorName := strings.ToLower(selector.Name)
+		for _, value := range selector.Value {
+			regionSelector := selector
+			regionSelector.Value = []string{value}
+			name := value
+			switch selectorName {
+			case "city", "state", "country", "continent", "asn":
+			default:
+				// The other values, such as the polygons, are too long to name a region
+				counts[selectorName]++
+				name = fmt.Sprintf("%s-%d", selectorName, counts[selectorName])
+			}
+			regions = append(regions, region{
+				name:      name,
+				suffix:    geolabel.EncodeName(name),
+				selectors: append([]apps_v1alpha.Selector{regionSelector}, shared...),
+				indices:   append([]int{i}, sharedIndices...),
+			})
+		}
+	}
+	return regions
+}
+
+// distributes returns whether the selective deployment runs a copy of its workloads in each region
+func distributes(sdCopy *apps_v1alpha.SelectiveDeployment) bool {
+	return sdCopy.Spec.Distribution != nil && sdCopy.Spec.Distribution.Mode == apps_v1alpha.DistributionPerValue
+}
+
+// spreads returns whether the selective deployment spreads the replicas of its workloads across the regions
+func spreads(sdCopy *apps_v1alpha.SelectiveDeployment) bool {
+	return sdCopy.Spec.Distribution != nil && sdCopy.Spec.Distribution.Mode == apps_v1alpha.DistributionSpread
+}
+
+// applyRegion applies the copy of a workload with the selectors of the region only. The nodes that
+// the distance-based selectors of the region pick add up to the ones of the other regions.
+func (t *SDHandler) applyRegion(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, region region, kind WorkloadKind, workloadRow *unstructured.Unstructured, ownerReferences []metav1.OwnerReference) (*unstructured.Unstructured, int, bool) {
+	selectors, selectedNodes := sdCopy.Spec.Selector, sdCopy.Status.Nodes
+	sdCopy.Spec.Selector = region.selectors
+	workloadObj, failureCount, applied := t.applyWorkload(ctx, sdCopy, kind, workloadRow, ownerReferences)
+	for _, selectedNode := range sdCopy.Status.Nodes {
+		selectedNode.Selector = region.indices[selectedNode.Selector]
+		exists := false
+		for _, existing := range selectedNodes {
+			if existing == selectedNode {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			selectedNodes = append(selectedNodes, selectedNode)
+		}
+	}
+	sdCopy.Spec.Selector, sdCopy.Status.Nodes = selectors, selectedNodes
+	return workloadObj, failureCount, applied
+}
+
+// distributeWorkload runs a copy of the workload with the replicas of the distribution in each region,
+// and removes the copies of the regions that the selectors do not pick anymore
+func (t *SDHandler) distributeWorkload(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadRow *unstructured.Unstructured, ownerReferences []metav1.OwnerReference) int {
+	failureCounter := 0
+	replicas := sdCopy.Spec.Distribution.Replicas
+	regionalCopies := map[string]bool{}
+	for _, region := range listRegions(sdCopy.Spec.Selector) {
+		regionalWorkload := workloadRow.DeepCopy()
+		regionalWorkload.SetName(regionalName(workloadRow.GetName(), region))
+		if err := setRegion(kind, regionalWorkload, workloadRow.GetName(), region.suffix, replicas); err != nil {
+			log.Println(err.Error())
+			reportIssue(sdCopy, apps_v1alpha.ConditionWorkloadsCreated, apps_v1alpha.ReasonWorkloadCreationFailed, fmt.Sprintf(statusDict["workload-creation-failure"], kind.Kind, regionalWorkload.GetName()))
+			failureCounter++
+			continue
+		}
+		regionalCopies[regionalWorkload.GetName()] = true
+		regionStatus := apps_v1alpha.RegionStatus{Workload: workloadRow.GetName(), Region: region.name}
+		if rolls(sdCopy) {
+			// The copies that the rollout holds back keep running whatever revision they run
+			workloadObj, held, failureCount := t.rollRegion(ctx, sdCopy, region, kind, regionalWorkload)
+			failureCounter += failureCount
+			if held {
+				regionStatus.Ready = fmt.Sprintf("%d/%d", readyReplicas(kind, workloadObj), replicas)
+				regionStatus.Revision = workloadObj.GetAnnotations()[revisionAnnotation]
+				sdCopy.Status.Regions = append(sdCopy.Status.Regions, regionStatus)
+				continue
+			}
+			regionStatus.Revision = sdCopy.Status.Rollout.Revision
+		}
+		workloadObj, failureCount, applied := t.applyRegion(ctx, sdCopy, region, kind, regionalWorkload, ownerReferences)
+		failureCounter += failureCount
+		if !applied {
+			continue
+		}
+		regionStatus.Ready = fmt.Sprintf("%d/%d", readyReplicas(kind, workloadObj), replicas)
+		sdCopy.Status.Regions = append(sdCopy.Status.Regions, regionStatus)
+	}
+	// The workload that ran before the distribution gives way to the copies
+	workloadClient := t.dynamicClientset.Resource(kind.groupVersionResource()).Namespace(sdCopy.GetNamespace())
+	if workloadObj, err := workloadClient.Get(ctx, workloadRow.GetName(), metav1.GetOptions{}); err == nil && isOwnedBy(sdCopy, workloadObj.GetOwnerReferences()) {
+		workloadClient.Delete(ctx, workloadRow.GetName(), metav1.DeleteOptions{})
+	}
+	t.pruneRegionalCopies(ctx, sdCopy, kind, workloadRow.GetName(), regionalCopies)
+	return failureCounter
+}
+
+// regionalName returns the name of the copy of the workload in the region, which is hashed if it is too long
+// for a DNS label
+func regionalName(workloadName string, region region) string {
+	return geolabel.EncodeName(fmt.Sprintf("%s-%s", workloadName, region.suffix))
+}
+
+// workloadLabelValue returns the value of the workload label of the copies of the workload, as the name of an object
+// may be longer than a label value
+func workloadLabelValue(workloadName string) string {
+	return geolabel.Encode(workloadName)
+}
+
+// readyReplicas returns the ready replicas of the workload, which are none if the workload does not exist yet
+// or its kind does not report them
+func readyReplicas(kind WorkloadKind, workloadObj *unstructured.Unstructured) int64 {
+	if workloadObj == nil || kind.ReadyReplicas == "" {
+		return 0
+	}
+	readyReplicas, _, _ := unstructured.NestedInt64(workloadObj.Object, fields(kind.ReadyReplicas)...)
+	return readyReplicas
+}
+
+// pruneRegionalCopies deletes the copies of the workload that the selective deployment made for the regions except the ones to keep
+func (t *SDHandler) pruneRegionalCopies(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadName string, keep map[string]bool) {
+	workloadClient := t.dynamicClientset.Resource(kind.groupVersionResource()).Namespace(sdCopy.GetNamespace())
+	workloadRaw, err := workloadClient.List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", workloadLabel, workloadLabelValue(workloadName))})
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	for _, workloadRow := range workloadRaw.Items {
+		if !keep[workloadRow.GetName()] && isOwnedBy(sdCopy, workloadRow.GetOwnerReferences()) {
+			workloadClient.Delete(ctx, workloadRow.GetName(), metav1.DeleteOptions{})
+		}
+	}
+}
+
+// spreadReplicas sets the topology spread constraint that balances the replicas across the regions in the Spread mode.
+// It drops the constraints on the geographic labels that a former distribution set.
+func spreadReplicas(sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadObj *unstructured.Unstructured) {
+	constraintFields := fields(kind.PodSpec, "topologySpreadConstraints")
+	existing, _, _ := unstructured.NestedSlice(workloadObj.Object, constraintFields...)
+	var constraints []interface{}
+	for _, constraint := range existing {
+		if constraintMap, ok := constraint.(map[string]interface{}); ok {
+			if topologyKey, _ := constraintMap["topologyKey"].(string); util.Contains(geoLabels, topologyKey) {
+				continue
+			}
+		}
+		constraints = append(constraints, constraint)
+	}
+	if spreads(sdCopy) {
+		maxSkew := sdCopy.Spec.Distribution.MaxSkew
+		if maxSkew == 0 {
+			maxSkew = defaultMaxSkew
+		}
+		constraint, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.TopologySpreadConstraint{
+			MaxSkew:           maxSkew,
+			TopologyKey:       geoLabelKey(sdCopy.Spec.Distribution.Topology),
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     getSelector(kind, workloadObj),
+		})
+		if err != nil {
+			log.Println(err.Error())
+		} else {
+			constraints = append(constraints, constraint)
+		}
+	}
+	if len(constraints) == 0 {
+		unstructured.RemoveNestedField(workloadObj.Object, constraintFields...)
+		return
+	}
+	if err := unstructured.SetNestedSlice(workloadObj.Object, constraints, constraintFields...); err != nil {
+		log.Println(err.Error())
+	}
+}
+
+// reportSpread reports the readiness of the replicas in each region of the Spread mode, which it gets from the pods of the workload
+func (t *SDHandler) reportSpread(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, workloadName string, selector *metav1.LabelSelector) {
+	if !spreads(sdCopy) || selector == nil {
+		return
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return
+	}
+	podRaw, err := t.clientset.CoreV1().Pods(sdCopy.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	nodes := t.getNodeIndex(ctx)
+	labelKey := geoLabelKey(sdCopy.Spec.Distribution.Topology)
+	readyPods, pods := map[string]int{}, map[string]int{}
+	for _, podRow := range podRaw.Items {
+		// The pods not scheduled yet are in no region
+		regionName := nodes.region(podRow.Spec.NodeName, labelKey)
+		if regionName == "" {
+			continue
+		}
+		pods[regionName]++
+		for _, condition := range podRow.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				readyPods[regionName]++
+			}
+		}
+	}
+	regionNames := make([]string, 0, len(pods))
+	for regionName := range pods {
+		regionNames = append(regionNames, regionName)
+	}
+	sort.Strings(regionNames)
+	for _, regionName := range regionNames {
+		sdCopy.Status.Regions = append(sdCopy.Status.Regions, apps_v1alpha.RegionStatus{Workload: workloadName, Region: regionName, Ready: fmt.Sprintf("%d/%d", readyPods[regionName], pods[regionName])})
+	}
+}
+
+// setRegion sets the replicas of a regional copy, and labels the copy and its pods so that the selector of the copy
+// takes the region into account
+func setRegion(kind WorkloadKind, workloadObj *unstructured.Unstructured, workloadName, suffix string, replicas int32) error {
+	regionLabels := map[string]string{workloadLabel: workloadLabelValue(workloadName), regionLabel: suffix}
+	labels := workloadObj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	podLabels, _, err := unstructured.NestedStringMap(workloadObj.Object, fields(kind.PodLabels)...)
+	if err != nil {
+		return err
+	}
+	if podLabels == nil {
+		podLabels = map[string]string{}
+	}
+	selector := getSelector(kind, workloadObj)
+	if selector == nil {
+		selector = &metav1.LabelSelector{}
+	}
+	if selector.MatchLabels == nil {
+		selector.MatchLabels = map[string]string{}
+	}
+	for key, value := range regionLabels {
+		labels[key] = value
+		podLabels[key] = value
+		selector.MatchLabels[key] = value
+	}
+	workloadObj.SetLabels(labels)
+	if err := unstructured.SetNestedStringMap(workloadObj.Object, podLabels, fields(kind.PodLabels)...); err != nil {
+		return err
+	}
+	if err := setNestedObject(workloadObj.Object, selector, fields(kind.Selector)...); err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(workloadObj.Object, int64(replicas), fields(kind.Replicas)...)
+}
+
+// isOwnedBy returns whether the selective deployment owns the workload
+func isOwnedBy(sdCopy *apps_v1alpha.SelectiveDeployment, ownerReferences []metav1.OwnerReference) bool {
+	for _, reference := range ownerReferences {
+		if reference.Kind == "SelectiveDeployment" && reference.UID == sdCopy.GetUID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package selectivedeployment
+
+import (
+	"strings"
+	"testing"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestLongWorkloadName(t *testing.T) {
+	// An object name may have 253 characters while a label value and a DNS label may have 63
+	workloadName := strings.Repeat("nginx-", 40) + "server.edge-net.io"
+	regions := listRegions([]apps_v1alpha.Selector{{Name: "City", Value: []string{"Saint-Rémy-de-Provence"}, Operator: "In"}})
+	util.Equals(t, 1, len(regions))
+
+	t.Run("regional name", func(t *testing.T) {
+		name := regionalName(workloadName, regions[0])
+		util.Equals(t, 0, len(validation.IsDNS1123Label(name)))
+		util.NotEquals(t, regionalName(strings.Repeat("nginx-", 40)+"server.edge-net.eu", regions[0]), name)
+	})
+	t.Run("labels", func(t *testing.T) {
+		kind := builtinWorkloadKinds[0]
+		workloadObj := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": kind.APIVersion, "kind": kind.Kind}}
+		workloadObj.SetName(regionalName(workloadName, regions[0]))
+		util.OK(t, setRegion(kind, workloadObj, workloadName, regions[0].suffix, 2))
+		for _, key := range []string{workloadLabel, regionLabel} {
+			value := workloadObj.GetLabels()[key]
+			util.Equals(t, 0, len(validation.IsValidLabelValue(value)))
+			util.Equals(t, value, getSelector(kind, workloadObj).MatchLabels[key])
+		}
+		util.Equals(t, workloadLabelValue(workloadName), workloadObj.GetLabels()[workloadLabel])
+	})
+}
@@ -18,308 +18,197 @@ package selectivedeployment
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
+	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
-	"github.com/EdgeNet-project/edgenet/pkg/node"
+	"github.com/EdgeNet-project/edgenet/pkg/geo"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	log "github.com/sirupsen/logrus"
-	appsv1 "k8s.io/api/apps/v1"
-	batchv1 "k8s.io/api/batch/v1"
-	batchv1beta "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
-	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj interface{})
-	ObjectDeleted(obj interface{})
+	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface, dynamic dynamic.Interface)
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
 }
 
 // SDHandler is a implementation of Handler
 type SDHandler struct {
 	clientset        kubernetes.Interface
 	edgenetClientset versioned.Interface
+	// dynamicClientset manages the workloads of any kind as unstructured objects
+	dynamicClientset dynamic.Interface
+	recorder         record.EventRecorder
+	// nodes is the index of the schedulable nodes, which the controller keeps
+	nodes *nodeIndex
+	// requeue brings the selective deployment back to the queue after the duration, such as at the end of a bake time
+	requeue func(sdCopy *apps_v1alpha.SelectiveDeployment, after time.Duration)
 }
 
 // Init handles any handler initialization
-func (t *SDHandler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
+func (t *SDHandler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interface, dynamic dynamic.Interface) {
 	log.Info("SDHandler.Init")
 	t.clientset = kubernetes
 	t.edgenetClientset = edgenet
+	t.dynamicClientset = dynamic
+	t.recorder = ctlruntime.NewEventRecorder(kubernetes, "selectivedeployment-controller")
 }
 
 // ObjectCreated is called when an object is created
-func (t *SDHandler) ObjectCreated(obj interface{}) {
+func (t *SDHandler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("SDHandler.ObjectCreated")
 	// Create a copy of the selectivedeployment object to make changes on it
 	sdCopy := obj.(*apps_v1alpha.SelectiveDeployment).DeepCopy()
-	t.applyCriteria(sdCopy, "create")
+	t.applyCriteria(ctx, sdCopy, "create")
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *SDHandler) ObjectUpdated(obj interface{}) {
+func (t *SDHandler) ObjectUpdated(ctx context.Context, obj interface{}) error {
 	log.Info("SDHandler.ObjectUpdated")
 	// Create a copy of the selectivedeployment object to make changes on it
 	sdCopy := obj.(*apps_v1alpha.SelectiveDeployment).DeepCopy()
-	t.applyCriteria(sdCopy, "update")
+	t.applyCriteria(ctx, sdCopy, "update")
+	return nil
 }
 
 // ObjectDeleted is called when an object is deleted
-func (t *SDHandler) ObjectDeleted(obj interface{}) {
+func (t *SDHandler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("SDHandler.ObjectDeleted")
-	// TBD
+	// The workloads go with the owner references, so the selective deployment needs no finalizer
+	return nil
 }
 
-// getByNode generates selectivedeployment list from the owner references of workloads which contains the node that has an event (add/update/delete)
-func (t *SDHandler) getByNode(nodeName string) ([][]string, bool) {
+// getByNode generates selectivedeployment list from the workloads of the selective deployments which contains the node that has an event (add/update/delete)
+func (t *SDHandler) getByNode(ctx context.Context, nodeName string) ([][]string, bool) {
 	ownerList := [][]string{}
 	status := false
-
-	setList := func(ctlPodSpec corev1.PodSpec, ownerReferences []metav1.OwnerReference, namespace string) {
-		podSpec := ctlPodSpec
-		if podSpec.Affinity != nil && podSpec.Affinity.NodeAffinity != nil && podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-		nodeSelectorLoop:
-			for _, nodeSelectorTerm := range podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
-				for _, matchExpression := range nodeSelectorTerm.MatchExpressions {
-					if matchExpression.Key == "kubernetes.io/hostname" {
-						for _, expressionNodeName := range matchExpression.Values {
-							if nodeName == expressionNodeName {
-								for _, owner := range ownerReferences {
-									if owner.Kind == "SelectiveDeployment" {
-										ownerDet := []string{namespace, owner.Name}
-										if !util.SliceContains(ownerList, ownerDet) {
-											ownerList = append(ownerList, ownerDet)
-										}
-										status = true
-									}
-								}
-								break nodeSelectorLoop
-							}
-						}
-					}
-				}
-			}
-		}
-	}
-	deploymentRaw, err := t.clientset.AppsV1().Deployments("").List(context.TODO(), metav1.ListOptions{})
+	sdRaw, err := t.edgenetClientset.AppsV1alpha().SelectiveDeployments("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		log.Println(err.Error())
-		panic(err.Error())
+		return nil, false
 	}
-	for _, deploymentRow := range deploymentRaw.Items {
-		setList(deploymentRow.Spec.Template.Spec, deploymentRow.GetOwnerReferences(), deploymentRow.GetNamespace())
-	}
-	daemonsetRaw, err := t.clientset.AppsV1().DaemonSets("").List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		log.Println(err.Error())
-		panic(err.Error())
+	for _, sdRow := range sdRaw.Items {
+	workloadLoop:
+		for _, workloadRow := range listWorkloads(&sdRow) {
+			kind, ok := lookupWorkloadKind(workloadRow.GroupVersionKind())
+			if !ok {
+				continue
+			}
+			for _, workloadObj := range t.getOwnedWorkloads(ctx, &sdRow, kind, workloadRow.GetName()) {
+				if selectsNode(kind, &workloadObj, nodeName) {
+					ownerList = append(ownerList, []string{sdRow.GetNamespace(), sdRow.GetName()})
+					status = true
+					break workloadLoop
+				}
+			}
+		}
 	}
-	for _, daemonsetRow := range daemonsetRaw.Items {
-		setList(daemonsetRow.Spec.Template.Spec, daemonsetRow.GetOwnerReferences(), daemonsetRow.GetNamespace())
+	return ownerList, status
+}
+
+// getOwnedWorkloads returns the workload of the name and its regional copies that the selective deployment owns
+func (t *SDHandler) getOwnedWorkloads(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadName string) []unstructured.Unstructured {
+	var workloads []unstructured.Unstructured
+	workloadClient := t.dynamicClientset.Resource(kind.groupVersionResource()).Namespace(sdCopy.GetNamespace())
+	if workloadObj, err := workloadClient.Get(ctx, workloadName, metav1.GetOptions{}); err == nil {
+		workloads = append(workloads, *workloadObj)
 	}
-	statefulsetRaw, err := t.clientset.AppsV1().StatefulSets("").List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		log.Println(err.Error())
-		panic(err.Error())
+	if kind.hasReplicas() {
+		if workloadRaw, err := workloadClient.List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", workloadLabel, workloadLabelValue(workloadName))}); err == nil {
+			workloads = append(workloads, workloadRaw.Items...)
+		}
 	}
-	for _, statefulsetRow := range statefulsetRaw.Items {
-		setList(statefulsetRow.Spec.Template.Spec, statefulsetRow.GetOwnerReferences(), statefulsetRow.GetNamespace())
+	owned := workloads[:0]
+	for _, workloadObj := range workloads {
+		if isOwnedBy(sdCopy, workloadObj.GetOwnerReferences()) {
+			owned = append(owned, workloadObj)
+		}
 	}
-	jobRaw, err := t.clientset.BatchV1().Jobs("").List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		log.Println(err.Error())
-		panic(err.Error())
+	return owned
+}
+
+// selectsNode returns whether the node affinity of the workload names the node in its terms
+func selectsNode(kind WorkloadKind, workloadObj *unstructured.Unstructured, nodeName string) bool {
+	nodeAffinity := &corev1.NodeAffinity{}
+	if !getNestedObject(workloadObj.Object, nodeAffinity, fields(kind.PodSpec, "affinity", "nodeAffinity")...) {
+		return false
 	}
-	for _, jobRow := range jobRaw.Items {
-		setList(jobRow.Spec.Template.Spec, jobRow.GetOwnerReferences(), jobRow.GetNamespace())
+	// The nodes of the preferred selectors are in the soft terms
+	var nodeSelectorTerms []corev1.NodeSelectorTerm
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		nodeSelectorTerms = append(nodeSelectorTerms, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms...)
 	}
-	cronjobRaw, err := t.clientset.BatchV1beta1().CronJobs("").List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		log.Println(err.Error())
-		panic(err.Error())
+	for _, preferredTerm := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		nodeSelectorTerms = append(nodeSelectorTerms, preferredTerm.Preference)
 	}
-	for _, cronjobRow := range cronjobRaw.Items {
-		setList(cronjobRow.Spec.JobTemplate.Spec.Template.Spec, cronjobRow.GetOwnerReferences(), cronjobRow.GetNamespace())
+	for _, nodeSelectorTerm := range nodeSelectorTerms {
+		for _, matchExpression := range nodeSelectorTerm.MatchExpressions {
+			if matchExpression.Key == "kubernetes.io/hostname" && util.Contains(matchExpression.Values, nodeName) {
+				return true
+			}
+		}
 	}
-	return ownerList, status
+	return false
 }
 
 // applyCriteria used by ObjectCreated, ObjectUpdated, and recoverSelectiveDeployments functions
-func (t *SDHandler) applyCriteria(sdCopy *apps_v1alpha.SelectiveDeployment, eventType string) {
-	oldStatus := sdCopy.Status
+func (t *SDHandler) applyCriteria(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, eventType string) {
+	oldStatus := *sdCopy.Status.DeepCopy()
 	statusUpdate := func() {
 		if !reflect.DeepEqual(oldStatus, sdCopy.Status) {
-			t.edgenetClientset.AppsV1alpha().SelectiveDeployments(sdCopy.GetNamespace()).UpdateStatus(context.TODO(), sdCopy, metav1.UpdateOptions{})
+			t.edgenetClientset.AppsV1alpha().SelectiveDeployments(sdCopy.GetNamespace()).UpdateStatus(ctx, sdCopy, metav1.UpdateOptions{})
 		}
 	}
 	defer statusUpdate()
 	// Flush the status
 	sdCopy.Status = apps_v1alpha.SelectiveDeploymentStatus{}
+	if sdCopy.Spec.DryRun {
+		// The workloads stay as they are, created or not, the status tells what the selectors pick
+		t.preview(ctx, sdCopy, *oldStatus.ConditionedStatus.DeepCopy())
+		t.recordReadiness(sdCopy, oldStatus.ConditionedStatus)
+		return
+	}
 
 	ownerReferences := SetAsOwnerReference(sdCopy)
-	workloadCounter := 0
+	workloads := listWorkloads(sdCopy)
+	workloadCounter := len(workloads)
 	failureCounter := 0
-	if sdCopy.Spec.Workloads.Deployment != nil {
-		workloadCounter += len(sdCopy.Spec.Workloads.Deployment)
-		for _, sdDeployment := range sdCopy.Spec.Workloads.Deployment {
-			deploymentObj, err := t.clientset.AppsV1().Deployments(sdCopy.GetNamespace()).Get(context.TODO(), sdDeployment.GetName(), metav1.GetOptions{})
-			if errors.IsNotFound(err) {
-				configuredDeployment, failureCount := t.configureWorkload(sdCopy, sdDeployment, ownerReferences)
-				failureCounter += failureCount
-				_, err = t.clientset.AppsV1().Deployments(sdCopy.GetNamespace()).Create(context.TODO(), configuredDeployment.(*appsv1.Deployment), metav1.CreateOptions{})
-				if err != nil {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["daemonset-creation-failure"], sdDeployment.GetName()))
-					failureCounter++
-				}
-			} else {
-				underControl := checkOwnerReferences(sdCopy, deploymentObj.GetOwnerReferences())
-				if !underControl {
-					// Configure the deployment according to the SD
-					configuredDeployment, failureCount := t.configureWorkload(sdCopy, sdDeployment, ownerReferences)
-					failureCounter += failureCount
-					_, err = t.clientset.AppsV1().Deployments(sdCopy.GetNamespace()).Update(context.TODO(), configuredDeployment.(*appsv1.Deployment), metav1.UpdateOptions{})
-					if err != nil {
-						sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["daemonset-creation-failure"], sdDeployment.GetName()))
-						failureCounter++
-					}
-				} else {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["deployment-in-use"], sdDeployment.GetName()))
-					failureCounter++
-				}
-			}
-		}
+	if rolls(sdCopy) {
+		t.planRollout(ctx, sdCopy, oldStatus.Rollout, workloads)
 	}
-	if sdCopy.Spec.Workloads.DaemonSet != nil {
-		workloadCounter += len(sdCopy.Spec.Workloads.DaemonSet)
-		for _, sdDaemonset := range sdCopy.Spec.Workloads.DaemonSet {
-			daemonsetObj, err := t.clientset.AppsV1().DaemonSets(sdCopy.GetNamespace()).Get(context.TODO(), sdDaemonset.GetName(), metav1.GetOptions{})
-			if errors.IsNotFound(err) {
-				configuredDaemonSet, failureCount := t.configureWorkload(sdCopy, sdDaemonset, ownerReferences)
-				failureCounter += failureCount
-				_, err = t.clientset.AppsV1().DaemonSets(sdCopy.GetNamespace()).Create(context.TODO(), configuredDaemonSet.(*appsv1.DaemonSet), metav1.CreateOptions{})
-				if err != nil {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["daemonset-creation-failure"], sdDaemonset.GetName()))
-					failureCounter++
-				}
-			} else {
-				underControl := checkOwnerReferences(sdCopy, daemonsetObj.GetOwnerReferences())
-				if !underControl {
-					// Configure the daemonset according to the SD
-					configuredDaemonSet, failureCount := t.configureWorkload(sdCopy, sdDaemonset, ownerReferences)
-					failureCounter += failureCount
-					_, err = t.clientset.AppsV1().DaemonSets(sdCopy.GetNamespace()).Update(context.TODO(), configuredDaemonSet.(*appsv1.DaemonSet), metav1.UpdateOptions{})
-					if err != nil {
-						sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["daemonset-creation-failure"], sdDaemonset.GetName()))
-						failureCounter++
-					}
-				} else {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["daemonset-in-use"], sdDaemonset.GetName()))
-					failureCounter++
-				}
-			}
-		}
-	}
-	if sdCopy.Spec.Workloads.StatefulSet != nil {
-		workloadCounter += len(sdCopy.Spec.Workloads.StatefulSet)
-		for _, sdStatefulset := range sdCopy.Spec.Workloads.StatefulSet {
-			statefulsetObj, err := t.clientset.AppsV1().StatefulSets(sdCopy.GetNamespace()).Get(context.TODO(), sdStatefulset.GetName(), metav1.GetOptions{})
-			if errors.IsNotFound(err) {
-				configuredStatefulSet, failureCount := t.configureWorkload(sdCopy, sdStatefulset, ownerReferences)
-				failureCounter += failureCount
-				_, err = t.clientset.AppsV1().StatefulSets(sdCopy.GetNamespace()).Create(context.TODO(), configuredStatefulSet.(*appsv1.StatefulSet), metav1.CreateOptions{})
-				if err != nil {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["statefulset-creation-failure"], sdStatefulset.GetName()))
-					failureCounter++
-				}
-			} else {
-				underControl := checkOwnerReferences(sdCopy, statefulsetObj.GetOwnerReferences())
-				if !underControl {
-					// Configure the statefulset according to the SD
-					configuredStatefulSet, failureCount := t.configureWorkload(sdCopy, sdStatefulset, ownerReferences)
-					failureCounter += failureCount
-					_, err = t.clientset.AppsV1().StatefulSets(sdCopy.GetNamespace()).Update(context.TODO(), configuredStatefulSet.(*appsv1.StatefulSet), metav1.UpdateOptions{})
-					if err != nil {
-						sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["statefulset-creation-failure"], sdStatefulset.GetName()))
-						failureCounter++
-					}
-				} else {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["statefulset-in-use"], sdStatefulset.GetName()))
-					failureCounter++
-				}
-			}
+	for _, workloadRow := range workloads {
+		kind, ok := lookupWorkloadKind(workloadRow.GroupVersionKind())
+		if !ok {
+			reportIssue(sdCopy, apps_v1alpha.ConditionWorkloadsCreated, apps_v1alpha.ReasonWorkloadKindUnknown, fmt.Sprintf(statusDict["workload-kind-unknown"], workloadRow.GetKind(), workloadRow.GetAPIVersion(), workloadRow.GetName()))
+			failureCounter++
+			continue
 		}
-	}
-	if sdCopy.Spec.Workloads.Job != nil {
-		workloadCounter += len(sdCopy.Spec.Workloads.Job)
-		for _, sdJob := range sdCopy.Spec.Workloads.Job {
-			jobObj, err := t.clientset.BatchV1().Jobs(sdCopy.GetNamespace()).Get(context.TODO(), sdJob.GetName(), metav1.GetOptions{})
-			if errors.IsNotFound(err) {
-				configuredJob, failureCount := t.configureWorkload(sdCopy, sdJob, ownerReferences)
-				failureCounter += failureCount
-				_, err = t.clientset.BatchV1().Jobs(sdCopy.GetNamespace()).Create(context.TODO(), configuredJob.(*batchv1.Job), metav1.CreateOptions{})
-				if err != nil {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["job-creation-failure"], sdJob.GetName()))
-					failureCounter++
-				}
-			} else {
-				underControl := checkOwnerReferences(sdCopy, jobObj.GetOwnerReferences())
-				if !underControl {
-					// Configure the job according to the SD
-					configuredJob, failureCount := t.configureWorkload(sdCopy, sdJob, ownerReferences)
-					failureCounter += failureCount
-					_, err = t.clientset.BatchV1().Jobs(sdCopy.GetNamespace()).Update(context.TODO(), configuredJob.(*batchv1.Job), metav1.UpdateOptions{})
-					if err != nil {
-						sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["job-creation-failure"], sdJob.GetName()))
-						failureCounter++
-					}
-				} else {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["job-in-use"], sdJob.GetName()))
-					failureCounter++
-				}
-			}
+		if distributes(sdCopy) && kind.hasReplicas() {
+			failureCounter += t.distributeWorkload(ctx, sdCopy, kind, workloadRow, ownerReferences)
+			continue
 		}
-	}
-	if sdCopy.Spec.Workloads.CronJob != nil {
-		workloadCounter += len(sdCopy.Spec.Workloads.CronJob)
-		for _, sdCronJob := range sdCopy.Spec.Workloads.CronJob {
-			cronjobObj, err := t.clientset.BatchV1beta1().CronJobs(sdCopy.GetNamespace()).Get(context.TODO(), sdCronJob.GetName(), metav1.GetOptions{})
-			if errors.IsNotFound(err) {
-				configuredCronJob, failureCount := t.configureWorkload(sdCopy, sdCronJob, ownerReferences)
-				failureCounter += failureCount
-				_, err = t.clientset.BatchV1beta1().CronJobs(sdCopy.GetNamespace()).Create(context.TODO(), configuredCronJob.(*batchv1beta.CronJob), metav1.CreateOptions{})
-				if err != nil {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["cronjob-creation-failure"], sdCronJob.GetName()))
-					failureCounter++
-				}
-			} else {
-				underControl := checkOwnerReferences(sdCopy, cronjobObj.GetOwnerReferences())
-				if !underControl {
-					// Configure the cronjob according to the SD
-					configuredCronJob, failureCount := t.configureWorkload(sdCopy, sdCronJob, ownerReferences)
-					failureCounter += failureCount
-					_, err = t.clientset.BatchV1beta1().CronJobs(sdCopy.GetNamespace()).Update(context.TODO(), configuredCronJob.(*batchv1beta.CronJob), metav1.UpdateOptions{})
-					if err != nil {
-						sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["cronjob-creation-failure"], sdCronJob.GetName()))
-						failureCounter++
-					}
-				} else {
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["cronjob-in-use"], sdCronJob.GetName()))
-					failureCounter++
-				}
-			}
+		if kind.hasReplicas() {
+			t.pruneRegionalCopies(ctx, sdCopy, kind, workloadRow.GetName(), nil)
 		}
+		_, failureCount, _ := t.applyWorkload(ctx, sdCopy, kind, workloadRow, ownerReferences)
+		failureCounter += failureCount
+		t.reportSpread(ctx, sdCopy, workloadRow.GetName(), getSelector(kind, workloadRow))
 	}
 
 	if failureCounter == 0 && workloadCounter != 0 {
@@ -331,265 +220,274 @@ func (t *SDHandler) applyCriteria(sdCopy *apps_v1alpha.SelectiveDeployment, even
 		sdCopy.Status.State = partial
 	}
 	sdCopy.Status.Ready = fmt.Sprintf("%d/%d", (workloadCounter - failureCounter), workloadCounter)
+	setConditions(sdCopy, *oldStatus.ConditionedStatus.DeepCopy(), workloadCounter, failureCounter)
+	t.recordReadiness(sdCopy, oldStatus.ConditionedStatus)
 }
 
-// configureWorkload manipulate the workload by selectivedeployments to match the desired state that users supplied
-func (t *SDHandler) configureWorkload(sdCopy *apps_v1alpha.SelectiveDeployment, workloadRow interface{}, ownerReferences []metav1.OwnerReference) (interface{}, int) {
-	log.Info("configureWorkload: start")
-	nodeSelectorTermList, failureCount := t.setFilter(sdCopy, "addOrUpdate")
-	// Set the new node affinity configuration for the workload and update that
-	nodeAffinity := &corev1.NodeAffinity{
-		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-			NodeSelectorTerms: nodeSelectorTermList,
-		},
-	}
-	if len(nodeSelectorTermList) <= 0 {
-		affinity := &corev1.Affinity{
-			NodeAffinity: &corev1.NodeAffinity{
-				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-					NodeSelectorTerms: nodeSelectorTermList,
-				},
-			},
+// recordReadiness records an event when the selective deployment becomes ready or the reason it is not ready changes
+func (t *SDHandler) recordReadiness(sdCopy *apps_v1alpha.SelectiveDeployment, previous apps_v1alpha.ConditionedStatus) {
+	ready := sdCopy.Status.GetCondition(apps_v1alpha.ConditionReady)
+	if ready == nil {
+		return
+	}
+	if before := previous.GetCondition(apps_v1alpha.ConditionReady); before != nil && before.Status == ready.Status && before.Reason == ready.Reason {
+		return
+	}
+	if ready.Status == metav1.ConditionTrue || ready.Reason == apps_v1alpha.ReasonDryRun {
+		t.recorder.Event(sdCopy, corev1.EventTypeNormal, ready.Reason, ready.Message)
+	} else {
+		t.recorder.Event(sdCopy, corev1.EventTypeWarning, ready.Reason, ready.Message)
+	}
+}
+
+// reportIssue appends the message to the status and sets the condition to false for the reason.
+// A fewer nodes issue prevails over the others as it tells the recovery to act on the selective deployment.
+func reportIssue(sdCopy *apps_v1alpha.SelectiveDeployment, conditionType, reason, message string) {
+	sdCopy.Status.Message = append(sdCopy.Status.Message, message)
+	if condition := sdCopy.Status.GetCondition(conditionType); condition == nil || (reason == apps_v1alpha.ReasonFewerNodes && condition.Reason != reason) {
+		sdCopy.Status.SetCondition(sdCopy.GetGeneration(), conditionType, metav1.ConditionFalse, reason, message)
+	}
+}
+
+// setConditions sets the ready condition according to the outcome, and merges the conditions of this pass into
+// the previous ones so that the transition times only change when a condition does
+func setConditions(sdCopy *apps_v1alpha.SelectiveDeployment, previous apps_v1alpha.ConditionedStatus, workloadCounter, failureCounter int) {
+	generation := sdCopy.GetGeneration()
+	for _, conditionType := range []string{apps_v1alpha.ConditionWorkloadsCreated, apps_v1alpha.ConditionNodesSelected} {
+		if sdCopy.Status.GetCondition(conditionType) == nil {
+			sdCopy.Status.SetCondition(generation, conditionType, metav1.ConditionTrue, apps_v1alpha.ReasonReconciled, "")
 		}
-		affinity.Reset()
-	}
-	var workloadCopy interface{}
-	switch workloadObj := workloadRow.(type) {
-	case appsv1.Deployment:
-		if len(nodeSelectorTermList) <= 0 && workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.Reset()
-		} else if workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.NodeAffinity = nodeAffinity
-		} else {
-			workloadObj.Spec.Template.Spec.Affinity = &corev1.Affinity{
-				NodeAffinity: nodeAffinity,
+	}
+	switch {
+	case failureCounter == 0 && workloadCounter != 0:
+		sdCopy.Status.SetReady(generation, true, apps_v1alpha.ReasonWorkloadsRunning, statusDict["sd-success"])
+	case workloadCounter == 0:
+		sdCopy.Status.SetReady(generation, false, apps_v1alpha.ReasonNoWorkloads, statusDict["workloads-empty"])
+	default:
+		// The first issue reported explains why the selective deployment is not ready
+		for _, conditionType := range []string{apps_v1alpha.ConditionWorkloadsCreated, apps_v1alpha.ConditionNodesSelected} {
+			if condition := sdCopy.Status.GetCondition(conditionType); condition.Status == metav1.ConditionFalse {
+				sdCopy.Status.SetReady(generation, false, condition.Reason, condition.Message)
+				break
 			}
 		}
-		workloadObj.ObjectMeta.OwnerReferences = ownerReferences
-		//log.Printf("%s/Deployment/%s: %s", workloadObj.GetNamespace(), workloadObj.GetName(), nodeAffinity)
-		workloadCopy = workloadObj.DeepCopy()
-		//t.clientset.AppsV1().Deployments(sdCopy.GetNamespace()).Update(workloadCopy)
-	case appsv1.DaemonSet:
-		if len(nodeSelectorTermList) <= 0 && workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.Reset()
-		} else if workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.NodeAffinity = nodeAffinity
-		} else {
-			workloadObj.Spec.Template.Spec.Affinity = &corev1.Affinity{
-				NodeAffinity: nodeAffinity,
-			}
+	}
+	mergeConditions(sdCopy, previous)
+}
+
+// mergeConditions merges the conditions of this pass into the previous ones
+func mergeConditions(sdCopy *apps_v1alpha.SelectiveDeployment, previous apps_v1alpha.ConditionedStatus) {
+	for _, condition := range sdCopy.Status.Conditions {
+		previous.SetCondition(sdCopy.GetGeneration(), condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+	sdCopy.Status.ConditionedStatus = previous
+}
+
+// preview resolves the selectors into the status without touching the workloads. Each selector gets the nodes it picks,
+// along with the shortfall if they are fewer than its quantity. The issues met are reported as they are in a real run.
+func (t *SDHandler) preview(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, previous apps_v1alpha.ConditionedStatus) {
+	nodeSelectorTermList, preferredTermList, _ := t.setFilter(ctx, sdCopy, "addOrUpdate")
+	required, preferred := 0, 0
+	for i, selectorRow := range sdCopy.Spec.Selector {
+		// The terms are in the order of the selectors, the preferred ones apart from the others
+		var nodes []string
+		if selectorRow.Preferred && preferred < len(preferredTermList) {
+			nodes = preferredTermList[preferred].Preference.MatchExpressions[0].Values
+			preferred++
+		} else if !selectorRow.Preferred && required < len(nodeSelectorTermList) {
+			nodes = nodeSelectorTermList[required].MatchExpressions[0].Values
+			required++
 		}
-		workloadObj.ObjectMeta.OwnerReferences = ownerReferences
-		//log.Printf("%s/DaemonSet/%s: %s", workloadObj.GetNamespace(), workloadObj.GetName(), nodeAffinity)
-		workloadCopy = workloadObj.DeepCopy()
-		//t.clientset.AppsV1().DaemonSets(sdCopy.GetNamespace()).Update(workloadCopy)
-	case appsv1.StatefulSet:
-		if len(nodeSelectorTermList) <= 0 && workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.Reset()
-		} else if workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.NodeAffinity = nodeAffinity
-		} else {
-			workloadObj.Spec.Template.Spec.Affinity = &corev1.Affinity{
-				NodeAffinity: nodeAffinity,
-			}
+		selectorPreview := apps_v1alpha.SelectorPreview{Selector: i, Name: selectorRow.Name, Nodes: append([]string{}, nodes...), Count: len(nodes)}
+		if selectorRow.Quantity > len(nodes) {
+			selectorPreview.Shortfall = selectorRow.Quantity - len(nodes)
 		}
-		workloadObj.ObjectMeta.OwnerReferences = ownerReferences
-		//log.Printf("%s/StatefulSet/%s: %s", workloadObj.GetNamespace(), workloadObj.GetName(), nodeAffinity)
-		workloadCopy = workloadObj.DeepCopy()
-		//t.clientset.AppsV1().StatefulSets(sdCopy.GetNamespace()).Update(workloadCopy)
-	case batchv1.Job:
-		if len(nodeSelectorTermList) <= 0 && workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.Reset()
-		} else if workloadObj.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.Template.Spec.Affinity.NodeAffinity = nodeAffinity
-		} else {
-			workloadObj.Spec.Template.Spec.Affinity = &corev1.Affinity{
-				NodeAffinity: nodeAffinity,
-			}
+		sdCopy.Status.Preview = append(sdCopy.Status.Preview, selectorPreview)
+	}
+	sdCopy.Status.State = dryRun
+	sdCopy.Status.Message = append([]string{statusDict["sd-dry-run"]}, sdCopy.Status.Message...)
+	generation := sdCopy.GetGeneration()
+	if sdCopy.Status.GetCondition(apps_v1alpha.ConditionNodesSelected) == nil {
+		sdCopy.Status.SetCondition(generation, apps_v1alpha.ConditionNodesSelected, metav1.ConditionTrue, apps_v1alpha.ReasonReconciled, "")
+	}
+	sdCopy.Status.SetReady(generation, false, apps_v1alpha.ReasonDryRun, statusDict["sd-dry-run"])
+	mergeConditions(sdCopy, previous)
+}
+
+// Preview resolves the selectors of the selective deployment against the schedulable nodes of the cluster as the dry run
+// mode does, and returns the status that the selective deployment would get. It writes nothing to the cluster, so that
+// a command line tool can preview a selective deployment before creating it.
+func Preview(ctx context.Context, clientset kubernetes.Interface, sdObj *apps_v1alpha.SelectiveDeployment) (apps_v1alpha.SelectiveDeploymentStatus, error) {
+	nodesRaw, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{FieldSelector: "spec.unschedulable!=true"})
+	if err != nil {
+		return apps_v1alpha.SelectiveDeploymentStatus{}, err
+	}
+	t := &SDHandler{clientset: clientset, nodes: newNodeIndexFromList(nodesRaw.Items)}
+	sdCopy := sdObj.DeepCopy()
+	sdCopy.Status = apps_v1alpha.SelectiveDeploymentStatus{}
+	t.preview(ctx, sdCopy, apps_v1alpha.ConditionedStatus{})
+	return sdCopy.Status, nil
+}
+
+// applyWorkload creates the workload, or updates it unless another selective deployment controls it. It returns
+// the workload as it was before, if any, the number of failures, and whether the workload is applied.
+func (t *SDHandler) applyWorkload(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadRow *unstructured.Unstructured, ownerReferences []metav1.OwnerReference) (*unstructured.Unstructured, int, bool) {
+	workloadClient := t.dynamicClientset.Resource(kind.groupVersionResource()).Namespace(sdCopy.GetNamespace())
+	workloadObj, err := workloadClient.Get(ctx, workloadRow.GetName(), metav1.GetOptions{})
+	if err == nil && checkOwnerReferences(sdCopy, workloadObj.GetOwnerReferences()) {
+		reportIssue(sdCopy, apps_v1alpha.ConditionWorkloadsCreated, apps_v1alpha.ReasonWorkloadInUse, fmt.Sprintf(statusDict["workload-in-use"], kind.Kind, workloadRow.GetName()))
+		return workloadObj, 1, false
+	}
+	// Configure the workload according to the SD
+	configuredWorkload, failureCount := t.configureWorkload(ctx, sdCopy, kind, workloadRow, ownerReferences)
+	if errors.IsNotFound(err) {
+		workloadObj = nil
+		_, err = workloadClient.Create(ctx, configuredWorkload, metav1.CreateOptions{})
+	} else if err == nil {
+		configuredWorkload.SetResourceVersion(workloadObj.GetResourceVersion())
+		_, err = workloadClient.Update(ctx, configuredWorkload, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		log.Println(err.Error())
+		reportIssue(sdCopy, apps_v1alpha.ConditionWorkloadsCreated, apps_v1alpha.ReasonWorkloadCreationFailed, fmt.Sprintf(statusDict["workload-creation-failure"], kind.Kind, workloadRow.GetName()))
+		return workloadObj, failureCount + 1, false
+	}
+	return workloadObj, failureCount, true
+}
+
+// configureWorkload manipulate the workload by selectivedeployments to match the desired state that users supplied
+func (t *SDHandler) configureWorkload(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadRow *unstructured.Unstructured, ownerReferences []metav1.OwnerReference) (*unstructured.Unstructured, int) {
+	log.Info("configureWorkload: start")
+	nodeSelectorTermList, preferredTermList, failureCount := t.setFilter(ctx, sdCopy, "addOrUpdate")
+	workloadCopy := workloadRow.DeepCopy()
+	nodeSelectorTermList = t.tolerateLimitations(ctx, sdCopy, kind, workloadCopy, nodeSelectorTermList)
+	nodeAffinityFields := fields(kind.PodSpec, "affinity", "nodeAffinity")
+	if len(nodeSelectorTermList)+len(preferredTermList) <= 0 {
+		unstructured.RemoveNestedField(workloadCopy.Object, nodeAffinityFields...)
+	} else {
+		// Set the new node affinity configuration for the workload and update that,
+		// the preferred selectors make the soft terms that the scheduler weighs
+		nodeAffinity := &corev1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: preferredTermList,
 		}
-		workloadObj.ObjectMeta.OwnerReferences = ownerReferences
-		//log.Printf("%s/Job/%s: %s", workloadObj.GetNamespace(), workloadObj.GetName(), nodeAffinity)
-		workloadCopy = workloadObj.DeepCopy()
-		//t.clientset.BatchV1().Jobs(sdCopy.GetNamespace()).Update(workloadCopy)
-	case batchv1beta.CronJob:
-		if len(nodeSelectorTermList) <= 0 && workloadObj.Spec.JobTemplate.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.JobTemplate.Spec.Template.Spec.Affinity.Reset()
-		} else if workloadObj.Spec.JobTemplate.Spec.Template.Spec.Affinity != nil {
-			workloadObj.Spec.JobTemplate.Spec.Template.Spec.Affinity.NodeAffinity = nodeAffinity
-		} else {
-			workloadObj.Spec.JobTemplate.Spec.Template.Spec.Affinity = &corev1.Affinity{
-				NodeAffinity: nodeAffinity,
+		if len(nodeSelectorTermList) > 0 {
+			nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+				NodeSelectorTerms: nodeSelectorTermList,
 			}
 		}
-		workloadObj.ObjectMeta.OwnerReferences = ownerReferences
-		//log.Printf("%s/CronJob/%s: %s", workloadObj.GetNamespace(), workloadObj.GetName(), nodeAffinity)
-		workloadCopy = workloadObj.DeepCopy()
-		//t.clientset.BatchV1beta1().CronJob(sdCopy.GetNamespace()).Update(workloadCopy)
+		if err := setNestedObject(workloadCopy.Object, nodeAffinity, nodeAffinityFields...); err != nil {
+			log.Println(err.Error())
+		}
 	}
+	if kind.hasReplicas() {
+		spreadReplicas(sdCopy, kind, workloadCopy)
+	}
+	workloadCopy.SetOwnerReferences(ownerReferences)
 	return workloadCopy, failureCount
 }
 
 // setFilter generates the values in the predefined form and puts those into the node selection fields of the selectivedeployment object
-func (t *SDHandler) setFilter(sdCopy *apps_v1alpha.SelectiveDeployment, event string) ([]corev1.NodeSelectorTerm, int) {
+func (t *SDHandler) setFilter(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, event string) ([]corev1.NodeSelectorTerm, []corev1.PreferredSchedulingTerm, int) {
 	var nodeSelectorTermList []corev1.NodeSelectorTerm
+	var preferredTermList []corev1.PreferredSchedulingTerm
 	failureCounter := 0
-	for _, selectorRow := range sdCopy.Spec.Selector {
+	// nextTransition is when the time window of a node opens or closes next, which changes the nodes selected
+	var nextTransition time.Time
+	// The filter is set for each workload, the nodes picked by distance are the same each time
+	sdCopy.Status.Nodes = nil
+	// If the event type is delete then we don't need to look for the nodes
+	var nodes *nodeIndex
+	// The limited nodes are left out unless their limitations permit the namespace of the selective deployment
+	var namespace *corev1.Namespace
+	if event != "delete" {
+		nodes = t.getNodeIndex(ctx)
+		namespace = t.getNamespace(ctx, sdCopy)
+	}
+	for selectorIndex, selectorRow := range sdCopy.Spec.Selector {
 		var matchExpression corev1.NodeSelectorRequirement
 		matchExpression.Values = []string{}
 		matchExpression.Operator = selectorRow.Operator
 		matchExpression.Key = "kubernetes.io/hostname"
 		selectorName := strings.ToLower(selectorRow.Name)
+		in := selectorRow.Operator == "In"
+		counter := 0
+		// pick adds the node to the selection unless it is there already, it returns false if the node is not added
+		// and whether the quantity is reached
+		pick := func(hostname string) (bool, bool) {
+			if util.Contains(matchExpression.Values, hostname) || !nodes.permits(hostname, namespace) {
+				return false, false
+			}
+			if len(selectorRow.Windows) != 0 && !windowOpen(nodes, hostname, selectorRow.Windows, &nextTransition) {
+				return false, false
+			}
+			matchExpression.Values = append(matchExpression.Values, hostname)
+			counter++
+			return true, selectorRow.Quantity != 0 && selectorRow.Quantity == counter
+		}
 		// Turn the key into the predefined form which is determined at the custom resource definition of selectivedeployment
 		switch selectorName {
-		case "city", "state", "country", "continent":
-			// If the event type is delete then we don't need to run the part below
+		case "city", "state", "country", "continent", "asn":
 			if event != "delete" {
-				labelKeySuffix := ""
-				if selectorName == "state" || selectorName == "country" {
-					labelKeySuffix = "-iso"
-				}
-				labelKey := strings.ToLower(fmt.Sprintf("edge-net.io/%s%s", selectorName, labelKeySuffix))
-				// This gets the node list which includes the EdgeNet geolabels
-				nodesRaw, err := t.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{FieldSelector: "spec.unschedulable!=true"})
-				if err != nil {
-					log.Println(err.Error())
-					panic(err.Error())
-				}
-				counter := 0
+				labelKey := geoLabelKey(selectorName)
 				// This loop allows us to process each value defined at the object of selectivedeployment resource
 			valueLoop:
 				for _, selectorValue := range selectorRow.Value {
-					// The loop to process each node separately
-					for _, nodeRow := range nodesRaw.Items {
-						taintBlock := false
-						for _, taint := range nodeRow.Spec.Taints {
-							if (taint.Key == "node-role.kubernetes.io/master" && taint.Effect == noSchedule) ||
-								(taint.Key == "node.kubernetes.io/unschedulable" && taint.Effect == noSchedule) {
-								taintBlock = true
-							}
+					for _, hostname := range nodes.withLabel(labelKey, geoLabelValue(selectorName, selectorValue), in) {
+						if _, done := pick(hostname); done {
+							break valueLoop
 						}
-						conditionBlock := false
-						if node.GetConditionReadyStatus(nodeRow.DeepCopy()) != trueStr {
-							conditionBlock = true
-						}
-
-						if !conditionBlock && !taintBlock {
-							if util.Contains(matchExpression.Values, nodeRow.Labels["kubernetes.io/hostname"]) {
-								continue
-							}
-							if selectorValue == nodeRow.Labels[labelKey] && selectorRow.Operator == "In" {
-								matchExpression.Values = append(matchExpression.Values, nodeRow.Labels["kubernetes.io/hostname"])
-								counter++
-							} else if selectorValue != nodeRow.Labels[labelKey] && selectorRow.Operator == "NotIn" {
-								matchExpression.Values = append(matchExpression.Values, nodeRow.Labels["kubernetes.io/hostname"])
-								counter++
-							}
-							if selectorRow.Quantity != 0 && selectorRow.Quantity == counter {
-								break valueLoop
-							}
-						}
-					}
-				}
-				if selectorRow.Quantity != 0 && selectorRow.Quantity > counter {
-					strLen := 16
-					strSuffix := "..."
-					if len(selectorRow.Value) <= strLen {
-						strLen = len(selectorRow.Value)
-						strSuffix = ""
 					}
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["nodes-fewer"], counter, selectorRow.Quantity, selectorRow.Value[0:strLen], strSuffix))
-					failureCounter++
 				}
+				failureCounter += reportFewerNodes(sdCopy, selectorRow, counter)
 			}
 		case "polygon":
-			// If the event type is delete then we don't need to run the GeoFence functions
 			if event != "delete" {
-				// If the selectivedeployment key is polygon then certain calculations like geofence need to be done
-				// for being had the list of nodes that the pods will be deployed on according to the desired state.
-				// This gets the node list which includes the EdgeNet geolabels
-				nodesRaw, err := t.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{FieldSelector: "spec.unschedulable!=true"})
-				if err != nil {
-					log.Println(err.Error())
-					panic(err.Error())
-				}
-
-				var polygon [][]float64
-				// This loop allows us to process each polygon defined at the object of selectivedeployment resource
-				counter := 0
+				// This loop allows us to process each region defined at the object of selectivedeployment resource,
+				// a region is a GeoJSON Polygon, MultiPolygon, Feature, or FeatureCollection
 			polyValueLoop:
 				for _, selectorValue := range selectorRow.Value {
-					err = json.Unmarshal([]byte(selectorValue), &polygon)
+					shape, err := geo.Parse([]byte(selectorValue))
 					if err != nil {
-						strLen := 16
+						strLen := 32
 						strSuffix := "..."
-						if len(selectorRow.Value) <= strLen {
-							strLen = len(selectorRow.Value)
+						if len(selectorValue) <= strLen {
+							strLen = len(selectorValue)
 							strSuffix = ""
 						}
-						sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["GeoJSON-err"], selectorValue[0:strLen], strSuffix))
+						reportIssue(sdCopy, apps_v1alpha.ConditionNodesSelected, apps_v1alpha.ReasonGeoJSONError, fmt.Sprintf(statusDict["GeoJSON-err"], selectorValue[0:strLen], strSuffix, err))
 						failureCounter++
 						continue
 					}
-					// The loop to process each node separately
-					for _, nodeRow := range nodesRaw.Items {
-						taintBlock := false
-						for _, taint := range nodeRow.Spec.Taints {
-							if (taint.Key == "node-role.kubernetes.io/master" && taint.Effect == noSchedule) ||
-								(taint.Key == "node.kubernetes.io/unschedulable" && taint.Effect == noSchedule) {
-								taintBlock = true
-							}
-						}
-						conditionBlock := false
-						for _, conditionRow := range nodeRow.Status.Conditions {
-							if conditionType := conditionRow.Type; conditionType == "Ready" {
-								if conditionRow.Status != trueStr {
-									conditionBlock = true
-								}
-							}
-						}
-						if !conditionBlock && !taintBlock {
-							if nodeRow.Labels["edge-net.io/lon"] != "" && nodeRow.Labels["edge-net.io/lat"] != "" {
-								if util.Contains(matchExpression.Values, nodeRow.Labels["kubernetes.io/hostname"]) {
-									continue
-								}
-								// Because of alphanumeric limitations of Kubernetes on the labels we use "w", "e", "n", and "s" prefixes
-								// at the labels of latitude and longitude. Here is the place those prefixes are dropped away.
-								lonStr := nodeRow.Labels["edge-net.io/lon"]
-								lonStr = string(lonStr[1:])
-								latStr := nodeRow.Labels["edge-net.io/lat"]
-								latStr = string(latStr[1:])
-								if lon, err := strconv.ParseFloat(lonStr, 64); err == nil {
-									if lat, err := strconv.ParseFloat(latStr, 64); err == nil {
-										// boundbox is a rectangle which provides to check whether the point is inside polygon
-										// without taking all point of the polygon into consideration
-										boundbox := node.Boundbox(polygon)
-										status := node.GeoFence(boundbox, polygon, lon, lat)
-										if status && selectorRow.Operator == "In" {
-											matchExpression.Values = append(matchExpression.Values, nodeRow.Labels["kubernetes.io/hostname"])
-											counter++
-										} else if !status && selectorRow.Operator == "NotIn" {
-											matchExpression.Values = append(matchExpression.Values, nodeRow.Labels["kubernetes.io/hostname"])
-											counter++
-										}
-									}
-								}
-							}
-							if selectorRow.Quantity != 0 && selectorRow.Quantity == counter {
-								break polyValueLoop
-							}
+					for _, hostname := range nodes.inShape(shape, in) {
+						if _, done := pick(hostname); done {
+							break polyValueLoop
 						}
 					}
 				}
-				if selectorRow.Quantity != 0 && selectorRow.Quantity > counter {
-					strLen := 16
-					strSuffix := "..."
-					if len(selectorRow.Value) <= strLen {
-						strLen = len(selectorRow.Value)
-						strSuffix = ""
+				failureCounter += reportFewerNodes(sdCopy, selectorRow, counter)
+			}
+		case "radius", "nearest":
+			if event != "delete" {
+			distanceValueLoop:
+				for _, selectorValue := range selectorRow.Value {
+					candidates, err := nodes.byDistance(selectorName, selectorValue, selectorRow.Operator)
+					if err != nil {
+						reportIssue(sdCopy, apps_v1alpha.ConditionNodesSelected, apps_v1alpha.ReasonInvalidSelectorValue, fmt.Sprintf(statusDict["selector-value-err"], selectorValue, selectorName, err))
+						failureCounter++
+						continue
+					}
+					for _, candidate := range candidates {
+						added, done := pick(candidate.Name)
+						if added {
+							candidate.Selector = selectorIndex
+							sdCopy.Status.Nodes = append(sdCopy.Status.Nodes, candidate)
+						}
+						if done {
+							break distanceValueLoop
+						}
 					}
-					sdCopy.Status.Message = append(sdCopy.Status.Message, fmt.Sprintf(statusDict["nodes-fewer"], counter, selectorRow.Quantity, selectorRow.Value[0:strLen], strSuffix))
-					failureCounter++
 				}
+				failureCounter += reportFewerNodes(sdCopy, selectorRow, counter)
 			}
 		default:
 			matchExpression.Key = ""
@@ -597,9 +495,49 @@ func (t *SDHandler) setFilter(sdCopy *apps_v1alpha.SelectiveDeployment, event st
 
 		var nodeSelectorTerm corev1.NodeSelectorTerm
 		nodeSelectorTerm.MatchExpressions = append(nodeSelectorTerm.MatchExpressions, matchExpression)
+		if selectorRow.Preferred {
+			weight := selectorRow.Weight
+			if weight == 0 {
+				weight = defaultWeight
+			}
+			preferredTermList = append(preferredTermList, corev1.PreferredSchedulingTerm{Weight: weight, Preference: nodeSelectorTerm})
+			continue
+		}
 		nodeSelectorTermList = append(nodeSelectorTermList, nodeSelectorTerm)
 	}
-	return nodeSelectorTermList, failureCounter
+	if !nextTransition.IsZero() {
+		t.requeueAfter(sdCopy, nextTransition.Sub(now().Time))
+	}
+	return nodeSelectorTermList, preferredTermList, failureCounter
+}
+
+// getNodeIndex returns the index of the nodes that the node informer keeps up to date.
+// Outside of the controller, as in the tests calling the handler directly, it builds one from the node list.
+func (t *SDHandler) getNodeIndex(ctx context.Context) *nodeIndex {
+	if t.nodes != nil {
+		return t.nodes
+	}
+	nodesRaw, err := t.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{FieldSelector: "spec.unschedulable!=true"})
+	if err != nil {
+		log.Println(err.Error())
+		panic(err.Error())
+	}
+	return newNodeIndexFromList(nodesRaw.Items)
+}
+
+// reportFewerNodes reports the issue if the selector picked fewer nodes than the quantity, it returns the number of failures
+func reportFewerNodes(sdCopy *apps_v1alpha.SelectiveDeployment, selectorRow apps_v1alpha.Selector, counter int) int {
+	if selectorRow.Quantity == 0 || selectorRow.Quantity <= counter {
+		return 0
+	}
+	strLen := 16
+	strSuffix := "..."
+	if len(selectorRow.Value) <= strLen {
+		strLen = len(selectorRow.Value)
+		strSuffix = ""
+	}
+	reportIssue(sdCopy, apps_v1alpha.ConditionNodesSelected, apps_v1alpha.ReasonFewerNodes, fmt.Sprintf(statusDict["nodes-fewer"], counter, selectorRow.Quantity, selectorRow.Value[0:strLen], strSuffix))
+	return 1
 }
 
 // SetAsOwnerReference returns the authority as owner
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectivedeployment
+
+import (
+	"context"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// getNamespace returns the namespace of the selective deployment, which the limitations of the contributed nodes go by.
+// It returns nil if the namespace cannot be found, and such a selective deployment may use no limited node.
+func (t *SDHandler) getNamespace(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment) *corev1.Namespace {
+	namespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, sdCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	return namespace
+}
+
+// tolerateLimitations lets the workload run on the limited nodes that the namespace of the selective deployment may use.
+// The workload tolerates the taint of the limited nodes, so the required terms keep it off those that the namespace may
+// not use. The selectors leave them out already, yet a NotIn operator or a selector of no known name would let them in.
+func (t *SDHandler) tolerateLimitations(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadCopy *unstructured.Unstructured,
+	nodeSelectorTermList []corev1.NodeSelectorTerm) []corev1.NodeSelectorTerm {
+	permitted, forbidden := t.getNodeIndex(ctx).limited(t.getNamespace(ctx, sdCopy))
+	if len(permitted) == 0 {
+		return nodeSelectorTermList
+	}
+	tolerationFields := fields(kind.PodSpec, "tolerations")
+	tolerations, _, _ := unstructured.NestedSlice(workloadCopy.Object, tolerationFields...)
+	tolerated := false
+	for _, toleration := range tolerations {
+		if tolerationMap, ok := toleration.(map[string]interface{}); ok && tolerationMap["key"] == node.LimitedKey {
+			tolerated = true
+		}
+	}
+	if !tolerated {
+		toleration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.Toleration{Key: node.LimitedKey, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule})
+		if err == nil {
+			err = unstructured.SetNestedSlice(workloadCopy.Object, append(tolerations, toleration), tolerationFields...)
+		}
+		if err != nil {
+			log.Println(err.Error())
+			return nodeSelectorTermList
+		}
+	}
+	if len(forbidden) == 0 {
+		return nodeSelectorTermList
+	}
+	exclusion := corev1.NodeSelectorRequirement{Key: "kubernetes.io/hostname", Operator: corev1.NodeSelectorOpNotIn, Values: forbidden}
+	if len(nodeSelectorTermList) == 0 {
+		return []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{exclusion}}}
+	}
+	limitedTermList := make([]corev1.NodeSelectorTerm, 0, len(nodeSelectorTermList))
+	for _, nodeSelectorTerm := range nodeSelectorTermList {
+		nodeSelectorTerm.MatchExpressions = append(append([]corev1.NodeSelectorRequirement{}, nodeSelectorTerm.MatchExpressions...), exclusion)
+		limitedTermList = append(limitedTermList, nodeSelectorTerm)
+	}
+	return limitedTermList
+}
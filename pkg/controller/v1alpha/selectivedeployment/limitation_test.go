@@ -0,0 +1,71 @@
+package selectivedeployment
+
+import (
+	"context"
+	"testing"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLimitations(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
+	// Creating nodes, one of which is open to all while the others are limited to a slice each
+	nodes := map[string]string{
+		"edgenet.planet-lab.eu":     "",
+		"ple.edge-net.io":           `[{"authority":"edgenet","slice":"experiment"}]`,
+		"paris-limited.edge-net.io": `[{"slice":"another"}]`,
+	}
+	for name, limitations := range nodes {
+		nodeObj := g.nodeObj
+		nodeObj.SetName(name)
+		nodeObj.ObjectMeta.Labels = map[string]string{"kubernetes.io/hostname": name, "edge-net.io/country-iso": "FR"}
+		if limitations != "" {
+			nodeObj.ObjectMeta.Annotations = map[string]string{node.LimitationsAnnotation: limitations}
+			nodeObj.Spec.Taints = []corev1.Taint{{Key: node.LimitedKey, Effect: corev1.TaintEffectNoSchedule}}
+		}
+		g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj.DeepCopy(), metav1.CreateOptions{})
+	}
+	sliceNamespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "authority-edgenet-slice-experiment",
+		Labels: map[string]string{"owner": "slice", "owner-name": "experiment", "authority-name": "edgenet"}}}
+	g.client.CoreV1().Namespaces().Create(context.TODO(), sliceNamespace.DeepCopy(), metav1.CreateOptions{})
+
+	// apply runs a selective deployment that picks the nodes in France in the namespace, and returns its deployment
+	apply := func(namespace string) *appsv1.Deployment {
+		sdObj := g.sdObj.DeepCopy()
+		sdObj.SetNamespace(namespace)
+		sdObj.Spec.Workloads = apps_v1alpha.Workloads{Deployment: sdObj.Spec.Workloads.Deployment}
+		sdObj.Spec.Workloads.Deployment[0].SetNamespace(namespace)
+		sdObj.Spec.Selector = []apps_v1alpha.Selector{{Name: "Country", Value: []string{"FR"}, Operator: "In"}}
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments(namespace).Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
+		g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
+		deploymentCopy, err := g.client.AppsV1().Deployments(namespace).Get(context.TODO(), "default", metav1.GetOptions{})
+		util.OK(t, err)
+		return deploymentCopy
+	}
+
+	t.Run("other namespace", func(t *testing.T) {
+		deploymentCopy := apply("")
+		podSpec := deploymentCopy.Spec.Template.Spec
+		util.Equals(t, 1, len(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms))
+		util.Equals(t, []string{"edgenet.planet-lab.eu"}, podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+		util.Equals(t, 0, len(podSpec.Tolerations))
+	})
+	t.Run("slice namespace", func(t *testing.T) {
+		deploymentCopy := apply(sliceNamespace.GetName())
+		podSpec := deploymentCopy.Spec.Template.Spec
+		matchExpressions := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions
+		util.Equals(t, []string{"edgenet.planet-lab.eu", "ple.edge-net.io"}, matchExpressions[0].Values)
+		// The toleration would let the workload onto the node of the other slice if it were not left out
+		util.Equals(t, corev1.NodeSelectorOpNotIn, matchExpressions[1].Operator)
+		util.Equals(t, []string{"paris-limited.edge-net.io"}, matchExpressions[1].Values)
+		util.Equals(t, []corev1.Toleration{{Key: node.LimitedKey, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}, podSpec.Tolerations)
+	})
+}
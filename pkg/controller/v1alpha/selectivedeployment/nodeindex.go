@@ -0,0 +1,323 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectivedeployment
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/geo"
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The labels that the location selectors other than polygon look up, along with the autonomous system of the nodes
+var geoLabels = []string{geolabel.City, geolabel.State, geolabel.Country, geolabel.Continent, node.ASNLabel}
+
+// nodeIndex keeps the schedulable nodes in memory, indexed by location and by the geographic labels.
+// The node informer keeps it up to date, so that the selectors neither list the nodes from the API server
+// nor go through all of them for each value. The queries return hostnames in the order of the node names.
+type nodeIndex struct {
+	mutex     sync.RWMutex
+	hostnames map[string]string
+	// labels maps the label keys to the values, and the values to the names of the nodes having them
+	labels map[string]map[string]map[string]bool
+	// regions maps the names of the nodes to their geographic labels
+	regions   map[string]map[string]string
+	locations *geo.Index
+	// zones maps the hostnames to the time zones of the nodes, which the time windows of the selectors go by
+	zones map[string]*time.Location
+	// limitations maps the hostnames of the limited nodes to the limitations of their contributions
+	limitations map[string][]apps_v1alpha.Limitations
+}
+
+func newNodeIndex() *nodeIndex {
+	index := &nodeIndex{
+		hostnames:   map[string]string{},
+		labels:      map[string]map[string]map[string]bool{},
+		regions:     map[string]map[string]string{},
+		locations:   geo.NewIndex(geo.DefaultCellSize),
+		zones:       map[string]*time.Location{},
+		limitations: map[string][]apps_v1alpha.Limitations{},
+	}
+	for _, key := range geoLabels {
+		index.labels[key] = map[string]map[string]bool{}
+	}
+	return index
+}
+
+// newNodeIndexFromList builds an index out of a node list
+func newNodeIndexFromList(nodes []corev1.Node) *nodeIndex {
+	index := newNodeIndex()
+	for i := range nodes {
+		index.set(&nodes[i])
+	}
+	return index
+}
+
+// set adds or updates the node, a node that is not schedulable anymore leaves the index
+func (n *nodeIndex) set(nodeObj *corev1.Node) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.remove(nodeObj.GetName())
+	if !isSchedulable(nodeObj) {
+		return
+	}
+	name := nodeObj.GetName()
+	n.hostnames[name] = nodeObj.Labels["kubernetes.io/hostname"]
+	n.regions[name] = map[string]string{}
+	for _, key := range geoLabels {
+		if value, ok := nodeObj.Labels[key]; ok {
+			n.regions[name][key] = value
+			if n.labels[key][value] == nil {
+				n.labels[key][value] = map[string]bool{}
+			}
+			n.labels[key][value][name] = true
+		}
+	}
+	if location, ok := getNodeLocation(nodeObj); ok {
+		n.locations.Set(name, location)
+	}
+	if zone, err := node.GetTimeZone(nodeObj); err == nil {
+		n.zones[n.hostnames[name]] = zone
+	}
+	if limitations, err := node.GetLimitations(nodeObj); err != nil {
+		// A node whose limitations cannot be read is kept from all namespaces, as the taint already does
+		n.limitations[n.hostnames[name]] = []apps_v1alpha.Limitations{{}}
+	} else if len(limitations) != 0 {
+		n.limitations[n.hostnames[name]] = limitations
+	}
+}
+
+// delete removes the node from the index
+func (n *nodeIndex) delete(name string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.remove(name)
+}
+
+func (n *nodeIndex) remove(name string) {
+	if _, exists := n.hostnames[name]; !exists {
+		return
+	}
+	delete(n.zones, n.hostnames[name])
+	delete(n.limitations, n.hostnames[name])
+	delete(n.hostnames, name)
+	for key, value := range n.regions[name] {
+		delete(n.labels[key][value], name)
+		if len(n.labels[key][value]) == 0 {
+			delete(n.labels[key], value)
+		}
+	}
+	delete(n.regions, name)
+	n.locations.Delete(name)
+}
+
+// region returns the value of the geographic label that the node has, or an empty string if the node is not in the index
+func (n *nodeIndex) region(name, key string) string {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return n.regions[name][key]
+}
+
+// zone returns the time zone of the node that has the hostname, or nil if the node has none
+func (n *nodeIndex) zone(hostname string) *time.Location {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return n.zones[hostname]
+}
+
+// permits returns whether the limitations of the node that has the hostname let the namespace use it
+func (n *nodeIndex) permits(hostname string, namespace *corev1.Namespace) bool {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return node.Permits(n.limitations[hostname], namespace)
+}
+
+// limited returns the hostnames of the limited nodes that the namespace may use, and of those that it may not
+func (n *nodeIndex) limited(namespace *corev1.Namespace) ([]string, []string) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	permitted, forbidden := map[string]bool{}, map[string]bool{}
+	for name, hostname := range n.hostnames {
+		limitations, ok := n.limitations[hostname]
+		if !ok {
+			continue
+		}
+		if node.Permits(limitations, namespace) {
+			permitted[name] = true
+		} else {
+			forbidden[name] = true
+		}
+	}
+	return n.sortedHostnames(permitted), n.sortedHostnames(forbidden)
+}
+
+// withLabel returns the nodes whose label has the value, or the other nodes if in is false
+func (n *nodeIndex) withLabel(key, value string, in bool) []string {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	matching := n.labels[key][value]
+	if in {
+		return n.sortedHostnames(matching)
+	}
+	others := map[string]bool{}
+	for name := range n.hostnames {
+		if !matching[name] {
+			others[name] = true
+		}
+	}
+	return n.sortedHostnames(others)
+}
+
+// inShape returns the located nodes in the shape, or the located nodes out of it if in is false
+func (n *nodeIndex) inShape(shape *geo.Shape, in bool) []string {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	inside := map[string]bool{}
+	for _, name := range n.locations.SearchShape(shape) {
+		inside[name] = true
+	}
+	if in {
+		return n.sortedHostnames(inside)
+	}
+	outside := map[string]bool{}
+	for _, name := range n.locations.Names() {
+		if !inside[name] {
+			outside[name] = true
+		}
+	}
+	return n.sortedHostnames(outside)
+}
+
+// byDistance returns the nodes that a radius or nearest selector value picks, closest first, by the great-circle
+// distance between the point of the value and the location of the nodes. The NotIn operator picks the other nodes.
+func (n *nodeIndex) byDistance(selectorName, selectorValue string, operator corev1.NodeSelectorOperator) ([]apps_v1alpha.SelectedNode, error) {
+	var point geo.Point
+	var neighbours []geo.Neighbour
+	var err error
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	if selectorName == "radius" {
+		var radius float64
+		if point, radius, err = geo.ParseRadius(selectorValue); err != nil {
+			return nil, err
+		}
+		neighbours = n.locations.Within(point, radius)
+	} else {
+		var count int
+		if point, count, err = geo.ParseNearest(selectorValue); err != nil {
+			return nil, err
+		}
+		neighbours = n.locations.Nearest(point, count)
+	}
+	if operator != "In" {
+		// The whole list is needed for the nodes out of the selection
+		picked := map[string]bool{}
+		for _, neighbour := range neighbours {
+			picked[neighbour.Name] = true
+		}
+		var others []geo.Neighbour
+		for _, neighbour := range n.locations.Nearest(point, n.locations.Len()) {
+			if !picked[neighbour.Name] {
+				others = append(others, neighbour)
+			}
+		}
+		neighbours = others
+	}
+	selected := make([]apps_v1alpha.SelectedNode, 0, len(neighbours))
+	for _, neighbour := range neighbours {
+		selected = append(selected, apps_v1alpha.SelectedNode{Name: n.hostnames[neighbour.Name], Distance: int64(math.Round(neighbour.Distance))})
+	}
+	return selected, nil
+}
+
+func (n *nodeIndex) sortedHostnames(names map[string]bool) []string {
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	hostnames := make([]string, 0, len(sortedNames))
+	for _, name := range sortedNames {
+		hostnames = append(hostnames, n.hostnames[name])
+	}
+	return hostnames
+}
+
+// geoLabelKey returns the key of the label that holds the city, state, country, continent, or autonomous system of the nodes
+func geoLabelKey(level string) string {
+	switch strings.ToLower(level) {
+	case "city":
+		return geolabel.City
+	case "state":
+		return geolabel.State
+	case "country":
+		return geolabel.Country
+	case "continent":
+		return geolabel.Continent
+	}
+	return fmt.Sprintf("edge-net.io/%s", strings.ToLower(level))
+}
+
+// geoLabelValue returns the label value that the selector value stands for. The names are encoded as the labeler
+// encodes them, so Saint-Étienne selects the nodes labeled Saint-Etienne, and the autonomous system numbers
+// may come with the AS prefix that the labels leave out.
+func geoLabelValue(level, value string) string {
+	if strings.ToLower(level) == "asn" {
+		if asn, err := node.ParseASN(value); err == nil {
+			return strconv.FormatUint(uint64(asn), 10)
+		}
+		return value
+	}
+	return geolabel.Encode(value)
+}
+
+// getNodeLocation reads the location of the node from its labels
+func getNodeLocation(nodeObj *corev1.Node) (geo.Point, bool) {
+	var location geo.Point
+	var err error
+	if location.Lon, err = geolabel.DecodeLon(nodeObj.Labels[geolabel.Lon]); err != nil {
+		return location, false
+	}
+	if location.Lat, err = geolabel.DecodeLat(nodeObj.Labels[geolabel.Lat]); err != nil {
+		return location, false
+	}
+	return location, true
+}
+
+// isSchedulable returns whether the node is ready and open to the workloads
+func isSchedulable(nodeObj *corev1.Node) bool {
+	if nodeObj.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range nodeObj.Spec.Taints {
+		if (taint.Key == "node-role.kubernetes.io/master" && taint.Effect == noSchedule) ||
+			(taint.Key == "node.kubernetes.io/unschedulable" && taint.Effect == noSchedule) {
+			return false
+		}
+	}
+	return node.GetConditionReadyStatus(nodeObj) == trueStr
+}
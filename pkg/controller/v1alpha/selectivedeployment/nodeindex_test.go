@@ -0,0 +1,144 @@
+package selectivedeployment
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/geo"
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newIndexedNode returns a ready node with the geographic labels that the node controller sets
+func newIndexedNode(name, country string, lat, lon float64) *corev1.Node {
+	latStr, lonStr := fmt.Sprintf("n%f", lat), fmt.Sprintf("e%f", lon)
+	if lat < 0 {
+		latStr = fmt.Sprintf("s%f", lat)
+	}
+	if lon < 0 {
+		lonStr = fmt.Sprintf("w%f", lon)
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"kubernetes.io/hostname":  name,
+				"edge-net.io/country-iso": country,
+				"edge-net.io/lat":         latStr,
+				"edge-net.io/lon":         lonStr,
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: "Ready", Status: "True"}},
+		},
+	}
+}
+
+func TestNodeIndex(t *testing.T) {
+	index := newNodeIndex()
+	index.set(newIndexedNode("paris", "FR", 48.86, 2.34))
+	index.set(newIndexedNode("lyon", "FR", 45.76, 4.84))
+	index.set(newIndexedNode("richardson", "US", 32.77, -96.78))
+	shape, err := geo.Parse([]byte("[[2.2150567, 48.8947616], [2.2040704, 48.8084639], [2.3393396, 48.7835862], [2.4519494, 48.8416903], [2.3932412, 48.9171024]]"))
+	util.OK(t, err)
+
+	t.Run("label", func(t *testing.T) {
+		util.Equals(t, []string{"lyon", "paris"}, index.withLabel("edge-net.io/country-iso", "FR", true))
+		util.Equals(t, []string{"richardson"}, index.withLabel("edge-net.io/country-iso", "FR", false))
+		util.Equals(t, []string{}, index.withLabel("edge-net.io/country-iso", "DE", true))
+	})
+	t.Run("polygon", func(t *testing.T) {
+		util.Equals(t, []string{"paris"}, index.inShape(shape, true))
+		util.Equals(t, []string{"lyon", "richardson"}, index.inShape(shape, false))
+	})
+	t.Run("distance", func(t *testing.T) {
+		selected, err := index.byDistance("nearest", "48.8566,2.3522,2", "In")
+		util.OK(t, err)
+		util.Equals(t, 2, len(selected))
+		util.Equals(t, "lyon", selected[1].Name)
+		selected, err = index.byDistance("radius", "48.8566,2.3522,300km", "NotIn")
+		util.OK(t, err)
+		util.Equals(t, "lyon", selected[0].Name)
+		util.Equals(t, "richardson", selected[1].Name)
+	})
+	t.Run("asn", func(t *testing.T) {
+		richardson := newIndexedNode("richardson", "US", 32.77, -96.78)
+		richardson.Labels[node.ASNLabel] = "2200"
+		index.set(richardson)
+		util.Equals(t, []string{"richardson"}, index.withLabel(geoLabelKey("ASN"), geoLabelValue("ASN", "AS2200"), true))
+		util.Equals(t, []string{"lyon", "paris"}, index.withLabel(geoLabelKey("ASN"), geoLabelValue("ASN", "2200"), false))
+	})
+	t.Run("encoded", func(t *testing.T) {
+		// The selector values are encoded as the labels are
+		saintEtienne := newIndexedNode("saint-etienne", "FR", 45.43, 4.39)
+		saintEtienne.Labels[geolabel.City] = geolabel.Encode("Saint-Étienne")
+		index.set(saintEtienne)
+		util.Equals(t, []string{"saint-etienne"}, index.withLabel(geoLabelKey("City"), geoLabelValue("City", "Saint-Étienne"), true))
+		index.delete("saint-etienne")
+		// A coordinate that cannot be decoded leaves the node out of the locations
+		saintEtienne.Labels[geolabel.Lon] = "e-4.39"
+		_, located := getNodeLocation(saintEtienne)
+		util.Equals(t, false, located)
+	})
+	t.Run("update", func(t *testing.T) {
+		// A node leaves the index once it is not schedulable anymore, and comes back along with its new labels
+		paris := newIndexedNode("paris", "FR", 48.86, 2.34)
+		paris.Spec.Unschedulable = true
+		index.set(paris)
+		util.Equals(t, []string{"lyon"}, index.withLabel("edge-net.io/country-iso", "FR", true))
+		util.Equals(t, []string{}, index.inShape(shape, true))
+		index.set(newIndexedNode("paris", "DE", 48.86, 2.34))
+		util.Equals(t, []string{"paris"}, index.withLabel("edge-net.io/country-iso", "DE", true))
+		util.Equals(t, []string{"paris"}, index.inShape(shape, true))
+	})
+	t.Run("delete", func(t *testing.T) {
+		index.delete("lyon")
+		index.delete("unknown")
+		util.Equals(t, []string{}, index.withLabel("edge-net.io/country-iso", "FR", true))
+		util.Equals(t, 2, index.locations.Len())
+	})
+}
+
+// BenchmarkNodeSelection runs the selectors against 10k synthetic nodes spread over the globe
+func BenchmarkNodeSelection(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	countries := []string{"FR", "US", "DE", "JP", "BR", "TR", "AU", "CA"}
+	nodes := make([]corev1.Node, 10000)
+	for i := range nodes {
+		nodes[i] = *newIndexedNode(fmt.Sprintf("node-%d", i), countries[random.Intn(len(countries))], random.Float64()*180-90, random.Float64()*360-180)
+	}
+	index := newNodeIndexFromList(nodes)
+	shape, err := geo.Parse([]byte(`{"type": "Polygon", "coordinates": [[[-5, 42], [8, 42], [8, 51], [-5, 51], [-5, 42]]]}`))
+	util.OK(b, err)
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			newNodeIndexFromList(nodes)
+		}
+	})
+	b.Run("label", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			index.withLabel("edge-net.io/country-iso", "FR", true)
+		}
+	})
+	b.Run("polygon", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			index.inShape(shape, true)
+		}
+	})
+	b.Run("radius", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			index.byDistance("radius", "48.8566,2.3522,300km", "In")
+		}
+	})
+	b.Run("nearest", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			index.byDistance("nearest", "48.8566,2.3522,5", "In")
+		}
+	})
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectivedeployment
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+// The annotations of the regional copies that the rollouts keep track of the revisions with
+const (
+	// revisionAnnotation is the revision of the workloads that the copy runs
+	revisionAnnotation = "edge-net.io/sd-revision"
+	// The revision and the spec that the copy ran before the current revision reached it, which a rollback brings back
+	previousRevisionAnnotation = "edge-net.io/sd-previous-revision"
+	previousSpecAnnotation     = "edge-net.io/sd-previous-spec"
+)
+
+// rolloutCheckPeriod is how often a rollout in progress checks the readiness of its stage, in case no workload event does
+const rolloutCheckPeriod = 30 * time.Second
+
+// now is the clock of the bake times
+var now = metav1.Now
+
+// rolls returns whether the changes of the workloads reach the regions stage by stage
+func rolls(sdCopy *apps_v1alpha.SelectiveDeployment) bool {
+	return sdCopy.Spec.Rollout != nil && distributes(sdCopy)
+}
+
+// workloadRevision hashes the workloads of the spec, any change in them makes a new revision
+func workloadRevision(sdCopy *apps_v1alpha.SelectiveDeployment) string {
+	content, err := json.Marshal(sdCopy.Spec.Workloads)
+	if err != nil {
+		log.Println(err.Error())
+	}
+	hash := fnv.New32a()
+	hash.Write(content)
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
+
+// stageOf returns the index of the stage that lists the region, the regions that no stage lists are in the remaining stage
+func stageOf(rollout *apps_v1alpha.Rollout, region region) int {
+	for i, stage := range rollout.Stages {
+		for _, regionName := range stage.Regions {
+			if strings.EqualFold(regionName, region.name) {
+				return i
+			}
+		}
+	}
+	return len(rollout.Stages)
+}
+
+// stageName returns the name of the stage, or an empty string once the rollout is past the remaining stage
+func stageName(rollout *apps_v1alpha.Rollout, stage int) string {
+	switch {
+	case stage < len(rollout.Stages):
+		return rollout.Stages[stage].Name
+	case stage == len(rollout.Stages):
+		return apps_v1alpha.RolloutRemaining
+	}
+	return ""
+}
+
+// promotedStage returns the index of the stage that the spec promotes, or -1 if there is none
+func promotedStage(rollout *apps_v1alpha.Rollout) int {
+	if rollout.Promote == apps_v1alpha.RolloutRemaining {
+		return len(rollout.Stages)
+	}
+	for i, stage := range rollout.Stages {
+		if rollout.Promote != "" && stage.Name == rollout.Promote {
+			return i
+		}
+	}
+	return -1
+}
+
+// planRollout works out the stage that the rollout of the current revision reaches. A stage is over once the copies of
+// its regions run the revision with all their replicas ready for the bake time, or once the spec promotes a later stage.
+// A new revision starts over from the first stage, and so does the rollout after a rollback.
+func (t *SDHandler) planRollout(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, previous *apps_v1alpha.RolloutStatus, workloads []*unstructured.Unstructured) {
+	rollout := sdCopy.Spec.Rollout
+	rolloutStatus := &apps_v1alpha.RolloutStatus{Revision: workloadRevision(sdCopy)}
+	if previous != nil && previous.Revision == rolloutStatus.Revision {
+		rolloutStatus.Stage, rolloutStatus.BakeStarted = previous.Stage, previous.BakeStarted.DeepCopy()
+	}
+	regions := listRegions(sdCopy.Spec.Selector)
+	switch {
+	case rollout.Rollback:
+		rolloutStatus.Stage, rolloutStatus.BakeStarted = 0, nil
+		rolloutStatus.Phase = apps_v1alpha.RolloutRolledBack
+	case rollout.Paused:
+		rolloutStatus.Phase = apps_v1alpha.RolloutPaused
+	default:
+		rolloutStatus.Phase = apps_v1alpha.RolloutProgressing
+		for rolloutStatus.Stage <= len(rollout.Stages) {
+			if rolloutStatus.Stage > promotedStage(rollout) {
+				if !t.stageReady(ctx, sdCopy, rolloutStatus, regions, workloads) {
+					rolloutStatus.BakeStarted = nil
+					t.requeueAfter(sdCopy, rolloutCheckPeriod)
+					break
+				}
+				if rolloutStatus.BakeStarted == nil {
+					bakeStarted := now()
+					rolloutStatus.BakeStarted = &bakeStarted
+				}
+				if remaining := rollout.BakeTime.Duration - now().Sub(rolloutStatus.BakeStarted.Time); remaining > 0 {
+					rolloutStatus.Phase = apps_v1alpha.RolloutBaking
+					t.requeueAfter(sdCopy, remaining)
+					break
+				}
+			}
+			t.recorder.Eventf(sdCopy, corev1.EventTypeNormal, "RolloutStage", "The %s stage of the revision %s is over", stageName(rollout, rolloutStatus.Stage), rolloutStatus.Revision)
+			rolloutStatus.Stage++
+			rolloutStatus.BakeStarted = nil
+		}
+		if rolloutStatus.Stage > len(rollout.Stages) {
+			rolloutStatus.Phase = apps_v1alpha.RolloutCompleted
+		}
+	}
+	rolloutStatus.StageName = stageName(rollout, rolloutStatus.Stage)
+	sdCopy.Status.Rollout = rolloutStatus
+}
+
+// requeueAfter brings the selective deployment back to the queue after the duration if the controller runs the handler
+func (t *SDHandler) requeueAfter(sdCopy *apps_v1alpha.SelectiveDeployment, after time.Duration) {
+	if t.requeue != nil {
+		t.requeue(sdCopy, after)
+	}
+}
+
+// stageReady returns whether the copies of the regions in the current stage run the revision with all their replicas ready
+func (t *SDHandler) stageReady(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, rolloutStatus *apps_v1alpha.RolloutStatus, regions []region, workloads []*unstructured.Unstructured) bool {
+	for _, workloadRow := range workloads {
+		kind, ok := lookupWorkloadKind(workloadRow.GroupVersionKind())
+		if !ok || !kind.hasReplicas() {
+			continue
+		}
+		workloadClient := t.dynamicClientset.Resource(kind.groupVersionResource()).Namespace(sdCopy.GetNamespace())
+		for _, region := range regions {
+			if stageOf(sdCopy.Spec.Rollout, region) != rolloutStatus.Stage {
+				continue
+			}
+			workloadObj, err := workloadClient.Get(ctx, regionalName(workloadRow.GetName(), region), metav1.GetOptions{})
+			if err != nil || workloadObj.GetAnnotations()[revisionAnnotation] != rolloutStatus.Revision {
+				return false
+			}
+			if kind.ReadyReplicas != "" && readyReplicas(kind, workloadObj) < int64(sdCopy.Spec.Distribution.Replicas) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// rollRegion decides what the rollout does with the copy of a region. The copies that the current stage has not reached
+// keep running as they are, and a rollback brings the ones that the revision reached back to the spec they ran before.
+// It returns the copy and true if the rollout holds the copy back, along with the number of failures. The copies to apply
+// get the annotations that keep track of the revisions.
+func (t *SDHandler) rollRegion(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, region region, kind WorkloadKind, regionalWorkload *unstructured.Unstructured) (*unstructured.Unstructured, bool, int) {
+	rollout, rolloutStatus := sdCopy.Spec.Rollout, sdCopy.Status.Rollout
+	workloadClient := t.dynamicClientset.Resource(kind.groupVersionResource()).Namespace(sdCopy.GetNamespace())
+	workloadObj, err := workloadClient.Get(ctx, regionalWorkload.GetName(), metav1.GetOptions{})
+	if err != nil || !isOwnedBy(sdCopy, workloadObj.GetOwnerReferences()) {
+		// Nothing runs in the region yet, so the new copy runs the current revision right away
+		setAnnotations(regionalWorkload, map[string]string{revisionAnnotation: rolloutStatus.Revision})
+		return nil, false, 0
+	}
+	annotations := workloadObj.GetAnnotations()
+	revision := annotations[revisionAnnotation]
+	switch {
+	case rollout.Rollback && (revision != rolloutStatus.Revision || annotations[previousSpecAnnotation] == ""):
+		return workloadObj, true, 0
+	case rollout.Rollback:
+		return t.rollBack(ctx, sdCopy, kind, workloadObj)
+	case revision != rolloutStatus.Revision && stageOf(rollout, region) > rolloutStatus.Stage:
+		return workloadObj, true, 0
+	}
+	revisionAnnotations := map[string]string{revisionAnnotation: rolloutStatus.Revision}
+	if revision == rolloutStatus.Revision {
+		revisionAnnotations[previousRevisionAnnotation] = annotations[previousRevisionAnnotation]
+		revisionAnnotations[previousSpecAnnotation] = annotations[previousSpecAnnotation]
+	} else {
+		spec, _, _ := unstructured.NestedFieldNoCopy(workloadObj.Object, rootField(kind))
+		if content, err := json.Marshal(spec); err == nil {
+			revisionAnnotations[previousRevisionAnnotation] = revision
+			revisionAnnotations[previousSpecAnnotation] = string(content)
+		}
+	}
+	setAnnotations(regionalWorkload, revisionAnnotations)
+	return nil, false, 0
+}
+
+// rollBack brings the copy back to the spec and the revision it ran before the current revision
+func (t *SDHandler) rollBack(ctx context.Context, sdCopy *apps_v1alpha.SelectiveDeployment, kind WorkloadKind, workloadObj *unstructured.Unstructured) (*unstructured.Unstructured, bool, int) {
+	rolledBack := workloadObj.DeepCopy()
+	annotations := rolledBack.GetAnnotations()
+	var spec interface{}
+	err := json.Unmarshal([]byte(annotations[previousSpecAnnotation]), &spec)
+	if err == nil {
+		err = unstructured.SetNestedField(rolledBack.Object, spec, rootField(kind))
+	}
+	if err == nil {
+		annotations[revisionAnnotation] = annotations[previousRevisionAnnotation]
+		delete(annotations, previousRevisionAnnotation)
+		delete(annotations, previousSpecAnnotation)
+		if annotations[revisionAnnotation] == "" {
+			delete(annotations, revisionAnnotation)
+		}
+		rolledBack.SetAnnotations(annotations)
+		rolledBack, err = t.dynamicClientset.Resource(kind.groupVersionResource()).Namespace(sdCopy.GetNamespace()).Update(ctx, rolledBack, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		log.Println(err.Error())
+		reportIssue(sdCopy, apps_v1alpha.ConditionWorkloadsCreated, apps_v1alpha.ReasonWorkloadCreationFailed, fmt.Sprintf(statusDict["workload-creation-failure"], kind.Kind, workloadObj.GetName()))
+		return workloadObj, true, 1
+	}
+	return rolledBack, true, 0
+}
+
+// rootField returns the top field of the objects of the kind that holds the pod spec, which a rollback restores
+func rootField(kind WorkloadKind) string {
+	return fields(kind.PodSpec)[0]
+}
+
+// setAnnotations adds the annotations to the workload, the empty values are left out
+func setAnnotations(workloadObj *unstructured.Unstructured, values map[string]string) {
+	annotations := workloadObj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range values {
+		if value != "" {
+			annotations[key] = value
+		}
+	}
+	workloadObj.SetAnnotations(annotations)
+}
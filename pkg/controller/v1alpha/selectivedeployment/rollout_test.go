@@ -0,0 +1,130 @@
+package selectivedeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRollout(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
+	clock := time.Now()
+	now = func() metav1.Time { return metav1.NewTime(clock) }
+	defer func() { now = metav1.Now }()
+	// Creating nodes
+	nodes := map[string]map[string]string{
+		"edgenet.planet-lab.eu":  {"edge-net.io/city": "Paris", "edge-net.io/country-iso": "FR"},
+		"utdallas-1.edge-net.io": {"edge-net.io/city": "Richardson", "edge-net.io/country-iso": "US"},
+	}
+	for name, labels := range nodes {
+		nodeObj := g.nodeObj
+		nodeObj.SetName(name)
+		nodeObj.ObjectMeta.Labels = labels
+		nodeObj.ObjectMeta.Labels["kubernetes.io/hostname"] = name
+		g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj.DeepCopy(), metav1.CreateOptions{})
+	}
+
+	sdObj := g.sdObj.DeepCopy()
+	sdObj.Spec.Workloads = apps_v1alpha.Workloads{Deployment: sdObj.Spec.Workloads.Deployment}
+	sdObj.Spec.Selector = []apps_v1alpha.Selector{{Name: "Country", Value: []string{"FR", "US"}, Operator: "In"}}
+	sdObj.Spec.Distribution = &apps_v1alpha.Distribution{Mode: apps_v1alpha.DistributionPerValue, Replicas: 2}
+	sdObj.Spec.Rollout = &apps_v1alpha.Rollout{Stages: []apps_v1alpha.RolloutStage{{Name: "canary", Regions: []string{"FR"}}}}
+	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
+
+	// setReady marks all the replicas of the regional copies ready
+	setReady := func(names ...string) {
+		for _, name := range names {
+			deploymentCopy, _ := g.client.AppsV1().Deployments("").Get(context.TODO(), name, metav1.GetOptions{})
+			deploymentCopy.Status.ReadyReplicas = *deploymentCopy.Spec.Replicas
+			g.client.AppsV1().Deployments("").UpdateStatus(context.TODO(), deploymentCopy, metav1.UpdateOptions{})
+		}
+	}
+	// update applies the changes to the selective deployment and returns it as the handler leaves it
+	update := func(change func(sdCopy *apps_v1alpha.SelectiveDeployment)) *apps_v1alpha.SelectiveDeployment {
+		sdCopy, _ := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		change(sdCopy)
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+		sdCopy, _ = g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		return sdCopy
+	}
+	image := func(name string) string {
+		deploymentCopy, err := g.client.AppsV1().Deployments("").Get(context.TODO(), name, metav1.GetOptions{})
+		util.OK(t, err)
+		return deploymentCopy.Spec.Template.Spec.Containers[0].Image
+	}
+
+	t.Run("creation", func(t *testing.T) {
+		g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
+		// The regions get their copies at once as nothing runs in them yet
+		util.Equals(t, "nginx:1.7.9", image("default-fr"))
+		util.Equals(t, "nginx:1.7.9", image("default-us"))
+		setReady("default-fr", "default-us")
+		sdCopy := update(func(sdCopy *apps_v1alpha.SelectiveDeployment) {})
+		util.Equals(t, apps_v1alpha.RolloutCompleted, sdCopy.Status.Rollout.Phase)
+		util.Equals(t, 2, sdCopy.Status.Rollout.Stage)
+	})
+
+	t.Run("stages", func(t *testing.T) {
+		sdCopy := update(func(sdCopy *apps_v1alpha.SelectiveDeployment) {
+			sdCopy.Spec.Workloads.Deployment[0].Spec.Template.Spec.Containers[0].Image = "nginx:1.8.0"
+			sdCopy.Spec.Rollout.BakeTime = metav1.Duration{Duration: time.Hour}
+		})
+		util.Equals(t, apps_v1alpha.RolloutProgressing, sdCopy.Status.Rollout.Phase)
+		util.Equals(t, "canary", sdCopy.Status.Rollout.StageName)
+		util.Equals(t, "nginx:1.8.0", image("default-fr"))
+		util.Equals(t, "nginx:1.7.9", image("default-us"))
+		revision := sdCopy.Status.Rollout.Revision
+		util.Equals(t, revision, sdCopy.Status.Regions[0].Revision)
+		util.Equals(t, false, revision == sdCopy.Status.Regions[1].Revision)
+
+		setReady("default-fr")
+		sdCopy = update(func(sdCopy *apps_v1alpha.SelectiveDeployment) {})
+		util.Equals(t, apps_v1alpha.RolloutBaking, sdCopy.Status.Rollout.Phase)
+		util.Equals(t, "nginx:1.7.9", image("default-us"))
+
+		// The fake client drops the status of the copies that the handler updates, unlike the API server
+		setReady("default-fr")
+		clock = clock.Add(2 * time.Hour)
+		sdCopy = update(func(sdCopy *apps_v1alpha.SelectiveDeployment) {})
+		util.Equals(t, apps_v1alpha.RolloutProgressing, sdCopy.Status.Rollout.Phase)
+		util.Equals(t, apps_v1alpha.RolloutRemaining, sdCopy.Status.Rollout.StageName)
+		util.Equals(t, "nginx:1.8.0", image("default-us"))
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		sdCopy := update(func(sdCopy *apps_v1alpha.SelectiveDeployment) {
+			sdCopy.Spec.Rollout.Rollback = true
+		})
+		util.Equals(t, apps_v1alpha.RolloutRolledBack, sdCopy.Status.Rollout.Phase)
+		util.Equals(t, "nginx:1.7.9", image("default-fr"))
+		util.Equals(t, "nginx:1.7.9", image("default-us"))
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		sdCopy := update(func(sdCopy *apps_v1alpha.SelectiveDeployment) {
+			sdCopy.Spec.Rollout.Rollback = false
+			sdCopy.Spec.Rollout.Paused = true
+		})
+		util.Equals(t, apps_v1alpha.RolloutPaused, sdCopy.Status.Rollout.Phase)
+		util.Equals(t, 0, sdCopy.Status.Rollout.Stage)
+		util.Equals(t, "nginx:1.8.0", image("default-fr"))
+		util.Equals(t, "nginx:1.7.9", image("default-us"))
+	})
+
+	t.Run("promote", func(t *testing.T) {
+		sdCopy := update(func(sdCopy *apps_v1alpha.SelectiveDeployment) {
+			sdCopy.Spec.Rollout.Paused = false
+			sdCopy.Spec.Rollout.Promote = apps_v1alpha.RolloutRemaining
+		})
+		util.Equals(t, apps_v1alpha.RolloutCompleted, sdCopy.Status.Rollout.Phase)
+		util.Equals(t, "nginx:1.8.0", image("default-us"))
+	})
+}
@@ -2,6 +2,7 @@ package selectivedeployment
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
@@ -17,15 +18,20 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 type TestGroup struct {
 	client         kubernetes.Interface
 	edgenetClient  versioned.Interface
+	dynamicClient  dynamic.Interface
 	sdObj          apps_v1alpha.SelectiveDeployment
 	selector       apps_v1alpha.Selector
 	deploymentObj  appsv1.Deployment
@@ -320,7 +326,9 @@ func (g *TestGroup) Init() {
 	g.cronjobObj = cronjobObj
 	g.selector = selectorObj
 	g.sdObj = sdObj
-	g.client = testclient.NewSimpleClientset()
+	client := testclient.NewSimpleClientset()
+	g.client = client
+	g.dynamicClient = newDynamicClient(client)
 	g.edgenetClient = edgenettestclient.NewSimpleClientset()
 }
 
@@ -330,15 +338,16 @@ func TestHandlerInit(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
 	// Initialize the handler
-	g.handler.Init(g.client, g.edgenetClient)
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
 	util.Equals(t, g.client, g.handler.clientset)
 	util.Equals(t, g.edgenetClient, g.handler.edgenetClientset)
+	util.Equals(t, g.dynamicClient, g.handler.dynamicClientset)
 }
 
 func TestCreate(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
-	g.handler.Init(g.client, g.edgenetClient)
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
 	// Creating nodes
 	nodeParis := g.nodeObj
 	nodeParis.SetName("edgenet.planet-lab.eu")
@@ -416,7 +425,7 @@ func TestCreate(t *testing.T) {
 
 	// Invoke the create function
 	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(sdObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
 	sdCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
 	t.Run("status", func(t *testing.T) {
 		util.OK(t, err)
@@ -424,16 +433,24 @@ func TestCreate(t *testing.T) {
 		util.Equals(t, statusDict["sd-success"], sdCopy.Status.Message[0])
 		util.Equals(t, "10/10", sdCopy.Status.Ready)
 	})
+	t.Run("conditions", func(t *testing.T) {
+		util.Equals(t, metav1.ConditionTrue, sdCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Status)
+		util.Equals(t, apps_v1alpha.ReasonWorkloadsRunning, sdCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Reason)
+		util.Equals(t, metav1.ConditionTrue, sdCopy.Status.GetCondition(apps_v1alpha.ConditionWorkloadsCreated).Status)
+		util.Equals(t, metav1.ConditionTrue, sdCopy.Status.GetCondition(apps_v1alpha.ConditionNodesSelected).Status)
+	})
 	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdRepeatedObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(sdRepeatedObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), sdRepeatedObj.DeepCopy())
 	sdRepeatedCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdRepeatedObj.GetName(), metav1.GetOptions{})
 	t.Run("status of failure", func(t *testing.T) {
 		util.OK(t, err)
 		util.Equals(t, failure, sdRepeatedCopy.Status.State)
 		util.Equals(t, "0/5", sdRepeatedCopy.Status.Ready)
+		util.Equals(t, metav1.ConditionFalse, sdRepeatedCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Status)
+		util.Equals(t, apps_v1alpha.ReasonWorkloadInUse, sdRepeatedCopy.Status.GetCondition(apps_v1alpha.ConditionWorkloadsCreated).Reason)
 	})
 	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdPartiallyRepeatedObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(sdPartiallyRepeatedObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), sdPartiallyRepeatedObj.DeepCopy())
 	sdPartialCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdPartiallyRepeatedObj.GetName(), metav1.GetOptions{})
 	t.Run("status of failure", func(t *testing.T) {
 		util.OK(t, err)
@@ -513,7 +530,7 @@ func TestCreate(t *testing.T) {
 func TestUpdate(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
-	g.handler.Init(g.client, g.edgenetClient)
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
 	// Creating nodes
 	nodeParis := g.nodeObj
 	nodeParis.SetName("edgenet.planet-lab.eu")
@@ -568,7 +585,7 @@ func TestUpdate(t *testing.T) {
 	// Invoke the create function
 	sdObj := g.sdObj.DeepCopy()
 	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(sdObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
 	sdCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
 	util.OK(t, err)
 	util.Equals(t, success, sdCopy.Status.State)
@@ -647,6 +664,35 @@ func TestUpdate(t *testing.T) {
 
 	countryUScityParis := []apps_v1alpha.Selector{us, paris}
 
+	parisGeoJSON := paris
+	parisGeoJSON.Value = []string{`{"type": "FeatureCollection", "features": [{"type": "Feature", "properties": {"name": "Paris"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[2.2150567, 48.8947616], [2.2040704, 48.8084639], [2.3393396, 48.7835862], [2.4519494, 48.8416903], [2.3932412, 48.9171024], [2.2150567, 48.8947616]]
+	]}}]}`}
+	polygonParisGeoJSON := []apps_v1alpha.Selector{parisGeoJSON}
+	parisHole := paris
+	parisHole.Value = []string{`{"type": "Polygon", "coordinates": [
+		[[2.2150567, 48.8947616], [2.2040704, 48.8084639], [2.3393396, 48.7835862], [2.4519494, 48.8416903], [2.3932412, 48.9171024], [2.2150567, 48.8947616]],
+		[[2.3, 48.83], [2.4, 48.83], [2.4, 48.88], [2.3, 48.88], [2.3, 48.83]]
+	]}`}
+	parisHole.Quantity = 1
+	polygonParisHole := []apps_v1alpha.Selector{parisHole}
+
+	aroundParis := g.selector
+	aroundParis.Value = []string{"48.8566,2.3522,300km"}
+	aroundParis.Name = "Radius"
+	radiusParis := []apps_v1alpha.Selector{aroundParis}
+	aroundParis.Operator = "NotIn"
+	radiusParisOut := []apps_v1alpha.Selector{aroundParis}
+	nearSeaside := g.selector
+	nearSeaside.Value = []string{"36.6,-121.8,2"}
+	nearSeaside.Name = "Nearest"
+	nearestSeaside := []apps_v1alpha.Selector{nearSeaside}
+	nearSeaside.Quantity = 1
+	nearestSeasideQuantity := []apps_v1alpha.Selector{nearSeaside}
+	nearSeaside.Value = []string{"36.6,-121.8"}
+	nearSeaside.Quantity = 0
+	nearestInvalid := []apps_v1alpha.Selector{nearSeaside}
+
 	paris.Quantity = 4
 	polygonParisFewer := []apps_v1alpha.Selector{paris}
 	us.Quantity = 3
@@ -659,6 +705,13 @@ func TestUpdate(t *testing.T) {
 	}{
 		"city/seaside":          {citySeaside, success, [][]string{[]string{nodeSeaside.GetName()}}},
 		"polygon/paris":         {polygonParis, success, [][]string{[]string{nodeParis.GetName()}}},
+		"polygon/paris/geojson": {polygonParisGeoJSON, success, [][]string{[]string{nodeParis.GetName()}}},
+		"polygon/paris/hole":    {polygonParisHole, failure, [][]string{nil}},
+		"radius/paris":          {radiusParis, success, [][]string{[]string{nodeParis.GetName()}}},
+		"radius/paris/out":      {radiusParisOut, success, [][]string{[]string{nodeRichardson.GetName(), nodeSeaside.GetName()}}},
+		"nearest/seaside":       {nearestSeaside, success, [][]string{[]string{nodeSeaside.GetName(), nodeRichardson.GetName()}}},
+		"nearest/seaside/1":     {nearestSeasideQuantity, success, [][]string{[]string{nodeSeaside.GetName()}}},
+		"nearest/invalid":       {nearestInvalid, failure, [][]string{nil}},
 		"state/ca":              {stateCA, success, [][]string{[]string{nodeSeaside.GetName()}}},
 		"country/us":            {countryUS, success, [][]string{[]string{nodeSeaside.GetName()}}},
 		"country/us/all":        {countryUSAll, success, [][]string{[]string{nodeSeaside.GetName(), nodeRichardson.GetName()}}},
@@ -675,7 +728,7 @@ func TestUpdate(t *testing.T) {
 			sdCopy, _ := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
 			sdCopy.Spec.Selector = tc.input
 			g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
-			g.handler.ObjectUpdated(sdCopy)
+			g.handler.ObjectUpdated(context.TODO(), sdCopy)
 			sdCopy, _ = g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
 			util.Equals(t, tc.expectedStatus, sdCopy.Status.State)
 			deploymentCopy, err := g.client.AppsV1().Deployments("").Get(context.TODO(), deploymentCopy.GetName(), metav1.GetOptions{})
@@ -716,6 +769,48 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 
+	t.Run("distances", func(t *testing.T) {
+		sdCopy, _ := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		sdCopy.Spec.Selector = nearestSeaside
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+		sdCopy, _ = g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, 2, len(sdCopy.Status.Nodes))
+		util.Equals(t, apps_v1alpha.SelectedNode{Selector: 0, Name: nodeSeaside.GetName(), Distance: 2396}, sdCopy.Status.Nodes[0])
+		util.Equals(t, nodeRichardson.GetName(), sdCopy.Status.Nodes[1].Name)
+		util.Equals(t, int64(2320), sdCopy.Status.Nodes[1].Distance/1000)
+	})
+
+	t.Run("preferred", func(t *testing.T) {
+		// The preferred selectors make soft terms, so the workloads stay schedulable on the other nodes
+		preferredEU := eu
+		preferredEU.Preferred = true
+		preferredEU.Weight = 60
+		sdCopy, _ := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		sdCopy.Spec.Selector = []apps_v1alpha.Selector{preferredEU}
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+		sdCopy, _ = g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, success, sdCopy.Status.State)
+		deploymentCopy, err := g.client.AppsV1().Deployments("").Get(context.TODO(), deploymentCopy.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		nodeAffinity := deploymentCopy.Spec.Template.Spec.Affinity.NodeAffinity
+		util.Equals(t, true, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil)
+		util.Equals(t, 1, len(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution))
+		util.Equals(t, int32(60), nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight)
+		util.Equals(t, []string{nodeParis.GetName()}, nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Preference.MatchExpressions[0].Values)
+
+		// Both kinds of terms are set when the selectors are mixed
+		sdCopy.Spec.Selector = []apps_v1alpha.Selector{preferredEU, countryUS[0]}
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+		cronjobCopy, err := g.client.BatchV1beta1().CronJobs("").Get(context.TODO(), cronjobCopy.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		nodeAffinity = cronjobCopy.Spec.JobTemplate.Spec.Template.Spec.Affinity.NodeAffinity
+		util.Equals(t, []string{nodeSeaside.GetName()}, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+		util.Equals(t, []string{nodeParis.GetName()}, nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Preference.MatchExpressions[0].Values)
+	})
+
 	t.Run("workload spec", func(t *testing.T) {
 		util.Equals(t, sdCopy.Spec.Workloads.Deployment[0].Spec.Template.Spec.Containers[0].Image, deploymentCopy.Spec.Template.Spec.Containers[0].Image)
 		util.Equals(t, sdCopy.Spec.Workloads.DaemonSet[0].Spec.Template.Spec.Containers[0].Image, daemonsetCopy.Spec.Template.Spec.Containers[0].Image)
@@ -730,7 +825,7 @@ func TestUpdate(t *testing.T) {
 		sdCopy.Spec.Workloads.CronJob[0].Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image = "nginx:1.8.4"
 
 		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
-		g.handler.ObjectUpdated(sdCopy)
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
 		deploymentCopy, err := g.client.AppsV1().Deployments("").Get(context.TODO(), deploymentCopy.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
 		daemonsetCopy, err := g.client.AppsV1().DaemonSets("").Get(context.TODO(), daemonsetCopy.GetName(), metav1.GetOptions{})
@@ -753,7 +848,7 @@ func TestUpdate(t *testing.T) {
 func TestGetByNode(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
-	g.handler.Init(g.client, g.edgenetClient)
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
 	// Creating nodes
 	nodeParis := g.nodeObj
 	nodeParis.SetName("edgenet.planet-lab.eu")
@@ -789,20 +884,209 @@ func TestGetByNode(t *testing.T) {
 	sdObj.Spec.Selector = []apps_v1alpha.Selector{useu}
 
 	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(sdObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
 	sdCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
 	util.OK(t, err)
 	util.Equals(t, success, sdCopy.Status.State)
 	util.Equals(t, statusDict["sd-success"], sdCopy.Status.Message[0])
 	util.Equals(t, "5/5", sdCopy.Status.Ready)
 
-	ownerList, status := g.handler.getByNode(nodeParis.GetName())
+	ownerList, status := g.handler.getByNode(context.TODO(), nodeParis.GetName())
 	util.Equals(t, true, status)
 	util.Equals(t, "", ownerList[0][0])
 	util.Equals(t, sdObj.GetName(), ownerList[0][1])
 
-	ownerList, status = g.handler.getByNode(nodeRichardson.GetName())
+	ownerList, status = g.handler.getByNode(context.TODO(), nodeRichardson.GetName())
 	util.Equals(t, true, status)
 	util.Equals(t, "", ownerList[0][0])
 	util.Equals(t, sdObj.GetName(), ownerList[0][1])
+
+	// The nodes that a preferred selector picks belong to the selective deployment as well
+	useu.Preferred = true
+	useu.Weight = 50
+	sdCopy.Spec.Selector = []apps_v1alpha.Selector{useu}
+	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+	g.handler.ObjectUpdated(context.TODO(), sdCopy)
+	ownerList, status = g.handler.getByNode(context.TODO(), nodeParis.GetName())
+	util.Equals(t, true, status)
+	util.Equals(t, sdObj.GetName(), ownerList[0][1])
+
+	// An API error leaves the node event without owners rather than crashing the controller
+	edgenetClient := edgenettestclient.NewSimpleClientset()
+	edgenetClient.PrependReactor("list", "selectivedeployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewServiceUnavailable("unavailable")
+	})
+	handler := SDHandler{edgenetClientset: edgenetClient}
+	ownerList, status = handler.getByNode(context.TODO(), nodeParis.GetName())
+	util.Equals(t, false, status)
+	util.Equals(t, 0, len(ownerList))
+}
+
+func TestDistribution(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
+	// Creating nodes
+	nodes := map[string]map[string]string{
+		"edgenet.planet-lab.eu":  {"edge-net.io/city": "Paris", "edge-net.io/country-iso": "FR"},
+		"utdallas-1.edge-net.io": {"edge-net.io/city": "Richardson", "edge-net.io/country-iso": "US"},
+		"nps-1.edge-net.io":      {"edge-net.io/city": "Seaside", "edge-net.io/country-iso": "US"},
+	}
+	for name, labels := range nodes {
+		nodeObj := g.nodeObj
+		nodeObj.SetName(name)
+		nodeObj.ObjectMeta.Labels = labels
+		nodeObj.ObjectMeta.Labels["kubernetes.io/hostname"] = name
+		g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj.DeepCopy(), metav1.CreateOptions{})
+	}
+
+	sdObj := g.sdObj.DeepCopy()
+	sdObj.Spec.Selector = []apps_v1alpha.Selector{{Name: "Country", Value: []string{"US", "FR"}, Operator: "In"}}
+	sdObj.Spec.Distribution = &apps_v1alpha.Distribution{Mode: apps_v1alpha.DistributionPerValue, Replicas: 2}
+	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
+	g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
+
+	t.Run("per value", func(t *testing.T) {
+		sdCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, success, sdCopy.Status.State)
+		util.Equals(t, []apps_v1alpha.RegionStatus{
+			{Workload: "default", Region: "US", Ready: "0/2"},
+			{Workload: "default", Region: "FR", Ready: "0/2"},
+			{Workload: "default", Region: "US", Ready: "0/2"},
+			{Workload: "default", Region: "FR", Ready: "0/2"},
+		}, sdCopy.Status.Regions)
+
+		deploymentCopy, err := g.client.AppsV1().Deployments("").Get(context.TODO(), "default-us", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, int32(2), *deploymentCopy.Spec.Replicas)
+		util.Equals(t, "us", deploymentCopy.Spec.Selector.MatchLabels[regionLabel])
+		util.Equals(t, "us", deploymentCopy.Spec.Template.Labels[regionLabel])
+		util.Equals(t, []string{"nps-1.edge-net.io", "utdallas-1.edge-net.io"},
+			deploymentCopy.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+		statefulsetCopy, err := g.client.AppsV1().StatefulSets("").Get(context.TODO(), "default-fr", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []string{"edgenet.planet-lab.eu"},
+			statefulsetCopy.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+		_, err = g.client.AppsV1().Deployments("").Get(context.TODO(), "default", metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		// The workloads without replicas are not distributed
+		daemonsetCopy, err := g.client.AppsV1().DaemonSets("").Get(context.TODO(), "default", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, 3, len(daemonsetCopy.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values))
+	})
+
+	t.Run("fewer regions", func(t *testing.T) {
+		sdCopy, _ := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		sdCopy.Spec.Selector[0].Value = []string{"US"}
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+		_, err := g.client.AppsV1().Deployments("").Get(context.TODO(), "default-fr", metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		_, err = g.client.AppsV1().StatefulSets("").Get(context.TODO(), "default-fr", metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		_, err = g.client.AppsV1().Deployments("").Get(context.TODO(), "default-us", metav1.GetOptions{})
+		util.OK(t, err)
+	})
+
+	t.Run("spread", func(t *testing.T) {
+		for i, nodeName := range []string{"edgenet.planet-lab.eu", "nps-1.edge-net.io", ""} {
+			podObj := corev1.Pod{}
+			podObj.SetName(fmt.Sprintf("nginx-%d", i))
+			podObj.SetLabels(map[string]string{"app": "nginx"})
+			podObj.Spec.NodeName = nodeName
+			if i == 0 {
+				podObj.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			}
+			g.client.CoreV1().Pods("").Create(context.TODO(), &podObj, metav1.CreateOptions{})
+		}
+		sdCopy, _ := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		sdCopy.Spec.Selector[0].Value = []string{"US", "FR"}
+		sdCopy.Spec.Distribution = &apps_v1alpha.Distribution{Mode: apps_v1alpha.DistributionSpread, Topology: "Country"}
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+
+		// The copies of the regions give way to the workload that spreads its replicas
+		_, err := g.client.AppsV1().Deployments("").Get(context.TODO(), "default-us", metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+		deploymentCopy, err := g.client.AppsV1().Deployments("").Get(context.TODO(), "default", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "edge-net.io/country-iso",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     deploymentCopy.Spec.Selector,
+		}}, deploymentCopy.Spec.Template.Spec.TopologySpreadConstraints)
+		sdCopy, _ = g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, []apps_v1alpha.RegionStatus{
+			{Workload: "default", Region: "FR", Ready: "1/1"},
+			{Workload: "default", Region: "US", Ready: "0/1"},
+		}, sdCopy.Status.Regions[:2])
+
+		// The constraint goes away along with the distribution
+		sdCopy.Spec.Distribution = nil
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+		deploymentCopy, err = g.client.AppsV1().Deployments("").Get(context.TODO(), "default", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, 0, len(deploymentCopy.Spec.Template.Spec.TopologySpreadConstraints))
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
+	// Creating nodes
+	nodes := map[string]map[string]string{
+		"edgenet.planet-lab.eu":  {"edge-net.io/city": "Paris", "edge-net.io/country-iso": "FR"},
+		"utdallas-1.edge-net.io": {"edge-net.io/city": "Richardson", "edge-net.io/country-iso": "US"},
+	}
+	for name, labels := range nodes {
+		nodeObj := g.nodeObj
+		nodeObj.SetName(name)
+		nodeObj.ObjectMeta.Labels = labels
+		nodeObj.ObjectMeta.Labels["kubernetes.io/hostname"] = name
+		g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj.DeepCopy(), metav1.CreateOptions{})
+	}
+
+	sdObj := g.sdObj.DeepCopy()
+	sdObj.Spec.DryRun = true
+	sdObj.Spec.Selector = []apps_v1alpha.Selector{
+		{Name: "Country", Value: []string{"US"}, Operator: "In", Quantity: 2},
+		{Name: "City", Value: []string{"Paris"}, Operator: "In"},
+	}
+	expected := []apps_v1alpha.SelectorPreview{
+		{Selector: 0, Name: "Country", Nodes: []string{"utdallas-1.edge-net.io"}, Count: 1, Shortfall: 1},
+		{Selector: 1, Name: "City", Nodes: []string{"edgenet.planet-lab.eu"}, Count: 1},
+	}
+
+	t.Run("status", func(t *testing.T) {
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
+		g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
+		sdCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, dryRun, sdCopy.Status.State)
+		util.Equals(t, expected, sdCopy.Status.Preview)
+		util.Equals(t, apps_v1alpha.ReasonDryRun, sdCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Reason)
+		util.Equals(t, apps_v1alpha.ReasonFewerNodes, sdCopy.Status.GetCondition(apps_v1alpha.ConditionNodesSelected).Reason)
+		_, err = g.client.AppsV1().Deployments("").Get(context.TODO(), "default", metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+	t.Run("leaving the dry run", func(t *testing.T) {
+		sdCopy, _ := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		sdCopy.Spec.DryRun = false
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy, metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy)
+		sdCopy, _ = g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, 0, len(sdCopy.Status.Preview))
+		_, err := g.client.AppsV1().Deployments("").Get(context.TODO(), "default", metav1.GetOptions{})
+		util.OK(t, err)
+	})
+	t.Run("function", func(t *testing.T) {
+		status, err := Preview(context.TODO(), g.client, sdObj)
+		util.OK(t, err)
+		util.Equals(t, dryRun, status.State)
+		util.Equals(t, expected, status.Preview)
+	})
 }
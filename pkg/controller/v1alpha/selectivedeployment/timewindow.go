@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectivedeployment
+
+import (
+	"strings"
+	"time"
+	// The time windows go by the time zones of the nodes, which the controller image may lack the database of
+	_ "time/tzdata"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+)
+
+// clockLayout is the form of the start and the end of the time windows
+const clockLayout = "15:04"
+
+// windowOpen returns whether the local time of the node is in one of the windows, a node with no time zone is in none.
+// It brings the next transition forward to the time the windows of the node open or close next if that comes sooner.
+func windowOpen(nodes *nodeIndex, hostname string, windows []apps_v1alpha.TimeWindow, next *time.Time) bool {
+	zone := nodes.zone(hostname)
+	if zone == nil {
+		return false
+	}
+	open, transition := inWindows(windows, now().In(zone))
+	if !transition.IsZero() && (next.IsZero() || transition.Before(*next)) {
+		*next = transition
+	}
+	return open
+}
+
+// inWindows returns whether the local time falls in one of the windows, along with the next time a window opens or closes
+func inWindows(windows []apps_v1alpha.TimeWindow, local time.Time) (bool, time.Time) {
+	open := false
+	var next time.Time
+	for _, window := range windows {
+		start, err := time.Parse(clockLayout, window.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(clockLayout, window.End)
+		if err != nil {
+			continue
+		}
+		startToday, endToday := atClock(local, start), atClock(local, end)
+		if endToday.After(startToday) {
+			open = open || (startsOn(window, startToday) && !local.Before(startToday) && local.Before(endToday))
+		} else {
+			// The window runs past midnight, so the one that started the day before may still be open
+			open = open || (startsOn(window, startToday.AddDate(0, 0, -1)) && local.Before(endToday))
+			open = open || (startsOn(window, startToday) && !local.Before(startToday))
+		}
+		for _, transition := range []time.Time{startToday, endToday} {
+			if !transition.After(local) {
+				transition = transition.AddDate(0, 0, 1)
+			}
+			if next.IsZero() || transition.Before(next) {
+				next = transition
+			}
+		}
+	}
+	return open, next
+}
+
+// atClock returns the time of the clock on the day of the local time
+func atClock(local time.Time, clock time.Time) time.Time {
+	return time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, local.Location())
+}
+
+// startsOn returns whether the window starts on the day, the windows with no days start every day
+func startsOn(window apps_v1alpha.TimeWindow, day time.Time) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+	for _, windowDay := range window.Days {
+		if strings.EqualFold(windowDay, day.Weekday().String()[:3]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package selectivedeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInWindows(t *testing.T) {
+	night := apps_v1alpha.TimeWindow{Start: "22:00", End: "06:00"}
+	weekend := apps_v1alpha.TimeWindow{Start: "22:00", End: "06:00", Days: []string{"Sat"}}
+	office := apps_v1alpha.TimeWindow{Start: "09:00", End: "17:00"}
+	// The 2nd of January 2021 is a Saturday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+	cases := map[string]struct {
+		windows []apps_v1alpha.TimeWindow
+		local   time.Time
+		open    bool
+		next    time.Time
+	}{
+		"night/evening":       {[]apps_v1alpha.TimeWindow{night}, at(4, 23, 0), true, at(5, 6, 0)},
+		"night/morning":       {[]apps_v1alpha.TimeWindow{night}, at(4, 5, 0), true, at(4, 6, 0)},
+		"night/noon":          {[]apps_v1alpha.TimeWindow{night}, at(4, 12, 0), false, at(4, 22, 0)},
+		"weekend/sunday":      {[]apps_v1alpha.TimeWindow{weekend}, at(3, 3, 0), true, at(3, 6, 0)},
+		"weekend/sunday/late": {[]apps_v1alpha.TimeWindow{weekend}, at(3, 23, 0), false, at(4, 6, 0)},
+		"office/start":        {[]apps_v1alpha.TimeWindow{office}, at(4, 9, 0), true, at(4, 17, 0)},
+		"office/night":        {[]apps_v1alpha.TimeWindow{night, office}, at(4, 8, 0), false, at(4, 9, 0)},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			open, next := inWindows(tc.windows, tc.local)
+			util.Equals(t, tc.open, open)
+			util.Equals(t, tc.next, next)
+		})
+	}
+}
+
+func TestTimeWindows(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
+	var requeued time.Duration
+	g.handler.requeue = func(sdCopy *apps_v1alpha.SelectiveDeployment, after time.Duration) {
+		requeued = after
+	}
+	clock := time.Date(2021, time.January, 4, 3, 0, 0, 0, time.UTC)
+	now = func() metav1.Time { return metav1.NewTime(clock) }
+	defer func() { now = metav1.Now }()
+	// Creating nodes
+	nodes := map[string]map[string]string{
+		"edgenet.planet-lab.eu":  {"edge-net.io/country-iso": "FR", "edge-net.io/timezone": "Europe.Paris"},
+		"utdallas-1.edge-net.io": {"edge-net.io/country-iso": "US", "edge-net.io/timezone": "America.Chicago"},
+		"nps-1.edge-net.io":      {"edge-net.io/country-iso": "US"},
+	}
+	for name, labels := range nodes {
+		nodeObj := g.nodeObj
+		nodeObj.SetName(name)
+		nodeObj.ObjectMeta.Labels = labels
+		nodeObj.ObjectMeta.Labels["kubernetes.io/hostname"] = name
+		g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj.DeepCopy(), metav1.CreateOptions{})
+	}
+
+	sdObj := g.sdObj.DeepCopy()
+	sdObj.Spec.Workloads = apps_v1alpha.Workloads{Deployment: sdObj.Spec.Workloads.Deployment}
+	sdObj.Spec.Selector = []apps_v1alpha.Selector{{Name: "Country", Value: []string{"FR", "US"}, Operator: "In",
+		Windows: []apps_v1alpha.TimeWindow{{Start: "22:00", End: "06:00"}}}}
+	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
+	selected := func() []string {
+		deploymentCopy, err := g.client.AppsV1().Deployments("").Get(context.TODO(), "default", metav1.GetOptions{})
+		util.OK(t, err)
+		return deploymentCopy.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values
+	}
+
+	t.Run("open in paris", func(t *testing.T) {
+		// It is 04:00 in Paris and 21:00 in Richardson, the node with no time zone is left out
+		g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
+		util.Equals(t, []string{"edgenet.planet-lab.eu"}, selected())
+		util.Equals(t, time.Hour, requeued)
+	})
+	t.Run("open in both", func(t *testing.T) {
+		clock = clock.Add(90 * time.Minute)
+		g.handler.ObjectUpdated(context.TODO(), sdObj.DeepCopy())
+		util.Equals(t, []string{"edgenet.planet-lab.eu", "utdallas-1.edge-net.io"}, selected())
+		util.Equals(t, 30*time.Minute, requeued)
+	})
+	t.Run("closed in paris", func(t *testing.T) {
+		clock = clock.Add(time.Hour)
+		g.handler.ObjectUpdated(context.TODO(), sdObj.DeepCopy())
+		util.Equals(t, []string{"utdallas-1.edge-net.io"}, selected())
+	})
+}
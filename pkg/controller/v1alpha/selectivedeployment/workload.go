@@ -0,0 +1,243 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectivedeployment
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+
+	log "github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// WorkloadKind tells where the fields that a selective deployment sets are in the objects of a kind.
+// The paths are made of the field names separated by dots, such as spec.template.spec.
+type WorkloadKind struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	// Resource is the plural name of the kind in the API
+	Resource string `yaml:"resource"`
+	// PodSpec is the path of the pod spec, or of any object having the affinity field of a pod spec
+	PodSpec string `yaml:"podSpec"`
+	// The kinds running replicas of a pod have the paths below, so that they can be distributed across regions
+	PodLabels     string `yaml:"podLabels,omitempty"`
+	Selector      string `yaml:"selector,omitempty"`
+	Replicas      string `yaml:"replicas,omitempty"`
+	ReadyReplicas string `yaml:"readyReplicas,omitempty"`
+}
+
+// The kinds that selective deployments manage without any configuration
+var builtinWorkloadKinds = []WorkloadKind{
+	{APIVersion: "apps/v1", Kind: "Deployment", Resource: "deployments", PodSpec: "spec.template.spec", PodLabels: "spec.template.metadata.labels",
+		Selector: "spec.selector", Replicas: "spec.replicas", ReadyReplicas: "status.readyReplicas"},
+	{APIVersion: "apps/v1", Kind: "DaemonSet", Resource: "daemonsets", PodSpec: "spec.template.spec"},
+	{APIVersion: "apps/v1", Kind: "StatefulSet", Resource: "statefulsets", PodSpec: "spec.template.spec", PodLabels: "spec.template.metadata.labels",
+		Selector: "spec.selector", Replicas: "spec.replicas", ReadyReplicas: "status.readyReplicas"},
+	{APIVersion: "apps/v1", Kind: "ReplicaSet", Resource: "replicasets", PodSpec: "spec.template.spec", PodLabels: "spec.template.metadata.labels",
+		Selector: "spec.selector", Replicas: "spec.replicas", ReadyReplicas: "status.readyReplicas"},
+	{APIVersion: "batch/v1", Kind: "Job", Resource: "jobs", PodSpec: "spec.template.spec"},
+	{APIVersion: "batch/v1beta1", Kind: "CronJob", Resource: "cronjobs", PodSpec: "spec.jobTemplate.spec.template.spec"},
+	{APIVersion: "batch/v1", Kind: "CronJob", Resource: "cronjobs", PodSpec: "spec.jobTemplate.spec.template.spec"},
+}
+
+// workloadKinds is the registry of the kinds, the configuration file adds to the built-in ones
+var workloadKinds = struct {
+	sync.RWMutex
+	kinds map[schema.GroupVersionKind]WorkloadKind
+}{kinds: map[schema.GroupVersionKind]WorkloadKind{}}
+
+func init() {
+	for _, kind := range builtinWorkloadKinds {
+		if err := RegisterWorkloadKind(kind); err != nil {
+			panic(err.Error())
+		}
+	}
+}
+
+// RegisterWorkloadKind adds the kind to the registry, or replaces the paths of a kind registered before
+func RegisterWorkloadKind(kind WorkloadKind) error {
+	if _, err := schema.ParseGroupVersion(kind.APIVersion); err != nil || kind.APIVersion == "" {
+		return fmt.Errorf("%s is not a valid API version for the workload kind %s", kind.APIVersion, kind.Kind)
+	}
+	if kind.Kind == "" || kind.Resource == "" || kind.PodSpec == "" {
+		return fmt.Errorf("the workload kind %s of %s needs a kind, a resource, and a pod spec path", kind.Kind, kind.APIVersion)
+	}
+	if kind.Replicas != "" && (kind.PodLabels == "" || kind.Selector == "") {
+		return fmt.Errorf("the workload kind %s of %s has replicas but lacks the pod labels or the selector path", kind.Kind, kind.APIVersion)
+	}
+	workloadKinds.Lock()
+	defer workloadKinds.Unlock()
+	workloadKinds.kinds[kind.groupVersionKind()] = kind
+	return nil
+}
+
+// LoadWorkloadKinds registers the kinds listed in the YAML file
+func LoadWorkloadKinds(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var file struct {
+		Kinds []WorkloadKind `yaml:"kinds"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return err
+	}
+	for _, kind := range file.Kinds {
+		if err := RegisterWorkloadKind(kind); err != nil {
+			return err
+		}
+		log.Infof("SD: workload kind %s of %s registered", kind.Kind, kind.APIVersion)
+	}
+	return nil
+}
+
+// lookupWorkloadKind returns the registered kind of the group, version, and kind
+func lookupWorkloadKind(gvk schema.GroupVersionKind) (WorkloadKind, bool) {
+	workloadKinds.RLock()
+	defer workloadKinds.RUnlock()
+	kind, ok := workloadKinds.kinds[gvk]
+	return kind, ok
+}
+
+// listWorkloadKinds returns the registered kinds in a stable order
+func listWorkloadKinds() []WorkloadKind {
+	workloadKinds.RLock()
+	defer workloadKinds.RUnlock()
+	kinds := make([]WorkloadKind, 0, len(workloadKinds.kinds))
+	for _, kind := range workloadKinds.kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		if kinds[i].APIVersion != kinds[j].APIVersion {
+			return kinds[i].APIVersion < kinds[j].APIVersion
+		}
+		return kinds[i].Kind < kinds[j].Kind
+	})
+	return kinds
+}
+
+func (k WorkloadKind) groupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(k.APIVersion, k.Kind)
+}
+
+func (k WorkloadKind) groupVersionResource() schema.GroupVersionResource {
+	gv, _ := schema.ParseGroupVersion(k.APIVersion)
+	return gv.WithResource(k.Resource)
+}
+
+// hasReplicas returns whether the objects of the kind run replicas of a pod
+func (k WorkloadKind) hasReplicas() bool {
+	return k.Replicas != ""
+}
+
+// isServed returns whether the API server serves the kind, a cluster may lack the CRD of a third-party kind
+// or a version of a built-in one
+func (k WorkloadKind) isServed(discoveryClient discovery.DiscoveryInterface) bool {
+	resourceList, err := discoveryClient.ServerResourcesForGroupVersion(k.APIVersion)
+	if err != nil {
+		return false
+	}
+	for _, resource := range resourceList.APIResources {
+		if resource.Name == k.Resource {
+			return true
+		}
+	}
+	return false
+}
+
+// fields splits the path into the field names
+func fields(path string, extra ...string) []string {
+	return append(strings.Split(path, "."), extra...)
+}
+
+// listWorkloads returns the workloads of the selective deployment as unstructured objects, in the order of the spec
+func listWorkloads(sdCopy *apps_v1alpha.SelectiveDeployment) []*unstructured.Unstructured {
+	var workloads []*unstructured.Unstructured
+	add := func(obj interface{}, gvk schema.GroupVersionKind) {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			log.Println(err.Error())
+			return
+		}
+		workloadObj := &unstructured.Unstructured{Object: content}
+		workloadObj.SetGroupVersionKind(gvk)
+		workloads = append(workloads, workloadObj)
+	}
+	for i := range sdCopy.Spec.Workloads.Deployment {
+		add(&sdCopy.Spec.Workloads.Deployment[i], appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	}
+	for i := range sdCopy.Spec.Workloads.DaemonSet {
+		add(&sdCopy.Spec.Workloads.DaemonSet[i], appsv1.SchemeGroupVersion.WithKind("DaemonSet"))
+	}
+	for i := range sdCopy.Spec.Workloads.StatefulSet {
+		add(&sdCopy.Spec.Workloads.StatefulSet[i], appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+	}
+	for i := range sdCopy.Spec.Workloads.Job {
+		add(&sdCopy.Spec.Workloads.Job[i], batchv1.SchemeGroupVersion.WithKind("Job"))
+	}
+	for i := range sdCopy.Spec.Workloads.CronJob {
+		add(&sdCopy.Spec.Workloads.CronJob[i], batchv1beta.SchemeGroupVersion.WithKind("CronJob"))
+	}
+	for i := range sdCopy.Spec.Workloads.Objects {
+		workloads = append(workloads, sdCopy.Spec.Workloads.Objects[i].DeepCopy())
+	}
+	return workloads
+}
+
+// setNestedObject converts the typed object into unstructured content and sets it at the fields
+func setNestedObject(content map[string]interface{}, obj interface{}, fields ...string) error {
+	value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(content, value, fields...)
+}
+
+// getNestedObject converts the unstructured content at the fields into the typed object, it returns false if there is none
+func getNestedObject(content map[string]interface{}, obj interface{}, fields ...string) bool {
+	value, found, err := unstructured.NestedMap(content, fields...)
+	if err != nil || !found {
+		return false
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(value, obj) == nil
+}
+
+// getSelector returns the label selector of the workload, or nil if its kind has none
+func getSelector(kind WorkloadKind, workloadObj *unstructured.Unstructured) *metav1.LabelSelector {
+	if kind.Selector == "" {
+		return nil
+	}
+	selector := &metav1.LabelSelector{}
+	if !getNestedObject(workloadObj.Object, selector, fields(kind.Selector)...) {
+		return nil
+	}
+	return selector
+}
@@ -0,0 +1,234 @@
+package selectivedeployment
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newDynamicClient returns a fake dynamic client that keeps the workloads of the built-in kinds in the tracker of
+// the typed client, so that the tests check them through either client. The workloads of the other registered
+// kinds stay unstructured. The discovery of the typed client serves all the registered kinds.
+func newDynamicClient(client *testclient.Clientset) dynamic.Interface {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	unstructuredTracker := k8stesting.NewObjectTracker(scheme, serializer.NewCodecFactory(scheme).UniversalDecoder())
+	typedReaction := k8stesting.ObjectReaction(client.Tracker())
+	unstructuredReaction := k8stesting.ObjectReaction(unstructuredTracker)
+	kinds := map[schema.GroupVersionResource]schema.GroupVersionKind{}
+	resources := map[string][]metav1.APIResource{}
+	for _, kind := range listWorkloadKinds() {
+		gvk := kind.groupVersionKind()
+		kinds[kind.groupVersionResource()] = gvk
+		resources[kind.APIVersion] = append(resources[kind.APIVersion], metav1.APIResource{Name: kind.Resource, Kind: kind.Kind, Namespaced: true})
+		if !scheme.Recognizes(gvk) {
+			scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+		}
+	}
+	client.Resources = nil
+	for groupVersion, apiResources := range resources {
+		client.Resources = append(client.Resources, &metav1.APIResourceList{GroupVersion: groupVersion, APIResources: apiResources})
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+	dynamicClient.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gvk, ok := kinds[action.GetResource()]
+		if !ok {
+			return false, nil, nil
+		}
+		typed := scheme.Recognizes(gvk)
+		toTyped := func(obj runtime.Object) (runtime.Object, error) {
+			typedObj, err := scheme.New(gvk)
+			if err != nil {
+				return nil, err
+			}
+			return typedObj, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, typedObj)
+		}
+		var err error
+		switch a := action.(type) {
+		case k8stesting.CreateActionImpl:
+			if typed {
+				if a.Object, err = toTyped(a.Object); err != nil {
+					return true, nil, err
+				}
+			}
+			action = a
+		case k8stesting.UpdateActionImpl:
+			if typed {
+				if a.Object, err = toTyped(a.Object); err != nil {
+					return true, nil, err
+				}
+			}
+			action = a
+		case k8stesting.ListActionImpl:
+			a.Kind = gvk
+			action = a
+		}
+		if typed {
+			return typedReaction(action)
+		}
+		return unstructuredReaction(action)
+	})
+	dynamicClient.PrependWatchReactor("*", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		gvk, ok := kinds[action.GetResource()]
+		if !ok {
+			return false, nil, nil
+		}
+		if !scheme.Recognizes(gvk) {
+			w, err := unstructuredTracker.Watch(action.GetResource(), action.GetNamespace())
+			return true, w, err
+		}
+		w, err := client.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		// The informers of the dynamic client expect unstructured objects
+		return true, watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event.Object)
+			if err != nil {
+				return event, false
+			}
+			workloadObj := &unstructured.Unstructured{Object: content}
+			workloadObj.SetGroupVersionKind(gvk)
+			event.Object = workloadObj
+			return event, true
+		}), nil
+	})
+	return dynamicClient
+}
+
+func TestLoadWorkloadKinds(t *testing.T) {
+	dir, err := ioutil.TempDir("", "workload-kinds")
+	util.OK(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "workload-kinds.yaml")
+	ioutil.WriteFile(path, []byte(`kinds:
+- apiVersion: argoproj.io/v1alpha1
+  kind: Workflow
+  resource: workflows
+  podSpec: spec
+`), 0644)
+	util.OK(t, LoadWorkloadKinds(path))
+	kind, ok := lookupWorkloadKind(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"})
+	util.Equals(t, true, ok)
+	util.Equals(t, schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows"}, kind.groupVersionResource())
+	util.Equals(t, []string{"spec", "affinity"}, fields(kind.PodSpec, "affinity"))
+	util.Equals(t, false, kind.hasReplicas())
+
+	t.Run("built-in", func(t *testing.T) {
+		kind, ok := lookupWorkloadKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))
+		util.Equals(t, true, ok)
+		util.Equals(t, true, kind.hasReplicas())
+		_, ok = lookupWorkloadKind(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"})
+		util.Equals(t, true, ok)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		ioutil.WriteFile(path, []byte(`kinds:
+- apiVersion: kubevirt.io/v1
+  kind: VirtualMachineInstance
+  resource: virtualmachineinstances
+`), 0644)
+		util.Equals(t, true, LoadWorkloadKinds(path) != nil)
+		util.Equals(t, true, RegisterWorkloadKind(WorkloadKind{APIVersion: "example.com/v1", Kind: "Pool", Resource: "pools", PodSpec: "spec.template.spec", Replicas: "spec.replicas"}) != nil)
+		util.Equals(t, true, LoadWorkloadKinds(filepath.Join(dir, "missing.yaml")) != nil)
+	})
+}
+
+func TestObjects(t *testing.T) {
+	util.OK(t, RegisterWorkloadKind(WorkloadKind{APIVersion: "kubevirt.io/v1", Kind: "VirtualMachineInstance", Resource: "virtualmachineinstances", PodSpec: "spec"}))
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient, g.dynamicClient)
+	nodeParis := g.nodeObj
+	nodeParis.SetName("edgenet.planet-lab.eu")
+	nodeParis.ObjectMeta.Labels = map[string]string{
+		"kubernetes.io/hostname":  "edgenet.planet-lab.eu",
+		"edge-net.io/country-iso": "FR",
+	}
+	g.client.CoreV1().Nodes().Create(context.TODO(), nodeParis.DeepCopy(), metav1.CreateOptions{})
+
+	replicaSet := appsv1.ReplicaSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+			Template: g.deploymentObj.Spec.Template,
+		},
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&replicaSet)
+	util.OK(t, err)
+	vmi := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "VirtualMachineInstance",
+		"metadata":   map[string]interface{}{"name": "fedora"},
+		"spec":       map[string]interface{}{"domain": map[string]interface{}{"devices": map[string]interface{}{}}},
+	}}
+	sdObj := g.sdObj.DeepCopy()
+	sdObj.Spec.Workloads = apps_v1alpha.Workloads{Objects: []unstructured.Unstructured{{Object: content}, vmi}}
+	selector := g.selector
+	selector.Name = "Country"
+	selector.Value = []string{"FR"}
+	selector.Quantity = 1
+	sdObj.Spec.Selector = []apps_v1alpha.Selector{selector}
+	g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Create(context.TODO(), sdObj.DeepCopy(), metav1.CreateOptions{})
+	g.handler.ObjectCreated(context.TODO(), sdObj.DeepCopy())
+
+	sdCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+	util.OK(t, err)
+	util.Equals(t, success, sdCopy.Status.State)
+	util.Equals(t, "2/2", sdCopy.Status.Ready)
+
+	t.Run("typed", func(t *testing.T) {
+		replicaSetCopy, err := g.client.AppsV1().ReplicaSets("").Get(context.TODO(), "nginx", metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, []string{"edgenet.planet-lab.eu"}, replicaSetCopy.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+		util.Equals(t, "SelectiveDeployment", replicaSetCopy.GetOwnerReferences()[0].Kind)
+	})
+	t.Run("custom", func(t *testing.T) {
+		vmiCopy, err := g.dynamicClient.Resource(schema.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachineinstances"}).Get(context.TODO(), "fedora", metav1.GetOptions{})
+		util.OK(t, err)
+		nodeAffinity := &corev1.NodeAffinity{}
+		util.Equals(t, true, getNestedObject(vmiCopy.Object, nodeAffinity, "spec", "affinity", "nodeAffinity"))
+		util.Equals(t, []string{"edgenet.planet-lab.eu"}, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+		_, found, _ := unstructured.NestedMap(vmiCopy.Object, "spec", "domain")
+		util.Equals(t, true, found)
+
+		ownerList, status := g.handler.getByNode(context.TODO(), nodeParis.GetName())
+		util.Equals(t, true, status)
+		util.Equals(t, [][]string{{"", sdObj.GetName()}}, ownerList)
+	})
+	t.Run("unknown kind", func(t *testing.T) {
+		sdCopy.Spec.Workloads.Objects = append(sdCopy.Spec.Workloads.Objects, unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Unknown",
+			"metadata":   map[string]interface{}{"name": "unknown"},
+		}})
+		g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Update(context.TODO(), sdCopy.DeepCopy(), metav1.UpdateOptions{})
+		g.handler.ObjectUpdated(context.TODO(), sdCopy.DeepCopy())
+		sdCopy, err := g.edgenetClient.AppsV1alpha().SelectiveDeployments("").Get(context.TODO(), sdObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, partial, sdCopy.Status.State)
+		util.Equals(t, "2/3", sdCopy.Status.Ready)
+		util.Equals(t, apps_v1alpha.ReasonWorkloadKindUnknown, sdCopy.Status.GetCondition(apps_v1alpha.ConditionWorkloadsCreated).Reason)
+	})
+}
@@ -18,40 +18,22 @@ package slice
 
 import (
 	"encoding/json"
-	"fmt"
-	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
-	appsinformer_v1 "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/apps/v1alpha"
 	"github.com/EdgeNet-project/edgenet/pkg/permission"
 
-	log "github.com/sirupsen/logrus"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 )
 
-// The main structure of controller
-type controller struct {
-	logger   *log.Entry
-	queue    workqueue.RateLimitingInterface
-	informer cache.SharedIndexInformer
-	handler  HandlerInterface
-}
-
-// The main structure of informerEvent
-type informerevent struct {
-	key      string
-	function string
-	change   fields
+// Dictionary of event messages
+var statusDict = map[string]string{
+	"owner-disabled": "The authority or the team that owns the slice is disabled, the slice is deleted",
 }
 
 // This contains the fields to check whether they are updated
@@ -71,174 +53,76 @@ type profileData struct {
 	old    string
 }
 
-// Constant variables for events
-const create = "create"
-const update = "update"
-const delete = "delete"
-
 // Start function is entry point of the controller
 func Start(clientset kubernetes.Interface, edgenetClientset versioned.Interface) {
-	var err error
+	manager := ctlruntime.NewManager(clientset, edgenetClientset)
+	Register(manager)
+	// Run the controller loop until a signal to terminate elegantly arrives
+	manager.Run(ctlruntime.SetupSignalHandler())
+}
+
+// Register adds the slice controller to the manager, which can host other controllers alongside
+func Register(manager *ctlruntime.Manager) {
+	clientset := manager.Clientset
+	edgenetClientset := manager.EdgeNetClientset
 	sliceHandler := &Handler{}
-	// Create the slice informer which was generated by the code generator to list and watch slice resources
-	informer := appsinformer_v1.NewSliceInformer(
-		edgenetClientset,
-		metav1.NamespaceAll,
-		0,
-		cache.Indexers{},
-	)
-	// Create a work queue which contains a key of the resource to be handled by the handler
-	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	var event informerevent
-	// Event handlers deal with events of resources. In here, we take into consideration of adding and updating nodes
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			// Put the resource object into a key
-			event.key, err = cache.MetaNamespaceKeyFunc(obj)
-			event.function = create
-			log.Infof("Add slice: %s", event.key)
-			if err == nil {
-				// Add the key to the queue
-				queue.Add(event)
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			event.key, err = cache.MetaNamespaceKeyFunc(newObj)
-			event.function = update
-			// Find out whether the fields updated
-			event.change.profile.status = false
-			event.change.profile.old = ""
-			event.change.users.status = false
-			event.change.users.deleted = ""
-			event.change.users.added = ""
-			if oldObj.(*apps_v1alpha.Slice).Spec.Profile != newObj.(*apps_v1alpha.Slice).Spec.Profile {
-				event.change.profile.status = true
-				event.change.profile.old = oldObj.(*apps_v1alpha.Slice).Spec.Profile
-			}
-			if !reflect.DeepEqual(oldObj.(*apps_v1alpha.Slice).Spec.Users, newObj.(*apps_v1alpha.Slice).Spec.Users) {
-				event.change.users.status = true
-				sliceDeleted, sliceAdded := dry(oldObj.(*apps_v1alpha.Slice).Spec.Users, newObj.(*apps_v1alpha.Slice).Spec.Users)
-				sliceDeletedJSON, err := json.Marshal(sliceDeleted)
-				if err == nil {
-					event.change.users.deleted = string(sliceDeletedJSON)
-				}
-				sliceAddedJSON, err := json.Marshal(sliceAdded)
-				if err == nil {
-					event.change.users.added = string(sliceAddedJSON)
-				}
-			}
-			log.Infof("Update slice: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			// DeletionHandlingMetaNamsespaceKeyFunc helps to check the existence of the object while it is still contained in the index.
-			// Put the resource object into a key
-			event.key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			event.function = delete
-			log.Infof("Delete slice: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-	})
-	controller := controller{
-		logger:   log.NewEntry(log.New()),
-		informer: informer,
-		queue:    queue,
-		handler:  sliceHandler,
-	}
+	sliceHandler.Init(clientset, edgenetClientset)
+	informer := manager.EdgeNetInformerFactory.Apps().V1alpha().Slices().Informer()
+	controller := newController(informer, sliceHandler)
+	manager.Scheduler.Register("Slice", informer, getDeadlines, sliceHandler.ObjectExpired)
 
 	// Create the roles of EdgeNet users
 	permission.Clientset = clientset
-	permission.CreateSliceRoles()
-
-	// A channel to terminate elegantly
-	stopCh := make(chan struct{})
-	defer close(stopCh)
-	// Run the controller loop as a background task to start processing resources
-	go controller.run(stopCh, clientset, edgenetClientset)
-	// A channel to observe OS signals for smooth shut down
-	sigTerm := make(chan os.Signal, 1)
-	signal.Notify(sigTerm, syscall.SIGTERM)
-	signal.Notify(sigTerm, syscall.SIGINT)
-	<-sigTerm
+	permission.CreateSliceRoles(controller.Context())
+	manager.Add(controller)
 }
 
-// Run starts the controller loop
-func (c *controller) run(stopCh <-chan struct{}, clientset kubernetes.Interface, edgenetClientset versioned.Interface) {
-	// A Go panic which includes logging and terminating
-	defer utilruntime.HandleCrash()
-	// Shutdown after all goroutines have done
-	defer c.queue.ShutDown()
-	c.logger.Info("run: initiating")
-	c.handler.Init(clientset, edgenetClientset)
-	// Run the informer to list and watch resources
-	go c.informer.Run(stopCh)
-
-	// Synchronization to settle resources one
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
-		utilruntime.HandleError(fmt.Errorf("Error syncing cache"))
-		return
+// newController plugs the slice handler into the controller runtime
+func newController(informer cache.SharedIndexInformer, handler HandlerInterface) *ctlruntime.Controller {
+	reconciler := ctlruntime.HandlerFuncs{
+		Indexer:      informer.GetIndexer(),
+		CreateFunc:   handler.ObjectCreated,
+		UpdateFunc:   handler.ObjectUpdated,
+		FinalizeFunc: handler.ObjectDeleted,
 	}
-	c.logger.Info("run: cache sync complete")
-	// Operate the runWorker
-	go wait.Until(c.runWorker, time.Second, stopCh)
-
-	<-stopCh
-}
-
-// To process new objects added to the queue
-func (c *controller) runWorker() {
-	log.Info("runWorker: starting")
-	// Run processNextItem for all the changes
-	for c.processNextItem() {
-		log.Info("runWorker: processing next item")
-	}
-
-	log.Info("runWorker: completed")
+	return ctlruntime.New(informer, reconciler, ctlruntime.Options{
+		Name:         "slice",
+		UpdateFilter: getChange,
+	})
 }
 
-// This function deals with the queue and sends each item in it to the specified handler to be processed.
-func (c *controller) processNextItem() bool {
-	log.Info("processNextItem: start")
-	// Fetch the next item of the queue
-	event, quit := c.queue.Get()
-	if quit {
-		return false
+// getChange finds out whether the profile and the users of the slice updated
+func getChange(oldObj, newObj interface{}) (interface{}, bool) {
+	var change fields
+	oldSlice := oldObj.(*apps_v1alpha.Slice)
+	newSlice := newObj.(*apps_v1alpha.Slice)
+	if oldSlice.Spec.Profile != newSlice.Spec.Profile {
+		change.profile.status = true
+		change.profile.old = oldSlice.Spec.Profile
 	}
-	defer c.queue.Done(event)
-	// Get the key string
-	keyRaw := event.(informerevent).key
-	// Use the string key to get the object from the indexer
-	item, exists, err := c.informer.GetIndexer().GetByKey(keyRaw)
-	if err != nil {
-		if c.queue.NumRequeues(event.(informerevent).key) < 5 {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, retrying", event.(informerevent).key, err)
-			c.queue.AddRateLimited(event.(informerevent).key)
-		} else {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, no more retries", event.(informerevent).key, err)
-			c.queue.Forget(event.(informerevent).key)
-			utilruntime.HandleError(err)
+	if !reflect.DeepEqual(oldSlice.Spec.Users, newSlice.Spec.Users) {
+		change.users.status = true
+		sliceDeleted, sliceAdded := dry(oldSlice.Spec.Users, newSlice.Spec.Users)
+		sliceDeletedJSON, err := json.Marshal(sliceDeleted)
+		if err == nil {
+			change.users.deleted = string(sliceDeletedJSON)
 		}
-	}
-
-	if !exists {
-		if event.(informerevent).function == delete {
-			c.logger.Infof("Controller.processNextItem: object deleted detected: %s", keyRaw)
-			c.handler.ObjectDeleted(item)
-		}
-	} else {
-		if event.(informerevent).function == create {
-			c.logger.Infof("Controller.processNextItem: object created detected: %s", keyRaw)
-			c.handler.ObjectCreated(item)
-		} else if event.(informerevent).function == update {
-			c.logger.Infof("Controller.processNextItem: object updated detected: %s", keyRaw)
-			c.handler.ObjectUpdated(item, event.(informerevent).change)
+		sliceAddedJSON, err := json.Marshal(sliceAdded)
+		if err == nil {
+			change.users.added = string(sliceAddedJSON)
 		}
 	}
-	c.queue.Forget(event.(informerevent).key)
+	return change, true
+}
 
-	return true
+// getDeadlines schedules a reminder three days before the slice expires, and the expiry itself
+func getDeadlines(obj interface{}) map[expiry.Action]time.Time {
+	sliceObj := obj.(*apps_v1alpha.Slice)
+	if sliceObj.Status.Expires == nil {
+		return nil
+	}
+	return map[expiry.Action]time.Time{
+		expiry.Remind: sliceObj.Status.Expires.Add(-72 * time.Hour),
+		expiry.Expire: sliceObj.Status.Expires.Time,
+	}
 }
@@ -23,26 +23,32 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/totalresourcequota"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/user"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/mailer"
 	ns "github.com/EdgeNet-project/edgenet/pkg/namespace"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
 	"github.com/EdgeNet-project/edgenet/pkg/permission"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj, updated interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj, updated interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
+	ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error
 }
 
 // Handler implementation
@@ -52,6 +58,7 @@ type Handler struct {
 	lowResourceQuota  *corev1.ResourceQuota
 	medResourceQuota  *corev1.ResourceQuota
 	highResourceQuota *corev1.ResourceQuota
+	recorder          record.EventRecorder
 }
 
 // Init handles any handler initialization
@@ -59,6 +66,7 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 	log.Info("SliceHandler.Init")
 	t.clientset = kubernetes
 	t.edgenetClientset = edgenet
+	t.recorder = ctlruntime.NewEventRecorder(kubernetes, "slice-controller")
 
 	t.lowResourceQuota = &corev1.ResourceQuota{}
 	t.lowResourceQuota.Name = "slice-low-quota"
@@ -91,13 +99,23 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("SliceHandler.ObjectCreated")
 	// Create a copy of the slice object to make changes on it
 	sliceCopy := obj.(*apps_v1alpha.Slice).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	sliceOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), sliceCopy.GetNamespace(), metav1.GetOptions{})
-	sliceOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), sliceOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	sliceOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, sliceCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// The finalizer holds the deletion of the slice until ObjectDeleted cleans up after it
+	if ctlruntime.AddFinalizer(sliceCopy) {
+		sliceUpdated, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(ctx, sliceCopy, metav1.UpdateOptions{})
+		if err == nil {
+			sliceCopy = sliceUpdated
+		}
+	}
+	sliceOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, sliceOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", sliceCopy.GetNamespace(), sliceCopy.GetName())
 	// The section below checks whether the slice belongs to a team or directly to a authority. After then, set the value as enabled
 	// if the authority and the team (if it is an owner) enabled.
@@ -106,7 +124,7 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 		sliceOwnerEnabled = sliceOwnerAuthority.Spec.Enabled
 		if sliceOwnerEnabled {
 			sliceOwnerTeam, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", sliceOwnerNamespace.Labels["authority-name"])).
-				Get(context.TODO(), sliceOwnerNamespace.Labels["owner-name"], metav1.GetOptions{})
+				Get(ctx, sliceOwnerNamespace.Labels["owner-name"], metav1.GetOptions{})
 			sliceOwnerEnabled = sliceOwnerTeam.Spec.Enabled
 		}
 	} else {
@@ -117,7 +135,7 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 		// If the service restarts, it creates all objects again
 		// Because of that, this section covers a variety of possibilities
 		if sliceCopy.Status.Expires == nil {
-			resourcesAvailability := t.checkResourcesAvailabilityForSlice(sliceCopy, sliceOwnerNamespace.Labels["authority-name"])
+			resourcesAvailability := t.checkResourcesAvailabilityForSlice(ctx, sliceCopy, sliceOwnerNamespace.Labels["authority-name"])
 			if resourcesAvailability {
 				// When a slice is deleted, the owner references feature allows the namespace to be automatically removed. Additionally,
 				// when all users who participate in the slice are disabled, the slice is automatically removed because of the owner references.
@@ -126,43 +144,53 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 				// Namespace labels indicate this namespace created by a slice, not by a authority or team
 				namespaceLabels := map[string]string{"owner": "slice", "owner-name": sliceCopy.GetName(), "authority-name": sliceOwnerNamespace.Labels["authority-name"]}
 				sliceChildNamespace.SetLabels(namespaceLabels)
-				sliceChildNamespaceCreated, err := t.clientset.CoreV1().Namespaces().Create(context.TODO(), sliceChildNamespace, metav1.CreateOptions{})
+				t.setDefaultTolerations(ctx, sliceChildNamespace)
+				sliceChildNamespaceCreated, err := t.clientset.CoreV1().Namespaces().Create(ctx, sliceChildNamespace, metav1.CreateOptions{})
 				if err == nil {
 					// Create rolebindings according to the users who participate in the slice and are authority-admin and authorized users of the authority
-					t.runUserInteractions(sliceCopy, sliceChildNamespaceCreated.GetName(), sliceOwnerNamespace.Labels["authority-name"],
+					t.runUserInteractions(ctx, sliceCopy, sliceChildNamespaceCreated.GetName(), sliceOwnerNamespace.Labels["authority-name"],
 						sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-creation", true)
 					// To set constraints in the slice namespace and to update the expiration date of slice
-					sliceCopy = t.setConstrainsByProfile(sliceChildNamespaceCreated.GetName(), sliceCopy)
-					ownerReferences := t.getOwnerReferences(sliceCopy, sliceChildNamespaceCreated)
+					sliceCopy.Status.SetReady(sliceCopy.GetGeneration(), true, apps_v1alpha.ReasonReconciled, fmt.Sprintf("Slice namespace created with the %s profile", sliceCopy.Spec.Profile))
+					if sliceCopy, err = t.setConstrainsByProfile(ctx, sliceChildNamespaceCreated.GetName(), sliceCopy); err != nil {
+						log.Println(err.Error())
+					}
+					t.recorder.Eventf(sliceCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonReconciled, "Slice namespace %s created with the %s profile", sliceChildNamespaceStr, sliceCopy.Spec.Profile)
+					ownerReferences := t.getOwnerReferences(ctx, sliceCopy, sliceChildNamespaceCreated)
 					sliceCopy.ObjectMeta.OwnerReferences = ownerReferences
-					t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
+					t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(ctx, sliceCopy, metav1.UpdateOptions{})
 				} else {
-					t.runUserInteractions(sliceCopy, sliceChildNamespaceCreated.GetName(), sliceOwnerNamespace.Labels["authority-name"],
+					t.recorder.Eventf(sliceCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonNamespaceCreationFailed, "Slice namespace %s cannot be created, the slice is deleted: %s", sliceChildNamespaceStr, err)
+					t.runUserInteractions(ctx, sliceCopy, sliceChildNamespaceCreated.GetName(), sliceOwnerNamespace.Labels["authority-name"],
 						sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-crash", true)
-					t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(context.TODO(), sliceCopy.GetName(), metav1.DeleteOptions{})
-					return
+					t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(ctx, sliceCopy.GetName(), metav1.DeleteOptions{})
+					return nil
 				}
 			} else if !resourcesAvailability {
 				log.Printf("Total resource quota exceeded for %s, %s couldn't be generated", sliceOwnerNamespace.Labels["authority-name"], sliceCopy.GetName())
-				t.runUserInteractions(sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-total-quota-exceeded", false)
-				t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(context.TODO(), sliceCopy.GetName(), metav1.DeleteOptions{})
+				t.recorder.Eventf(sliceCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonQuotaExceeded, "Total resource quota of %s exceeded, the slice is deleted", sliceOwnerNamespace.Labels["authority-name"])
+				t.runUserInteractions(ctx, sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-total-quota-exceeded", false)
+				t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(ctx, sliceCopy.GetName(), metav1.DeleteOptions{})
 			}
 		}
-		// Run timeout goroutine
-		go t.runTimeout(sliceCopy)
 	} else {
-		t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(context.TODO(), sliceCopy.GetName(), metav1.DeleteOptions{})
+		t.recorder.Event(sliceCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonAuthorityDisabled, statusDict["owner-disabled"])
+		t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(ctx, sliceCopy.GetName(), metav1.DeleteOptions{})
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj, updated interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj, updated interface{}) error {
 	log.Info("SliceHandler.ObjectUpdated")
 	// Create a copy of the slice object to make changes on it
 	sliceCopy := obj.(*apps_v1alpha.Slice).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	sliceOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), sliceCopy.GetNamespace(), metav1.GetOptions{})
-	sliceOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), sliceOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	sliceOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, sliceCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	sliceOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, sliceOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", sliceCopy.GetNamespace(), sliceCopy.GetName())
 	fieldUpdated := updated.(fields)
 	// The section below checks whether the slice belongs to a team or directly to a authority. After then, set the value as enabled
@@ -172,7 +200,7 @@ func (t *Handler) ObjectUpdated(obj, updated interface{}) {
 		sliceOwnerEnabled = sliceOwnerAuthority.Spec.Enabled
 		if sliceOwnerEnabled {
 			sliceOwnerTeam, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", sliceOwnerNamespace.Labels["authority-name"])).
-				Get(context.TODO(), sliceOwnerNamespace.Labels["owner-name"], metav1.GetOptions{})
+				Get(ctx, sliceOwnerNamespace.Labels["owner-name"], metav1.GetOptions{})
 			sliceOwnerEnabled = sliceOwnerTeam.Spec.Enabled
 		}
 	} else {
@@ -182,67 +210,144 @@ func (t *Handler) ObjectUpdated(obj, updated interface{}) {
 	if sliceOwnerEnabled {
 		// If the users who participate in the slice have changed
 		if fieldUpdated.users.status { // Delete all existing role bindings in the slice (child) namespace
-			t.clientset.RbacV1().RoleBindings(sliceChildNamespaceStr).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+			t.clientset.RbacV1().RoleBindings(sliceChildNamespaceStr).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
 			// Create role bindings in the slice namespace from scratch
-			t.runUserInteractions(sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"],
+			t.runUserInteractions(ctx, sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"],
 				sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-creation", false)
 			// Send emails to those who have been added to, or removed from the slice.
 			var deletedUserList []apps_v1alpha.SliceUsers
 			json.Unmarshal([]byte(fieldUpdated.users.deleted), &deletedUserList)
 			var addedUserList []apps_v1alpha.SliceUsers
 			json.Unmarshal([]byte(fieldUpdated.users.added), &addedUserList)
+			t.recorder.Eventf(sliceCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonParticipantsChanged, "%d participant(s) added, %d removed", len(addedUserList), len(deletedUserList))
 			if len(deletedUserList) > 0 {
 				for _, deletedUser := range deletedUserList {
-					t.sendEmail(deletedUser.Username, deletedUser.Authority, sliceOwnerNamespace.Labels["authority-name"], sliceCopy.GetNamespace(), sliceCopy.GetName(), sliceChildNamespaceStr, "slice-removal")
+					t.sendEmail(ctx, deletedUser.Username, deletedUser.Authority, sliceOwnerNamespace.Labels["authority-name"], sliceCopy.GetNamespace(), sliceCopy.GetName(), sliceChildNamespaceStr, "slice-removal")
 				}
 			}
 			if len(addedUserList) > 0 {
 				for _, addedUser := range addedUserList {
-					t.sendEmail(addedUser.Username, addedUser.Authority, sliceOwnerNamespace.Labels["authority-name"], sliceCopy.GetNamespace(), sliceCopy.GetName(), sliceChildNamespaceStr, "slice-creation")
+					t.sendEmail(ctx, addedUser.Username, addedUser.Authority, sliceOwnerNamespace.Labels["authority-name"], sliceCopy.GetNamespace(), sliceCopy.GetName(), sliceChildNamespaceStr, "slice-creation")
 				}
 			}
 		}
 		// If the slice renewed or its profile updated
 		if sliceCopy.Spec.Renew || fieldUpdated.profile.status {
 			// Delete all existing resource quotas in the slice (child) namespace
-			t.clientset.CoreV1().ResourceQuotas(sliceChildNamespaceStr).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+			t.clientset.CoreV1().ResourceQuotas(sliceChildNamespaceStr).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
 			if sliceCopy.Spec.Renew {
 				sliceCopy.Spec.Renew = false
-				sliceCopyUpdate, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
+				sliceCopyUpdate, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(ctx, sliceCopy, metav1.UpdateOptions{})
 				if err == nil {
 					sliceCopy = sliceCopyUpdate
 				}
 			}
+			sliceCopy.Status.SetReady(sliceCopy.GetGeneration(), true, apps_v1alpha.ReasonReconciled, fmt.Sprintf("Slice runs with the %s profile", sliceCopy.Spec.Profile))
 			if fieldUpdated.profile.status {
-				resourcesAvailability := t.checkResourcesAvailabilityForSlice(sliceCopy, sliceOwnerNamespace.Labels["authority-name"])
+				resourcesAvailability := t.checkResourcesAvailabilityForSlice(ctx, sliceCopy, sliceOwnerNamespace.Labels["authority-name"])
 				if !resourcesAvailability {
 					sliceCopy.Spec.Profile = fieldUpdated.profile.old
-					sliceCopyUpdate, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
+					sliceCopyUpdate, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(ctx, sliceCopy, metav1.UpdateOptions{})
 					if err == nil {
 						sliceCopy = sliceCopyUpdate
-						t.runUserInteractions(sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-lack-of-quota", false)
+						t.recorder.Eventf(sliceCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonQuotaExceeded, "Total resource quota of %s exceeded, the profile goes back to %s", sliceOwnerNamespace.Labels["authority-name"], sliceCopy.Spec.Profile)
+						t.runUserInteractions(ctx, sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-lack-of-quota", false)
 					}
+					// The slice is not ready as the profile it asks for does not fit in the total resource quota
+					sliceCopy.Status.SetReady(sliceCopy.GetGeneration(), false, apps_v1alpha.ReasonQuotaExceeded,
+						fmt.Sprintf("Total resource quota of %s exceeded, the profile goes back to %s", sliceOwnerNamespace.Labels["authority-name"], fieldUpdated.profile.old))
 				}
 			}
-			t.setConstrainsByProfile(sliceChildNamespaceStr, sliceCopy)
+			sliceCopy, err = t.setConstrainsByProfile(ctx, sliceChildNamespaceStr, sliceCopy)
+			if err != nil {
+				log.Println(err.Error())
+			} else if sliceCopy.Status.Expires != nil {
+				t.recorder.Eventf(sliceCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonRenewed, "Slice runs with the %s profile until %s", sliceCopy.Spec.Profile, sliceCopy.Status.Expires.Format(time.RFC1123))
+			}
 		}
 	} else {
-		t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(context.TODO(), sliceCopy.GetName(), metav1.DeleteOptions{})
+		t.recorder.Event(sliceCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonAuthorityDisabled, statusDict["owner-disabled"])
+		t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(ctx, sliceCopy.GetName(), metav1.DeleteOptions{})
 	}
+	return nil
 }
 
-// ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+// ObjectDeleted is called when an object is deleted, before it disappears thanks to the finalizer
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("SliceHandler.ObjectDeleted")
-	// Mail notification, TBD
+	sliceCopy := obj.(*apps_v1alpha.Slice).DeepCopy()
+	if err := t.cleanup(ctx, sliceCopy); err != nil {
+		return err
+	}
+	if ctlruntime.RemoveFinalizer(sliceCopy) {
+		_, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(ctx, sliceCopy, metav1.UpdateOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObjectExpired is called by the scheduler when the slice is about to expire, and when it expires
+func (t *Handler) ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error {
+	log.Info("SliceHandler.ObjectExpired")
+	sliceCopy := obj.(*apps_v1alpha.Slice).DeepCopy()
+	switch action {
+	case expiry.Remind:
+		sliceOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, sliceCopy.GetNamespace(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", sliceCopy.GetNamespace(), sliceCopy.GetName())
+		t.runUserInteractions(ctx, sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-reminder", false)
+		t.recorder.Eventf(sliceCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonExpiryReminder, "Slice expires on %s", sliceCopy.Status.Expires.Format(time.RFC1123))
+	case expiry.Expire:
+		t.recorder.Event(sliceCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonExpired, "Slice expired, it is deleted")
+		err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(ctx, sliceCopy.GetName(), metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		// The finalizer may do it as well
+		return t.cleanup(ctx, sliceCopy)
+	}
+	return nil
+}
+
+// cleanup notifies the participants, removes the slice namespace, and gives the resources back to the total resource quota.
+// Both the finalizer and the expiry of the slice call it, the one that finds the slice namespace in place does the work.
+func (t *Handler) cleanup(ctx context.Context, sliceCopy *apps_v1alpha.Slice) error {
+	sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", sliceCopy.GetNamespace(), sliceCopy.GetName())
+	sliceChildNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, sliceChildNamespaceStr, metav1.GetOptions{})
+	if errors.IsNotFound(err) || (err == nil && sliceChildNamespace.GetDeletionTimestamp() != nil) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	sliceOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, sliceCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	t.runUserInteractions(ctx, sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-deletion", false)
+	err = t.clientset.CoreV1().Namespaces().Delete(ctx, sliceChildNamespaceStr, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	t.recorder.Eventf(sliceCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonCleanedUp, "Slice namespace %s deleted", sliceChildNamespaceStr)
+	TRQCopy, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(ctx, sliceOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	if err == nil {
+		TRQHandler := totalresourcequota.Handler{}
+		TRQHandler.Init(t.clientset, t.edgenetClientset)
+		TRQHandler.ResourceConsumptionControl(ctx, TRQCopy, 0, 0)
+	}
+	return nil
 }
 
 // getOwnerReferences returns the users and the child namespace as owners
-func (t *Handler) getOwnerReferences(sliceCopy *apps_v1alpha.Slice, namespace *corev1.Namespace) []metav1.OwnerReference {
+func (t *Handler) getOwnerReferences(ctx context.Context, sliceCopy *apps_v1alpha.Slice, namespace *corev1.Namespace) []metav1.OwnerReference {
 	ownerReferences := ns.SetAsOwnerReference(namespace)
 	// The following section makes users who participate in that team become the team owners
 	for _, sliceUser := range sliceCopy.Spec.Users {
-		userCopy, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUser.Authority)).Get(context.TODO(), sliceUser.Username, metav1.GetOptions{})
+		userCopy, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUser.Authority)).Get(ctx, sliceUser.Username, metav1.GetOptions{})
 		if err == nil && userCopy.Spec.Active && userCopy.Status.AUP {
 			ownerReferences = append(ownerReferences, user.SetAsOwnerReference(userCopy)...)
 		}
@@ -250,79 +355,102 @@ func (t *Handler) getOwnerReferences(sliceCopy *apps_v1alpha.Slice, namespace *c
 	return ownerReferences
 }
 
-func (t *Handler) checkResourcesAvailabilityForSlice(sliceCopy *apps_v1alpha.Slice, authorityName string) bool {
-	TRQCopy, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), authorityName, metav1.GetOptions{})
+func (t *Handler) checkResourcesAvailabilityForSlice(ctx context.Context, sliceCopy *apps_v1alpha.Slice, authorityName string) bool {
+	TRQCopy, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(ctx, authorityName, metav1.GetOptions{})
 	quotaExceeded := true
 	if err == nil {
 		TRQHandler := totalresourcequota.Handler{}
 		TRQHandler.Init(t.clientset, t.edgenetClientset)
 		switch sliceCopy.Spec.Profile {
 		case "Low":
-			_, quotaExceeded = TRQHandler.ResourceConsumptionControl(TRQCopy, t.lowResourceQuota.Spec.Hard.Cpu().Value(), t.lowResourceQuota.Spec.Hard.Memory().Value())
+			_, quotaExceeded = TRQHandler.ResourceConsumptionControl(ctx, TRQCopy, t.lowResourceQuota.Spec.Hard.Cpu().Value(), t.lowResourceQuota.Spec.Hard.Memory().Value())
 		case "Medium":
-			_, quotaExceeded = TRQHandler.ResourceConsumptionControl(TRQCopy, t.medResourceQuota.Spec.Hard.Cpu().Value(), t.medResourceQuota.Spec.Hard.Memory().Value())
+			_, quotaExceeded = TRQHandler.ResourceConsumptionControl(ctx, TRQCopy, t.medResourceQuota.Spec.Hard.Cpu().Value(), t.medResourceQuota.Spec.Hard.Memory().Value())
 		case "High":
-			_, quotaExceeded = TRQHandler.ResourceConsumptionControl(TRQCopy, t.highResourceQuota.Spec.Hard.Cpu().Value(), t.highResourceQuota.Spec.Hard.Memory().Value())
+			_, quotaExceeded = TRQHandler.ResourceConsumptionControl(ctx, TRQCopy, t.highResourceQuota.Spec.Hard.Cpu().Value(), t.highResourceQuota.Spec.Hard.Memory().Value())
 		}
 	}
 	return !quotaExceeded
 }
 
-// setConstrainsByProfile allocates the resources corresponding to the slice profile and defines the expiration date
-func (t *Handler) setConstrainsByProfile(childNamespace string, sliceCopy *apps_v1alpha.Slice) *apps_v1alpha.Slice {
+// setConstrainsByProfile allocates the resources corresponding to the slice profile and defines the expiration date.
+// It returns the updated slice, or the slice as it is if its status cannot be updated.
+func (t *Handler) setConstrainsByProfile(ctx context.Context, childNamespace string, sliceCopy *apps_v1alpha.Slice) (*apps_v1alpha.Slice, error) {
 	switch sliceCopy.Spec.Profile {
 	case "Low":
 		// Set the timeout which is 6 weeks for low profile slices
 		sliceCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(1344 * time.Hour),
 		}
-		t.clientset.CoreV1().ResourceQuotas(childNamespace).Create(context.TODO(), t.lowResourceQuota, metav1.CreateOptions{})
+		t.clientset.CoreV1().ResourceQuotas(childNamespace).Create(ctx, t.lowResourceQuota, metav1.CreateOptions{})
 	case "Medium":
 		// Set the timeout which is 4 weeks for medium profile slices
 		sliceCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(672 * time.Hour),
 		}
-		t.clientset.CoreV1().ResourceQuotas(childNamespace).Create(context.TODO(), t.medResourceQuota, metav1.CreateOptions{})
+		t.clientset.CoreV1().ResourceQuotas(childNamespace).Create(ctx, t.medResourceQuota, metav1.CreateOptions{})
 	case "High":
 		// Set the timeout which is 2 weeks for high profile slices
 		sliceCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(336 * time.Hour),
 		}
-		t.clientset.CoreV1().ResourceQuotas(childNamespace).Create(context.TODO(), t.highResourceQuota, metav1.CreateOptions{})
+		t.clientset.CoreV1().ResourceQuotas(childNamespace).Create(ctx, t.highResourceQuota, metav1.CreateOptions{})
+	}
+	sliceCopyUpdate, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).UpdateStatus(ctx, sliceCopy, metav1.UpdateOptions{})
+	if err != nil {
+		return sliceCopy, err
+	}
+	return sliceCopyUpdate, nil
+}
+
+// setDefaultTolerations lets the pods of the slice namespace run on the limited nodes that the namespace may use,
+// whether a selective deployment creates them or not
+func (t *Handler) setDefaultTolerations(ctx context.Context, namespace *corev1.Namespace) {
+	limitedNodes, err := t.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: node.LimitedKey})
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	if err := node.SetDefaultTolerations(namespace, node.DefaultTolerations(limitedNodes.Items, namespace)); err != nil {
+		log.Println(err.Error())
 	}
-	sliceCopyUpdate, _ := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).UpdateStatus(context.TODO(), sliceCopy, metav1.UpdateOptions{})
-	return sliceCopyUpdate
 }
 
 // runUserInteractions creates user role bindings according to the roles and send emails separately
-func (t *Handler) runUserInteractions(sliceCopy *apps_v1alpha.Slice, sliceChildNamespaceStr, ownerAuthority, sliceOwner, sliceOwnerName, operation string, firstCreation bool) {
+func (t *Handler) runUserInteractions(ctx context.Context, sliceCopy *apps_v1alpha.Slice, sliceChildNamespaceStr, ownerAuthority, sliceOwner, sliceOwnerName, operation string, firstCreation bool) {
 	// This part for the users who participate in the slice
 	for _, sliceUser := range sliceCopy.Spec.Users {
-		user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUser.Authority)).Get(context.TODO(), sliceUser.Username, metav1.GetOptions{})
+		user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUser.Authority)).Get(ctx, sliceUser.Username, metav1.GetOptions{})
 		if err == nil && user.Spec.Active && user.Status.AUP {
 			if operation == "slice-creation" {
-				permission.EstablishRoleBindings(user.DeepCopy(), sliceChildNamespaceStr, "Slice")
+				permission.EstablishRoleBindings(ctx, user.DeepCopy(), sliceChildNamespaceStr, "Slice")
 			}
 			if !(operation == "slice-creation" && !firstCreation) {
-				t.sendEmail(sliceUser.Username, sliceUser.Authority, ownerAuthority, sliceCopy.GetNamespace(), sliceCopy.GetName(), sliceChildNamespaceStr, operation)
+				t.sendEmail(ctx, sliceUser.Username, sliceUser.Authority, ownerAuthority, sliceCopy.GetNamespace(), sliceCopy.GetName(), sliceChildNamespaceStr, operation)
 			}
 		}
 	}
 
 	if !(sliceOwner == "team" && operation != "slice-creation") {
 		// For those who are authority-admin and authorized users of the authority
-		userRaw, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", ownerAuthority)).List(context.TODO(), metav1.ListOptions{})
+		userRaw, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", ownerAuthority)).List(ctx, metav1.ListOptions{})
 		if err == nil {
 			for _, userRow := range userRaw.Items {
 				if userRow.Spec.Active && userRow.Status.AUP && (userRow.Status.Type == "admin" ||
-					permission.CheckAuthorization(sliceCopy.GetNamespace(), userRow.Spec.Email, "slices", sliceCopy.GetName())) {
+					permission.CheckAuthorization(ctx, sliceCopy.GetNamespace(), userRow.Spec.Email, "slices", sliceCopy.GetName())) {
 					if operation == "slice-creation" {
-						permission.EstablishRoleBindings(userRow.DeepCopy(), sliceChildNamespaceStr, "Slice")
-						//mailSubject = "creation"
+						permission.EstablishRoleBindings(ctx, userRow.DeepCopy(), sliceChildNamespaceStr, "Slice")
+					}
+					// The participants got the email above
+					participant := false
+					for _, sliceUser := range sliceCopy.Spec.Users {
+						if sliceUser.Authority == ownerAuthority && sliceUser.Username == userRow.GetName() {
+							participant = true
+						}
+					}
+					if !participant && !(operation == "slice-creation" && !firstCreation) && !(operation == "slice-creation" && sliceOwner == "team") {
+						t.sendEmail(ctx, userRow.GetName(), ownerAuthority, ownerAuthority, sliceCopy.GetNamespace(), sliceCopy.GetName(), sliceChildNamespaceStr, operation)
 					}
-					/*if !(operation == "slice-creation" && !firstCreation) && !(operation == "slice-creation" && sliceOwner == "team") {
-						t.sendEmail(userRow.GetName(), ownerAuthority, ownerAuthority, sliceCopy.GetName(), sliceChildNamespaceStr, mailSubject)
-					}*/
 				}
 			}
 		}
@@ -330,8 +458,8 @@ func (t *Handler) runUserInteractions(sliceCopy *apps_v1alpha.Slice, sliceChildN
 }
 
 // sendEmail to send notification to participants
-func (t *Handler) sendEmail(sliceUsername, sliceUserAuthority, sliceAuthority, sliceOwnerNamespace, sliceName, sliceNamespace, subject string) {
-	user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUserAuthority)).Get(context.TODO(), sliceUsername, metav1.GetOptions{})
+func (t *Handler) sendEmail(ctx context.Context, sliceUsername, sliceUserAuthority, sliceAuthority, sliceOwnerNamespace, sliceName, sliceNamespace, subject string) {
+	user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUserAuthority)).Get(ctx, sliceUsername, metav1.GetOptions{})
 	if err == nil && user.Spec.Active && user.Status.AUP {
 		// Set the HTML template variables
 		contentData := mailer.ResourceAllocationData{}
@@ -347,99 +475,6 @@ func (t *Handler) sendEmail(sliceUsername, sliceUserAuthority, sliceAuthority, s
 	}
 }
 
-// runTimeout puts a procedure in place to remove slice after the timeout
-func (t *Handler) runTimeout(sliceCopy *apps_v1alpha.Slice) {
-	timeoutRenewed := make(chan bool, 1)
-	terminated := make(chan bool, 1)
-	var timeout <-chan time.Time
-	var reminder <-chan time.Time
-	if sliceCopy.Status.Expires != nil {
-		timeout = time.After(time.Until(sliceCopy.Status.Expires.Time))
-		reminder = time.After(time.Until(sliceCopy.Status.Expires.Time.Add(time.Hour * -72)))
-	}
-	closeChannels := func() {
-		close(timeoutRenewed)
-		close(terminated)
-	}
-
-	// Watch the events of slice object
-	watchSlice, err := t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Watch(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", sliceCopy.GetName())})
-	if err == nil {
-		go func() {
-			// Get events from watch interface
-			for SliceEvent := range watchSlice.ResultChan() {
-				// Get updated slice object
-				updatedSlice, status := SliceEvent.Object.(*apps_v1alpha.Slice)
-				// FieldSelector doesn't work properly, and will be checked in for next releases.
-				if sliceCopy.GetUID() == updatedSlice.GetUID() {
-					if status {
-						if SliceEvent.Type == "DELETED" {
-							terminated <- true
-							continue
-						}
-
-						if updatedSlice.Status.Expires != nil {
-							// Check whether expiration date updated - TBD
-							/*if sliceCopy.Status.Expires != nil && timeout != nil {
-								if sliceCopy.Status.Expires.Time == updatedSlice.Status.Expires.Time {
-									sliceCopy = updatedSlice
-									continue
-								}
-							}*/
-
-							if updatedSlice.Status.Expires.Time.Sub(time.Now()) >= 0 {
-								timeout = time.After(time.Until(updatedSlice.Status.Expires.Time))
-								reminder = time.After(time.Until(updatedSlice.Status.Expires.Time.Add(time.Hour * -72)))
-								timeoutRenewed <- true
-							} else {
-								terminated <- true
-							}
-						}
-						sliceCopy = updatedSlice
-					}
-				}
-			}
-		}()
-	} else {
-		// In case of any malfunction of watching slice resources,
-		// there is a timeout at 72 hours
-		timeout = time.After(72 * time.Hour)
-	}
-
-	// Infinite loop
-timeoutLoop:
-	for {
-		// Wait on multiple channel operations
-	timeoutOptions:
-		select {
-		case <-timeoutRenewed:
-			break timeoutOptions
-		case <-reminder:
-			sliceOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), sliceCopy.GetNamespace(), metav1.GetOptions{})
-			sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", sliceCopy.GetNamespace(), sliceCopy.GetName())
-			t.runUserInteractions(sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-reminder", false)
-			break timeoutOptions
-		case <-timeout:
-			t.edgenetClientset.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Delete(context.TODO(), sliceCopy.GetName(), metav1.DeleteOptions{})
-			break timeoutOptions
-		case <-terminated:
-			watchSlice.Stop()
-			sliceOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), sliceCopy.GetNamespace(), metav1.GetOptions{})
-			sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", sliceCopy.GetNamespace(), sliceCopy.GetName())
-			t.runUserInteractions(sliceCopy, sliceChildNamespaceStr, sliceOwnerNamespace.Labels["authority-name"], sliceOwnerNamespace.Labels["owner"], sliceOwnerNamespace.Labels["owner-name"], "slice-deletion", false)
-			t.clientset.CoreV1().Namespaces().Delete(context.TODO(), sliceChildNamespaceStr, metav1.DeleteOptions{})
-			TRQCopy, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), sliceOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
-			if err == nil {
-				TRQHandler := totalresourcequota.Handler{}
-				TRQHandler.Init(t.clientset, t.edgenetClientset)
-				TRQHandler.ResourceConsumptionControl(TRQCopy, 0, 0)
-			}
-			closeChannels()
-			break timeoutLoop
-		}
-	}
-}
-
 // dry function remove the same values of the old and new objects from the old object to have
 // the slice of deleted and added values.
 func dry(oldSlice []apps_v1alpha.SliceUsers, newSlice []apps_v1alpha.SliceUsers) ([]apps_v1alpha.SliceUsers, []apps_v1alpha.SliceUsers) {
@@ -2,6 +2,7 @@ package slice
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -12,8 +13,11 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	"github.com/EdgeNet-project/edgenet/pkg/node"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 	"github.com/sirupsen/logrus"
 
@@ -23,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 // Constant variables for events
@@ -186,9 +191,11 @@ func TestSlice(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
 	g.handler.Init(g.client, g.edgenetClient)
+	recorder := record.NewFakeRecorder(100)
+	g.handler.recorder = recorder
 	// Create a slice
 	g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Create(context.TODO(), g.sliceObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(g.sliceObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.sliceObj.DeepCopy())
 	sliceCopy, err := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
 	util.OK(t, err)
 	childNamespaceStr := fmt.Sprintf("%s-slice-%s", g.sliceObj.GetNamespace(), g.sliceObj.GetName())
@@ -197,6 +204,9 @@ func TestSlice(t *testing.T) {
 	memory := memoryRes.Value()
 	CPURes := resource.MustParse(TRQCopy.Spec.Claim[0].CPU)
 	cpu := CPURes.Value()
+	t.Run("reconciled event", func(t *testing.T) {
+		util.Equals(t, true, strings.HasPrefix(<-recorder.Events, fmt.Sprintf("%s %s", corev1.EventTypeNormal, apps_v1alpha.ReasonReconciled)))
+	})
 	t.Run("namespace", func(t *testing.T) {
 		_, err := g.handler.clientset.CoreV1().Namespaces().Get(context.TODO(), childNamespaceStr, metav1.GetOptions{})
 		util.OK(t, err)
@@ -209,6 +219,13 @@ func TestSlice(t *testing.T) {
 		_, err := g.client.RbacV1().RoleBindings(childNamespaceStr).Get(context.TODO(), fmt.Sprintf("authority-%s-%s-slice-%s", g.authorityObj.GetName(), g.authorityObj.Spec.Contact.Username, "admin"), metav1.GetOptions{})
 		util.OK(t, err)
 	})
+	t.Run("finalizer", func(t *testing.T) {
+		util.Equals(t, true, ctlruntime.HasFinalizer(sliceCopy))
+	})
+	t.Run("ready", func(t *testing.T) {
+		util.Equals(t, metav1.ConditionTrue, sliceCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Status)
+		util.Equals(t, apps_v1alpha.ReasonReconciled, sliceCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Reason)
+	})
 	t.Run("set expiry date", func(t *testing.T) {
 		expected := metav1.Time{
 			Time: time.Now().Add(336 * time.Hour),
@@ -228,9 +245,12 @@ func TestSlice(t *testing.T) {
 		slice := g.sliceObj
 		slice.SetName("exceed")
 		g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Create(context.TODO(), slice.DeepCopy(), metav1.CreateOptions{})
-		g.handler.ObjectCreated(slice.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), slice.DeepCopy())
 		_, err := g.edgenetClient.AppsV1alpha().Slices(slice.GetNamespace()).Get(context.TODO(), slice.GetName(), metav1.GetOptions{})
 		util.Equals(t, true, errors.IsNotFound(err))
+		t.Run("event", func(t *testing.T) {
+			util.Equals(t, true, strings.HasPrefix(<-recorder.Events, fmt.Sprintf("%s %s", corev1.EventTypeWarning, apps_v1alpha.ReasonQuotaExceeded)))
+		})
 		t.Run("consumed quota", func(t *testing.T) {
 			TRQCopy, _ := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), g.TRQObj.GetName(), metav1.GetOptions{})
 			CPUPercentage := float64(g.handler.highResourceQuota.Spec.Hard.Cpu().Value()) / float64(cpu) * 100
@@ -240,12 +260,20 @@ func TestSlice(t *testing.T) {
 		})
 	})
 	t.Run("timeout", func(t *testing.T) {
-		go g.handler.runTimeout(sliceCopy)
 		sliceCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(10 * time.Millisecond),
 		}
 		g.edgenetClient.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
-		time.Sleep(100 * time.Millisecond)
+		t.Run("deadlines", func(t *testing.T) {
+			deadlines := getDeadlines(sliceCopy)
+			util.Equals(t, sliceCopy.Status.Expires.Add(-72*time.Hour), deadlines[expiry.Remind])
+			util.Equals(t, sliceCopy.Status.Expires.Time, deadlines[expiry.Expire])
+		})
+		util.OK(t, g.handler.ObjectExpired(context.TODO(), sliceCopy, expiry.Remind))
+		t.Run("reminder event", func(t *testing.T) {
+			util.Equals(t, true, strings.HasPrefix(<-recorder.Events, fmt.Sprintf("%s %s", corev1.EventTypeNormal, apps_v1alpha.ReasonExpiryReminder)))
+		})
+		util.OK(t, g.handler.ObjectExpired(context.TODO(), sliceCopy, expiry.Expire))
 		t.Run("delete slice", func(t *testing.T) {
 			_, err := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
 			util.Equals(t, true, errors.IsNotFound(err))
@@ -262,6 +290,81 @@ func TestSlice(t *testing.T) {
 	})
 }
 
+func TestLimitedNodes(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient)
+	node.Clientset = g.client
+	limitations := map[string][]apps_v1alpha.Limitations{
+		"permitted.edge-net.io": {{Slice: g.sliceObj.GetName()}},
+		"forbidden.edge-net.io": {{Slice: "other"}},
+	}
+	for name, nodeLimitations := range limitations {
+		g.client.CoreV1().Nodes().Create(context.TODO(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+		util.OK(t, node.SetLimitations(context.TODO(), name, nodeLimitations))
+	}
+	g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Create(context.TODO(), g.sliceObj.DeepCopy(), metav1.CreateOptions{})
+	g.handler.ObjectCreated(context.TODO(), g.sliceObj.DeepCopy())
+	childNamespaceStr := fmt.Sprintf("%s-slice-%s", g.sliceObj.GetNamespace(), g.sliceObj.GetName())
+	childNamespace, err := g.client.CoreV1().Namespaces().Get(context.TODO(), childNamespaceStr, metav1.GetOptions{})
+	util.OK(t, err)
+	// A plain pod gets the default tolerations of its namespace through the admission plugin
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: childNamespaceStr}}
+	util.OK(t, json.Unmarshal([]byte(childNamespace.Annotations[node.DefaultTolerationsAnnotation]), &pod.Spec.Tolerations))
+	tolerates := func(nodeName string) bool {
+		nodeObj, err := g.client.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		util.OK(t, err)
+		for _, taint := range nodeObj.Spec.Taints {
+			tolerated := false
+			for _, toleration := range pod.Spec.Tolerations {
+				tolerated = tolerated || toleration.ToleratesTaint(&taint)
+			}
+			if !tolerated {
+				return false
+			}
+		}
+		return true
+	}
+	t.Run("permitted node", func(t *testing.T) {
+		util.Equals(t, true, tolerates("permitted.edge-net.io"))
+	})
+	t.Run("forbidden node", func(t *testing.T) {
+		util.Equals(t, false, tolerates("forbidden.edge-net.io"))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient)
+	g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Create(context.TODO(), g.sliceObj.DeepCopy(), metav1.CreateOptions{})
+	g.handler.ObjectCreated(context.TODO(), g.sliceObj.DeepCopy())
+	sliceCopy, _ := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
+	childNamespaceStr := fmt.Sprintf("%s-slice-%s", g.sliceObj.GetNamespace(), g.sliceObj.GetName())
+	// The finalizer holds the slice in place
+	sliceCopy.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	sliceCopy, _ = g.edgenetClient.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
+
+	err := g.handler.ObjectDeleted(context.TODO(), sliceCopy.DeepCopy())
+	util.OK(t, err)
+	t.Run("finalizer", func(t *testing.T) {
+		sliceCopy, _ := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, false, ctlruntime.HasFinalizer(sliceCopy))
+	})
+	t.Run("delete namespace", func(t *testing.T) {
+		_, err := g.handler.clientset.CoreV1().Namespaces().Get(context.TODO(), childNamespaceStr, metav1.GetOptions{})
+		util.Equals(t, true, errors.IsNotFound(err))
+	})
+	t.Run("consumed quota", func(t *testing.T) {
+		TRQCopy, _ := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), g.TRQObj.GetName(), metav1.GetOptions{})
+		util.Equals(t, float64(0), TRQCopy.Status.Used.CPU)
+		util.Equals(t, float64(0), TRQCopy.Status.Used.Memory)
+	})
+	t.Run("repeated", func(t *testing.T) {
+		util.OK(t, g.handler.ObjectDeleted(context.TODO(), sliceCopy.DeepCopy()))
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
@@ -270,7 +373,7 @@ func TestUpdate(t *testing.T) {
 	// Create a slice
 	sliceCopy, err := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Create(context.TODO(), g.sliceObj.DeepCopy(), metav1.CreateOptions{})
 	util.OK(t, err)
-	g.handler.ObjectCreated(g.sliceObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.sliceObj.DeepCopy())
 	childNamespaceStr := fmt.Sprintf("%s-slice-%s", g.sliceObj.GetNamespace(), g.sliceObj.GetName())
 	TRQCopy, _ := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), g.TRQObj.GetName(), metav1.GetOptions{})
 	memoryRes := resource.MustParse(TRQCopy.Spec.Claim[0].Memory)
@@ -296,14 +399,13 @@ func TestUpdate(t *testing.T) {
 		_, err = g.edgenetClient.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
 		util.OK(t, err)
 		// Invoking ObjectUpdated to send emails to users removed or added to slice
-		g.handler.ObjectUpdated(sliceCopy, field)
+		g.handler.ObjectUpdated(context.TODO(), sliceCopy, field)
 		// Check user rolebinding in slice child namespace
 		_, err = g.client.RbacV1().RoleBindings(childNamespaceStr).Get(context.TODO(), fmt.Sprintf("%s-%s-slice-%s", g.userObj.GetNamespace(), g.userObj.GetName(), "user"), metav1.GetOptions{})
 		// Verifying server created rolebinding for new user in slice's child namespace
 		util.OK(t, err)
 	})
 	t.Run("renew", func(t *testing.T) {
-		go g.handler.runTimeout(sliceCopy)
 		sliceCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(300 * time.Millisecond),
 		}
@@ -313,8 +415,13 @@ func TestUpdate(t *testing.T) {
 		_, err = g.edgenetClient.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
 		util.OK(t, err)
 		var field fields
-		g.handler.ObjectUpdated(sliceCopy, field)
-		time.Sleep(350 * time.Millisecond)
+		g.handler.ObjectUpdated(context.TODO(), sliceCopy, field)
+		sliceCopy, err = g.edgenetClient.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Get(context.TODO(), sliceCopy.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+
+		t.Run("postpone expiry", func(t *testing.T) {
+			util.Equals(t, true, getDeadlines(sliceCopy)[expiry.Expire].After(time.Now().Add(300*time.Millisecond)))
+		})
 
 		t.Run("save slice", func(t *testing.T) {
 			_, err := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
@@ -341,7 +448,7 @@ func TestUpdate(t *testing.T) {
 		field.profile.status = true
 		err := g.client.CoreV1().ResourceQuotas(childNamespaceStr).Delete(context.TODO(), g.handler.highResourceQuota.GetName(), metav1.DeleteOptions{})
 		util.OK(t, err)
-		g.handler.ObjectUpdated(sliceCopy, field)
+		g.handler.ObjectUpdated(context.TODO(), sliceCopy, field)
 		sliceCopy, err := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
 		t.Run("set expiry date", func(t *testing.T) {
@@ -360,6 +467,28 @@ func TestUpdate(t *testing.T) {
 			util.Equals(t, memoryPercentage, TRQCopy.Status.Used.Memory)
 		})
 	})
+
+	t.Run("profile beyond quota", func(t *testing.T) {
+		// Another slice takes the quota that the first one leaves
+		slice := g.sliceObj.DeepCopy()
+		slice.SetName("other")
+		slice.Spec.Profile = "High"
+		g.edgenetClient.AppsV1alpha().Slices(slice.GetNamespace()).Create(context.TODO(), slice.DeepCopy(), metav1.CreateOptions{})
+		g.handler.ObjectCreated(context.TODO(), slice.DeepCopy())
+		sliceCopy, err := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		sliceCopy.Spec.Profile = "High"
+		g.edgenetClient.AppsV1alpha().Slices(sliceCopy.GetNamespace()).Update(context.TODO(), sliceCopy, metav1.UpdateOptions{})
+		var field fields
+		field.profile.old = "Low"
+		field.profile.status = true
+		g.handler.ObjectUpdated(context.TODO(), sliceCopy, field)
+		sliceCopy, err = g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Get(context.TODO(), g.sliceObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		util.Equals(t, "Low", sliceCopy.Spec.Profile)
+		util.Equals(t, metav1.ConditionFalse, sliceCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Status)
+		util.Equals(t, apps_v1alpha.ReasonQuotaExceeded, sliceCopy.Status.GetCondition(apps_v1alpha.ConditionReady).Reason)
+	})
 }
 
 func TestOperations(t *testing.T) {
@@ -369,7 +498,7 @@ func TestOperations(t *testing.T) {
 	// Create a slice
 	sliceCopy, err := g.edgenetClient.AppsV1alpha().Slices(g.sliceObj.GetNamespace()).Create(context.TODO(), g.sliceObj.DeepCopy(), metav1.CreateOptions{})
 	util.OK(t, err)
-	g.handler.ObjectCreated(g.sliceObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.sliceObj.DeepCopy())
 	childNamespaceStr := fmt.Sprintf("%s-slice-%s", g.sliceObj.GetNamespace(), g.sliceObj.GetName())
 	TRQCopy, _ := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), g.TRQObj.GetName(), metav1.GetOptions{})
 	memoryRes := resource.MustParse(TRQCopy.Spec.Claim[0].Memory)
@@ -381,8 +510,9 @@ func TestOperations(t *testing.T) {
 		err := g.client.CoreV1().ResourceQuotas(childNamespaceStr).Delete(context.TODO(), oldQuota.GetName(), metav1.DeleteOptions{})
 		util.OK(t, err)
 		sliceCopy.Spec.Profile = profile
-		g.handler.checkResourcesAvailabilityForSlice(sliceCopy, g.authorityObj.GetName())
-		sliceCopy := g.handler.setConstrainsByProfile(childNamespaceStr, sliceCopy)
+		g.handler.checkResourcesAvailabilityForSlice(context.TODO(), sliceCopy, g.authorityObj.GetName())
+		sliceCopy, err := g.handler.setConstrainsByProfile(context.TODO(), childNamespaceStr, sliceCopy)
+		util.OK(t, err)
 		t.Run("set expiry date", func(t *testing.T) {
 			expected := metav1.Time{
 				Time: time.Now().Add(expectedDuration),
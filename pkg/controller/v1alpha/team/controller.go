@@ -17,43 +17,20 @@ limitations under the License.
 package team
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
-	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
-	appsinformer_v1 "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/apps/v1alpha"
 	"github.com/EdgeNet-project/edgenet/pkg/permission"
 
-	log "github.com/sirupsen/logrus"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 )
 
-// The main structure of controller
-type controller struct {
-	logger   *log.Entry
-	queue    workqueue.RateLimitingInterface
-	informer cache.SharedIndexInformer
-	handler  HandlerInterface
-}
-
-// The main structure of informerEvent
-type informerevent struct {
-	key      string
-	function string
-	change   fields
-}
-
 // This contains the fields to check whether they are updated
 type fields struct {
 	enabled bool
@@ -74,180 +51,85 @@ type objectData struct {
 }
 
 // Constant variables for events
-const create = "create"
-const update = "update"
-const delete = "delete"
 const success = "Successful"
 
+// Dictionary of status messages
+var statusDict = map[string]string{
+	"authority-disabled": "Authority disabled, the team is deleted",
+	"team-disabled":      "Team disabled, its slices are deleted",
+}
+
 // Start function is entry point of the controller
 func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
-	var err error
-	clientset := kubernetes
-	edgenetClientset := edgenet
+	manager := ctlruntime.NewManager(kubernetes, edgenet)
+	Register(manager)
+	// Run the controller loop until a signal to terminate elegantly arrives
+	manager.Run(ctlruntime.SetupSignalHandler())
+}
+
+// Register adds the team controller to the manager, which can host other controllers alongside
+func Register(manager *ctlruntime.Manager) {
+	clientset := manager.Clientset
+	edgenetClientset := manager.EdgeNetClientset
 	teamHandler := &Handler{}
-	// Create the team informer which was generated by the code generator to list and watch team resources
-	informer := appsinformer_v1.NewTeamInformer(
-		edgenetClientset,
-		metav1.NamespaceAll,
-		0,
-		cache.Indexers{},
-	)
-	// Create a work queue which contains a key of the resource to be handled by the handler
-	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	var event informerevent
-	// Event handlers deal with events of resources. In here, we take into consideration of adding and updating nodes
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			// Put the resource object into a key
-			event.key, err = cache.MetaNamespaceKeyFunc(obj)
-			event.function = create
-			log.Infof("Add team: %s", event.key)
-			if err == nil {
-				// Add the key to the queue
-				queue.Add(event)
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			event.key, err = cache.MetaNamespaceKeyFunc(newObj)
-			event.function = update
-			// Find out whether the fields updated
-			event.change.users.status = false
-			event.change.users.deleted = ""
-			event.change.users.added = ""
-			if !reflect.DeepEqual(oldObj.(*apps_v1alpha.Team).Spec.Users, newObj.(*apps_v1alpha.Team).Spec.Users) {
-				event.change.users.status = true
-				sliceDeleted, sliceAdded := dry(oldObj.(*apps_v1alpha.Team).Spec.Users, newObj.(*apps_v1alpha.Team).Spec.Users)
-				sliceDeletedJSON, err := json.Marshal(sliceDeleted)
-				if err == nil {
-					event.change.users.deleted = string(sliceDeletedJSON)
-				}
-				sliceAddedJSON, err := json.Marshal(sliceAdded)
-				if err == nil {
-					event.change.users.added = string(sliceAddedJSON)
-				}
-			}
-			log.Infof("Update team: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			// DeletionHandlingMetaNamsespaceKeyFunc helps to check the existence of the object while it is still contained in the index.
-			// Put the resource object into a key
-			event.key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			event.function = delete
-			event.change.users.status = true
-			event.change.users.deleted = ""
-			sliceDeletedJSON, err := json.Marshal(obj.(*apps_v1alpha.Team).Spec.Users)
-			if err == nil {
-				event.change.users.deleted = string(sliceDeletedJSON)
-			}
-			event.change.object.name = obj.(*apps_v1alpha.Team).GetName()
-			event.change.object.ownerNamespace = obj.(*apps_v1alpha.Team).GetNamespace()
-			event.change.object.childNamespace = fmt.Sprintf("%s-team-%s", obj.(*apps_v1alpha.Team).GetNamespace(), obj.(*apps_v1alpha.Team).GetName())
-			event.change.enabled = obj.(*apps_v1alpha.Team).Spec.Enabled
-			log.Infof("Delete team: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-	})
-	controller := controller{
-		logger:   log.NewEntry(log.New()),
-		informer: informer,
-		queue:    queue,
-		handler:  teamHandler,
-	}
+	teamHandler.Init(clientset, edgenetClientset)
+	informer := manager.EdgeNetInformerFactory.Apps().V1alpha().Teams().Informer()
+	controller := newController(informer, teamHandler)
 
 	// Create the roles of EdgeNet users
 	permission.Clientset = clientset
-	permission.CreateTeamRoles()
-
-	// A channel to terminate elegantly
-	stopCh := make(chan struct{})
-	defer close(stopCh)
-	// Run the controller loop as a background task to start processing resources
-	go controller.run(stopCh, clientset, edgenetClientset)
-	// A channel to observe OS signals for smooth shut down
-	sigTerm := make(chan os.Signal, 1)
-	signal.Notify(sigTerm, syscall.SIGTERM)
-	signal.Notify(sigTerm, syscall.SIGINT)
-	<-sigTerm
+	permission.CreateTeamRoles(controller.Context())
+	manager.Add(controller)
 }
 
-// Run starts the controller loop
-func (c *controller) run(stopCh <-chan struct{}, clientset kubernetes.Interface, edgenetClientset versioned.Interface) {
-	// A Go panic which includes logging and terminating
-	defer utilruntime.HandleCrash()
-	// Shutdown after all goroutines have done
-	defer c.queue.ShutDown()
-	c.logger.Info("run: initiating")
-	c.handler.Init(clientset, edgenetClientset)
-	// Run the informer to list and watch resources
-	go c.informer.Run(stopCh)
-
-	// Synchronization to settle resources one
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
-		utilruntime.HandleError(fmt.Errorf("Error syncing cache"))
-		return
-	}
-	c.logger.Info("run: cache sync complete")
-	// Operate the runWorker
-	go wait.Until(c.runWorker, time.Second, stopCh)
-
-	<-stopCh
-}
-
-// To process new objects added to the queue
-func (c *controller) runWorker() {
-	log.Info("runWorker: starting")
-	// Run processNextItem for all the changes
-	for c.processNextItem() {
-		log.Info("runWorker: processing next item")
+// newController plugs the team handler into the controller runtime
+func newController(informer cache.SharedIndexInformer, handler HandlerInterface) *ctlruntime.Controller {
+	reconciler := ctlruntime.HandlerFuncs{
+		Indexer:    informer.GetIndexer(),
+		CreateFunc: handler.ObjectCreated,
+		UpdateFunc: handler.ObjectUpdated,
+		DeleteFunc: func(ctx context.Context, obj interface{}) error {
+			return handler.ObjectDeleted(ctx, obj, getDeletion(obj))
+		},
 	}
-
-	log.Info("runWorker: completed")
+	return ctlruntime.New(informer, reconciler, ctlruntime.Options{
+		Name:         "team",
+		UpdateFilter: getChange,
+	})
 }
 
-// This function deals with the queue and sends each item in it to the specified handler to be processed.
-func (c *controller) processNextItem() bool {
-	log.Info("processNextItem: start")
-	// Fetch the next item of the queue
-	event, quit := c.queue.Get()
-	if quit {
-		return false
-	}
-	defer c.queue.Done(event)
-	// Get the key string
-	keyRaw := event.(informerevent).key
-	// Use the string key to get the object from the indexer
-	item, exists, err := c.informer.GetIndexer().GetByKey(keyRaw)
-	if err != nil {
-		if c.queue.NumRequeues(event.(informerevent).key) < 5 {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, retrying", event.(informerevent).key, err)
-			c.queue.AddRateLimited(event.(informerevent).key)
-		} else {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, no more retries", event.(informerevent).key, err)
-			c.queue.Forget(event.(informerevent).key)
-			utilruntime.HandleError(err)
-		}
-	}
-
-	if !exists {
-		if event.(informerevent).function == delete {
-			c.logger.Infof("Controller.processNextItem: object deleted detected: %s", keyRaw)
-			c.handler.ObjectDeleted(item, event.(informerevent).change)
+// getChange finds out whether the users of the team updated
+func getChange(oldObj, newObj interface{}) (interface{}, bool) {
+	var change fields
+	oldTeam := oldObj.(*apps_v1alpha.Team)
+	newTeam := newObj.(*apps_v1alpha.Team)
+	if !reflect.DeepEqual(oldTeam.Spec.Users, newTeam.Spec.Users) {
+		change.users.status = true
+		sliceDeleted, sliceAdded := dry(oldTeam.Spec.Users, newTeam.Spec.Users)
+		sliceDeletedJSON, err := json.Marshal(sliceDeleted)
+		if err == nil {
+			change.users.deleted = string(sliceDeletedJSON)
 		}
-	} else {
-		if event.(informerevent).function == create {
-			c.logger.Infof("Controller.processNextItem: object created detected: %s", keyRaw)
-			c.handler.ObjectCreated(item)
-		} else if event.(informerevent).function == update {
-			c.logger.Infof("Controller.processNextItem: object updated detected: %s", keyRaw)
-			c.handler.ObjectUpdated(item, event.(informerevent).change)
+		sliceAddedJSON, err := json.Marshal(sliceAdded)
+		if err == nil {
+			change.users.added = string(sliceAddedJSON)
 		}
 	}
-	c.queue.Forget(event.(informerevent).key)
+	return change, true
+}
 
-	return true
+// getDeletion collects the fields of the deleted team that are required to clean up after it
+func getDeletion(obj interface{}) fields {
+	var deletion fields
+	teamObj := obj.(*apps_v1alpha.Team)
+	deletion.users.status = true
+	sliceDeletedJSON, err := json.Marshal(teamObj.Spec.Users)
+	if err == nil {
+		deletion.users.deleted = string(sliceDeletedJSON)
+	}
+	deletion.object.name = teamObj.GetName()
+	deletion.object.ownerNamespace = teamObj.GetNamespace()
+	deletion.object.childNamespace = fmt.Sprintf("%s-team-%s", teamObj.GetNamespace(), teamObj.GetName())
+	deletion.enabled = teamObj.Spec.Enabled
+	return deletion
 }
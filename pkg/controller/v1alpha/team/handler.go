@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/user"
@@ -38,9 +39,9 @@ import (
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj, updated interface{})
-	ObjectDeleted(obj, deleted interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj, updated interface{}) error
+	ObjectDeleted(ctx context.Context, obj, deleted interface{}) error
 }
 
 // Handler implementation
@@ -81,61 +82,76 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("TeamHandler.ObjectCreated")
 	// Create a copy of the team object to make changes on it
 	teamCopy := obj.(*apps_v1alpha.Team).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	teamOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), teamCopy.GetNamespace(), metav1.GetOptions{})
-	teamOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), teamOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	teamOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, teamCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	teamOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, teamOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	// Check if the authority is active
 	if teamOwnerAuthority.Spec.Enabled && teamCopy.Spec.Enabled {
 		// If the service restarts, it creates all objects again
 		// Because of that, this section covers a variety of possibilities
-		_, err := t.clientset.CoreV1().Namespaces().Get(context.TODO(), fmt.Sprintf("%s-team-%s", teamCopy.GetNamespace(), teamCopy.GetName()), metav1.GetOptions{})
+		_, err := t.clientset.CoreV1().Namespaces().Get(ctx, fmt.Sprintf("%s-team-%s", teamCopy.GetNamespace(), teamCopy.GetName()), metav1.GetOptions{})
 		if err != nil {
 			// Each namespace created by teams have an indicator as "team" to provide singularity
 			teamChildNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-team-%s", teamCopy.GetNamespace(), teamCopy.GetName())}}
 			// Namespace labels indicate this namespace created by a team, not by a authority or slice
 			namespaceLabels := map[string]string{"owner": "team", "owner-name": teamCopy.GetName(), "authority-name": teamOwnerNamespace.Labels["authority-name"]}
 			teamChildNamespace.SetLabels(namespaceLabels)
-			teamChildNamespaceCreated, err := t.clientset.CoreV1().Namespaces().Create(context.TODO(), teamChildNamespace, metav1.CreateOptions{})
+			teamChildNamespaceCreated, err := t.clientset.CoreV1().Namespaces().Create(ctx, teamChildNamespace, metav1.CreateOptions{})
 			if err != nil {
-				t.runUserInteractions(teamCopy, teamChildNamespaceCreated.GetName(), teamOwnerNamespace.Labels["authority-name"],
+				t.setReady(ctx, teamCopy, false, apps_v1alpha.ReasonNamespaceCreationFailed,
+					fmt.Sprintf("Team namespace %s cannot be created, the team is deleted: %s", teamChildNamespace.GetName(), err))
+				t.runUserInteractions(ctx, teamCopy, teamChildNamespaceCreated.GetName(), teamOwnerNamespace.Labels["authority-name"],
 					teamOwnerNamespace.Labels["owner"], teamOwnerNamespace.Labels["owner-name"], "team-crash", true)
-				t.edgenetClientset.AppsV1alpha().Teams(teamCopy.GetNamespace()).Delete(context.TODO(), teamCopy.GetName(), metav1.DeleteOptions{})
-				return
+				t.edgenetClientset.AppsV1alpha().Teams(teamCopy.GetNamespace()).Delete(ctx, teamCopy.GetName(), metav1.DeleteOptions{})
+				return nil
 			}
 			// Delete all existing role bindings in the team (child) namespace
-			t.clientset.RbacV1().RoleBindings(teamChildNamespaceCreated.GetName()).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+			t.clientset.RbacV1().RoleBindings(teamChildNamespaceCreated.GetName()).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
 			// Create rolebindings according to the users who participate in the team and are authority-admin and authorized users of the authority
-			t.runUserInteractions(teamCopy, teamChildNamespaceCreated.GetName(), teamOwnerNamespace.Labels["authority-name"], teamOwnerNamespace.Labels["owner"], teamOwnerNamespace.Labels["owner-name"], "team-creation", true)
-			ownerReferences := t.getOwnerReferences(teamCopy, teamChildNamespaceCreated)
+			t.runUserInteractions(ctx, teamCopy, teamChildNamespaceCreated.GetName(), teamOwnerNamespace.Labels["authority-name"], teamOwnerNamespace.Labels["owner"], teamOwnerNamespace.Labels["owner-name"], "team-creation", true)
+			ownerReferences := t.getOwnerReferences(ctx, teamCopy, teamChildNamespaceCreated)
 			teamCopy.ObjectMeta.OwnerReferences = ownerReferences
-			t.edgenetClientset.AppsV1alpha().Teams(teamCopy.GetNamespace()).Update(context.TODO(), teamCopy, metav1.UpdateOptions{})
+			teamUpdated, err := t.edgenetClientset.AppsV1alpha().Teams(teamCopy.GetNamespace()).Update(ctx, teamCopy, metav1.UpdateOptions{})
+			if err == nil {
+				t.setReady(ctx, teamUpdated, true, apps_v1alpha.ReasonReconciled, "Team namespace created")
+			}
 		}
 	} else if !teamOwnerAuthority.Spec.Enabled {
-		t.edgenetClientset.AppsV1alpha().Teams(teamCopy.GetNamespace()).Delete(context.TODO(), teamCopy.GetName(), metav1.DeleteOptions{})
+		t.setReady(ctx, teamCopy, false, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"])
+		t.edgenetClientset.AppsV1alpha().Teams(teamCopy.GetNamespace()).Delete(ctx, teamCopy.GetName(), metav1.DeleteOptions{})
+	} else {
+		t.setReady(ctx, teamCopy, false, apps_v1alpha.ReasonTeamDisabled, statusDict["team-disabled"])
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj, updated interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj, updated interface{}) error {
 	log.Info("TeamHandler.ObjectUpdated")
 	// Create a copy of the team object to make changes on it
 	teamCopy := obj.(*apps_v1alpha.Team).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	teamOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), teamCopy.GetNamespace(), metav1.GetOptions{})
-	teamOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), teamOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	teamOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, teamCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	teamOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, teamOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	teamChildNamespaceStr := fmt.Sprintf("%s-team-%s", teamCopy.GetNamespace(), teamCopy.GetName())
 	fieldUpdated := updated.(fields)
 	// Check if the authority and team are active
 	if teamOwnerAuthority.Spec.Enabled && teamCopy.Spec.Enabled {
 		if fieldUpdated.users.status {
 			// Delete all existing role bindings in the team (child) namespace
-			t.clientset.RbacV1().RoleBindings(teamChildNamespaceStr).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+			t.clientset.RbacV1().RoleBindings(teamChildNamespaceStr).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
 			// Create rolebindings according to the users who participate in the team and are authority-admin and authorized users of the authority
-			t.runUserInteractions(teamCopy, teamChildNamespaceStr, teamOwnerNamespace.Labels["authority-name"], teamOwnerNamespace.Labels["owner"], teamOwnerNamespace.Labels["owner-name"], "team-creation", false)
+			t.runUserInteractions(ctx, teamCopy, teamChildNamespaceStr, teamOwnerNamespace.Labels["authority-name"], teamOwnerNamespace.Labels["owner"], teamOwnerNamespace.Labels["owner-name"], "team-creation", false)
 			// Send emails to those who have been added to, or removed from the slice.
 			var deletedUserList []apps_v1alpha.TeamUsers
 			json.Unmarshal([]byte(fieldUpdated.users.deleted), &deletedUserList)
@@ -143,71 +159,87 @@ func (t *Handler) ObjectUpdated(obj, updated interface{}) {
 			json.Unmarshal([]byte(fieldUpdated.users.added), &addedUserList)
 			if len(deletedUserList) > 0 {
 				for _, deletedUser := range deletedUserList {
-					t.sendEmail(deletedUser.Username, deletedUser.Authority, teamOwnerNamespace.Labels["authority-name"], teamCopy.GetNamespace(), teamCopy.GetName(), teamChildNamespaceStr, "team-removal")
+					t.sendEmail(ctx, deletedUser.Username, deletedUser.Authority, teamOwnerNamespace.Labels["authority-name"], teamCopy.GetNamespace(), teamCopy.GetName(), teamChildNamespaceStr, "team-removal")
 				}
 			}
 			if len(addedUserList) > 0 {
 				for _, addedUser := range addedUserList {
-					t.sendEmail(addedUser.Username, addedUser.Authority, teamOwnerNamespace.Labels["authority-name"], teamCopy.GetNamespace(), teamCopy.GetName(), teamChildNamespaceStr, "team-creation")
+					t.sendEmail(ctx, addedUser.Username, addedUser.Authority, teamOwnerNamespace.Labels["authority-name"], teamCopy.GetNamespace(), teamCopy.GetName(), teamChildNamespaceStr, "team-creation")
 				}
 			}
 		}
+		t.setReady(ctx, teamCopy, true, apps_v1alpha.ReasonReconciled, "Team namespace created")
 	} else if teamOwnerAuthority.Spec.Enabled && !teamCopy.Spec.Enabled {
-		t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
-		t.clientset.RbacV1().RoleBindings(teamChildNamespaceStr).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+		t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
+		t.clientset.RbacV1().RoleBindings(teamChildNamespaceStr).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
+		t.setReady(ctx, teamCopy, false, apps_v1alpha.ReasonTeamDisabled, statusDict["team-disabled"])
 	} else if !teamOwnerAuthority.Spec.Enabled {
-		t.edgenetClientset.AppsV1alpha().Teams(teamChildNamespaceStr).Delete(context.TODO(), teamCopy.GetName(), metav1.DeleteOptions{})
+		t.setReady(ctx, teamCopy, false, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"])
+		t.edgenetClientset.AppsV1alpha().Teams(teamChildNamespaceStr).Delete(ctx, teamCopy.GetName(), metav1.DeleteOptions{})
 	}
+	return nil
 }
 
 // ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj, deleted interface{}) {
+func (t *Handler) ObjectDeleted(ctx context.Context, obj, deleted interface{}) error {
 	log.Info("TeamHandler.ObjectDeleted")
 	fieldDeleted := deleted.(fields)
-	t.clientset.CoreV1().Namespaces().Delete(context.TODO(), fieldDeleted.object.childNamespace, metav1.DeleteOptions{})
+	t.clientset.CoreV1().Namespaces().Delete(ctx, fieldDeleted.object.childNamespace, metav1.DeleteOptions{})
 	// If there are users who participate in the team and team is enabled
 	if fieldDeleted.users.status && fieldDeleted.enabled {
-		teamOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), fieldDeleted.object.ownerNamespace, metav1.GetOptions{})
+		teamOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(ctx, fieldDeleted.object.ownerNamespace, metav1.GetOptions{})
 		var deletedUserList []apps_v1alpha.SliceUsers
 		json.Unmarshal([]byte(fieldDeleted.users.deleted), &deletedUserList)
 		if len(deletedUserList) > 0 {
 			for _, deletedUser := range deletedUserList {
-				t.sendEmail(deletedUser.Username, deletedUser.Authority, teamOwnerNamespace.Labels["authority-name"], fieldDeleted.object.ownerNamespace, fieldDeleted.object.name, fieldDeleted.object.childNamespace, "team-deletion")
+				t.sendEmail(ctx, deletedUser.Username, deletedUser.Authority, teamOwnerNamespace.Labels["authority-name"], fieldDeleted.object.ownerNamespace, fieldDeleted.object.name, fieldDeleted.object.childNamespace, "team-deletion")
 			}
 		}
 	}
+	return nil
+}
+
+// setReady updates the ready condition of the team. The status is written only if the condition changes,
+// as the status updates come back to the controller as updates of the team.
+func (t *Handler) setReady(ctx context.Context, teamCopy *apps_v1alpha.Team, ready bool, reason, message string) {
+	status := teamCopy.Status.DeepCopy()
+	teamCopy.Status.SetReady(teamCopy.GetGeneration(), ready, reason, message)
+	if reflect.DeepEqual(status, &teamCopy.Status) {
+		return
+	}
+	t.edgenetClientset.AppsV1alpha().Teams(teamCopy.GetNamespace()).UpdateStatus(ctx, teamCopy, metav1.UpdateOptions{})
 }
 
 // runUserInteractions creates user role bindings according to the roles
-func (t *Handler) runUserInteractions(teamCopy *apps_v1alpha.Team, teamChildNamespaceStr, ownerAuthority, teamOwner, teamOwnerName, operation string, enabled bool) {
+func (t *Handler) runUserInteractions(ctx context.Context, teamCopy *apps_v1alpha.Team, teamChildNamespaceStr, ownerAuthority, teamOwner, teamOwnerName, operation string, enabled bool) {
 	// This part creates the rolebindings for the users who participate in the team
 	for _, teamUser := range teamCopy.Spec.Users {
-		user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", teamUser.Authority)).Get(context.TODO(), teamUser.Username, metav1.GetOptions{})
+		user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", teamUser.Authority)).Get(ctx, teamUser.Username, metav1.GetOptions{})
 		if err == nil && user.Spec.Active && user.Status.AUP {
 			if operation == "team-creation" {
-				permission.EstablishRoleBindings(user.DeepCopy(), teamChildNamespaceStr, "Team")
+				permission.EstablishRoleBindings(ctx, user.DeepCopy(), teamChildNamespaceStr, "Team")
 			}
 
 			if !(operation == "team-creation" && !enabled) {
-				t.sendEmail(teamUser.Username, teamUser.Authority, ownerAuthority, teamCopy.GetNamespace(), teamCopy.GetName(), teamChildNamespaceStr, operation)
+				t.sendEmail(ctx, teamUser.Username, teamUser.Authority, ownerAuthority, teamCopy.GetNamespace(), teamCopy.GetName(), teamChildNamespaceStr, operation)
 			}
 		}
 	}
 	// To create the rolebindings for the users who are authority-admin and authorized users of the authority
-	userRaw, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", ownerAuthority)).List(context.TODO(), metav1.ListOptions{})
+	userRaw, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", ownerAuthority)).List(ctx, metav1.ListOptions{})
 	if err == nil {
 		for _, userRow := range userRaw.Items {
 			if userRow.Spec.Active && userRow.Status.AUP && (userRow.Status.Type == "admin" ||
-				permission.CheckAuthorization(teamCopy.GetNamespace(), userRow.Spec.Email, "teams", teamCopy.GetName())) {
-				permission.EstablishRoleBindings(userRow.DeepCopy(), teamChildNamespaceStr, "Team")
+				permission.CheckAuthorization(ctx, teamCopy.GetNamespace(), userRow.Spec.Email, "teams", teamCopy.GetName())) {
+				permission.EstablishRoleBindings(ctx, userRow.DeepCopy(), teamChildNamespaceStr, "Team")
 			}
 		}
 	}
 }
 
 // sendEmail to send notification to participants
-func (t *Handler) sendEmail(teamUsername, teamUserAuthority, teamAuthority, teamOwnerNamespace, teamName, teamChildNamespace, subject string) {
-	user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", teamUserAuthority)).Get(context.TODO(), teamUsername, metav1.GetOptions{})
+func (t *Handler) sendEmail(ctx context.Context, teamUsername, teamUserAuthority, teamAuthority, teamOwnerNamespace, teamName, teamChildNamespace, subject string) {
+	user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", teamUserAuthority)).Get(ctx, teamUsername, metav1.GetOptions{})
 	if err == nil && user.Spec.Active && user.Status.AUP {
 		// Set the HTML template variables
 		contentData := mailer.ResourceAllocationData{}
@@ -224,11 +256,11 @@ func (t *Handler) sendEmail(teamUsername, teamUserAuthority, teamAuthority, team
 }
 
 // getOwnerReferences returns the users and the child namespace as owners
-func (t *Handler) getOwnerReferences(teamCopy *apps_v1alpha.Team, namespace *corev1.Namespace) []metav1.OwnerReference {
+func (t *Handler) getOwnerReferences(ctx context.Context, teamCopy *apps_v1alpha.Team, namespace *corev1.Namespace) []metav1.OwnerReference {
 	ownerReferences := ns.SetAsOwnerReference(namespace)
 	// The following section makes users who participate in that team become the team owners
 	for _, teamUser := range teamCopy.Spec.Users {
-		userCopy, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", teamUser.Authority)).Get(context.TODO(), teamUser.Username, metav1.GetOptions{})
+		userCopy, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", teamUser.Authority)).Get(ctx, teamUser.Username, metav1.GetOptions{})
 		if err == nil && userCopy.Spec.Active && userCopy.Status.AUP {
 			ownerReferences = append(ownerReferences, user.SetAsOwnerReference(userCopy)...)
 		}
@@ -137,7 +137,7 @@ func (g *TestGroup) Init() {
 	user.Status.AUP = true
 	user.Status.Type = "admin"
 	g.edgenetClient.AppsV1alpha().Users(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Create(context.TODO(), user.DeepCopy(), metav1.CreateOptions{})
-	// authorityHandler.ObjectCreated(g.authorityObj.DeepCopy())
+	// authorityHandler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 }
 
 // TestHandlerInit for handler initialization
@@ -160,7 +160,7 @@ func TestCreate(t *testing.T) {
 	g.handler.Init(g.client, g.edgenetClient)
 	// Create Team
 	g.edgenetClient.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Create(context.TODO(), g.teamObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(g.teamObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.teamObj.DeepCopy())
 	childNamespaceStr := fmt.Sprintf("%s-team-%s", g.teamObj.GetNamespace(), g.teamObj.GetName())
 	t.Run("namespace", func(t *testing.T) {
 		_, err := g.handler.clientset.CoreV1().Namespaces().Get(context.TODO(), childNamespaceStr, metav1.GetOptions{})
@@ -181,7 +181,7 @@ func TestUpdate(t *testing.T) {
 	// Create Team to update later
 	team, _ := g.edgenetClient.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Create(context.TODO(), g.teamObj.DeepCopy(), metav1.CreateOptions{})
 	// Invoke ObjectCreated func to create a team
-	g.handler.ObjectCreated(g.teamObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.teamObj.DeepCopy())
 	childNamespaceStr := fmt.Sprintf("%s-team-%s", g.teamObj.GetNamespace(), g.teamObj.GetName())
 
 	// Add new users to team
@@ -204,7 +204,7 @@ func TestUpdate(t *testing.T) {
 		util.OK(t, err)
 
 		// Invoking ObjectUpdated to send emails to users removed or added to team
-		g.handler.ObjectUpdated(team.DeepCopy(), field)
+		g.handler.ObjectUpdated(context.TODO(), team.DeepCopy(), field)
 		// Check user rolebinding in team child namespace
 		_, err = g.client.RbacV1().RoleBindings(childNamespaceStr).Get(context.TODO(), fmt.Sprintf("%s-%s-team-%s", g.userObj.GetNamespace(), g.userObj.GetName(), "user"), metav1.GetOptions{})
 		// Verifying server created rolebinding for new user in team's child namespace
@@ -212,6 +212,44 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestUpdateConditions(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient)
+	team, _ := g.edgenetClient.AppsV1alpha().Teams(g.teamObj.GetNamespace()).Create(context.TODO(), g.teamObj.DeepCopy(), metav1.CreateOptions{})
+	g.handler.ObjectCreated(context.TODO(), team.DeepCopy())
+	ready := func() *metav1.Condition {
+		team, err := g.edgenetClient.AppsV1alpha().Teams(g.teamObj.GetNamespace()).Get(context.TODO(), g.teamObj.GetName(), metav1.GetOptions{})
+		util.OK(t, err)
+		return team.Status.GetCondition(apps_v1alpha.ConditionReady)
+	}
+	util.Equals(t, metav1.ConditionTrue, ready().Status)
+
+	t.Run("team disabled", func(t *testing.T) {
+		team, _ := g.edgenetClient.AppsV1alpha().Teams(g.teamObj.GetNamespace()).Get(context.TODO(), g.teamObj.GetName(), metav1.GetOptions{})
+		team.Spec.Enabled = false
+		g.handler.ObjectUpdated(context.TODO(), team.DeepCopy(), fields{})
+		util.Equals(t, metav1.ConditionFalse, ready().Status)
+		util.Equals(t, apps_v1alpha.ReasonTeamDisabled, ready().Reason)
+	})
+	t.Run("team enabled", func(t *testing.T) {
+		team, _ := g.edgenetClient.AppsV1alpha().Teams(g.teamObj.GetNamespace()).Get(context.TODO(), g.teamObj.GetName(), metav1.GetOptions{})
+		team.Spec.Enabled = true
+		g.handler.ObjectUpdated(context.TODO(), team.DeepCopy(), fields{})
+		util.Equals(t, metav1.ConditionTrue, ready().Status)
+		util.Equals(t, apps_v1alpha.ReasonReconciled, ready().Reason)
+	})
+	t.Run("authority disabled", func(t *testing.T) {
+		authority := g.authorityObj.DeepCopy()
+		authority.Spec.Enabled = false
+		g.edgenetClient.AppsV1alpha().Authorities().Update(context.TODO(), authority, metav1.UpdateOptions{})
+		team, _ := g.edgenetClient.AppsV1alpha().Teams(g.teamObj.GetNamespace()).Get(context.TODO(), g.teamObj.GetName(), metav1.GetOptions{})
+		g.handler.ObjectUpdated(context.TODO(), team.DeepCopy(), fields{})
+		util.Equals(t, metav1.ConditionFalse, ready().Status)
+		util.Equals(t, apps_v1alpha.ReasonAuthorityDisabled, ready().Reason)
+	})
+}
+
 func TestGetOwnerReferences(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
@@ -224,11 +262,11 @@ func TestGetOwnerReferences(t *testing.T) {
 			Username:  g.userObj.GetName(),
 		},
 	}
-	g.handler.ObjectCreated(g.teamObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.teamObj.DeepCopy())
 
 	teamChildNamespaceStr := fmt.Sprintf("%s-team-%s", g.teamObj.GetNamespace(), g.teamObj.GetName())
 	teamChildNamespace, _ := g.client.CoreV1().Namespaces().Get(context.TODO(), teamChildNamespaceStr, metav1.GetOptions{})
-	ownerReferences := g.handler.getOwnerReferences(g.teamObj.DeepCopy(), teamChildNamespace)
+	ownerReferences := g.handler.getOwnerReferences(context.TODO(), g.teamObj.DeepCopy(), teamChildNamespace)
 	util.NotEquals(t, nil, ownerReferences)
 	util.Equals(t, 2, len(ownerReferences))
 	util.Equals(t, teamChildNamespaceStr, ownerReferences[0].Name)
@@ -249,7 +287,7 @@ func TestDelete(t *testing.T) {
 	}
 	// Creating team with one user
 	g.edgenetClient.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Create(context.TODO(), g.teamObj.DeepCopy(), metav1.CreateOptions{})
-	g.handler.ObjectCreated(g.teamObj.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), g.teamObj.DeepCopy())
 	_, err := g.client.CoreV1().Namespaces().Get(context.TODO(), fmt.Sprintf("%s-team-%s", g.teamObj.GetNamespace(), g.teamObj.GetName()), metav1.GetOptions{})
 	util.OK(t, err)
 
@@ -261,7 +299,7 @@ func TestDelete(t *testing.T) {
 		ownerNamespace: g.teamObj.GetNamespace(),
 		childNamespace: teamChildNamespaceStr,
 	}
-	g.handler.ObjectDeleted(g.teamObj.DeepCopy(), field)
+	g.handler.ObjectDeleted(context.TODO(), g.teamObj.DeepCopy(), field)
 
 	_, err = g.client.CoreV1().Namespaces().Get(context.TODO(), fmt.Sprintf("%s-team-%s", g.teamObj.GetNamespace(), g.teamObj.GetName()), metav1.GetOptions{})
 	util.Equals(t, true, errors.IsNotFound(err))
@@ -17,59 +17,30 @@ limitations under the License.
 package totalresourcequota
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"os/signal"
 	"reflect"
-	"syscall"
 	"time"
 
 	"github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
-	appsinformer_v1 "github.com/EdgeNet-project/edgenet/pkg/generated/informers/externalversions/apps/v1alpha"
 	"github.com/EdgeNet-project/edgenet/pkg/node"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 )
 
-// The main structure of controller
-type controller struct {
-	logger       *log.Entry
-	queue        workqueue.RateLimitingInterface
-	informer     cache.SharedIndexInformer
-	nodeInformer cache.SharedIndexInformer
-	handler      HandlerInterface
-}
-
-// The main structure of informerEvent
-type informerevent struct {
-	key      string
-	function string
-	change   fields
-}
-
 // This contains the fields to check whether they are updated
 type fields struct {
-	expiry bool
-	spec   bool
+	spec bool
 }
 
 // Constant variables for events
-const create = "create"
-const update = "update"
-const delete = "delete"
 const failure = "Pulled off"
 const success = "Applied"
 const trueStr = "True"
@@ -84,81 +55,28 @@ var statusDict = map[string]string{
 	"TRQ-disabled":      "Total resource quota disabled",
 	"TRQ-applied":       "Total resource quota applied",
 	"TRQ-appliedFail":   "Total resource quota couldn't be applied",
+	"TRQ-exceeded":      "Total resource quota exceeded",
 }
 
 // Start function is entry point of the controller
 func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
-	var err error
-	clientset := kubernetes
-	edgenetClientset := edgenet
+	manager := ctlruntime.NewManager(kubernetes, edgenet)
+	Register(manager)
+	// Run the controller loop until a signal to terminate elegantly arrives
+	manager.Run(ctlruntime.SetupSignalHandler())
+}
 
+// Register adds the total resource quota controller to the manager, which can host other controllers alongside
+func Register(manager *ctlruntime.Manager) {
+	clientset := manager.Clientset
+	edgenetClientset := manager.EdgeNetClientset
 	TRQHandler := &Handler{}
-	// Create the TRQ informer which was generated by the code generator to list and watch TRQ resources
-	informer := appsinformer_v1.NewTotalResourceQuotaInformer(
-		edgenetClientset,
-		0,
-		cache.Indexers{},
-	)
-	// Create a work queue which contains a key of the resource to be handled by the handler
-	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	var event informerevent
-	// Event handlers deal with events of resources. Here, there are three types of events as Add, Update, and Delete
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(obj interface{}) {
-			// Put the resource object into a key
-			event.key, err = cache.MetaNamespaceKeyFunc(obj)
-			event.function = create
-			log.Infof("Add TRQ: %s", event.key)
-			if err == nil {
-				// Add the key to the queue
-				queue.Add(event)
-			}
-		},
-		UpdateFunc: func(oldObj, newObj interface{}) {
-			event.key, err = cache.MetaNamespaceKeyFunc(newObj)
-			event.function = update
-			event.change.expiry = false
-			event.change.spec = false
-			oldExists := CheckExpiryDate(oldObj.(*apps_v1alpha.TotalResourceQuota))
-			newExists := CheckExpiryDate(newObj.(*apps_v1alpha.TotalResourceQuota))
-			if oldExists == false && newExists == true {
-				event.change.expiry = true
-			}
-			if !reflect.DeepEqual(oldObj.(*apps_v1alpha.TotalResourceQuota).Spec, newObj.(*apps_v1alpha.TotalResourceQuota).Spec) {
-				event.change.spec = true
-			}
-			log.Infof("Update TRQ: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-		DeleteFunc: func(obj interface{}) {
-			// DeletionHandlingMetaNamsespaceKeyFunc helps to check the existence of the object while it is still contained in the index.
-			// Put the resource object into a key
-			event.key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			event.function = delete
-			log.Infof("Delete TRQ: %s", event.key)
-			if err == nil {
-				queue.Add(event)
-			}
-		},
-	})
+	TRQHandler.Init(clientset, edgenetClientset)
+	informer := manager.EdgeNetInformerFactory.Apps().V1alpha().TotalResourceQuotas().Informer()
+	controller := newController(informer, TRQHandler)
+	manager.Scheduler.Register("TotalResourceQuota", informer, getDeadlines, TRQHandler.ObjectExpired)
 	// The total resource quota objects are reconfigured according to node events in this section
-	nodeInformer := cache.NewSharedIndexInformer(
-		&cache.ListWatch{
-			// The main purpose of listing is to attach geo labels to whole nodes at the beginning
-			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
-				return clientset.CoreV1().Nodes().List(context.TODO(), options)
-			},
-			// This function watches all changes/updates of nodes
-			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-				return clientset.CoreV1().Nodes().Watch(context.TODO(), options)
-			},
-		},
-		&corev1.Node{},
-		0,
-		cache.Indexers{},
-	)
+	nodeInformer := manager.InformerFactory.Core().V1().Nodes().Informer()
 	nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			nodeObj := obj.(*corev1.Node)
@@ -171,7 +89,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 			if ready == trueStr {
 				for _, owner := range nodeObj.GetOwnerReferences() {
 					if owner.Kind == "Authority" {
-						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), owner.Name, metav1.GetOptions{})
+						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(controller.Context(), owner.Name, metav1.GetOptions{})
 						if err == nil {
 							exists := false
 							CPUAward := resource.NewQuantity(int64(float64(nodeObj.Status.Capacity.Cpu().Value())*1.5), resource.BinarySI).DeepCopy()
@@ -199,7 +117,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 							} else {
 								TRQCopy.Spec.Claim = claims
 							}
-							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(context.TODO(), TRQCopy, metav1.UpdateOptions{})
+							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(controller.Context(), TRQCopy, metav1.UpdateOptions{})
 						}
 					}
 				}
@@ -214,7 +132,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 				(oldReady == unknownStr && newReady == trueStr) {
 				for _, owner := range newObj.GetOwnerReferences() {
 					if owner.Kind == "Authority" {
-						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), owner.Name, metav1.GetOptions{})
+						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(controller.Context(), owner.Name, metav1.GetOptions{})
 						if err == nil {
 							exists := false
 							CPUAward := resource.NewQuantity(int64(float64(newObj.Status.Capacity.Cpu().Value())*1.5), resource.BinarySI).DeepCopy()
@@ -242,7 +160,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 							} else {
 								TRQCopy.Spec.Claim = claims
 							}
-							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(context.TODO(), TRQCopy, metav1.UpdateOptions{})
+							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(controller.Context(), TRQCopy, metav1.UpdateOptions{})
 						}
 					}
 				}
@@ -250,7 +168,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 				(oldReady == trueStr && newReady == unknownStr) {
 				for _, owner := range newObj.GetOwnerReferences() {
 					if owner.Kind == "Authority" {
-						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), owner.Name, metav1.GetOptions{})
+						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(controller.Context(), owner.Name, metav1.GetOptions{})
 						if err == nil {
 							CPUAward := resource.NewQuantity(int64(float64(newObj.Status.Capacity.Cpu().Value())*1.5), resource.BinarySI).DeepCopy()
 							memoryAward := resource.NewQuantity(int64(float64(newObj.Status.Capacity.Memory().Value())*1.3), resource.BinarySI).DeepCopy()
@@ -268,7 +186,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 								}
 							}
 							TRQCopy.Spec.Claim = claims
-							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(context.TODO(), TRQCopy, metav1.UpdateOptions{})
+							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(controller.Context(), TRQCopy, metav1.UpdateOptions{})
 						}
 					}
 				}
@@ -281,7 +199,7 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 			if ready == trueStr {
 				for _, owner := range nodeObj.GetOwnerReferences() {
 					if owner.Kind == "Authority" {
-						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), owner.Name, metav1.GetOptions{})
+						TRQCopy, err := edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(controller.Context(), owner.Name, metav1.GetOptions{})
 						if err == nil {
 							CPUAward := resource.NewQuantity(int64(float64(nodeObj.Status.Capacity.Cpu().Value())*1.5), resource.BinarySI).DeepCopy()
 							memoryAward := resource.NewQuantity(int64(float64(nodeObj.Status.Capacity.Memory().Value())*1.3), resource.BinarySI).DeepCopy()
@@ -299,108 +217,54 @@ func Start(kubernetes kubernetes.Interface, edgenet versioned.Interface) {
 								}
 							}
 							TRQCopy.Spec.Claim = claims
-							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(context.TODO(), TRQCopy, metav1.UpdateOptions{})
+							edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(controller.Context(), TRQCopy, metav1.UpdateOptions{})
 						}
 					}
 				}
 			}
 		},
 	})
-	controller := controller{
-		logger:       log.NewEntry(log.New()),
-		informer:     informer,
-		nodeInformer: nodeInformer,
-		queue:        queue,
-		handler:      TRQHandler,
-	}
-
-	// A channel to terminate elegantly
-	stopCh := make(chan struct{})
-	defer close(stopCh)
-	// Run the controller loop as a background task to start processing resources
-	go controller.run(stopCh, clientset, edgenetClientset)
-	// A channel to observe OS signals for smooth shut down
-	sigTerm := make(chan os.Signal, 1)
-	signal.Notify(sigTerm, syscall.SIGTERM)
-	signal.Notify(sigTerm, syscall.SIGINT)
-	<-sigTerm
+	controller.AddInformer(nodeInformer)
+	manager.Add(controller)
 }
 
-// Run starts the controller loop
-func (c *controller) run(stopCh <-chan struct{}, clientset kubernetes.Interface, edgenetClientset versioned.Interface) {
-	// A Go panic which includes logging and terminating
-	defer utilruntime.HandleCrash()
-	// Shutdown after all goroutines have done
-	defer c.queue.ShutDown()
-	c.logger.Info("run: initiating")
-	c.handler.Init(clientset, edgenetClientset)
-	// Run the informer to list and watch resources
-	go c.informer.Run(stopCh)
-	go c.nodeInformer.Run(stopCh)
-
-	// Synchronization to settle resources one
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced, c.nodeInformer.HasSynced) {
-		utilruntime.HandleError(fmt.Errorf("Error syncing cache"))
-		return
+// newController plugs the TRQ handler into the controller runtime
+func newController(informer cache.SharedIndexInformer, handler HandlerInterface) *ctlruntime.Controller {
+	reconciler := ctlruntime.HandlerFuncs{
+		Indexer:    informer.GetIndexer(),
+		CreateFunc: handler.ObjectCreated,
+		UpdateFunc: handler.ObjectUpdated,
+		DeleteFunc: handler.ObjectDeleted,
 	}
-	c.logger.Info("run: cache sync complete")
-	// Operate the runWorker
-	go wait.Until(c.runWorker, time.Second, stopCh)
-
-	<-stopCh
+	return ctlruntime.New(informer, reconciler, ctlruntime.Options{
+		Name:         "TRQ",
+		UpdateFilter: getChange,
+	})
 }
 
-// To process new objects added to the queue
-func (c *controller) runWorker() {
-	log.Info("runWorker: starting")
-	// Run processNextItem for all the changes
-	for c.processNextItem() {
-		log.Info("runWorker: processing next item")
+// getChange finds out whether the spec of the TRQ updated
+func getChange(oldObj, newObj interface{}) (interface{}, bool) {
+	var change fields
+	if !reflect.DeepEqual(oldObj.(*apps_v1alpha.TotalResourceQuota).Spec, newObj.(*apps_v1alpha.TotalResourceQuota).Spec) {
+		change.spec = true
 	}
-
-	log.Info("runWorker: completed")
+	return change, true
 }
 
-// This function deals with the queue and sends each item in it to the specified handler to be processed.
-func (c *controller) processNextItem() bool {
-	log.Info("processNextItem: start")
-	// Fetch the next item of the queue
-	event, quit := c.queue.Get()
-	if quit {
-		return false
+// getDeadlines schedules the expiry of the claim or the drop that expires first
+func getDeadlines(obj interface{}) map[expiry.Action]time.Time {
+	TRQObj := obj.(*apps_v1alpha.TotalResourceQuota)
+	if !TRQObj.Spec.Enabled {
+		return nil
 	}
-	defer c.queue.Done(event)
-	// Get the key string
-	keyRaw := event.(informerevent).key
-	// Use the string key to get the object from the indexer
-	item, exists, err := c.informer.GetIndexer().GetByKey(keyRaw)
-	if err != nil {
-		if c.queue.NumRequeues(event.(informerevent).key) < 5 {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, retrying", event.(informerevent).key, err)
-			c.queue.AddRateLimited(event.(informerevent).key)
-		} else {
-			c.logger.Errorf("Controller.processNextItem: Failed processing item with key %s with error %v, no more retries", event.(informerevent).key, err)
-			c.queue.Forget(event.(informerevent).key)
-			utilruntime.HandleError(err)
+	var closestDate *metav1.Time
+	for _, item := range append(append([]apps_v1alpha.TotalResourceDetails{}, TRQObj.Spec.Claim...), TRQObj.Spec.Drop...) {
+		if item.Expires != nil && (closestDate == nil || item.Expires.Before(closestDate)) {
+			closestDate = item.Expires
 		}
 	}
-
-	if !exists {
-		if event.(informerevent).function == delete {
-			c.logger.Infof("Controller.processNextItem: object deleted detected: %s", keyRaw)
-			c.handler.ObjectDeleted(item)
-		}
-	} else {
-		if event.(informerevent).function == create {
-			c.logger.Infof("Controller.processNextItem: object created detected: %s", keyRaw)
-			c.handler.ObjectCreated(item)
-		} else if event.(informerevent).function == update {
-			log.Println(event.(informerevent).key)
-			c.logger.Infof("Controller.processNextItem: object updated detected: %s", keyRaw)
-			c.handler.ObjectUpdated(item, event.(informerevent).change)
-		}
+	if closestDate == nil {
+		return nil
 	}
-	c.queue.Forget(event.(informerevent).key)
-
-	return true
+	return map[expiry.Action]time.Time{expiry.Expire: closestDate.Time}
 }
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/mailer"
 
@@ -32,14 +34,16 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj, updated interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj, updated interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
+	ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error
 }
 
 // Handler implementation
@@ -47,6 +51,7 @@ type Handler struct {
 	clientset        kubernetes.Interface
 	edgenetClientset versioned.Interface
 	resourceQuota    *corev1.ResourceQuota
+	recorder         record.EventRecorder
 }
 
 // Init handles any handler initialization
@@ -54,17 +59,20 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 	log.Info("TotalResourceQuotaHandler.Init")
 	t.clientset = kubernetes
 	t.edgenetClientset = edgenet
+	t.recorder = ctlruntime.NewEventRecorder(kubernetes, "totalresourcequota-controller")
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("TotalResourceQuotaHandler.ObjectCreated")
 	// Create a copy of the TRQ object to make changes on it
 	TRQCopy := obj.(*apps_v1alpha.TotalResourceQuota).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	authority, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), TRQCopy.GetName(), metav1.GetOptions{})
+	authority, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, TRQCopy.GetName(), metav1.GetOptions{})
 	if errors.IsNotFound(err) {
-		t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Delete(context.TODO(), authority.GetName(), metav1.DeleteOptions{})
+		t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Delete(ctx, authority.GetName(), metav1.DeleteOptions{})
+	} else if err != nil {
+		return err
 	} else {
 		// Check if the authority is active
 		if authority.Spec.Enabled && TRQCopy.Spec.Enabled {
@@ -72,71 +80,79 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 			// Because of that, this section covers a variety of possibilities
 			TRQCopy.Status.State = success
 			TRQCopy.Status.Message = []string{statusDict["TRQ-created"]}
-			TRQCopyUpdated, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().UpdateStatus(context.TODO(), TRQCopy, metav1.UpdateOptions{})
+			TRQCopy.Status.SetReady(TRQCopy.GetGeneration(), true, apps_v1alpha.ReasonQuotaApplied, statusDict["TRQ-created"])
+			TRQCopyUpdated, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().UpdateStatus(ctx, TRQCopy, metav1.UpdateOptions{})
 			if err == nil {
 				TRQCopy = TRQCopyUpdated
 			} else {
 				log.Infof("Couldn't update the status of total resource quota in %s: %s", TRQCopy.GetName(), err)
 			}
+			t.recorder.Event(TRQCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonQuotaApplied, statusDict["TRQ-created"])
 			// Check the total resource consumption in authority
-			TRQCopy, _ = t.ResourceConsumptionControl(TRQCopy, 0, 0)
+			TRQCopy, _ = t.ResourceConsumptionControl(ctx, TRQCopy, 0, 0)
 			// If they reached the limit, remove some slices randomly
 			if TRQCopy.Status.Exceeded {
-				TRQCopy = t.balanceResourceConsumption(TRQCopy)
-			}
-			// Run timeout function if there is a claim or drop with an expiry date
-			exists := CheckExpiryDate(TRQCopy)
-			if exists {
-				go t.runTimeout(TRQCopy)
+				TRQCopy = t.balanceResourceConsumption(ctx, TRQCopy)
 			}
 		} else {
 			// Block the authority to prevent using the cluster resources
-			t.prohibitResourceConsumption(TRQCopy, authority)
+			t.prohibitResourceConsumption(ctx, TRQCopy, authority)
 		}
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj, updated interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj, updated interface{}) error {
 	log.Info("TotalResourceQuotaHandler.ObjectUpdated")
 	// Create a copy of the TRQ object to make changes on it
 	TRQCopy := obj.(*apps_v1alpha.TotalResourceQuota).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	authority, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), TRQCopy.GetName(), metav1.GetOptions{})
+	authority, err := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, TRQCopy.GetName(), metav1.GetOptions{})
 	if errors.IsNotFound(err) {
-		t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Delete(context.TODO(), authority.GetName(), metav1.DeleteOptions{})
+		t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Delete(ctx, authority.GetName(), metav1.DeleteOptions{})
+	} else if err != nil {
+		return err
 	} else {
 		fieldUpdated := updated.(fields)
 		// Check if the authority is active
 		if authority.Spec.Enabled && TRQCopy.Spec.Enabled {
 			// Start procedures if the spec changes
 			if fieldUpdated.spec {
-				TRQCopy, _ = t.ResourceConsumptionControl(TRQCopy, 0, 0)
+				TRQCopy, _ = t.ResourceConsumptionControl(ctx, TRQCopy, 0, 0)
 				if TRQCopy.Status.Exceeded {
-					TRQCopy = t.balanceResourceConsumption(TRQCopy)
-				}
-				if fieldUpdated.expiry {
-					exists := CheckExpiryDate(TRQCopy)
-					if exists {
-						go t.runTimeout(TRQCopy)
-					}
+					TRQCopy = t.balanceResourceConsumption(ctx, TRQCopy)
 				}
 			}
 		} else {
-			t.prohibitResourceConsumption(TRQCopy, authority)
+			t.prohibitResourceConsumption(ctx, TRQCopy, authority)
 		}
 	}
+	return nil
 }
 
 // ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("TotalResourceQuotaHandler.ObjectDeleted")
-	// Delete or disable slices added by authority, TBD.
+	// Nothing outlives the total resource quota, so it needs no finalizer
+	return nil
+}
+
+// ObjectExpired is called by the scheduler when a claim or a drop expires
+func (t *Handler) ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error {
+	log.Info("TotalResourceQuotaHandler.ObjectExpired")
+	TRQCopy := obj.(*apps_v1alpha.TotalResourceQuota).DeepCopy()
+	// The expired items get removed while calculating the total quota
+	TRQCopy, _ = t.ResourceConsumptionControl(ctx, TRQCopy, 0, 0)
+	if TRQCopy.Status.Exceeded {
+		t.balanceResourceConsumption(ctx, TRQCopy)
+	}
+	return nil
 }
 
 // Create generates a total resource quota with the name provided
-func (t *Handler) Create(name string) {
-	_, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), name, metav1.GetOptions{})
+func (t *Handler) Create(ctx context.Context, name string) {
+	_, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		// Set a total resource quota
 		TRQ := apps_v1alpha.TotalResourceQuota{}
@@ -147,7 +163,7 @@ func (t *Handler) Create(name string) {
 		claim.Memory = "12Gi"
 		TRQ.Spec.Claim = append(TRQ.Spec.Claim, claim)
 		TRQ.Spec.Enabled = true
-		_, err = t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Create(context.TODO(), TRQ.DeepCopy(), metav1.CreateOptions{})
+		_, err = t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Create(ctx, TRQ.DeepCopy(), metav1.CreateOptions{})
 		if err != nil {
 			log.Infof(statusDict["TRQ-failed"], name, err)
 		}
@@ -170,18 +186,32 @@ func (t *Handler) sendEmail(username, name, email, userAuthority, sliceAuthority
 }
 
 // prohibitResourceConsumption deletes all slices in authority
-func (t *Handler) prohibitResourceConsumption(TRQCopy *apps_v1alpha.TotalResourceQuota, authority *apps_v1alpha.Authority) {
+func (t *Handler) prohibitResourceConsumption(ctx context.Context, TRQCopy *apps_v1alpha.TotalResourceQuota, authority *apps_v1alpha.Authority) {
+	// Record why the resources are pulled off
+	reason, message := apps_v1alpha.ReasonQuotaDisabled, statusDict["TRQ-disabled"]
+	if !authority.Spec.Enabled {
+		reason, message = apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disable"]
+	}
+	if ready := TRQCopy.Status.GetCondition(apps_v1alpha.ConditionReady); ready == nil || ready.Reason != reason || TRQCopy.Status.ObservedGeneration != TRQCopy.GetGeneration() {
+		TRQCopy.Status.State = failure
+		TRQCopy.Status.Message = []string{message}
+		TRQCopy.Status.SetReady(TRQCopy.GetGeneration(), false, reason, message)
+		if _, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().UpdateStatus(ctx, TRQCopy, metav1.UpdateOptions{}); err != nil {
+			log.Infof("Couldn't update the status of total resource quota in %s: %s", TRQCopy.GetName(), err)
+		}
+		t.recorder.Eventf(TRQCopy, corev1.EventTypeWarning, reason, "%s, the slices of the authority are deleted", message)
+	}
 	// Delete all slices of authority
-	err := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", TRQCopy.GetName())).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+	err := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", TRQCopy.GetName())).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
 	if err != nil {
 		log.Printf("Slice deletion failed in authority %s", TRQCopy.GetName())
 		t.sendEmail("", "", "", "", TRQCopy.GetName(), "", "", "", "slice-collection-deletion-failed")
 	}
-	teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(context.TODO(), metav1.ListOptions{})
+	teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(ctx, metav1.ListOptions{})
 	if len(teamsRaw.Items) != 0 {
 		for _, teamRow := range teamsRaw.Items {
 			teamChildNamespaceStr := fmt.Sprintf("%s-team-%s", teamRow.GetNamespace(), teamRow.GetName())
-			err = t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{})
+			err = t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{})
 			if err != nil {
 				log.Printf("Slice deletion failed in %s", teamChildNamespaceStr)
 				t.sendEmail("", "", "", "", TRQCopy.GetName(), teamChildNamespaceStr, "", "", "slice-collection-deletion-failed")
@@ -209,11 +239,11 @@ func CheckExpiryDate(TRQCopy *apps_v1alpha.TotalResourceQuota) bool {
 // ResourceConsumptionControl both calculates the total resource quota and the total consumption in the authority.
 // Additionally, when a Slice created it comes along with a resource consumption demand. This function also allows us
 // to compare free resources with demands as well.
-func (t *Handler) ResourceConsumptionControl(TRQCopy *apps_v1alpha.TotalResourceQuota, CPUDemand int64, memoryDemand int64) (*apps_v1alpha.TotalResourceQuota, bool) {
+func (t *Handler) ResourceConsumptionControl(ctx context.Context, TRQCopy *apps_v1alpha.TotalResourceQuota, CPUDemand int64, memoryDemand int64) (*apps_v1alpha.TotalResourceQuota, bool) {
 	// Find out the total resource quota by taking claims and drops into account
-	TRQCopy, CPUQuota, MemoryQuota := t.calculateTotalQuota(TRQCopy)
+	TRQCopy, CPUQuota, MemoryQuota := t.calculateTotalQuota(ctx, TRQCopy)
 	// Get the total consumption that all Slices do in authority
-	consumedCPU, consumedMemory := t.calculateConsumedResources(TRQCopy)
+	consumedCPU, consumedMemory := t.calculateConsumedResources(ctx, TRQCopy)
 	consumedCPU += CPUDemand
 	consumedMemory += memoryDemand
 	demand := false
@@ -222,83 +252,84 @@ func (t *Handler) ResourceConsumptionControl(TRQCopy *apps_v1alpha.TotalResource
 	}
 
 	// Compare the consumption with the total resource quota
-	TRQCopy, quotaExceeded := t.checkResourceBalance(TRQCopy, CPUQuota, MemoryQuota, consumedCPU, consumedMemory, demand)
+	TRQCopy, quotaExceeded := t.checkResourceBalance(ctx, TRQCopy, CPUQuota, MemoryQuota, consumedCPU, consumedMemory, demand)
 	return TRQCopy, quotaExceeded
 }
 
 // calculateTotalQuota adds the resources defined in claims, and subtracts those in drops to calculate the total resource quota.
 // Moreover, the function checkes whether any claim or drop has an expiry date and updates the object if exists.
-func (t *Handler) calculateTotalQuota(TRQCopy *apps_v1alpha.TotalResourceQuota) (*apps_v1alpha.TotalResourceQuota, int64, int64) {
+func (t *Handler) calculateTotalQuota(ctx context.Context, TRQCopy *apps_v1alpha.TotalResourceQuota) (*apps_v1alpha.TotalResourceQuota, int64, int64) {
 	var CPUQuota int64
 	var memoryQuota int64
 	// To make comparison
 	oldTRQCopy := TRQCopy.DeepCopy()
-	// claimSlice to be manipulated
-	claimSlice := TRQCopy.Spec.Claim
-	// dropSlice to be manipulated
-	dropSlice := TRQCopy.Spec.Drop
+	// claimSlice and dropSlice keep the items that have not expired yet
+	claimSlice := []apps_v1alpha.TotalResourceDetails{}
+	dropSlice := []apps_v1alpha.TotalResourceDetails{}
 	if len(TRQCopy.Spec.Claim) > 0 {
-		j := 0
 		for _, claim := range TRQCopy.Spec.Claim {
 			if claim.Expires == nil || (claim.Expires != nil && claim.Expires.Time.Sub(time.Now()) >= 0) {
 				CPUResource := resource.MustParse(claim.CPU)
 				CPUQuota += CPUResource.Value()
 				memoryResource := resource.MustParse(claim.Memory)
 				memoryQuota += memoryResource.Value()
+				claimSlice = append(claimSlice, claim)
 			} else {
 				// Remove the item from claims if the expiry date has run out
-				claimSlice = append(claimSlice[:j], claimSlice[j+1:]...)
-				j--
+				t.recorder.Eventf(TRQCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonExpired, "Claim %s expired", claim.Name)
 			}
-			j++
 		}
 		// Sync the claims
 		TRQCopy.Spec.Claim = claimSlice
 	}
 	if len(TRQCopy.Spec.Drop) > 0 {
-		j := 0
 		for _, drop := range TRQCopy.Spec.Drop {
 			if drop.Expires == nil || (drop.Expires != nil && drop.Expires.Time.Sub(time.Now()) >= 0) {
 				CPUResource := resource.MustParse(drop.CPU)
 				CPUQuota -= CPUResource.Value()
 				memoryResource := resource.MustParse(drop.Memory)
 				memoryQuota -= memoryResource.Value()
+				dropSlice = append(dropSlice, drop)
 			} else {
 				// Remove the item from drops if the expiry date has run out
-				dropSlice = append(dropSlice[:j], dropSlice[j+1:]...)
-				j--
+				t.recorder.Eventf(TRQCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonExpired, "Drop %s expired", drop.Name)
 			}
-			j++
 		}
 		// Sync the drops
 		TRQCopy.Spec.Drop = dropSlice
 	}
 	// Check if there is an update
 	if !reflect.DeepEqual(oldTRQCopy, TRQCopy) {
-		TRQCopyUpdated, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(context.TODO(), TRQCopy, metav1.UpdateOptions{})
+		TRQCopyUpdated, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Update(ctx, TRQCopy, metav1.UpdateOptions{})
 		if err == nil {
 			TRQCopy = TRQCopyUpdated
 			TRQCopy.Status.State = success
 			TRQCopy.Status.Message = []string{statusDict["TRQ-applied"]}
+			TRQCopy.Status.SetReady(TRQCopy.GetGeneration(), true, apps_v1alpha.ReasonQuotaApplied, statusDict["TRQ-applied"])
 		} else {
 			log.Infof("Couldn't update total resource quota in %s: %s", TRQCopy.GetName(), err)
 			TRQCopy.Status.State = failure
 			TRQCopy.Status.Message = []string{statusDict["TRQ-appliedFail"]}
+			TRQCopy.Status.SetReady(TRQCopy.GetGeneration(), false, apps_v1alpha.ReasonQuotaUpdateFailed, statusDict["TRQ-appliedFail"])
 		}
 	}
 	return TRQCopy, CPUQuota, memoryQuota
 }
 
 // calculateConsumedResources looks out for slices in authority and teams to determine the total consumption
-func (t *Handler) calculateConsumedResources(TRQCopy *apps_v1alpha.TotalResourceQuota) (int64, int64) {
+func (t *Handler) calculateConsumedResources(ctx context.Context, TRQCopy *apps_v1alpha.TotalResourceQuota) (int64, int64) {
 	var consumedCPU int64
 	var consumedMemory int64
-	slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(context.TODO(), metav1.ListOptions{})
+	slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(ctx, metav1.ListOptions{})
 	if len(slicesRaw.Items) != 0 {
 		for _, slicesRow := range slicesRaw.Items {
+			// The slices being deleted give their resources back
+			if slicesRow.GetDeletionTimestamp() != nil {
+				continue
+			}
 			sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", slicesRow.GetNamespace(), slicesRow.GetName())
 			// Check out the resource quotas in the slice namespace rather than the slice profile
-			resourceQuotasRaw, _ := t.clientset.CoreV1().ResourceQuotas(sliceChildNamespaceStr).List(context.TODO(), metav1.ListOptions{})
+			resourceQuotasRaw, _ := t.clientset.CoreV1().ResourceQuotas(sliceChildNamespaceStr).List(ctx, metav1.ListOptions{})
 			if len(resourceQuotasRaw.Items) != 0 {
 				for _, resourceQuotasRow := range resourceQuotasRaw.Items {
 					consumedCPU += resourceQuotasRow.Spec.Hard.Cpu().Value()
@@ -307,15 +338,18 @@ func (t *Handler) calculateConsumedResources(TRQCopy *apps_v1alpha.TotalResource
 			}
 		}
 	}
-	teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(context.TODO(), metav1.ListOptions{})
+	teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(ctx, metav1.ListOptions{})
 	if len(teamsRaw.Items) != 0 {
 		for _, teamRow := range teamsRaw.Items {
 			teamChildNamespaceStr := fmt.Sprintf("%s-team-%s", teamRow.GetNamespace(), teamRow.GetName())
-			slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).List(context.TODO(), metav1.ListOptions{})
+			slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).List(ctx, metav1.ListOptions{})
 			if len(slicesRaw.Items) != 0 {
 				for _, slicesRow := range slicesRaw.Items {
+					if slicesRow.GetDeletionTimestamp() != nil {
+						continue
+					}
 					sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", slicesRow.GetNamespace(), slicesRow.GetName())
-					resourceQuotasRaw, _ := t.clientset.CoreV1().ResourceQuotas(sliceChildNamespaceStr).List(context.TODO(), metav1.ListOptions{})
+					resourceQuotasRaw, _ := t.clientset.CoreV1().ResourceQuotas(sliceChildNamespaceStr).List(ctx, metav1.ListOptions{})
 					if len(resourceQuotasRaw.Items) != 0 {
 						for _, resourceQuotasRow := range resourceQuotasRaw.Items {
 							consumedCPU += resourceQuotasRow.Spec.Hard.Cpu().Value()
@@ -330,7 +364,7 @@ func (t *Handler) calculateConsumedResources(TRQCopy *apps_v1alpha.TotalResource
 }
 
 // checkResourceBalance compares the total resource quota with the total consumption to detect if there is an overusing of resources
-func (t *Handler) checkResourceBalance(TRQCopy *apps_v1alpha.TotalResourceQuota,
+func (t *Handler) checkResourceBalance(ctx context.Context, TRQCopy *apps_v1alpha.TotalResourceQuota,
 	CPUQuota, memoryQuota, consumedCPU, consumedMemory int64, resourceDemand bool) (*apps_v1alpha.TotalResourceQuota, bool) {
 	log.Println("checkResourceBalance")
 	log.Printf("Quota = CPU: %d, Memory: %d - Consumed = CPU: %d, Memory: %d", CPUQuota, memoryQuota, consumedCPU, consumedMemory)
@@ -348,17 +382,27 @@ func (t *Handler) checkResourceBalance(TRQCopy *apps_v1alpha.TotalResourceQuota,
 	TRQCopy.Status.Exceeded = quotaExceeded
 	TRQCopy.Status.Used.CPU = percentage(consumedCPU, CPUQuota)
 	TRQCopy.Status.Used.Memory = percentage(consumedMemory, memoryQuota)
+	if quotaExceeded {
+		TRQCopy.Status.SetReady(TRQCopy.GetGeneration(), false, apps_v1alpha.ReasonQuotaExceeded, statusDict["TRQ-exceeded"])
+	} else if ready := TRQCopy.Status.GetCondition(apps_v1alpha.ConditionReady); ready != nil && ready.Reason == apps_v1alpha.ReasonQuotaExceeded {
+		TRQCopy.Status.SetReady(TRQCopy.GetGeneration(), true, apps_v1alpha.ReasonQuotaApplied, statusDict["TRQ-applied"])
+	}
 	// Check if there is an update
 	if !reflect.DeepEqual(oldTRQCopy, TRQCopy) {
 		// If there is a resource request causing the quota to be exceeded, skip this section.
 		// The slice demanding resource will be removed.
 		if (!TRQCopy.Status.Exceeded && resourceDemand) || !resourceDemand {
-			TRQCopyUpdated, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().UpdateStatus(context.TODO(), TRQCopy, metav1.UpdateOptions{})
+			TRQCopyUpdated, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().UpdateStatus(ctx, TRQCopy, metav1.UpdateOptions{})
 			if err == nil {
 				TRQCopy = TRQCopyUpdated
 			} else {
 				log.Infof("Couldn't update the status of total resource quota in %s: %s", TRQCopy.GetName(), err)
 			}
+			if quotaExceeded && !oldTRQCopy.Status.Exceeded {
+				t.recorder.Event(TRQCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonQuotaExceeded, statusDict["TRQ-exceeded"])
+			} else if !quotaExceeded && oldTRQCopy.Status.Exceeded {
+				t.recorder.Event(TRQCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonQuotaApplied, statusDict["TRQ-applied"])
+			}
 		} else {
 			// Replace with the old version since the process is canceled
 			TRQCopy.Status = oldTRQCopy.Status
@@ -368,12 +412,12 @@ func (t *Handler) checkResourceBalance(TRQCopy *apps_v1alpha.TotalResourceQuota,
 }
 
 // balanceResourceConsumption determines the slice to be removed by picking the oldest one
-func (t *Handler) balanceResourceConsumption(TRQCopy *apps_v1alpha.TotalResourceQuota) *apps_v1alpha.TotalResourceQuota {
+func (t *Handler) balanceResourceConsumption(ctx context.Context, TRQCopy *apps_v1alpha.TotalResourceQuota) *apps_v1alpha.TotalResourceQuota {
 	var oldestDate metav1.Time
 	var oldestSlice apps_v1alpha.Slice
 	log.Println("balanceResourceConsumption")
 	// Get the oldest slice in the authority namespace
-	slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(context.TODO(), metav1.ListOptions{})
+	slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(ctx, metav1.ListOptions{})
 	if len(slicesRaw.Items) != 0 {
 		for i, sliceRow := range slicesRaw.Items {
 			if i == 0 {
@@ -388,11 +432,11 @@ func (t *Handler) balanceResourceConsumption(TRQCopy *apps_v1alpha.TotalResource
 		}
 	}
 	// Get the oldest slice in the team namespaces
-	teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(context.TODO(), metav1.ListOptions{})
+	teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(fmt.Sprintf("authority-%s", TRQCopy.GetName())).List(ctx, metav1.ListOptions{})
 	if len(teamsRaw.Items) != 0 {
 		for _, teamRow := range teamsRaw.Items {
 			teamChildNamespaceStr := fmt.Sprintf("authority-%s-team-%s", TRQCopy.GetName(), teamRow.GetName())
-			slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).List(context.TODO(), metav1.ListOptions{})
+			slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(teamChildNamespaceStr).List(ctx, metav1.ListOptions{})
 			if len(slicesRaw.Items) != 0 {
 				for _, sliceRow := range slicesRaw.Items {
 					if oldestDate.Sub(sliceRow.GetCreationTimestamp().Time) <= 0 {
@@ -404,11 +448,13 @@ func (t *Handler) balanceResourceConsumption(TRQCopy *apps_v1alpha.TotalResource
 		}
 	}
 	// Delete the oldest slice and send a notification email
-	err := t.edgenetClientset.AppsV1alpha().Slices(oldestSlice.GetNamespace()).Delete(context.TODO(), oldestSlice.GetName(), metav1.DeleteOptions{})
+	err := t.edgenetClientset.AppsV1alpha().Slices(oldestSlice.GetNamespace()).Delete(ctx, oldestSlice.GetName(), metav1.DeleteOptions{})
 	sliceChildNamespaceStr := fmt.Sprintf("%s-slice-%s", oldestSlice.GetNamespace(), oldestSlice.GetName())
 	if err == nil {
+		t.recorder.Eventf(TRQCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonSliceEvicted, "Slice %s in %s deleted to get back under the quota", oldestSlice.GetName(), oldestSlice.GetNamespace())
+		t.recorder.Eventf(&oldestSlice, corev1.EventTypeWarning, apps_v1alpha.ReasonQuotaExceeded, "Total resource quota of %s exceeded, the oldest slice is deleted", TRQCopy.GetName())
 		for _, sliceUser := range oldestSlice.Spec.Users {
-			user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUser.Authority)).Get(context.TODO(), sliceUser.Username, metav1.GetOptions{})
+			user, err := t.edgenetClientset.AppsV1alpha().Users(fmt.Sprintf("authority-%s", sliceUser.Authority)).Get(ctx, sliceUser.Username, metav1.GetOptions{})
 			if err == nil && user.Spec.Active && user.Status.AUP {
 				t.sendEmail(sliceUser.Username, fmt.Sprintf("%s %s", user.Spec.FirstName, user.Spec.LastName), user.Spec.Email, sliceUser.Authority,
 					TRQCopy.GetName(), oldestSlice.GetNamespace(), oldestSlice.GetName(), sliceChildNamespaceStr, "slice-total-quota-exceeded")
@@ -416,123 +462,18 @@ func (t *Handler) balanceResourceConsumption(TRQCopy *apps_v1alpha.TotalResource
 		}
 	} else {
 		log.Printf("Slice %s deletion failed in %s", oldestSlice.GetName(), oldestSlice.GetNamespace())
+		t.recorder.Eventf(TRQCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonSliceEvicted, "Slice %s in %s cannot be deleted: %s", oldestSlice.GetName(), oldestSlice.GetNamespace(), err)
 		t.sendEmail("", "", "", "", TRQCopy.GetName(), oldestSlice.GetNamespace(), oldestSlice.GetName(), sliceChildNamespaceStr, "slice-deletion-failed")
 	}
 	// Check out the balance again
-	TRQCopy, _ = t.ResourceConsumptionControl(TRQCopy, 0, 0)
+	TRQCopy, _ = t.ResourceConsumptionControl(ctx, TRQCopy, 0, 0)
 	// Run the procedure again if the consumption still reaches the quota limit
 	if TRQCopy.Status.Exceeded {
-		TRQCopy = t.balanceResourceConsumption(TRQCopy)
+		TRQCopy = t.balanceResourceConsumption(ctx, TRQCopy)
 	}
 	return TRQCopy
 }
 
-// runTimeout puts a procedure in place to remove claims and drops after the timeout
-func (t *Handler) runTimeout(TRQCopy *apps_v1alpha.TotalResourceQuota) {
-	timeoutRenewed := make(chan bool, 1)
-	terminated := make(chan bool, 1)
-	var timeout <-chan time.Time
-	timeout = time.After(time.Until(getClosestExpiryDate(TRQCopy)))
-	closeChannels := func() {
-		close(timeoutRenewed)
-		close(terminated)
-	}
-
-	// Watch the events of total resource quota object
-	watchTRQ, err := t.edgenetClientset.AppsV1alpha().TotalResourceQuotas().Watch(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", TRQCopy.GetName())})
-	if err == nil {
-		go func() {
-			// Get events from watch interface
-			for TRQEvent := range watchTRQ.ResultChan() {
-				// Get updated slice object
-				updatedTRQ, status := TRQEvent.Object.(*apps_v1alpha.TotalResourceQuota)
-				if TRQCopy.GetUID() == updatedTRQ.GetUID() {
-					if status {
-						if TRQEvent.Type == "DELETED" {
-							terminated <- true
-							continue
-						}
-						TRQCopy = updatedTRQ
-						exists := CheckExpiryDate(TRQCopy)
-						if exists {
-							timeout = time.After(time.Until(getClosestExpiryDate(TRQCopy)))
-							timeoutRenewed <- true
-						} else {
-							select {
-							case <-terminated:
-								watchTRQ.Stop()
-							default:
-								terminated <- true
-							}
-						}
-					}
-				}
-			}
-		}()
-	} else {
-		// In case of any malfunction of watching total resource quota objects,
-		// there is a timeout at 72 hours
-		timeout = time.After(72 * time.Hour)
-	}
-
-	// Infinite loop
-timeoutLoop:
-	for {
-		// Wait on multiple channel operations
-	timeoutOptions:
-		select {
-		case <-timeoutRenewed:
-			break timeoutOptions
-		case <-timeout:
-			TRQCopy, _ = t.ResourceConsumptionControl(TRQCopy, 0, 0)
-			if TRQCopy.Status.Exceeded {
-				TRQCopy = t.balanceResourceConsumption(TRQCopy)
-			}
-			exists := CheckExpiryDate(TRQCopy)
-			if !exists {
-				terminated <- true
-			}
-			break timeoutOptions
-		case <-terminated:
-			watchTRQ.Stop()
-			closeChannels()
-			break timeoutLoop
-		}
-	}
-}
-
-// getClosestExpiryDate determines the item, a claim or a drop, having the closest expiry date
-func getClosestExpiryDate(TRQCopy *apps_v1alpha.TotalResourceQuota) time.Time {
-	var closestDate *metav1.Time
-	for i, claim := range TRQCopy.Spec.Claim {
-		if i == 0 {
-			if claim.Expires != nil {
-				closestDate = claim.Expires
-			}
-		} else if i != 0 {
-			if claim.Expires != nil {
-				if closestDate.Sub(claim.Expires.Time) >= 0 {
-					closestDate = claim.Expires
-				}
-			}
-		}
-	}
-	for j, drop := range TRQCopy.Spec.Drop {
-		if j == 0 {
-			if drop.Expires != nil {
-				closestDate = drop.Expires
-			}
-		} else if j != 0 {
-			if drop.Expires != nil {
-				if closestDate.Sub(drop.Expires.Time) >= 0 {
-					closestDate = drop.Expires
-				}
-			}
-		}
-	}
-	return closestDate.Time
-}
-
 // percentage to give a overview of resource consumption
 func percentage(value1, value2 int64) float64 {
 	var percentage float64
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
@@ -244,11 +245,17 @@ func TestCreate(t *testing.T) {
 				TRQ.Spec.Drop = append(TRQ.Spec.Drop, drop)
 			}
 			g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Create(context.TODO(), TRQ.DeepCopy(), metav1.CreateOptions{})
-			g.handler.ObjectCreated(TRQ.DeepCopy())
+			g.handler.ObjectCreated(context.TODO(), TRQ.DeepCopy())
 			defer g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Delete(context.TODO(), TRQ.GetName(), metav1.DeleteOptions{})
 			time.Sleep(tc.sleep * time.Millisecond)
 			TRQCopy, err := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), TRQ.GetName(), metav1.GetOptions{})
 			util.OK(t, err)
+			// The scheduler calls the handler once the closest expiry date passes
+			if deadline, due := getDeadlines(TRQCopy)[expiry.Expire]; due && !deadline.After(time.Now()) {
+				util.OK(t, g.handler.ObjectExpired(context.TODO(), TRQCopy, expiry.Expire))
+				TRQCopy, err = g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), TRQ.GetName(), metav1.GetOptions{})
+				util.OK(t, err)
+			}
 			util.Equals(t, true, TRQCopy.Spec.Enabled)
 			util.Equals(t, tc.expected, (len(TRQCopy.Spec.Claim) + len(TRQCopy.Spec.Drop)))
 		})
@@ -278,7 +285,7 @@ func TestCreate(t *testing.T) {
 		TRQ := g.TRQObj
 		TRQ.Status.Exceeded = true
 		g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Create(context.TODO(), TRQ.DeepCopy(), metav1.CreateOptions{})
-		g.handler.ObjectCreated(TRQ.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), TRQ.DeepCopy())
 		defer g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Delete(context.TODO(), TRQ.GetName(), metav1.DeleteOptions{})
 		TRQCopy, err := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), TRQ.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
@@ -300,7 +307,7 @@ func TestUpdate(t *testing.T) {
 	TRQ := g.TRQObj
 	_, err := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Create(context.TODO(), TRQ.DeepCopy(), metav1.CreateOptions{})
 	util.OK(t, err)
-	g.handler.ObjectCreated(TRQ.DeepCopy())
+	g.handler.ObjectCreated(context.TODO(), TRQ.DeepCopy())
 	defer g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Delete(context.TODO(), TRQ.GetName(), metav1.DeleteOptions{})
 
 	cases := map[string]struct {
@@ -338,18 +345,21 @@ func TestUpdate(t *testing.T) {
 					}
 					TRQCopy.Spec.Drop = append(TRQCopy.Spec.Drop, drop)
 				}
-				field.expiry = true
 			} else {
 				TRQCopy.Spec.Claim = append(TRQCopy.Spec.Claim, claim)
 				TRQCopy.Spec.Drop = append(TRQCopy.Spec.Drop, drop)
-				field.expiry = false
 			}
 			_, err = g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Update(context.TODO(), TRQCopy.DeepCopy(), metav1.UpdateOptions{})
 			util.OK(t, err)
-			g.handler.ObjectUpdated(TRQCopy.DeepCopy(), field)
+			g.handler.ObjectUpdated(context.TODO(), TRQCopy.DeepCopy(), field)
 			time.Sleep(tc.sleep * time.Millisecond)
 			TRQCopy, err = g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), TRQCopy.GetName(), metav1.GetOptions{})
 			util.OK(t, err)
+			if deadline, due := getDeadlines(TRQCopy)[expiry.Expire]; due && !deadline.After(time.Now()) {
+				util.OK(t, g.handler.ObjectExpired(context.TODO(), TRQCopy, expiry.Expire))
+				TRQCopy, err = g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), TRQCopy.GetName(), metav1.GetOptions{})
+				util.OK(t, err)
+			}
 			util.Equals(t, true, TRQCopy.Spec.Enabled)
 			util.Equals(t, tc.expected, (len(TRQCopy.Spec.Claim) + len(TRQCopy.Spec.Drop)))
 		})
@@ -371,7 +381,7 @@ func TestUpdate(t *testing.T) {
 		util.OK(t, err)
 		var field fields
 		field.spec = true
-		g.handler.ObjectUpdated(TRQCopy.DeepCopy(), field)
+		g.handler.ObjectUpdated(context.TODO(), TRQCopy.DeepCopy(), field)
 		time.Sleep(100 * time.Millisecond)
 
 		cases := map[string]struct {
@@ -447,9 +457,6 @@ func TestUpdate(t *testing.T) {
 							claim.Expires = &metav1.Time{
 								Time: time.Now().Add(tc.expiry[i] * time.Millisecond),
 							}
-							field.expiry = true
-						} else {
-							field.expiry = false
 						}
 						TRQCopy.Spec.Claim = append(TRQCopy.Spec.Claim, claim)
 					} else if tc.kind[i] == "Drop" {
@@ -458,17 +465,19 @@ func TestUpdate(t *testing.T) {
 							drop.Expires = &metav1.Time{
 								Time: time.Now().Add(tc.expiry[i] * time.Millisecond),
 							}
-							field.expiry = true
-						} else {
-							field.expiry = false
 						}
 						TRQCopy.Spec.Drop = append(TRQCopy.Spec.Drop, drop)
 					}
 				}
 				_, err = g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Update(context.TODO(), TRQCopy.DeepCopy(), metav1.UpdateOptions{})
 				util.OK(t, err)
-				g.handler.ObjectUpdated(TRQCopy.DeepCopy(), field)
+				g.handler.ObjectUpdated(context.TODO(), TRQCopy.DeepCopy(), field)
 				time.Sleep(150 * time.Millisecond)
+				TRQCopy, err = g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), TRQ.GetName(), metav1.GetOptions{})
+				util.OK(t, err)
+				if deadline, due := getDeadlines(TRQCopy)[expiry.Expire]; due && !deadline.After(time.Now()) {
+					util.OK(t, g.handler.ObjectExpired(context.TODO(), TRQCopy, expiry.Expire))
+				}
 
 				_, err = g.edgenetClient.AppsV1alpha().Slices(teamChildNamespace).Get(context.TODO(), slice.GetName(), metav1.GetOptions{})
 				util.Equals(t, tc.expected, errors.IsNotFound(err))
@@ -485,7 +494,7 @@ func TestCreateTotalResourceQuota(t *testing.T) {
 
 	_, err := g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), g.TRQObj.GetName(), metav1.GetOptions{})
 	util.Equals(t, true, errors.IsNotFound(err))
-	g.handler.Create(g.TRQObj.GetName())
+	g.handler.Create(context.TODO(), g.TRQObj.GetName())
 	_, err = g.edgenetClient.AppsV1alpha().TotalResourceQuotas().Get(context.TODO(), g.TRQObj.GetName(), metav1.GetOptions{})
 	util.OK(t, err)
 }
@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/emailverification"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	"github.com/EdgeNet-project/edgenet/pkg/mailer"
@@ -29,24 +31,27 @@ import (
 	"github.com/EdgeNet-project/edgenet/pkg/registration"
 
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 )
 
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj, updated interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj, updated interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
 }
 
 // Handler implementation
 type Handler struct {
 	clientset        kubernetes.Interface
 	edgenetClientset versioned.Interface
+	recorder         record.EventRecorder
 }
 
 // Init handles any handler initialization
@@ -54,63 +59,76 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 	log.Info("UserHandler.Init")
 	t.clientset = kubernetes
 	t.edgenetClientset = edgenet
+	t.recorder = ctlruntime.NewEventRecorder(kubernetes, "user-controller")
 	permission.Clientset = t.clientset
 	registration.Clientset = t.clientset
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("UserHandler.ObjectCreated")
 	// Create a copy of the user object to make changes on it
 	userCopy := obj.(*apps_v1alpha.User).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	userOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), userCopy.GetNamespace(), metav1.GetOptions{})
+	userOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, userCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// The finalizer holds the deletion of the user until ObjectDeleted cleans up after it
+	if ctlruntime.AddFinalizer(userCopy) {
+		userUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
+		if err == nil {
+			userCopy = userUpdated
+		}
+	}
 	// Check if the email address is already taken
-	emailExists, message := t.checkDuplicateObject(userCopy, userOwnerNamespace.Labels["authority-name"])
+	emailExists, message := t.checkDuplicateObject(ctx, userCopy, userOwnerNamespace.Labels["authority-name"])
 
 	if emailExists {
 		userCopy.Spec.Active = false
-		userUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(context.TODO(), userCopy, metav1.UpdateOptions{})
+		userUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
 		if err == nil {
 			userCopy = userUpdated
 		}
 		userCopy.Status.State = failure
 		userCopy.Status.Message = []string{message}
-		t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(context.TODO(), userCopy, metav1.UpdateOptions{})
-		return
+		userCopy.Status.SetReady(userCopy.GetGeneration(), false, apps_v1alpha.ReasonEmailInUse, message)
+		t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(ctx, userCopy, metav1.UpdateOptions{})
+		t.recorder.Event(userCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonEmailInUse, message)
+		return nil
 	}
 
-	userOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), userOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	userOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, userOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 
-	_, serviceAccountErr := t.clientset.CoreV1().ServiceAccounts(userCopy.GetNamespace()).Get(context.TODO(), userCopy.GetName(), metav1.GetOptions{})
+	_, serviceAccountErr := t.clientset.CoreV1().ServiceAccounts(userCopy.GetNamespace()).Get(ctx, userCopy.GetName(), metav1.GetOptions{})
 	if !errors.IsNotFound(serviceAccountErr) {
 		// To demission service accounts providing user authentication
-		t.clientset.CoreV1().ServiceAccounts(userCopy.GetNamespace()).Delete(context.TODO(), userCopy.GetName(), metav1.DeleteOptions{})
+		t.clientset.CoreV1().ServiceAccounts(userCopy.GetNamespace()).Delete(ctx, userCopy.GetName(), metav1.DeleteOptions{})
 	}
 	// Check if the authority is active
 	if (userOwnerAuthority.Spec.Enabled && userCopy.Spec.Active) || (userOwnerAuthority.Spec.Enabled && userCopy.Spec.Active && !errors.IsNotFound(serviceAccountErr)) {
 		// If the service restarts, it creates all objects again
 		// Because of that, this section covers a variety of possibilities
-		_, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(userCopy.GetNamespace()).Get(context.TODO(), userCopy.GetName(), metav1.GetOptions{})
+		_, err := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(userCopy.GetNamespace()).Get(ctx, userCopy.GetName(), metav1.GetOptions{})
 		if err != nil || !errors.IsNotFound(serviceAccountErr) {
 			// Automatically creates an acceptable use policy object belonging to the user in the authority namespace
 			// When a user is deleted, the owner references feature allows the related AUP to be automatically removed
 			userOwnerReferences := SetAsOwnerReference(userCopy)
 			userAUP := &apps_v1alpha.AcceptableUsePolicy{TypeMeta: metav1.TypeMeta{Kind: "AcceptableUsePolicy", APIVersion: "apps.edgenet.io/v1alpha"},
 				ObjectMeta: metav1.ObjectMeta{Name: userCopy.GetName(), OwnerReferences: userOwnerReferences}, Spec: apps_v1alpha.AcceptableUsePolicySpec{Accepted: false}}
-			t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(userCopy.GetNamespace()).Create(context.TODO(), userAUP, metav1.CreateOptions{})
+			t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(userCopy.GetNamespace()).Create(ctx, userAUP, metav1.CreateOptions{})
 			// Create user specific roles
-			err = permission.CreateUserSpecificRole(userCopy, userOwnerNamespace, userOwnerReferences)
+			err = permission.CreateUserSpecificRole(ctx, userCopy, userOwnerNamespace, userOwnerReferences)
 			if err != nil {
 				// TBD
 			}
 
 			// Create the AUP role
-			err = permission.CreateUserAUPRole(userCopy, userOwnerReferences)
+			err = permission.CreateUserAUPRole(ctx, userCopy, userOwnerReferences)
 			if err != nil {
 				// TBD
 			}
-			defer t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(context.TODO(), userCopy, metav1.UpdateOptions{})
+			defer t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(ctx, userCopy, metav1.UpdateOptions{})
 			if userOwnerAuthority.Spec.Contact.Username == userCopy.GetName() && userOwnerAuthority.Spec.Contact.Email == userCopy.Spec.Email {
 				userCopy.Status.Type = "admin"
 			} else {
@@ -118,63 +136,76 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 			}
 			// Create the client certs for permanent use
 			// In next versions, there will be a method to renew the certs for security
-			crt, key, err := registration.MakeUser(userOwnerNamespace.Labels["authority-name"], userCopy.GetName(), userCopy.Spec.Email)
+			crt, key, err := registration.MakeUser(ctx, userOwnerNamespace.Labels["authority-name"], userCopy.GetName(), userCopy.Spec.Email)
 			if err != nil {
 				log.Println(err.Error())
 				userCopy.Status.State = failure
 				userCopy.Status.Message = []string{fmt.Sprintf(statusDict["cert-fail"], userCopy.GetName())}
+				userCopy.Status.SetReady(userCopy.GetGeneration(), false, apps_v1alpha.ReasonCertificateFailed, userCopy.Status.Message[0])
+				t.recorder.Eventf(userCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonCertificateFailed, "%s: %s", userCopy.Status.Message[0], err)
 				t.sendEmail(userCopy, userOwnerNamespace.Labels["authority-name"], "user-cert-failure")
-				return
+				return nil
 			}
 			err = registration.MakeConfig(userOwnerNamespace.Labels["authority-name"], userCopy.GetName(), userCopy.Spec.Email, crt, key)
 			if err != nil {
 				log.Println(err.Error())
 				userCopy.Status.State = failure
 				userCopy.Status.Message = []string{fmt.Sprintf(statusDict["kubeconfig-fail"], userCopy.GetName())}
+				userCopy.Status.SetReady(userCopy.GetGeneration(), false, apps_v1alpha.ReasonKubeconfigFailed, userCopy.Status.Message[0])
+				t.recorder.Eventf(userCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonKubeconfigFailed, "%s: %s", userCopy.Status.Message[0], err)
 				t.sendEmail(userCopy, userOwnerNamespace.Labels["authority-name"], "user-kubeconfig-failure")
 			}
 			userCopy.Status.State = success
 			userCopy.Status.Message = []string{statusDict["cert-ok"]}
+			userCopy.Status.SetReady(userCopy.GetGeneration(), true, apps_v1alpha.ReasonReconciled, statusDict["cert-ok"])
+			t.recorder.Event(userCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonReconciled, statusDict["cert-ok"])
 			t.sendEmail(userCopy, userOwnerNamespace.Labels["authority-name"], "user-registration-successful")
 
-			slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(userCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
-			teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(userCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
-			t.createRoleBindings(userCopy, slicesRaw, teamsRaw, userOwnerAuthority.GetName())
-			permission.CreateAUPRoleBinding(userCopy, userOwnerReferences)
+			slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(userCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
+			teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(userCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
+			t.createRoleBindings(ctx, userCopy, slicesRaw, teamsRaw, userOwnerAuthority.GetName())
+			permission.CreateAUPRoleBinding(ctx, userCopy, userOwnerReferences)
 		}
 	} else if userOwnerAuthority.Spec.Enabled == false && userCopy.Spec.Active == true {
-		defer t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(context.TODO(), userCopy, metav1.UpdateOptions{})
+		defer t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
 		userCopy.Spec.Active = false
+		t.recorder.Eventf(userCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"], userOwnerNamespace.Labels["authority-name"])
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj, updated interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj, updated interface{}) error {
 	log.Info("UserHandler.ObjectUpdated")
 	// Create a copy of the user object to make changes on it
 	userCopy := obj.(*apps_v1alpha.User).DeepCopy()
-	userOwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), userCopy.GetNamespace(), metav1.GetOptions{})
+	userOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, userCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
 	// Check if the email address is already taken
-	emailExists, message := t.checkDuplicateObject(userCopy, userOwnerNamespace.Labels["authority-name"])
+	emailExists, message := t.checkDuplicateObject(ctx, userCopy, userOwnerNamespace.Labels["authority-name"])
 	if emailExists {
 		userCopy.Spec.Active = false
-		userUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(context.TODO(), userCopy, metav1.UpdateOptions{})
+		userUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
 		if err == nil {
 			userCopy = userUpdated
 		}
 		userCopy.Status.State = failure
 		userCopy.Status.Message = []string{message}
-		t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(context.TODO(), userCopy, metav1.UpdateOptions{})
-		return
+		userCopy.Status.SetReady(userCopy.GetGeneration(), false, apps_v1alpha.ReasonEmailInUse, message)
+		t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(ctx, userCopy, metav1.UpdateOptions{})
+		t.recorder.Event(userCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonEmailInUse, message)
+		return nil
 	}
-	userOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), userOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	userOwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, userOwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	fieldUpdated := updated.(fields)
 	// Security check to prevent any kind of manipulation on the AUP
 	if fieldUpdated.aup {
-		userAUP, _ := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(userCopy.GetNamespace()).Get(context.TODO(), userCopy.GetName(), metav1.GetOptions{})
+		userAUP, _ := t.edgenetClientset.AppsV1alpha().AcceptableUsePolicies(userCopy.GetNamespace()).Get(ctx, userCopy.GetName(), metav1.GetOptions{})
 		if userAUP.Spec.Accepted != userCopy.Status.AUP {
 			userCopy.Status.AUP = userAUP.Spec.Accepted
-			userCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(context.TODO(), userCopy, metav1.UpdateOptions{})
+			userCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(ctx, userCopy, metav1.UpdateOptions{})
 			if err == nil {
 				userCopy = userCopyUpdated
 			}
@@ -183,7 +214,7 @@ func (t *Handler) ObjectUpdated(obj, updated interface{}) {
 	if userOwnerAuthority.Spec.Enabled {
 		if fieldUpdated.email {
 			userCopy.Spec.Active = false
-			userCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(context.TODO(), userCopy, metav1.UpdateOptions{})
+			userCopyUpdated, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
 			if err == nil {
 				userCopy = userCopyUpdated
 			} else {
@@ -192,17 +223,19 @@ func (t *Handler) ObjectUpdated(obj, updated interface{}) {
 			}
 			emailVerificationHandler := emailverification.Handler{}
 			emailVerificationHandler.Init(t.clientset, t.edgenetClientset)
-			created := emailVerificationHandler.Create(userCopy, SetAsOwnerReference(userCopy))
+			created := emailVerificationHandler.Create(ctx, userCopy, SetAsOwnerReference(userCopy))
 			if created {
 				// Update the status as successful
 				userCopy.Status.State = success
 				userCopy.Status.Message = []string{statusDict["email-ok"]}
+				userCopy.Status.SetReady(userCopy.GetGeneration(), false, apps_v1alpha.ReasonVerificationEmailSent, statusDict["email-ok"])
 			} else {
 				userCopy.Status.State = failure
 				userCopy.Status.Message = []string{statusDict["email-fail"]}
+				userCopy.Status.SetReady(userCopy.GetGeneration(), false, apps_v1alpha.ReasonVerificationEmailFailed, statusDict["email-fail"])
 			}
 
-			userCopyUpdated, err = t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(context.TODO(), userCopy, metav1.UpdateOptions{})
+			userCopyUpdated, err = t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).UpdateStatus(ctx, userCopy, metav1.UpdateOptions{})
 			if err == nil {
 				userCopy = userCopyUpdated
 			}
@@ -212,42 +245,69 @@ func (t *Handler) ObjectUpdated(obj, updated interface{}) {
 		if userCopy.Spec.Active && userCopy.Status.AUP {
 			// To manipulate role bindings according to the changes
 			if fieldUpdated.active || fieldUpdated.aup || fieldUpdated.role {
-				slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(userCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
-				teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(userCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
+				slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(userCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
+				teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(userCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
 				if fieldUpdated.role {
-					t.deleteRoleBindings(userCopy, slicesRaw, teamsRaw)
+					t.deleteRoleBindings(ctx, userCopy, slicesRaw, teamsRaw)
 				}
-				t.createRoleBindings(userCopy, slicesRaw, teamsRaw, userOwnerAuthority.GetName())
+				t.createRoleBindings(ctx, userCopy, slicesRaw, teamsRaw, userOwnerAuthority.GetName())
 				if fieldUpdated.active {
-					permission.CreateAUPRoleBinding(userCopy, userOwnerReferences)
+					permission.CreateAUPRoleBinding(ctx, userCopy, userOwnerReferences)
 				}
 			}
 		} else if !userCopy.Spec.Active || !userCopy.Status.AUP {
 			// To manipulate role bindings according to the changes
 			if (userCopy.Spec.Active == false && fieldUpdated.active) || (userCopy.Status.AUP == false && fieldUpdated.aup) {
-				slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(userCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
-				teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(userCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
-				t.deleteRoleBindings(userCopy, slicesRaw, teamsRaw)
+				slicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(userCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
+				teamsRaw, _ := t.edgenetClientset.AppsV1alpha().Teams(userCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
+				t.deleteRoleBindings(ctx, userCopy, slicesRaw, teamsRaw)
 			}
 			// To create AUP role binding for the user
 			if userCopy.Spec.Active && fieldUpdated.active {
-				permission.CreateAUPRoleBinding(userCopy, userOwnerReferences)
+				permission.CreateAUPRoleBinding(ctx, userCopy, userOwnerReferences)
 			}
 		}
 	} else if userOwnerAuthority.Spec.Enabled == false && userCopy.Spec.Active == true {
-		defer t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(context.TODO(), userCopy, metav1.UpdateOptions{})
+		defer t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
 		userCopy.Spec.Active = false
+		t.recorder.Eventf(userCopy, corev1.EventTypeWarning, apps_v1alpha.ReasonAuthorityDisabled, statusDict["authority-disabled"], userOwnerNamespace.Labels["authority-name"])
 	}
+	return nil
 }
 
-// ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+// ObjectDeleted is called when an object is deleted, before it disappears thanks to the finalizer
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("UserHandler.ObjectDeleted")
-	// Mail notification, TBD
+	userCopy := obj.(*apps_v1alpha.User).DeepCopy()
+	// The namespace may be on its way out along with the authority
+	authorityName := strings.TrimPrefix(userCopy.GetNamespace(), "authority-")
+	if userOwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, userCopy.GetNamespace(), metav1.GetOptions{}); err == nil {
+		if label, ok := userOwnerNamespace.Labels["authority-name"]; ok {
+			authorityName = label
+		}
+	}
+	// Revoke the certificate, and remove the kubeconfig file of the user
+	if err := registration.RemoveUser(ctx, authorityName, userCopy.GetName(), userCopy.Spec.Email); err != nil {
+		return err
+	}
+	// The roles and role bindings in the namespace go with the owner references, unlike the cluster role binding
+	err := t.clientset.RbacV1().ClusterRoleBindings().Delete(ctx, fmt.Sprintf("%s-%s-for-authority", userCopy.GetNamespace(), userCopy.GetName()), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	t.recorder.Event(userCopy, corev1.EventTypeNormal, apps_v1alpha.ReasonCleanedUp, statusDict["user-cleaned"])
+	if ctlruntime.RemoveFinalizer(userCopy) {
+		_, err := t.edgenetClientset.AppsV1alpha().Users(userCopy.GetNamespace()).Update(ctx, userCopy, metav1.UpdateOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	t.sendEmail(userCopy, authorityName, "user-deletion")
+	return nil
 }
 
 // Create function is for being used by other resources to create an authority
-func (t *Handler) Create(obj interface{}) bool {
+func (t *Handler) Create(ctx context.Context, obj interface{}) bool {
 	failed := true
 	switch obj.(type) {
 	case *apps_v1alpha.UserRegistrationRequest:
@@ -261,10 +321,10 @@ func (t *Handler) Create(obj interface{}) bool {
 		user.Spec.LastName = URRCopy.Spec.LastName
 		user.Spec.URL = URRCopy.Spec.URL
 		user.Spec.Active = true
-		_, err := t.edgenetClientset.AppsV1alpha().Users(URRCopy.GetNamespace()).Create(context.TODO(), user.DeepCopy(), metav1.CreateOptions{})
+		_, err := t.edgenetClientset.AppsV1alpha().Users(URRCopy.GetNamespace()).Create(ctx, user.DeepCopy(), metav1.CreateOptions{})
 		if err == nil {
 			failed = false
-			t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(context.TODO(), URRCopy.GetName(), metav1.DeleteOptions{})
+			t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(ctx, URRCopy.GetName(), metav1.DeleteOptions{})
 		}
 	}
 
@@ -272,9 +332,9 @@ func (t *Handler) Create(obj interface{}) bool {
 }
 
 // createRoleBindings creates user role bindings according to the roles
-func (t *Handler) createRoleBindings(userCopy *apps_v1alpha.User, slicesRaw *apps_v1alpha.SliceList, teamsRaw *apps_v1alpha.TeamList, ownerAuthority string) {
+func (t *Handler) createRoleBindings(ctx context.Context, userCopy *apps_v1alpha.User, slicesRaw *apps_v1alpha.SliceList, teamsRaw *apps_v1alpha.TeamList, ownerAuthority string) {
 	// Create role bindings independent of user roles
-	permission.EstablishPrivateRoleBindings(userCopy)
+	permission.EstablishPrivateRoleBindings(ctx, userCopy)
 	// This part creates the rolebindings one by one in different namespaces
 	createLoop := func(slicesRaw *apps_v1alpha.SliceList, namespacePrefix string) {
 		for _, sliceRow := range slicesRaw.Items {
@@ -282,14 +342,14 @@ func (t *Handler) createRoleBindings(userCopy *apps_v1alpha.User, slicesRaw *app
 				// If the user participates in the slice or it is an admin of the owner authority
 				if (sliceUser.Authority == ownerAuthority && sliceUser.Username == userCopy.GetName()) ||
 					(userCopy.GetNamespace() == sliceRow.GetNamespace() && userCopy.Status.Type == "admin") ||
-					permission.CheckAuthorization(namespacePrefix, userCopy.Spec.Email, "slices", sliceRow.GetName()) {
-					permission.EstablishRoleBindings(userCopy, fmt.Sprintf("%s-slice-%s", namespacePrefix, sliceRow.GetName()), "Slice")
+					permission.CheckAuthorization(ctx, namespacePrefix, userCopy.Spec.Email, "slices", sliceRow.GetName()) {
+					permission.EstablishRoleBindings(ctx, userCopy, fmt.Sprintf("%s-slice-%s", namespacePrefix, sliceRow.GetName()), "Slice")
 				}
 			}
 		}
 	}
 	// Create the rolebindings in the authority namespace
-	permission.EstablishRoleBindings(userCopy, userCopy.GetNamespace(), "Authority")
+	permission.EstablishRoleBindings(ctx, userCopy, userCopy.GetNamespace(), "Authority")
 	createLoop(slicesRaw, userCopy.GetNamespace())
 	// List the teams in the authority namespace
 	for _, teamRow := range teamsRaw.Items {
@@ -297,27 +357,27 @@ func (t *Handler) createRoleBindings(userCopy *apps_v1alpha.User, slicesRaw *app
 			// If the user participates in the team or it is an admin of the owner authority
 			if (teamUser.Authority == ownerAuthority && teamUser.Username == userCopy.GetName()) ||
 				(userCopy.GetNamespace() == teamRow.GetNamespace() && userCopy.Status.Type == "admin") ||
-				permission.CheckAuthorization(userCopy.GetNamespace(), userCopy.Spec.Email, "teams", teamRow.GetName()) {
-				permission.EstablishRoleBindings(userCopy, fmt.Sprintf("%s-team-%s", userCopy.GetNamespace(), teamRow.GetName()), "Team")
+				permission.CheckAuthorization(ctx, userCopy.GetNamespace(), userCopy.Spec.Email, "teams", teamRow.GetName()) {
+				permission.EstablishRoleBindings(ctx, userCopy, fmt.Sprintf("%s-team-%s", userCopy.GetNamespace(), teamRow.GetName()), "Team")
 			}
 		}
 		// List the slices in the team namespace
-		teamSlicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("%s-team-%s", userCopy.GetNamespace(), teamRow.GetName())).List(context.TODO(), metav1.ListOptions{})
+		teamSlicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("%s-team-%s", userCopy.GetNamespace(), teamRow.GetName())).List(ctx, metav1.ListOptions{})
 		createLoop(teamSlicesRaw, fmt.Sprintf("%s-team-%s", userCopy.GetNamespace(), teamRow.GetName()))
 	}
 }
 
 // deleteRoleBindings removes user role bindings in the namespaces related
-func (t *Handler) deleteRoleBindings(userCopy *apps_v1alpha.User, slicesRaw *apps_v1alpha.SliceList, teamsRaw *apps_v1alpha.TeamList) {
+func (t *Handler) deleteRoleBindings(ctx context.Context, userCopy *apps_v1alpha.User, slicesRaw *apps_v1alpha.SliceList, teamsRaw *apps_v1alpha.TeamList) {
 	// To delete the cluster role binding which allows user to get the authority object
-	t.clientset.RbacV1().ClusterRoleBindings().Delete(context.TODO(), fmt.Sprintf("%s-%s-for-authority", userCopy.GetNamespace(), userCopy.GetName()), metav1.DeleteOptions{})
+	t.clientset.RbacV1().ClusterRoleBindings().Delete(ctx, fmt.Sprintf("%s-%s-for-authority", userCopy.GetNamespace(), userCopy.GetName()), metav1.DeleteOptions{})
 	// This part deletes the rolebindings one by one
 	deletionLoop := func(roleBindings *rbacv1.RoleBindingList) {
 		for _, roleBindingRow := range roleBindings.Items {
 			for _, roleBindingSubject := range roleBindingRow.Subjects {
 				if roleBindingSubject.Kind == "User" && (roleBindingSubject.Name == userCopy.Spec.Email) &&
 					roleBindingSubject.Namespace == userCopy.GetNamespace() {
-					t.clientset.RbacV1().RoleBindings(roleBindingRow.GetNamespace()).Delete(context.TODO(), roleBindingRow.GetName(), metav1.DeleteOptions{})
+					t.clientset.RbacV1().RoleBindings(roleBindingRow.GetNamespace()).Delete(ctx, roleBindingRow.GetName(), metav1.DeleteOptions{})
 					break
 				}
 			}
@@ -329,21 +389,21 @@ func (t *Handler) deleteRoleBindings(userCopy *apps_v1alpha.User, slicesRaw *app
 		roleBindingListOptions = metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name!=%s-user-aup-%s", userCopy.GetNamespace(), userCopy.GetName())}
 	}
 	// List the rolebindings in the authority namespace
-	roleBindings, _ := t.clientset.RbacV1().RoleBindings(userCopy.GetNamespace()).List(context.TODO(), roleBindingListOptions)
+	roleBindings, _ := t.clientset.RbacV1().RoleBindings(userCopy.GetNamespace()).List(ctx, roleBindingListOptions)
 	deletionLoop(roleBindings)
 	// List the rolebindings in the slice namespaces which directly created by slices in the authority namespace
 	for _, sliceRow := range slicesRaw.Items {
-		roleBindings, _ := t.clientset.RbacV1().RoleBindings(fmt.Sprintf("%s-slice-%s", userCopy.GetNamespace(), sliceRow.GetName())).List(context.TODO(), metav1.ListOptions{})
+		roleBindings, _ := t.clientset.RbacV1().RoleBindings(fmt.Sprintf("%s-slice-%s", userCopy.GetNamespace(), sliceRow.GetName())).List(ctx, metav1.ListOptions{})
 		deletionLoop(roleBindings)
 	}
 	for _, teamRow := range teamsRaw.Items {
 		// List the rolebindings in the team namespace
-		roleBindings, _ := t.clientset.RbacV1().RoleBindings(teamRow.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
+		roleBindings, _ := t.clientset.RbacV1().RoleBindings(teamRow.GetNamespace()).List(ctx, metav1.ListOptions{})
 		deletionLoop(roleBindings)
 		// List the rolebindings in the slice namespaces which created by slices in the team namespace
-		teamSlicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("%s-team-%s", userCopy.GetNamespace(), teamRow.GetName())).List(context.TODO(), metav1.ListOptions{})
+		teamSlicesRaw, _ := t.edgenetClientset.AppsV1alpha().Slices(fmt.Sprintf("%s-team-%s", userCopy.GetNamespace(), teamRow.GetName())).List(ctx, metav1.ListOptions{})
 		for _, teamSliceRow := range teamSlicesRaw.Items {
-			roleBindings, _ := t.clientset.RbacV1().RoleBindings(fmt.Sprintf("%s-team-%s-slice-%s", userCopy.GetNamespace(), teamRow.GetName(), teamSliceRow.GetName())).List(context.TODO(), metav1.ListOptions{})
+			roleBindings, _ := t.clientset.RbacV1().RoleBindings(fmt.Sprintf("%s-team-%s-slice-%s", userCopy.GetNamespace(), teamRow.GetName(), teamSliceRow.GetName())).List(ctx, metav1.ListOptions{})
 			deletionLoop(roleBindings)
 		}
 	}
@@ -361,11 +421,11 @@ func (t *Handler) sendEmail(userCopy *apps_v1alpha.User, authorityName, subject
 }
 
 // checkDuplicateObject checks whether a user exists with the same username or email address
-func (t *Handler) checkDuplicateObject(userCopy *apps_v1alpha.User, authorityName string) (bool, string) {
+func (t *Handler) checkDuplicateObject(ctx context.Context, userCopy *apps_v1alpha.User, authorityName string) (bool, string) {
 	exists := false
 	var message string
 	// To check email address
-	userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(context.TODO(), metav1.ListOptions{})
+	userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(ctx, metav1.ListOptions{})
 	for _, userRow := range userRaw.Items {
 		if userRow.Spec.Email == userCopy.Spec.Email && userRow.GetUID() != userCopy.GetUID() {
 			exists = true
@@ -375,17 +435,17 @@ func (t *Handler) checkDuplicateObject(userCopy *apps_v1alpha.User, authorityNam
 	}
 	if !exists {
 		// Delete the user registration requests which have duplicate values, if any
-		URRRaw, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests("").List(context.TODO(), metav1.ListOptions{})
+		URRRaw, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests("").List(ctx, metav1.ListOptions{})
 		for _, URRRow := range URRRaw.Items {
 			if URRRow.Spec.Email == userCopy.Spec.Email {
-				t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRRow.GetNamespace()).Delete(context.TODO(), URRRow.GetName(), metav1.DeleteOptions{})
+				t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRRow.GetNamespace()).Delete(ctx, URRRow.GetName(), metav1.DeleteOptions{})
 			}
 		}
 		// Delete the user registration requests which have duplicate values in the same namespace, if any
-		URRRaw, _ = t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(userCopy.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
+		URRRaw, _ = t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(userCopy.GetNamespace()).List(ctx, metav1.ListOptions{})
 		for _, URRRow := range URRRaw.Items {
 			if URRRow.GetName() == userCopy.GetName() || URRRow.Spec.Email == userCopy.Spec.Email {
-				t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRRow.GetNamespace()).Delete(context.TODO(), URRRow.GetName(), metav1.DeleteOptions{})
+				t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRRow.GetNamespace()).Delete(ctx, URRRow.GetName(), metav1.DeleteOptions{})
 			}
 		}
 	} else if exists && !reflect.DeepEqual(userCopy.Status.Message, message) {
@@ -11,8 +11,10 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	ctlruntime "github.com/EdgeNet-project/edgenet/pkg/controller/runtime"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
+	"github.com/EdgeNet-project/edgenet/pkg/permission"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 	"github.com/sirupsen/logrus"
 
@@ -194,7 +196,7 @@ func (g *TestGroup) Init() {
 	namespaceLabels := map[string]string{"owner": "authority", "owner-name": g.authorityObj.GetName(), "authority-name": g.authorityObj.GetName()}
 	namespace.SetLabels(namespaceLabels)
 	g.client.CoreV1().Namespaces().Create(context.TODO(), &namespace, metav1.CreateOptions{})
-	// authorityHandler.ObjectCreated(g.authorityObj.DeepCopy())
+	// authorityHandler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 }
 
 // TestHandlerInit for handler initialization
@@ -238,7 +240,7 @@ func TestCollision(t *testing.T) {
 		t.Run(k, func(t *testing.T) {
 			_, err := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(tc.request.GetNamespace()).Create(context.TODO(), tc.request.DeepCopy(), metav1.CreateOptions{})
 			util.OK(t, err)
-			g.handler.checkDuplicateObject(g.userObj.DeepCopy(), g.authorityObj.GetName())
+			g.handler.checkDuplicateObject(context.TODO(), g.userObj.DeepCopy(), g.authorityObj.GetName())
 			_, err = g.edgenetClient.AppsV1alpha().UserRegistrationRequests(tc.request.GetNamespace()).Get(context.TODO(), tc.request.GetName(), metav1.GetOptions{})
 			util.Equals(t, tc.expected, errors.IsNotFound(err))
 			g.edgenetClient.AppsV1alpha().UserRegistrationRequests(tc.request.GetNamespace()).Delete(context.TODO(), tc.request.GetName(), metav1.DeleteOptions{})
@@ -250,7 +252,7 @@ func TestCollision(t *testing.T) {
 	user.SetUID("UID")
 	t.Run("user/email/different-namespace", func(t *testing.T) {
 		g.edgenetClient.AppsV1alpha().Users(user.GetNamespace()).Create(context.TODO(), user.DeepCopy(), metav1.CreateOptions{})
-		emailExists, _ := g.handler.checkDuplicateObject(g.userObj.DeepCopy(), g.authorityObj.GetName())
+		emailExists, _ := g.handler.checkDuplicateObject(context.TODO(), g.userObj.DeepCopy(), g.authorityObj.GetName())
 		util.Equals(t, true, emailExists)
 	})
 }
@@ -259,7 +261,7 @@ func TestCreateUser(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
 	g.handler.Init(g.client, g.edgenetClient)
-	failed := g.handler.Create(g.urrObj.DeepCopy())
+	failed := g.handler.Create(context.TODO(), g.urrObj.DeepCopy())
 	util.Equals(t, false, failed)
 }
 
@@ -268,7 +270,7 @@ func TestCreateRoleBindings(t *testing.T) {
 	g.Init()
 	g.handler.Init(g.client, g.edgenetClient)
 	// Invoking createRoleBindings
-	g.handler.createRoleBindings(g.userObj.DeepCopy(), g.sliceList.DeepCopy(), g.teamList.DeepCopy(), fmt.Sprintf("authority-%s", g.authorityObj.GetName()))
+	g.handler.createRoleBindings(context.TODO(), g.userObj.DeepCopy(), g.sliceList.DeepCopy(), g.teamList.DeepCopy(), fmt.Sprintf("authority-%s", g.authorityObj.GetName()))
 	// Check the creation of use role Binding
 	_, err := g.handler.clientset.RbacV1().RoleBindings(g.userObj.GetNamespace()).Get(context.TODO(), fmt.Sprintf("%s-user-%s", g.userObj.GetNamespace(), g.userObj.GetName()), metav1.GetOptions{})
 	util.OK(t, err)
@@ -279,16 +281,36 @@ func TestDeleteRoleBindings(t *testing.T) {
 	g.Init()
 	g.handler.Init(g.client, g.edgenetClient)
 	// Invoking createRoleBindings
-	g.handler.createRoleBindings(g.userObj.DeepCopy(), g.sliceList.DeepCopy(), g.teamList.DeepCopy(), fmt.Sprintf("authority-%s", g.authorityObj.GetName()))
+	g.handler.createRoleBindings(context.TODO(), g.userObj.DeepCopy(), g.sliceList.DeepCopy(), g.teamList.DeepCopy(), fmt.Sprintf("authority-%s", g.authorityObj.GetName()))
 	// Check the creation of use role Binding
 	_, err := g.handler.clientset.RbacV1().RoleBindings(g.userObj.GetNamespace()).Get(context.TODO(), fmt.Sprintf("%s-user-%s", g.userObj.GetNamespace(), g.userObj.GetName()), metav1.GetOptions{})
 	util.OK(t, err)
 
-	g.handler.deleteRoleBindings(g.userObj.DeepCopy(), g.sliceList.DeepCopy(), g.teamList.DeepCopy())
+	g.handler.deleteRoleBindings(context.TODO(), g.userObj.DeepCopy(), g.sliceList.DeepCopy(), g.teamList.DeepCopy())
 	_, err = g.handler.clientset.RbacV1().RoleBindings(g.userObj.GetNamespace()).Get(context.TODO(), fmt.Sprintf("%s-user-aup-%s", g.userObj.GetNamespace(), g.userObj.GetName()), metav1.GetOptions{})
 	util.Equals(t, true, errors.IsNotFound(err))
 }
 
+func TestDeleteUser(t *testing.T) {
+	g := TestGroup{}
+	g.Init()
+	g.handler.Init(g.client, g.edgenetClient)
+	userCopy := g.userObj.DeepCopy()
+	ctlruntime.AddFinalizer(userCopy)
+	g.edgenetClient.AppsV1alpha().Users(userCopy.GetNamespace()).Create(context.TODO(), userCopy, metav1.CreateOptions{})
+	util.OK(t, permission.EstablishPrivateRoleBindings(context.TODO(), userCopy))
+	clusterRoleBindingName := fmt.Sprintf("%s-%s-for-authority", userCopy.GetNamespace(), userCopy.GetName())
+	_, err := g.client.RbacV1().ClusterRoleBindings().Get(context.TODO(), clusterRoleBindingName, metav1.GetOptions{})
+	util.OK(t, err)
+
+	err = g.handler.ObjectDeleted(context.TODO(), userCopy.DeepCopy())
+	util.OK(t, err)
+	_, err = g.client.RbacV1().ClusterRoleBindings().Get(context.TODO(), clusterRoleBindingName, metav1.GetOptions{})
+	util.Equals(t, true, errors.IsNotFound(err))
+	user, _ := g.edgenetClient.AppsV1alpha().Users(userCopy.GetNamespace()).Get(context.TODO(), userCopy.GetName(), metav1.GetOptions{})
+	util.Equals(t, false, ctlruntime.HasFinalizer(user))
+}
+
 func (g *TestGroup) mockSigner(authority, user string) {
 	// Mock the signer
 	go func() {
@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/emailverification"
 	"github.com/EdgeNet-project/edgenet/pkg/controller/v1alpha/user"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
@@ -37,9 +39,10 @@ import (
 // HandlerInterface interface contains the methods that are required
 type HandlerInterface interface {
 	Init(kubernetes kubernetes.Interface, edgenet versioned.Interface)
-	ObjectCreated(obj interface{})
-	ObjectUpdated(obj interface{})
-	ObjectDeleted(obj interface{})
+	ObjectCreated(ctx context.Context, obj interface{}) error
+	ObjectUpdated(ctx context.Context, obj interface{}) error
+	ObjectDeleted(ctx context.Context, obj interface{}) error
+	ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error
 }
 
 // Handler implementation
@@ -56,40 +59,43 @@ func (t *Handler) Init(kubernetes kubernetes.Interface, edgenet versioned.Interf
 }
 
 // ObjectCreated is called when an object is created
-func (t *Handler) ObjectCreated(obj interface{}) {
+func (t *Handler) ObjectCreated(ctx context.Context, obj interface{}) error {
 	log.Info("URRHandler.ObjectCreated")
 	// Create a copy of the user registration request object to make changes on it
 	URRCopy := obj.(*apps_v1alpha.UserRegistrationRequest).DeepCopy()
 	// Find the authority from the namespace in which the object is
-	URROwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), URRCopy.GetNamespace(), metav1.GetOptions{})
+	URROwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, URRCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
 	// Check if the email address is already taken
-	exists, message := t.checkDuplicateObject(URRCopy, URROwnerNamespace.Labels["authority-name"])
+	exists, reason, message := t.checkDuplicateObject(ctx, URRCopy, URROwnerNamespace.Labels["authority-name"])
 	if exists {
 		URRCopy.Status.State = failure
 		URRCopy.Status.Message = message
-		// Run timeout goroutine
-		go t.runApprovalTimeout(URRCopy)
+		URRCopy.Status.SetReady(URRCopy.GetGeneration(), false, reason, strings.Join(message, "; "))
 		// Set the approval timeout which is 24 hours
 		URRCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(24 * time.Hour),
 		}
-		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(context.TODO(), URRCopy, metav1.UpdateOptions{})
-		return
+		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(ctx, URRCopy, metav1.UpdateOptions{})
+		return nil
 	}
-	URROwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), URROwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	URROwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, URROwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	// Check if the authority is active
 	if URROwnerAuthority.Spec.Enabled {
 		if URRCopy.Spec.Approved {
 			userHandler := user.Handler{}
 			userHandler.Init(t.clientset, t.edgenetClientset)
-			created := !userHandler.Create(URRCopy)
+			created := !userHandler.Create(ctx, URRCopy)
 			if created {
-				return
+				return nil
 			}
 			t.sendEmail(URRCopy, URROwnerNamespace.Labels["authority-name"], "user-creation-failure")
 			URRCopy.Status.State = failure
 			URRCopy.Status.Message = []string{statusDict["user-failed"]}
-			URRCopyUpdated, err := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(context.TODO(), URRCopy, metav1.UpdateOptions{})
+			URRCopy.Status.SetReady(URRCopy.GetGeneration(), false, apps_v1alpha.ReasonUserCreationFailed, statusDict["user-failed"])
+			URRCopyUpdated, err := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(ctx, URRCopy, metav1.UpdateOptions{})
 			if err == nil {
 				URRCopy = URRCopyUpdated
 			}
@@ -97,9 +103,7 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 		// If the service restarts, it creates all objects again
 		// Because of that, this section covers a variety of possibilities
 		if URRCopy.Status.Expires == nil {
-			// Run timeout goroutine
-			go t.runApprovalTimeout(URRCopy)
-			defer t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(context.TODO(), URRCopy, metav1.UpdateOptions{})
+			defer t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(ctx, URRCopy, metav1.UpdateOptions{})
 
 			// Set the approval timeout which is 72 hours
 			URRCopy.Status.Expires = &metav1.Time{
@@ -107,76 +111,86 @@ func (t *Handler) ObjectCreated(obj interface{}) {
 			}
 			emailVerificationHandler := emailverification.Handler{}
 			emailVerificationHandler.Init(t.clientset, t.edgenetClientset)
-			created := emailVerificationHandler.Create(URRCopy, SetAsOwnerReference(URRCopy))
+			created := emailVerificationHandler.Create(ctx, URRCopy, SetAsOwnerReference(URRCopy))
 			if created {
 				// Update the status as successful
 				URRCopy.Status.State = success
 				URRCopy.Status.Message = []string{statusDict["email-ok"]}
+				URRCopy.Status.SetReady(URRCopy.GetGeneration(), true, apps_v1alpha.ReasonVerificationEmailSent, statusDict["email-ok"])
 			} else {
 				URRCopy.Status.State = issue
 				URRCopy.Status.Message = []string{statusDict["email-fail"]}
+				URRCopy.Status.SetReady(URRCopy.GetGeneration(), false, apps_v1alpha.ReasonVerificationEmailFailed, statusDict["email-fail"])
 			}
-		} else {
-			go t.runApprovalTimeout(URRCopy)
 		}
 	} else {
-		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(context.TODO(), URRCopy.GetName(), metav1.DeleteOptions{})
+		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(ctx, URRCopy.GetName(), metav1.DeleteOptions{})
 	}
+	return nil
 }
 
 // ObjectUpdated is called when an object is updated
-func (t *Handler) ObjectUpdated(obj interface{}) {
+func (t *Handler) ObjectUpdated(ctx context.Context, obj interface{}) error {
 	log.Info("URRHandler.ObjectUpdated")
 	// Create a copy of the user registration request object to make changes on it
 	URRCopy := obj.(*apps_v1alpha.UserRegistrationRequest).DeepCopy()
 	changeStatus := false
-	URROwnerNamespace, _ := t.clientset.CoreV1().Namespaces().Get(context.TODO(), URRCopy.GetNamespace(), metav1.GetOptions{})
-	URROwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(context.TODO(), URROwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
+	URROwnerNamespace, err := t.clientset.CoreV1().Namespaces().Get(ctx, URRCopy.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	URROwnerAuthority, _ := t.edgenetClientset.AppsV1alpha().Authorities().Get(ctx, URROwnerNamespace.Labels["authority-name"], metav1.GetOptions{})
 	if URROwnerAuthority.Spec.Enabled {
 		// Check again if the email address is already taken
-		exists, message := t.checkDuplicateObject(URRCopy, URROwnerNamespace.Labels["authority-name"])
+		exists, reason, message := t.checkDuplicateObject(ctx, URRCopy, URROwnerNamespace.Labels["authority-name"])
 		if !exists {
 			// Check whether the request for user registration approved
 			if URRCopy.Spec.Approved {
 				userHandler := user.Handler{}
 				userHandler.Init(t.clientset, t.edgenetClientset)
-				changeStatus := userHandler.Create(URRCopy)
+				changeStatus := userHandler.Create(ctx, URRCopy)
 				if changeStatus {
 					t.sendEmail(URRCopy, URROwnerNamespace.Labels["authority-name"], "user-creation-failure")
 					URRCopy.Status.State = failure
 					URRCopy.Status.Message = []string{statusDict["user-failed"]}
+					URRCopy.Status.SetReady(URRCopy.GetGeneration(), false, apps_v1alpha.ReasonUserCreationFailed, statusDict["user-failed"])
 				}
 			} else if !URRCopy.Spec.Approved && URRCopy.Status.State == failure {
 				emailVerificationHandler := emailverification.Handler{}
 				emailVerificationHandler.Init(t.clientset, t.edgenetClientset)
-				created := emailVerificationHandler.Create(URRCopy, SetAsOwnerReference(URRCopy))
+				created := emailVerificationHandler.Create(ctx, URRCopy, SetAsOwnerReference(URRCopy))
 				if created {
 					// Update the status as successful
 					URRCopy.Status.State = success
 					URRCopy.Status.Message = []string{statusDict["email-ok"]}
+					URRCopy.Status.SetReady(URRCopy.GetGeneration(), true, apps_v1alpha.ReasonVerificationEmailSent, statusDict["email-ok"])
 				} else {
 					URRCopy.Status.State = issue
 					URRCopy.Status.Message = []string{statusDict["email-fail"]}
+					URRCopy.Status.SetReady(URRCopy.GetGeneration(), false, apps_v1alpha.ReasonVerificationEmailFailed, statusDict["email-fail"])
 				}
 				changeStatus = true
 			}
 		} else if exists && !reflect.DeepEqual(URRCopy.Status.Message, message) {
 			URRCopy.Status.State = failure
 			URRCopy.Status.Message = message
+			URRCopy.Status.SetReady(URRCopy.GetGeneration(), false, reason, strings.Join(message, "; "))
 			changeStatus = true
 		}
 		if changeStatus {
-			t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(context.TODO(), URRCopy, metav1.UpdateOptions{})
+			t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).UpdateStatus(ctx, URRCopy, metav1.UpdateOptions{})
 		}
 	} else {
-		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(context.TODO(), URRCopy.GetName(), metav1.DeleteOptions{})
+		t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(ctx, URRCopy.GetName(), metav1.DeleteOptions{})
 	}
+	return nil
 }
 
 // ObjectDeleted is called when an object is deleted
-func (t *Handler) ObjectDeleted(obj interface{}) {
+func (t *Handler) ObjectDeleted(ctx context.Context, obj interface{}) error {
 	log.Info("URRHandler.ObjectDeleted")
 	// Mail notification, TBD
+	return nil
 }
 
 // sendEmail to send notification to participants
@@ -190,100 +204,34 @@ func (t *Handler) sendEmail(URRCopy *apps_v1alpha.UserRegistrationRequest, autho
 	mailer.Send(subject, contentData)
 }
 
-// runApprovalTimeout puts a procedure in place to remove requests by approval or timeout
-func (t *Handler) runApprovalTimeout(URRCopy *apps_v1alpha.UserRegistrationRequest) {
-	registrationApproved := make(chan bool, 1)
-	timeoutRenewed := make(chan bool, 1)
-	terminated := make(chan bool, 1)
-	var timeout <-chan time.Time
-	if URRCopy.Status.Expires != nil {
-		timeout = time.After(time.Until(URRCopy.Status.Expires.Time))
-	}
-	closeChannels := func() {
-		close(registrationApproved)
-		close(timeoutRenewed)
-		close(terminated)
-	}
-
-	// Watch the events of user registration request object
-	watchURR, err := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Watch(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("metadata.name==%s", URRCopy.GetName())})
-	if err == nil {
-		go func() {
-			// Get events from watch interface
-			for URREvent := range watchURR.ResultChan() {
-				// Get updated user registration request object
-				updatedURR, status := URREvent.Object.(*apps_v1alpha.UserRegistrationRequest)
-				// FieldSelector doesn't work properly, and will be checked in for next releases.
-				if URRCopy.GetUID() == updatedURR.GetUID() {
-					if status {
-						if URREvent.Type == "DELETED" {
-							terminated <- true
-							continue
-						}
-
-						if updatedURR.Spec.Approved == true {
-							registrationApproved <- true
-							break
-						} else if !updatedURR.Spec.Approved && updatedURR.Status.Expires != nil {
-							// Check whether expiration date updated - TBD
-							if updatedURR.Status.Expires.Time.Sub(time.Now()) >= 0 {
-								timeout = time.After(time.Until(updatedURR.Status.Expires.Time))
-								timeoutRenewed <- true
-							} else {
-								terminated <- true
-							}
-						}
-						URRCopy = updatedURR
-					}
-				}
-			}
-		}()
-	} else {
-		// In case of any malfunction of watching userregistrationrequest resources,
-		// there is a timeout at 72 hours
-		timeout = time.After(72 * time.Hour)
-	}
-
-	// Infinite loop
-timeoutLoop:
-	for {
-		// Wait on multiple channel operations
-	timeoutOptions:
-		select {
-		case <-registrationApproved:
-			watchURR.Stop()
-			closeChannels()
-			break timeoutLoop
-		case <-timeoutRenewed:
-			break timeoutOptions
-		case <-timeout:
-			watchURR.Stop()
-			t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(context.TODO(), URRCopy.GetName(), metav1.DeleteOptions{})
-			closeChannels()
-			break timeoutLoop
-		case <-terminated:
-			watchURR.Stop()
-			closeChannels()
-			break timeoutLoop
-		}
+// ObjectExpired is called by the scheduler when the user registration request is not approved in time
+func (t *Handler) ObjectExpired(ctx context.Context, obj interface{}, action expiry.Action) error {
+	log.Info("URRHandler.ObjectExpired")
+	URRCopy := obj.(*apps_v1alpha.UserRegistrationRequest).DeepCopy()
+	err := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests(URRCopy.GetNamespace()).Delete(ctx, URRCopy.GetName(), metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
 	}
+	return err
 }
 
-// checkDuplicateObject checks whether a user exists with the same username or email address
-func (t *Handler) checkDuplicateObject(URRCopy *apps_v1alpha.UserRegistrationRequest, authorityName string) (bool, []string) {
+// checkDuplicateObject checks whether a user exists with the same username or email address, and returns the reason along with the messages
+func (t *Handler) checkDuplicateObject(ctx context.Context, URRCopy *apps_v1alpha.UserRegistrationRequest, authorityName string) (bool, string, []string) {
 	exists := false
+	var reason string
 	message := []string{}
 	// To check username on the users resource
-	_, err := t.edgenetClientset.AppsV1alpha().Users(URRCopy.GetNamespace()).Get(context.TODO(), URRCopy.GetName(), metav1.GetOptions{})
+	_, err := t.edgenetClientset.AppsV1alpha().Users(URRCopy.GetNamespace()).Get(ctx, URRCopy.GetName(), metav1.GetOptions{})
 	if !errors.IsNotFound(err) {
 		exists = true
+		reason = apps_v1alpha.ReasonUsernameTaken
 		message = append(message, fmt.Sprintf(statusDict["username-exist"], URRCopy.GetName()))
 		if exists && !reflect.DeepEqual(URRCopy.Status.Message, message) {
 			t.sendEmail(URRCopy, authorityName, "user-validation-failure-name")
 		}
 	} else {
 		// To check email address
-		userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(context.TODO(), metav1.ListOptions{})
+		userRaw, _ := t.edgenetClientset.AppsV1alpha().Users("").List(ctx, metav1.ListOptions{})
 		for _, userRow := range userRaw.Items {
 			if userRow.Spec.Email == URRCopy.Spec.Email {
 				exists = true
@@ -293,7 +241,7 @@ func (t *Handler) checkDuplicateObject(URRCopy *apps_v1alpha.UserRegistrationReq
 		}
 		if !exists {
 			// To check email address
-			URRRaw, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests("").List(context.TODO(), metav1.ListOptions{})
+			URRRaw, _ := t.edgenetClientset.AppsV1alpha().UserRegistrationRequests("").List(ctx, metav1.ListOptions{})
 			for _, URRRow := range URRRaw.Items {
 				if URRRow.Spec.Email == URRCopy.Spec.Email && URRRow.GetUID() != URRCopy.GetUID() {
 					exists = true
@@ -302,7 +250,7 @@ func (t *Handler) checkDuplicateObject(URRCopy *apps_v1alpha.UserRegistrationReq
 			}
 			if !exists {
 				// To check email address given at authorityRequest
-				authorityRequestRaw, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().List(context.TODO(), metav1.ListOptions{})
+				authorityRequestRaw, _ := t.edgenetClientset.AppsV1alpha().AuthorityRequests().List(ctx, metav1.ListOptions{})
 				for _, authorityRequestRow := range authorityRequestRaw.Items {
 					if authorityRequestRow.Spec.Contact.Email == URRCopy.Spec.Email {
 						exists = true
@@ -311,11 +259,14 @@ func (t *Handler) checkDuplicateObject(URRCopy *apps_v1alpha.UserRegistrationReq
 				}
 			}
 		}
+		if exists {
+			reason = apps_v1alpha.ReasonEmailInUse
+		}
 		if exists && !reflect.DeepEqual(URRCopy.Status.Message, message) {
 			t.sendEmail(URRCopy, authorityName, "user-validation-failure-email")
 		}
 	}
-	return exists, message
+	return exists, reason, message
 }
 
 // SetAsOwnerReference put the userregistrationrequest as owner
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/controller/expiry"
 	"github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	edgenettestclient "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/fake"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
@@ -151,7 +152,7 @@ func (g *TestGroup) Init() {
 	namespace.SetLabels(namespaceLabels)
 	g.client.CoreV1().Namespaces().Create(context.TODO(), &namespace, metav1.CreateOptions{})
 	// Invoke ObjectCreated to create namespace
-	// authorityHandler.ObjectCreated(g.authorityObj.DeepCopy())
+	// authorityHandler.ObjectCreated(context.TODO(), g.authorityObj.DeepCopy())
 }
 
 func TestHandlerInit(t *testing.T) {
@@ -170,7 +171,7 @@ func TestCreate(t *testing.T) {
 	g.handler.Init(g.client, g.edgenetClient)
 	t.Run("set expiry date", func(t *testing.T) {
 		g.edgenetClient.AppsV1alpha().UserRegistrationRequests(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Create(context.TODO(), g.userRegistrationObj.DeepCopy(), metav1.CreateOptions{})
-		g.handler.ObjectCreated(g.userRegistrationObj.DeepCopy())
+		g.handler.ObjectCreated(context.TODO(), g.userRegistrationObj.DeepCopy())
 		URRCopy, _ := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Get(context.TODO(), g.userRegistrationObj.GetName(), metav1.GetOptions{})
 		expected := metav1.Time{
 			Time: time.Now().Add(72 * time.Hour),
@@ -181,12 +182,12 @@ func TestCreate(t *testing.T) {
 	})
 	t.Run("timeout", func(t *testing.T) {
 		URRCopy, _ := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Get(context.TODO(), g.userRegistrationObj.GetName(), metav1.GetOptions{})
-		go g.handler.runApprovalTimeout(URRCopy)
 		URRCopy.Status.Expires = &metav1.Time{
 			Time: time.Now().Add(10 * time.Millisecond),
 		}
 		g.edgenetClient.AppsV1alpha().UserRegistrationRequests(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Update(context.TODO(), URRCopy, metav1.UpdateOptions{})
-		time.Sleep(100 * time.Millisecond)
+		util.Equals(t, URRCopy.Status.Expires.Time, getDeadlines(URRCopy)[expiry.Expire])
+		util.OK(t, g.handler.ObjectExpired(context.TODO(), URRCopy, expiry.Expire))
 		_, err := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Get(context.TODO(), g.userRegistrationObj.GetName(), metav1.GetOptions{})
 		util.Equals(t, true, errors.IsNotFound(err))
 	})
@@ -223,7 +224,7 @@ func TestCreate(t *testing.T) {
 			t.Run(k, func(t *testing.T) {
 				_, err := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(tc.request.GetNamespace()).Create(context.TODO(), tc.request.DeepCopy(), metav1.CreateOptions{})
 				util.OK(t, err)
-				g.handler.ObjectCreated(tc.request.DeepCopy())
+				g.handler.ObjectCreated(context.TODO(), tc.request.DeepCopy())
 				URR, err := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(tc.request.GetNamespace()).Get(context.TODO(), tc.request.GetName(), metav1.GetOptions{})
 				util.OK(t, err)
 				util.Equals(t, tc.expected, URR.Status.Message[0])
@@ -277,7 +278,7 @@ func TestUpdate(t *testing.T) {
 				tc.request.Status = status
 				_, err := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(tc.request.GetNamespace()).Update(context.TODO(), tc.request.DeepCopy(), metav1.UpdateOptions{})
 				util.OK(t, err)
-				g.handler.ObjectUpdated(tc.request.DeepCopy())
+				g.handler.ObjectUpdated(context.TODO(), tc.request.DeepCopy())
 				URR, err := g.edgenetClient.AppsV1alpha().UserRegistrationRequests(tc.request.GetNamespace()).Get(context.TODO(), tc.request.GetName(), metav1.GetOptions{})
 				util.OK(t, err)
 				util.EqualsMultipleExp(t, tc.expected, URR.Status.State)
@@ -290,7 +291,7 @@ func TestUpdate(t *testing.T) {
 		// Updating user registration status to approved
 		g.userRegistrationObj.Spec.Approved = true
 		// Requesting server to update internal representation of user registration object and transition it to user
-		g.handler.ObjectUpdated(g.userRegistrationObj.DeepCopy())
+		g.handler.ObjectUpdated(context.TODO(), g.userRegistrationObj.DeepCopy())
 		// Checking if handler created user from user registration
 		_, err := g.edgenetClient.AppsV1alpha().Users(fmt.Sprintf("authority-%s", g.authorityObj.GetName())).Get(context.TODO(), g.userRegistrationObj.GetName(), metav1.GetOptions{})
 		util.OK(t, err)
@@ -22,6 +22,8 @@ import (
 	clientset "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned"
 	appsv1alpha "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/typed/apps/v1alpha"
 	fakeappsv1alpha "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/typed/apps/v1alpha/fake"
+	appsv1beta1 "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/typed/apps/v1beta1"
+	fakeappsv1beta1 "github.com/EdgeNet-project/edgenet/pkg/generated/clientset/versioned/typed/apps/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -80,3 +82,8 @@ var _ clientset.Interface = &Clientset{}
 func (c *Clientset) AppsV1alpha() appsv1alpha.AppsV1alphaInterface {
 	return &fakeappsv1alpha.FakeAppsV1alpha{Fake: &c.Fake}
 }
+
+// AppsV1beta1 retrieves the AppsV1beta1Client
+func (c *Clientset) AppsV1beta1() appsv1beta1.AppsV1beta1Interface {
+	return &fakeappsv1beta1.FakeAppsV1beta1{Fake: &c.Fake}
+}
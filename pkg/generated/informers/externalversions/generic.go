@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	v1beta1 "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -76,6 +77,30 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1alpha.SchemeGroupVersion.WithResource("userregistrationrequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha().UserRegistrationRequests().Informer()}, nil
 
+	// Group=apps.edgenet.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("acceptableusepolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().AcceptableUsePolicies().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("authorities"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().Authorities().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("authorityrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().AuthorityRequests().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("emailverifications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().EmailVerifications().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("nodecontributions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().NodeContributions().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("selectivedeployments"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().SelectiveDeployments().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("slices"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().Slices().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("teams"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().Teams().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("totalresourcequotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().TotalResourceQuotas().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("users"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().Users().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("userregistrationrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1beta1().UserRegistrationRequests().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
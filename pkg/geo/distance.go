@@ -0,0 +1,95 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// EarthRadius is the mean radius of the Earth in meters
+const EarthRadius = 6371008.8
+
+// Point is a location given in degrees
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Distance returns the great-circle distance in meters between two points by using the haversine formula
+func Distance(a, b Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	deltaLat := lat2 - lat1
+	deltaLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Pow(math.Sin(deltaLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLon/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// ParseRadius reads the value of a radius selector, which is "latitude,longitude,distance".
+// The distance is in kilometers unless it ends with "m", e.g. "48.8566,2.3522,300km" or "48.8566,2.3522,500m".
+// It returns the point and the distance in meters.
+func ParseRadius(value string) (Point, float64, error) {
+	point, rest, err := parsePoint(value)
+	if err != nil {
+		return point, 0, err
+	}
+	multiplier := 1000.0
+	switch {
+	case strings.HasSuffix(rest, "km"):
+		rest = strings.TrimSuffix(rest, "km")
+	case strings.HasSuffix(rest, "m"):
+		rest = strings.TrimSuffix(rest, "m")
+		multiplier = 1
+	}
+	distance, err := strconv.ParseFloat(strings.TrimSpace(rest), 64)
+	if err != nil || distance <= 0 || math.IsInf(distance, 0) {
+		return point, 0, fmt.Errorf("the distance must be a positive number of kilometers or meters, e.g. 300km or 500m")
+	}
+	return point, distance * multiplier, nil
+}
+
+// ParseNearest reads the value of a nearest selector, which is "latitude,longitude,count", e.g. "48.8566,2.3522,5"
+func ParseNearest(value string) (Point, int, error) {
+	point, rest, err := parsePoint(value)
+	if err != nil {
+		return point, 0, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(rest))
+	if err != nil || count <= 0 {
+		return point, 0, fmt.Errorf("the count must be a positive integer")
+	}
+	return point, count, nil
+}
+
+// parsePoint reads the latitude and the longitude at the beginning of a selector value and returns the rest of it
+func parsePoint(value string) (Point, string, error) {
+	var point Point
+	fields := strings.Split(value, ",")
+	if len(fields) != 3 {
+		return point, "", fmt.Errorf("must be given as latitude,longitude,<distance or count>")
+	}
+	var err error
+	if point.Lat, err = strconv.ParseFloat(strings.TrimSpace(fields[0]), 64); err != nil || point.Lat < -90 || point.Lat > 90 {
+		return point, "", fmt.Errorf("the latitude must be a number within [-90, 90]")
+	}
+	if point.Lon, err = strconv.ParseFloat(strings.TrimSpace(fields[1]), 64); err != nil || point.Lon < -180 || point.Lon > 180 {
+		return point, "", fmt.Errorf("the longitude must be a number within [-180, 180]")
+	}
+	return point, strings.TrimSpace(fields[2]), nil
+}
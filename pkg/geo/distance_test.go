@@ -0,0 +1,68 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+)
+
+func TestDistance(t *testing.T) {
+	paris := Point{Lat: 48.8566, Lon: 2.3522}
+	cases := map[string]struct {
+		a        Point
+		b        Point
+		expected float64
+	}{
+		"same":         {paris, paris, 0},
+		"london":       {paris, Point{Lat: 51.5074, Lon: -0.1278}, 343.6},
+		"new york":     {paris, Point{Lat: 40.7128, Lon: -74.0060}, 5837.2},
+		"antimeridian": {Point{Lat: 0, Lon: 179.5}, Point{Lat: 0, Lon: -179.5}, 111.2},
+		"antipodes":    {Point{Lat: 0, Lon: 0}, Point{Lat: 0, Lon: 180}, 20015.1},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			// The distances are compared in kilometers rounded to one decimal
+			util.Equals(t, tc.expected, math.Round(Distance(tc.a, tc.b)/100)/10)
+		})
+	}
+}
+
+func TestParseRadius(t *testing.T) {
+	cases := map[string]struct {
+		input    string
+		point    Point
+		distance float64
+		err      string
+	}{
+		"km":              {"48.8566,2.3522,300km", Point{48.8566, 2.3522}, 300000, ""},
+		"m":               {"48.8566, 2.3522, 500m", Point{48.8566, 2.3522}, 500, ""},
+		"unitless":        {"48.8566,2.3522,1.5", Point{48.8566, 2.3522}, 1500, ""},
+		"fields":          {"48.8566,2.3522", Point{}, 0, "must be given as latitude,longitude,<distance or count>"},
+		"latitude/range":  {"98.8566,2.3522,300km", Point{}, 0, "the latitude must be a number within [-90, 90]"},
+		"longitude/nan":   {"48.8566,east,300km", Point{}, 0, "the longitude must be a number within [-180, 180]"},
+		"distance/zero":   {"48.8566,2.3522,0km", Point{}, 0, "the distance must be a positive number of kilometers or meters, e.g. 300km or 500m"},
+		"distance/suffix": {"48.8566,2.3522,300mi", Point{}, 0, "the distance must be a positive number of kilometers or meters, e.g. 300km or 500m"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			point, distance, err := ParseRadius(tc.input)
+			if tc.err != "" {
+				util.Equals(t, tc.err, err.Error())
+				return
+			}
+			util.OK(t, err)
+			util.Equals(t, tc.point, point)
+			util.Equals(t, tc.distance, distance)
+		})
+	}
+}
+
+func TestParseNearest(t *testing.T) {
+	point, count, err := ParseNearest("48.8566,2.3522,5")
+	util.OK(t, err)
+	util.Equals(t, Point{48.8566, 2.3522}, point)
+	util.Equals(t, 5, count)
+	_, _, err = ParseNearest("48.8566,2.3522,-1")
+	util.Equals(t, "the count must be a positive integer", err.Error())
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package geo provides the geographic computations behind the location-based selectors of EdgeNet,
+// such as reading the regions given in GeoJSON and finding out whether a node lies in them.
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Shape is a region made of polygons, a point is in the shape if it is in any of its polygons
+type Shape struct {
+	Polygons []Polygon
+}
+
+// Polygon is an area bounded by an exterior ring, minus the holes that its interior rings cut out
+type Polygon struct {
+	Exterior Ring
+	Holes    []Ring
+}
+
+// Ring is a closed line given as [longitude, latitude] points.
+// The longitudes of a ring crossing the antimeridian are unwrapped beyond ±180° so that the ring stays continuous.
+type Ring struct {
+	Points [][]float64
+	bounds Bounds
+}
+
+// Bounds is a rectangle given by its minimum and maximum longitudes and latitudes
+type Bounds struct {
+	MinLon float64
+	MaxLon float64
+	MinLat float64
+	MaxLat float64
+}
+
+// geoJSON holds the members of the GeoJSON objects that describe an area
+type geoJSON struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometry    json.RawMessage   `json:"geometry"`
+	Geometries  []json.RawMessage `json:"geometries"`
+	Features    []json.RawMessage `json:"features"`
+}
+
+// Parse reads a region given as a GeoJSON Polygon, MultiPolygon, GeometryCollection, Feature, or FeatureCollection.
+// A bare array of [longitude, latitude] points, the form the polygon selector has taken so far, is read as a polygon
+// without holes. The errors tell where the document is invalid, e.g. "features[1].geometry.coordinates[0]: ...".
+func Parse(data []byte) (*Shape, error) {
+	data = bytes.TrimSpace(data)
+	shape := &Shape{}
+	if len(data) > 0 && data[0] == '[' {
+		var points [][]float64
+		if err := json.Unmarshal(data, &points); err != nil {
+			return nil, fmt.Errorf("must be a GeoJSON object or an array of [longitude, latitude] points: %s", err)
+		}
+		ring, err := newRing(points, false)
+		if err != nil {
+			return nil, err
+		}
+		shape.Polygons = append(shape.Polygons, Polygon{Exterior: ring})
+		return shape, nil
+	}
+	if err := shape.add(data, ""); err != nil {
+		return nil, err
+	}
+	return shape, nil
+}
+
+// Contains returns whether the point given in degrees is in the shape
+func (s *Shape) Contains(lon, lat float64) bool {
+	for _, polygon := range s.Polygons {
+		if polygon.Contains(lon, lat) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the rectangles that cover the polygons of the shape.
+// Their longitudes go beyond ±180° for the polygons crossing the antimeridian.
+func (s *Shape) Bounds() []Bounds {
+	bounds := make([]Bounds, 0, len(s.Polygons))
+	for _, polygon := range s.Polygons {
+		bounds = append(bounds, polygon.Exterior.bounds)
+	}
+	return bounds
+}
+
+// Contains returns whether the point given in degrees is in the exterior ring of the polygon but not in any of its holes
+func (p *Polygon) Contains(lon, lat float64) bool {
+	// The point is also checked one turn east and one turn west for the rings unwrapped beyond ±180°
+	for _, offset := range []float64{0, 360, -360} {
+		if !p.Exterior.contains(lon+offset, lat) {
+			continue
+		}
+		inHole := false
+		for _, hole := range p.Holes {
+			if hole.contains(lon+offset, lat) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// add puts the polygons of a GeoJSON object into the shape, path locates the object in the document for the errors
+func (s *Shape) add(data json.RawMessage, path string) error {
+	var object geoJSON
+	if err := json.Unmarshal(data, &object); err != nil {
+		return pathError(path, fmt.Errorf("must be a GeoJSON object: %s", err))
+	}
+	switch object.Type {
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(object.Coordinates, &rings); err != nil {
+			return pathError(path+".coordinates", fmt.Errorf("must be an array of linear rings: %s", err))
+		}
+		polygon, err := newPolygon(rings, path+".coordinates")
+		if err != nil {
+			return err
+		}
+		s.Polygons = append(s.Polygons, polygon)
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(object.Coordinates, &polygons); err != nil {
+			return pathError(path+".coordinates", fmt.Errorf("must be an array of polygons: %s", err))
+		}
+		if len(polygons) == 0 {
+			return pathError(path+".coordinates", fmt.Errorf("a multipolygon needs at least one polygon"))
+		}
+		for i, rings := range polygons {
+			polygon, err := newPolygon(rings, fmt.Sprintf("%s.coordinates[%d]", path, i))
+			if err != nil {
+				return err
+			}
+			s.Polygons = append(s.Polygons, polygon)
+		}
+	case "GeometryCollection":
+		if len(object.Geometries) == 0 {
+			return pathError(path+".geometries", fmt.Errorf("a geometry collection needs at least one geometry"))
+		}
+		for i, geometry := range object.Geometries {
+			if err := s.add(geometry, fmt.Sprintf("%s.geometries[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "Feature":
+		if len(object.Geometry) == 0 || string(object.Geometry) == "null" {
+			return pathError(path+".geometry", fmt.Errorf("a feature needs a geometry"))
+		}
+		return s.add(object.Geometry, path+".geometry")
+	case "FeatureCollection":
+		if len(object.Features) == 0 {
+			return pathError(path+".features", fmt.Errorf("a feature collection needs at least one feature"))
+		}
+		for i, feature := range object.Features {
+			if err := s.add(feature, fmt.Sprintf("%s.features[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "":
+		return pathError(path+".type", fmt.Errorf("the GeoJSON type is missing"))
+	default:
+		return pathError(path+".type", fmt.Errorf("%s does not cover an area, the types allowed are Polygon, MultiPolygon, GeometryCollection, Feature, and FeatureCollection", object.Type))
+	}
+	return nil
+}
+
+// newPolygon makes a polygon whose first ring is the exterior one and the rest are the holes
+func newPolygon(rings [][][]float64, path string) (Polygon, error) {
+	var polygon Polygon
+	if len(rings) == 0 {
+		return polygon, pathError(path, fmt.Errorf("a polygon needs an exterior ring"))
+	}
+	for i, points := range rings {
+		ring, err := newRing(points, true)
+		if err != nil {
+			return polygon, pathError(fmt.Sprintf("%s[%d]", path, i), err)
+		}
+		if i == 0 {
+			polygon.Exterior = ring
+			continue
+		}
+		// A hole unwrapped on its own may end up one turn away from the exterior ring
+		if offset := ring.center() - polygon.Exterior.center(); offset > 180 {
+			ring.shift(-360)
+		} else if offset < -180 {
+			ring.shift(360)
+		}
+		polygon.Holes = append(polygon.Holes, ring)
+	}
+	return polygon, nil
+}
+
+// newRing validates the points and unwraps the longitudes of a ring crossing the antimeridian.
+// GeoJSON requires the linear rings to be closed, i.e. to end with their first point.
+func newRing(points [][]float64, closed bool) (Ring, error) {
+	ring := Ring{}
+	if closed {
+		if len(points) < 4 {
+			return ring, fmt.Errorf("a linear ring needs at least 4 points, %d given", len(points))
+		}
+	} else if len(points) < 3 {
+		return ring, fmt.Errorf("a polygon needs at least 3 points, %d given", len(points))
+	}
+	for i, point := range points {
+		// The third value of a position, if any, is the altitude
+		if len(point) != 2 && len(point) != 3 {
+			return ring, fmt.Errorf("point %d must have a longitude and a latitude", i)
+		}
+		if point[0] < -180 || point[0] > 180 || point[1] < -90 || point[1] > 90 {
+			return ring, fmt.Errorf("point %d is out of range, the longitude must be within [-180, 180] and the latitude within [-90, 90]", i)
+		}
+	}
+	if closed && (points[0][0] != points[len(points)-1][0] || points[0][1] != points[len(points)-1][1]) {
+		return ring, fmt.Errorf("a linear ring must end with its first point")
+	}
+	ring.Points = make([][]float64, len(points))
+	for i, point := range points {
+		lon := point[0]
+		if i > 0 {
+			// An edge spanning more than 180° of longitude is taken as crossing the antimeridian
+			previous := ring.Points[i-1][0]
+			for lon-previous > 180 {
+				lon -= 360
+			}
+			for lon-previous < -180 {
+				lon += 360
+			}
+		}
+		ring.Points[i] = []float64{lon, point[1]}
+	}
+	ring.setBounds()
+	return ring, nil
+}
+
+// contains determines whether the point is in the ring by using the crossing number method,
+// which counts the number of times a ray starting at the point crosses the edges of the ring
+func (r *Ring) contains(x, y float64) bool {
+	if !r.bounds.contains(x, y) {
+		return false
+	}
+	inside := false
+	last := len(r.Points) - 1
+	for i, point := range r.Points {
+		previous := r.Points[last]
+		if (point[1] < y && previous[1] >= y || previous[1] < y && point[1] >= y) &&
+			point[0]+(y-point[1])/(previous[1]-point[1])*(previous[0]-point[0]) < x {
+			inside = !inside
+		}
+		last = i
+	}
+	return inside
+}
+
+func (r *Ring) setBounds() {
+	r.bounds = Bounds{MinLon: math.MaxFloat64, MaxLon: -math.MaxFloat64, MinLat: math.MaxFloat64, MaxLat: -math.MaxFloat64}
+	for _, point := range r.Points {
+		r.bounds.MinLon = math.Min(r.bounds.MinLon, point[0])
+		r.bounds.MaxLon = math.Max(r.bounds.MaxLon, point[0])
+		r.bounds.MinLat = math.Min(r.bounds.MinLat, point[1])
+		r.bounds.MaxLat = math.Max(r.bounds.MaxLat, point[1])
+	}
+}
+
+func (r *Ring) center() float64 {
+	return (r.bounds.MinLon + r.bounds.MaxLon) / 2
+}
+
+func (r *Ring) shift(offset float64) {
+	for _, point := range r.Points {
+		point[0] += offset
+	}
+	r.setBounds()
+}
+
+func (b Bounds) contains(lon, lat float64) bool {
+	return lon >= b.MinLon && lon <= b.MaxLon && lat >= b.MinLat && lat <= b.MaxLat
+}
+
+func pathError(path string, err error) error {
+	if path == "" {
+		return err
+	}
+	// The paths start with a dot as they are built up from the root object
+	return fmt.Errorf("%s: %s", path[1:], err)
+}
@@ -0,0 +1,82 @@
+package geo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+)
+
+// A square around Paris with a hole over its center, the coordinates as given by the GIS tools
+const parisWithHole = `{"type": "Polygon", "coordinates": [
+	[[2.2, 48.8], [2.5, 48.8], [2.5, 48.95], [2.2, 48.95], [2.2, 48.8]],
+	[[2.3, 48.85], [2.4, 48.85], [2.4, 48.9], [2.3, 48.9], [2.3, 48.85]]
+]}`
+
+// The Fiji islands lie on both sides of the antimeridian
+const fiji = `{"type": "Polygon", "coordinates": [[[177, -19], [-179, -19], [-179, -16], [177, -16], [177, -19]]]}`
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		input    string
+		polygons int
+		err      string
+	}{
+		"legacy":                {"[[2.2150567, 48.8947616], [2.2040704, 48.8084639], [2.4807885, 48.8574024]]", 1, ""},
+		"polygon":               {parisWithHole, 1, ""},
+		"polygon/altitude":      {`{"type": "Polygon", "coordinates": [[[0, 0, 10], [1, 0, 10], [1, 1, 10], [0, 0, 10]]]}`, 1, ""},
+		"multipolygon":          {fmt.Sprintf(`{"type": "MultiPolygon", "coordinates": [%s, %s]}`, `[[[0, 0], [1, 0], [1, 1], [0, 0]]]`, `[[[5, 5], [6, 5], [6, 6], [5, 5]]]`), 2, ""},
+		"feature":               {fmt.Sprintf(`{"type": "Feature", "properties": {"name": "Paris"}, "geometry": %s}`, parisWithHole), 1, ""},
+		"featurecollection":     {fmt.Sprintf(`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": %s}, {"type": "Feature", "geometry": %s}]}`, parisWithHole, fiji), 2, ""},
+		"geometrycollection":    {fmt.Sprintf(`{"type": "GeometryCollection", "geometries": [%s]}`, fiji), 1, ""},
+		"legacy/json":           {"[[2.2150567, 48.8947616", 0, "must be a GeoJSON object or an array of [longitude, latitude] points: unexpected end of JSON input"},
+		"legacy/points":         {"[[2.2150567, 48.8947616]]", 0, "a polygon needs at least 3 points, 1 given"},
+		"type/missing":          {`{"coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`, 0, "type: the GeoJSON type is missing"},
+		"type/point":            {`{"type": "Point", "coordinates": [0, 0]}`, 0, "type: Point does not cover an area, the types allowed are Polygon, MultiPolygon, GeometryCollection, Feature, and FeatureCollection"},
+		"ring/open":             {`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}`, 0, "coordinates[0]: a linear ring must end with its first point"},
+		"ring/points":           {`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [0, 0]]]}`, 0, "coordinates[0]: a linear ring needs at least 4 points, 3 given"},
+		"ring/range":            {`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 91], [0, 0]]]}`, 0, "coordinates[0]: point 2 is out of range, the longitude must be within [-180, 180] and the latitude within [-90, 90]"},
+		"polygon/empty":         {`{"type": "Polygon", "coordinates": []}`, 0, "coordinates: a polygon needs an exterior ring"},
+		"feature/null":          {`{"type": "Feature", "geometry": null}`, 0, "geometry: a feature needs a geometry"},
+		"featurecollection/nth": {fmt.Sprintf(`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": %s}, {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}]}`, fiji), 0, "features[1].geometry.type: LineString does not cover an area, the types allowed are Polygon, MultiPolygon, GeometryCollection, Feature, and FeatureCollection"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			shape, err := Parse([]byte(tc.input))
+			if tc.err != "" {
+				util.Equals(t, tc.err, err.Error())
+				return
+			}
+			util.OK(t, err)
+			util.Equals(t, tc.polygons, len(shape.Polygons))
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	cases := map[string]struct {
+		input    string
+		point    []float64
+		expected bool
+	}{
+		"legacy/in":              {"[[2.2150567, 48.8947616], [2.2040704, 48.8084639], [2.3393396, 48.7835862], [2.4519494, 48.8416903], [2.3932412, 48.9171024]]", []float64{2.3522, 48.8566}, true},
+		"legacy/out":             {"[[2.2150567, 48.8947616], [2.2040704, 48.8084639], [2.3393396, 48.7835862], [2.4519494, 48.8416903], [2.3932412, 48.9171024]]", []float64{-96.7298, 32.9483}, false},
+		"hole/ring":              {parisWithHole, []float64{2.25, 48.82}, true},
+		"hole/in":                {parisWithHole, []float64{2.35, 48.87}, false},
+		"hole/out":               {parisWithHole, []float64{2.6, 48.87}, false},
+		"antimeridian/east":      {fiji, []float64{178.4, -18.1}, true},
+		"antimeridian/west":      {fiji, []float64{-179.9, -16.5}, true},
+		"antimeridian/elsewhere": {fiji, []float64{0, -18}, false},
+		"antimeridian/beyond":    {fiji, []float64{-178, -18}, false},
+		"antimeridian/hole":      {`{"type": "Polygon", "coordinates": [[[170, -10], [-170, -10], [-170, 10], [170, 10], [170, -10]], [[-179, -1], [179, -1], [179, 1], [-179, 1], [-179, -1]]]}`, []float64{180, 0}, false},
+		"antimeridian/split":     {`{"type": "MultiPolygon", "coordinates": [[[[177, -19], [180, -19], [180, -16], [177, -16], [177, -19]]], [[[-180, -19], [-179, -19], [-179, -16], [-180, -16], [-180, -19]]]]}`, []float64{-179.5, -17}, true},
+		"multipolygon/second":    {`{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 0]]], [[[5, 5], [6, 5], [6, 6], [5, 6], [5, 5]]]]}`, []float64{5.5, 5.5}, true},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			shape, err := Parse([]byte(tc.input))
+			util.OK(t, err)
+			util.Equals(t, tc.expected, shape.Contains(tc.point[0], tc.point[1]))
+		})
+	}
+}
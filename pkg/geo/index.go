@@ -0,0 +1,227 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultCellSize is the size of the cells of an index in degrees, about 111 km at the equator
+const DefaultCellSize = 1.0
+
+// Index keeps named points in a grid of cells over the globe. The points in an area are found by looking
+// at the cells that the area overlaps, instead of going through all points. An index is not safe for
+// concurrent use, its owner guards it.
+type Index struct {
+	cellSize float64
+	points   map[string]Point
+	cells    map[cell]map[string]Point
+}
+
+// cell is the position of a cell in the grid, counted from the longitude -180° and the latitude -90°
+type cell struct {
+	x int
+	y int
+}
+
+// Neighbour is a point of an index along with its distance in meters to the point a search starts from
+type Neighbour struct {
+	Name     string
+	Distance float64
+}
+
+// NewIndex creates an empty index whose cells are of the size given in degrees
+func NewIndex(cellSize float64) *Index {
+	return &Index{cellSize: cellSize, points: map[string]Point{}, cells: map[cell]map[string]Point{}}
+}
+
+// Set adds the point to the index or moves it
+func (i *Index) Set(name string, point Point) {
+	i.Delete(name)
+	i.points[name] = point
+	c := i.cellOf(point.Lon, point.Lat)
+	if i.cells[c] == nil {
+		i.cells[c] = map[string]Point{}
+	}
+	i.cells[c][name] = point
+}
+
+// Delete removes the point from the index
+func (i *Index) Delete(name string) {
+	point, exists := i.points[name]
+	if !exists {
+		return
+	}
+	delete(i.points, name)
+	c := i.cellOf(point.Lon, point.Lat)
+	delete(i.cells[c], name)
+	if len(i.cells[c]) == 0 {
+		delete(i.cells, c)
+	}
+}
+
+// Get returns the point of the name
+func (i *Index) Get(name string) (Point, bool) {
+	point, exists := i.points[name]
+	return point, exists
+}
+
+// Len returns the number of points in the index
+func (i *Index) Len() int {
+	return len(i.points)
+}
+
+// Names returns the names of all points in the index
+func (i *Index) Names() []string {
+	names := make([]string, 0, len(i.points))
+	for name := range i.points {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Search returns the names of the points within the bounds, whose longitudes may go beyond ±180°
+func (i *Index) Search(bounds Bounds) []string {
+	var names []string
+	for _, lon := range splitLongitudes(bounds.MinLon, bounds.MaxLon) {
+		names = i.search(Bounds{MinLon: lon[0], MaxLon: lon[1], MinLat: bounds.MinLat, MaxLat: bounds.MaxLat}, names)
+	}
+	return names
+}
+
+// SearchShape returns the names of the points in the shape
+func (i *Index) SearchShape(shape *Shape) []string {
+	var names []string
+	found := map[string]bool{}
+	for _, bounds := range shape.Bounds() {
+		for _, name := range i.Search(bounds) {
+			if point := i.points[name]; !found[name] && shape.Contains(point.Lon, point.Lat) {
+				found[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Within returns the points within the distance in meters of the center, closest first
+func (i *Index) Within(center Point, distance float64) []Neighbour {
+	var candidates []string
+	angle := distance / EarthRadius
+	if angle >= math.Pi {
+		candidates = i.Names()
+	} else {
+		// The bounding rectangle of the circle spans all longitudes if it reaches a pole
+		delta := angle * 180 / math.Pi
+		bounds := Bounds{MinLon: -180, MaxLon: 180, MinLat: math.Max(center.Lat-delta, -90), MaxLat: math.Min(center.Lat+delta, 90)}
+		if bounds.MinLat > -90 && bounds.MaxLat < 90 {
+			lonDelta := math.Asin(math.Sin(angle)/math.Cos(center.Lat*math.Pi/180)) * 180 / math.Pi
+			bounds.MinLon, bounds.MaxLon = center.Lon-lonDelta, center.Lon+lonDelta
+		}
+		candidates = i.Search(bounds)
+	}
+	var neighbours []Neighbour
+	for _, name := range candidates {
+		if d := Distance(center, i.points[name]); d <= distance {
+			neighbours = append(neighbours, Neighbour{Name: name, Distance: d})
+		}
+	}
+	sortNeighbours(neighbours)
+	return neighbours
+}
+
+// Nearest returns the count points closest to the center, closest first.
+// It searches within a circle that doubles until the circle holds enough points.
+func (i *Index) Nearest(center Point, count int) []Neighbour {
+	if count <= 0 {
+		return nil
+	}
+	halfTurn := math.Pi * EarthRadius
+	distance := i.cellSize * math.Pi / 180 * EarthRadius
+	for {
+		if count >= len(i.points) {
+			distance = halfTurn
+		}
+		neighbours := i.Within(center, distance)
+		if len(neighbours) >= count || distance >= halfTurn {
+			if len(neighbours) > count {
+				neighbours = neighbours[:count]
+			}
+			return neighbours
+		}
+		distance *= 2
+	}
+}
+
+// search appends the names of the points within the bounds, which lie within [-180, 180]
+func (i *Index) search(bounds Bounds, names []string) []string {
+	lower, upper := i.cellOf(bounds.MinLon, bounds.MinLat), i.cellOf(bounds.MaxLon, bounds.MaxLat)
+	visit := func(points map[string]Point) {
+		for name, point := range points {
+			if bounds.contains(point.Lon, point.Lat) {
+				names = append(names, name)
+			}
+		}
+	}
+	// Going through the cells that hold points is quicker when the bounds cover more cells than that
+	if (upper.x-lower.x+1)*(upper.y-lower.y+1) > len(i.cells) {
+		for c, points := range i.cells {
+			if c.x >= lower.x && c.x <= upper.x && c.y >= lower.y && c.y <= upper.y {
+				visit(points)
+			}
+		}
+		return names
+	}
+	for x := lower.x; x <= upper.x; x++ {
+		for y := lower.y; y <= upper.y; y++ {
+			visit(i.cells[cell{x: x, y: y}])
+		}
+	}
+	return names
+}
+
+func (i *Index) cellOf(lon, lat float64) cell {
+	return cell{x: int(math.Floor((lon + 180) / i.cellSize)), y: int(math.Floor((lat + 90) / i.cellSize))}
+}
+
+// splitLongitudes brings a range of longitudes back within [-180, 180], a range crossing the antimeridian becomes two
+func splitLongitudes(min, max float64) [][2]float64 {
+	if max-min >= 360 {
+		return [][2]float64{{-180, 180}}
+	}
+	for min < -180 {
+		min, max = min+360, max+360
+	}
+	for min > 180 {
+		min, max = min-360, max-360
+	}
+	if max <= 180 {
+		return [][2]float64{{min, max}}
+	}
+	return [][2]float64{{min, 180}, {-180, max - 360}}
+}
+
+// sortNeighbours puts the closest first, the names break the ties so that the order is stable
+func sortNeighbours(neighbours []Neighbour) {
+	sort.Slice(neighbours, func(i, j int) bool {
+		if neighbours[i].Distance != neighbours[j].Distance {
+			return neighbours[i].Distance < neighbours[j].Distance
+		}
+		return neighbours[i].Name < neighbours[j].Name
+	})
+}
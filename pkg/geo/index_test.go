@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+)
+
+// newRandomIndex fills an index with points spread over the globe, returned along with the points
+func newRandomIndex(count int) (*Index, map[string]Point) {
+	random := rand.New(rand.NewSource(1))
+	index := NewIndex(DefaultCellSize)
+	points := map[string]Point{}
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("node-%d", i)
+		points[name] = Point{Lat: random.Float64()*180 - 90, Lon: random.Float64()*360 - 180}
+		index.Set(name, points[name])
+	}
+	return index, points
+}
+
+func TestIndexSetDelete(t *testing.T) {
+	index := NewIndex(DefaultCellSize)
+	index.Set("paris", Point{Lat: 48.8566, Lon: 2.3522})
+	index.Set("london", Point{Lat: 51.5074, Lon: -0.1278})
+	util.Equals(t, 2, index.Len())
+	// A point that moves leaves its former cell
+	index.Set("paris", Point{Lat: 40.7128, Lon: -74.0060})
+	util.Equals(t, 2, index.Len())
+	util.Equals(t, 0, len(index.Search(Bounds{MinLon: 2, MaxLon: 3, MinLat: 48, MaxLat: 49})))
+	util.Equals(t, []string{"paris"}, index.Search(Bounds{MinLon: -75, MaxLon: -74, MinLat: 40, MaxLat: 41}))
+	index.Delete("paris")
+	index.Delete("unknown")
+	util.Equals(t, []string{"london"}, index.Names())
+	util.Equals(t, 1, len(index.cells))
+}
+
+func TestIndexSearch(t *testing.T) {
+	index, points := newRandomIndex(2000)
+	cases := map[string]Bounds{
+		"europe":       {MinLon: -10, MaxLon: 40, MinLat: 35, MaxLat: 70},
+		"antimeridian": {MinLon: 170, MaxLon: 190, MinLat: -30, MaxLat: 0},
+		"world":        {MinLon: -180, MaxLon: 180, MinLat: -90, MaxLat: 90},
+		"cell":         {MinLon: 2.1, MaxLon: 2.2, MinLat: 48.1, MaxLat: 48.2},
+	}
+	for k, bounds := range cases {
+		t.Run(k, func(t *testing.T) {
+			var expected []string
+			for name, point := range points {
+				for _, offset := range []float64{0, 360} {
+					if bounds.contains(point.Lon+offset, point.Lat) {
+						expected = append(expected, name)
+					}
+				}
+			}
+			found := index.Search(bounds)
+			sort.Strings(expected)
+			sort.Strings(found)
+			util.Equals(t, expected, found)
+		})
+	}
+}
+
+func TestIndexWithin(t *testing.T) {
+	index, points := newRandomIndex(2000)
+	cases := map[string]struct {
+		center   Point
+		distance float64
+	}{
+		"paris":        {Point{Lat: 48.8566, Lon: 2.3522}, 1000000},
+		"antimeridian": {Point{Lat: -17, Lon: 179}, 800000},
+		"pole":         {Point{Lat: 85, Lon: 0}, 1500000},
+		"world":        {Point{Lat: 0, Lon: 0}, 30000000},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			var expected []Neighbour
+			for name, point := range points {
+				if d := Distance(tc.center, point); d <= tc.distance {
+					expected = append(expected, Neighbour{Name: name, Distance: d})
+				}
+			}
+			sortNeighbours(expected)
+			util.Equals(t, expected, index.Within(tc.center, tc.distance))
+		})
+	}
+}
+
+func TestIndexNearest(t *testing.T) {
+	index, points := newRandomIndex(2000)
+	var all []Neighbour
+	paris := Point{Lat: 48.8566, Lon: 2.3522}
+	for name, point := range points {
+		all = append(all, Neighbour{Name: name, Distance: Distance(paris, point)})
+	}
+	sortNeighbours(all)
+	util.Equals(t, all[:5], index.Nearest(paris, 5))
+	util.Equals(t, all, index.Nearest(paris, 5000))
+	util.Equals(t, 0, len(index.Nearest(paris, 0)))
+}
+
+func TestIndexSearchShape(t *testing.T) {
+	index := NewIndex(DefaultCellSize)
+	index.Set("paris", Point{Lat: 48.8566, Lon: 2.3522})
+	index.Set("suva", Point{Lat: -18.1, Lon: 178.4})
+	index.Set("taveuni", Point{Lat: -16.5, Lon: -179.9})
+	shape, err := Parse([]byte(fiji))
+	util.OK(t, err)
+	found := index.SearchShape(shape)
+	sort.Strings(found)
+	util.Equals(t, []string{"suva", "taveuni"}, found)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package geolabel encodes the names and the coordinates into the values of the edge-net.io labels of the nodes,
+// and decodes them back. A label value has 63 characters at most, which are alphanumeric, '-', '_', or '.',
+// and begins and ends with an alphanumeric character. The names are transliterated to ASCII and hashed if they
+// are too long, so that the labeler and the selectors, which encode the names they look for, agree on the values.
+package geolabel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// The keys of the geographic labels
+const (
+	Continent = "edge-net.io/continent"
+	Country   = "edge-net.io/country-iso"
+	State     = "edge-net.io/state-iso"
+	City      = "edge-net.io/city"
+	Lat       = "edge-net.io/lat"
+	Lon       = "edge-net.io/lon"
+	TimeZone  = "edge-net.io/timezone"
+)
+
+// MaxLength is the length that a label value may not exceed
+const MaxLength = validation.LabelValueMaxLength
+
+// hashLength is the number of the hexadecimal digits of the hash that stands for the end of a long value
+const hashLength = 10
+
+// special are the letters that do not decompose into a Latin letter and diacritics
+var special = map[rune]string{
+	'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'Ø': "O", 'ø': "o", 'Ł': "L", 'ł': "l",
+	'Đ': "D", 'đ': "d", 'Ð': "D", 'ð': "d", 'Þ': "Th", 'þ': "th", 'Ħ': "H", 'ħ': "h", 'ı': "i",
+}
+
+var (
+	invalidValueChars = regexp.MustCompile("[^A-Za-z0-9_.-]+")
+	invalidNameChars  = regexp.MustCompile("[^a-z0-9]+")
+)
+
+// Transliterate writes the Latin letters of the text in ASCII, such as Saint-Étienne as Saint-Etienne.
+// The characters of the other scripts are left as they are.
+func Transliterate(text string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case r < utf8.RuneSelf:
+			builder.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+			// The diacritics go away once separated from their letters
+		case special[r] != "":
+			builder.WriteString(special[r])
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// Encode returns the label value of a name, such as that of a city. The name is transliterated, and the characters
+// that a label value may not have become underscores, as the spaces do. A value that is too long ends with a hash
+// of the whole value, and a name that has nothing left, such as one in another script, is hashed altogether.
+func Encode(name string) string {
+	value := strings.Trim(invalidValueChars.ReplaceAllString(Transliterate(name), "_"), "_.-")
+	if value == "" {
+		if strings.TrimSpace(name) == "" {
+			return ""
+		}
+		return hash(name)
+	}
+	return limit(value, "_")
+}
+
+// Decode returns the name that a label value reads as, the underscores becoming spaces. The transliteration
+// and the hash are one-way, so the name may differ from the encoded one.
+func Decode(value string) string {
+	return strings.Replace(value, "_", " ", -1)
+}
+
+// EncodeName returns a name as a DNS label, which is both a label value and a part of the names of the objects.
+// It is in lower case, and the characters other than the letters and the digits become hyphens.
+func EncodeName(name string) string {
+	value := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(Transliterate(name)), "-"), "-")
+	if value == "" {
+		if strings.TrimSpace(name) == "" {
+			return ""
+		}
+		return hash(name)
+	}
+	return limit(value, "-")
+}
+
+// EncodeTimeZone returns the label value of a time zone of the IANA database, whose slashes become dots
+// as in Europe.Paris
+func EncodeTimeZone(zone string) string {
+	return Encode(strings.Replace(zone, "/", ".", -1))
+}
+
+// DecodeTimeZone returns the time zone of a label value, the dots becoming slashes again
+func DecodeTimeZone(value string) string {
+	return strings.Replace(value, ".", "/", -1)
+}
+
+// limit cuts a value that is too long, and ends it with the separator and a hash of the whole value,
+// so that the values sharing the beginning stay apart
+func limit(value, separator string) string {
+	if len(value) <= MaxLength {
+		return value
+	}
+	digest := hash(value)
+	return strings.TrimRight(value[:MaxLength-len(separator)-len(digest)], "_.-") + separator + digest
+}
+
+// hash returns the beginning of the SHA-256 digest of the value in hexadecimal
+func hash(value string) string {
+	digest := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(digest[:])[:hashLength]
+}
+
+// The coordinates are written in degrees with 6 decimals, which is about 10 cm, after a letter that tells the
+// hemisphere: n or s for the latitude, e or w for the longitude. The letter lets the value begin with an
+// alphanumeric character while the sign stays, so 76.9457° W is w-76.945700. Decoding an encoded coordinate gives
+// it back rounded to 6 decimals, and encoding a decoded label value gives the value back.
+
+// EncodeLat returns the label value of a latitude
+func EncodeLat(lat float64) string {
+	return encodeCoordinate(lat, 'n', 's')
+}
+
+// EncodeLon returns the label value of a longitude
+func EncodeLon(lon float64) string {
+	return encodeCoordinate(lon, 'e', 'w')
+}
+
+// DecodeLat returns the latitude of a label value, which must be within [-90, 90] and match its letter
+func DecodeLat(value string) (float64, error) {
+	return decodeCoordinate(value, 'n', 's', 90)
+}
+
+// DecodeLon returns the longitude of a label value, which must be within [-180, 180] and match its letter
+func DecodeLon(value string) (float64, error) {
+	return decodeCoordinate(value, 'e', 'w', 180)
+}
+
+func encodeCoordinate(coordinate float64, positive, negative byte) string {
+	letter := positive
+	if coordinate < 0 {
+		letter = negative
+	}
+	return fmt.Sprintf("%c%.6f", letter, coordinate)
+}
+
+func decodeCoordinate(value string, positive, negative byte, bound float64) (float64, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("%q is not a coordinate", value)
+	}
+	coordinate, err := strconv.ParseFloat(value[1:], 64)
+	if err != nil || math.IsNaN(coordinate) {
+		return 0, fmt.Errorf("%q is not a coordinate", value)
+	}
+	if !(value[0] == positive && coordinate >= 0) && !(value[0] == negative && coordinate <= 0) {
+		return 0, fmt.Errorf("the letter of %q must be %c or %c, as its sign tells", value, positive, negative)
+	}
+	if math.Abs(coordinate) > bound {
+		return 0, fmt.Errorf("%q is out of [-%v, %v]", value, bound, bound)
+	}
+	return coordinate, nil
+}
@@ -0,0 +1,73 @@
+package geolabel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestEncode(t *testing.T) {
+	long := strings.Repeat("Llanfairpwllgwyngyll ", 4)
+	cases := map[string]struct {
+		name     string
+		expected string
+	}{
+		"plain":        {"Paris", "Paris"},
+		"space":        {"College Park", "College_Park"},
+		"diacritics":   {"Saint-Étienne", "Saint-Etienne"},
+		"special":      {"Łódź Straße", "Lodz_Strasse"},
+		"punctuation":  {" L'Haÿ-les-Roses.", "L_Hay-les-Roses"},
+		"other script": {"東京", hash("東京")},
+		"empty":        {"", ""},
+		"long":         {long, strings.TrimRight(strings.Replace(long, " ", "_", -1)[:52], "_") + "_" + hash(strings.Replace(strings.TrimSpace(long), " ", "_", -1))},
+		"long/valid":   {strings.Repeat("a", 63), strings.Repeat("a", 63)},
+		"organization": {"Renater, Paris.", "Renater_Paris"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			value := Encode(tc.name)
+			util.Equals(t, tc.expected, value)
+			util.Equals(t, 0, len(validation.IsValidLabelValue(value)))
+		})
+	}
+	util.Equals(t, "College Park", Decode(Encode("College Park")))
+}
+
+func TestEncodeName(t *testing.T) {
+	util.Equals(t, "saint-etienne", EncodeName("Saint-Étienne"))
+	util.Equals(t, "north-america", EncodeName("North_America"))
+	util.Equals(t, MaxLength, len(EncodeName(strings.Repeat("region ", 20))))
+	util.Equals(t, 0, len(validation.IsDNS1123Label(EncodeName(strings.Repeat("région ", 20)))))
+}
+
+func TestTimeZone(t *testing.T) {
+	util.Equals(t, "America.Argentina.Buenos_Aires", EncodeTimeZone("America/Argentina/Buenos_Aires"))
+	util.Equals(t, "America/Argentina/Buenos_Aires", DecodeTimeZone(EncodeTimeZone("America/Argentina/Buenos_Aires")))
+}
+
+func TestCoordinates(t *testing.T) {
+	util.Equals(t, "n38.989600", EncodeLat(38.9896))
+	util.Equals(t, "s-33.868800", EncodeLat(-33.8688))
+	util.Equals(t, "e2.328100", EncodeLon(2.3281))
+	util.Equals(t, "w-76.945700", EncodeLon(-76.9457))
+
+	// The coordinates come back rounded to 6 decimals, and the values come back as they are
+	for _, lon := range []float64{-76.9457, 0, 179.9999994, -180} {
+		decoded, err := DecodeLon(EncodeLon(lon))
+		util.OK(t, err)
+		util.Equals(t, EncodeLon(lon), EncodeLon(decoded))
+	}
+	lat, err := DecodeLat("s-33.868800")
+	util.OK(t, err)
+	util.Equals(t, -33.8688, lat)
+
+	for _, value := range []string{"", "n", "48.8566", "s48.8566", "n-48.8566", "nNaN", "n90.5", "x1.0"} {
+		_, err := DecodeLat(value)
+		util.Equals(t, true, err != nil)
+	}
+	_, err = DecodeLon("e180.5")
+	util.Equals(t, true, err != nil)
+}
@@ -28,6 +28,7 @@ import (
 	"net/smtp"
 	"os"
 
+	"github.com/EdgeNet-project/edgenet/pkg/metrics"
 	"github.com/EdgeNet-project/edgenet/pkg/util"
 
 	yaml "gopkg.in/yaml.v2"
@@ -94,6 +95,9 @@ type console struct {
 
 var dir = "../.."
 
+// emails counts the emails by subject and result, sent or failed
+var emails = metrics.NewCounterVec("edgenet_mailer_emails_total", "Total number of emails by subject and result", "subject", "result")
+
 // address to get URI of smtp server
 func (s *smtpServer) address() string {
 	return fmt.Sprintf("%s:%s", s.Host, s.Port)
@@ -101,6 +105,17 @@ func (s *smtpServer) address() string {
 
 // Send function consumed by the custom resources to send emails
 func Send(subject string, contentData interface{}) error {
+	err := send(subject, contentData)
+	if err != nil {
+		emails.WithLabelValues(subject, "failed").Inc()
+	} else {
+		emails.WithLabelValues(subject, "sent").Inc()
+	}
+	return err
+}
+
+// send prepares the email of the subject and delivers it through the SMTP server
+func send(subject string, contentData interface{}) error {
 	// The code below inits the SMTP configuration for sending emails
 	if flag.Lookup("dir") != nil {
 		dir = flag.Lookup("dir").Value.(flag.Getter).Get().(string)
@@ -214,7 +229,7 @@ func prepareNotification(subject string, contentData interface{}, smtpServer smt
 		to, body = setSliceContent(contentData, smtpServer.From, []string{smtpServer.To}, subject)
 	case "team-creation", "team-removal", "team-deletion", "team-crash":
 		to, body = setTeamContent(contentData, smtpServer.From, subject)
-	case "node-contribution-successful", "node-contribution-failure", "node-contribution-failure-support":
+	case "node-contribution-successful", "node-contribution-failure", "node-contribution-failure-support", "node-contribution-removal":
 		to, body = setNodeContributionContent(contentData, smtpServer.From, []string{smtpServer.To}, subject)
 	case "authority-validation-failure-name", "authority-validation-failure-email", "authority-email-verification-malfunction",
 		"authority-creation-failure", "authority-email-verification-dubious":
@@ -222,6 +237,8 @@ func prepareNotification(subject string, contentData interface{}, smtpServer smt
 	case "user-validation-failure-name", "user-validation-failure-email", "user-email-verification-malfunction", "user-creation-failure", "user-cert-failure",
 		"user-kubeconfig-failure", "user-email-verification-dubious", "user-email-verification-update-malfunction", "user-deactivation-failure":
 		to, body = setUserFailureContent(contentData, smtpServer.From, []string{smtpServer.To}, subject)
+	case "authority-deletion", "user-deletion":
+		to, body = setDeletionContent(contentData, smtpServer.From, subject)
 	}
 	return to, body
 }
@@ -311,6 +328,9 @@ func setNodeContributionContent(contentData interface{}, from string, to []strin
 		title = "[EdgeNet] Node Contribution - Failed"
 	case "node-contribution-failure-support":
 		title = "[EdgeNet Admin] Node Contribution - Failure"
+	case "node-contribution-removal":
+		to = NCData.CommonData.Email
+		title = "[EdgeNet] Node Contribution - Removed"
 	}
 	body := setCommonEmailHeaders(title, from, to, delimiter)
 	t.Execute(&body, NCData)
@@ -318,6 +338,24 @@ func setNodeContributionContent(contentData interface{}, from string, to []strin
 	return to, body
 }
 
+// setDeletionContent to create an email body related to the deletion of an authority or a user
+func setDeletionContent(contentData interface{}, from, subject string) ([]string, bytes.Buffer) {
+	deletionData := contentData.(CommonContentData)
+	// This represents receivers' email addresses
+	to := deletionData.CommonData.Email
+	// The HTML template
+	t, _ := template.ParseFiles(fmt.Sprintf("%s/assets/templates/email/%s.html", dir, subject))
+	delimiter := ""
+	title := "[EdgeNet] Authority deleted"
+	if subject == "user-deletion" {
+		title = "[EdgeNet] User deleted"
+	}
+	body := setCommonEmailHeaders(title, from, to, delimiter)
+	t.Execute(&body, deletionData)
+
+	return to, body
+}
+
 // setTeamContent to create an email body related to the team invitation
 func setTeamContent(contentData interface{}, from, subject string) ([]string, bytes.Buffer) {
 	teamData := contentData.(ResourceAllocationData)
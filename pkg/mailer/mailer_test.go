@@ -110,6 +110,9 @@ func TestNotification(t *testing.T) {
 		"node-contribution-successful":               {multiProviderData, []string{multiProviderData.CommonData.Authority, multiProviderData.CommonData.Username, multiProviderData.CommonData.Name, multiProviderData.Name, multiProviderData.Host, multiProviderData.Message[0]}},
 		"node-contribution-failure":                  {multiProviderData, []string{multiProviderData.CommonData.Authority, multiProviderData.CommonData.Username, multiProviderData.CommonData.Name, multiProviderData.Name, multiProviderData.Host, multiProviderData.Message[0]}},
 		"node-contribution-failure-support":          {multiProviderData, []string{multiProviderData.CommonData.Authority, multiProviderData.Name, multiProviderData.Host, multiProviderData.Message[0]}},
+		"node-contribution-removal":                  {multiProviderData, []string{multiProviderData.CommonData.Authority, multiProviderData.CommonData.Username, multiProviderData.CommonData.Name, multiProviderData.Name, multiProviderData.Host}},
+		"authority-deletion":                         {contentData, []string{contentData.CommonData.Authority, contentData.CommonData.Username, contentData.CommonData.Name}},
+		"user-deletion":                              {contentData, []string{contentData.CommonData.Authority, contentData.CommonData.Username, contentData.CommonData.Name}},
 		"authority-validation-failure-name":          {contentData, []string{contentData.CommonData.Authority, contentData.CommonData.Username, contentData.CommonData.Name}},
 		"authority-validation-failure-email":         {contentData, []string{contentData.CommonData.Authority, contentData.CommonData.Username, contentData.CommonData.Name}},
 		"authority-email-verification-malfunction":   {contentData, []string{contentData.CommonData.Authority, contentData.CommonData.Username}},
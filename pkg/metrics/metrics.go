@@ -0,0 +1,60 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics keeps the counters, gauges, and histograms of the EdgeNet controllers in a
+// Prometheus registry and exposes them through the handler of client_golang.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefBuckets are the default histogram buckets, in seconds, tailored to the duration of API calls
+var DefBuckets = prometheus.DefBuckets
+
+// Registry holds the metrics of the controllers along with those of the Go runtime and the process
+var Registry = prometheus.NewRegistry()
+
+// factory registers the metrics it creates to the registry, and panics if a name is already in use as it is a programming error
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(prometheus.NewGoCollector(), prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+}
+
+// Handler serves the metrics of the registry
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// NewCounterVec creates and registers a counter with the given labels
+func NewCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	return factory.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+}
+
+// NewGaugeVec creates and registers a gauge with the given labels
+func NewGaugeVec(name, help string, labels ...string) *prometheus.GaugeVec {
+	return factory.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+}
+
+// NewHistogramVec creates and registers a histogram with the given buckets and labels
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	return factory.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+}
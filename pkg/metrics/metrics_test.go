@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCounterVec(t *testing.T) {
+	counter := NewCounterVec("edgenet_test_counter_total", "Counter for test", "controller", "result")
+	counter.WithLabelValues("slice", "success").Inc()
+	counter.WithLabelValues("slice", "success").Add(2)
+	counter.WithLabelValues("team", "error").Inc()
+
+	expected := `# HELP edgenet_test_counter_total Counter for test
+# TYPE edgenet_test_counter_total counter
+edgenet_test_counter_total{controller="slice",result="success"} 3
+edgenet_test_counter_total{controller="team",result="error"} 1
+`
+	util.OK(t, testutil.CollectAndCompare(counter, strings.NewReader(expected)))
+}
+
+func TestGaugeVec(t *testing.T) {
+	gauge := NewGaugeVec("edgenet_test_gauge", "Gauge for test", "name")
+	gauge.WithLabelValues("slice").Inc()
+	gauge.WithLabelValues("slice").Inc()
+	gauge.WithLabelValues("slice").Dec()
+	gauge.WithLabelValues("user").Set(2.5)
+
+	expected := `# HELP edgenet_test_gauge Gauge for test
+# TYPE edgenet_test_gauge gauge
+edgenet_test_gauge{name="slice"} 1
+edgenet_test_gauge{name="user"} 2.5
+`
+	util.OK(t, testutil.CollectAndCompare(gauge, strings.NewReader(expected)))
+}
+
+func TestHistogramVec(t *testing.T) {
+	histogram := NewHistogramVec("edgenet_test_duration_seconds", "Histogram for test", []float64{0.1, 1}, "name")
+	histogram.WithLabelValues("slice").Observe(0.05)
+	histogram.WithLabelValues("slice").Observe(0.5)
+	histogram.WithLabelValues("slice").Observe(5)
+
+	expected := `# HELP edgenet_test_duration_seconds Histogram for test
+# TYPE edgenet_test_duration_seconds histogram
+edgenet_test_duration_seconds_bucket{name="slice",le="0.1"} 1
+edgenet_test_duration_seconds_bucket{name="slice",le="1"} 2
+edgenet_test_duration_seconds_bucket{name="slice",le="+Inf"} 3
+edgenet_test_duration_seconds_sum{name="slice"} 5.55
+edgenet_test_duration_seconds_count{name="slice"} 3
+`
+	util.OK(t, testutil.CollectAndCompare(histogram, strings.NewReader(expected)))
+}
+
+func TestHandler(t *testing.T) {
+	WorkqueueProvider{}.NewDepthMetric("edgenet-test").Inc()
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	util.Equals(t, 200, recorder.Code)
+	util.Equals(t, true, strings.Contains(recorder.Body.String(), "workqueue_depth{name=\"edgenet-test\"} 1\n"))
+}
+
+func TestRegisterTwice(t *testing.T) {
+	defer func() {
+		util.Equals(t, true, recover() != nil)
+	}()
+	NewCounterVec("workqueue_adds_total", "Duplicate")
+}
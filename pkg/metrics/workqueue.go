@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"k8s.io/client-go/util/workqueue"
+)
+
+// The metrics of the work queues, labeled by the name of the queue which is the name of the controller
+var (
+	queueDepth = NewGaugeVec("workqueue_depth",
+		"Current depth of the work queue", "name")
+	queueAdds = NewCounterVec("workqueue_adds_total",
+		"Total number of requests added to the work queue", "name")
+	queueLatency = NewHistogramVec("workqueue_queue_duration_seconds",
+		"How long a request stays in the work queue before being processed", DefBuckets, "name")
+	queueWorkDuration = NewHistogramVec("workqueue_work_duration_seconds",
+		"How long processing a request from the work queue takes", DefBuckets, "name")
+	queueUnfinishedWork = NewGaugeVec("workqueue_unfinished_work_seconds",
+		"Seconds of work in progress that has not been observed by work_duration yet", "name")
+	queueLongestRunningProcessor = NewGaugeVec("workqueue_longest_running_processor_seconds",
+		"Seconds the longest running processor of the work queue has been running", "name")
+	queueRetries = NewCounterVec("workqueue_retries_total",
+		"Total number of retries handled by the work queue", "name")
+)
+
+// WorkqueueProvider feeds the metrics above with the named work queues of client-go
+type WorkqueueProvider struct{}
+
+var _ workqueue.MetricsProvider = WorkqueueProvider{}
+
+// NewDepthMetric returns the depth gauge of the queue
+func (WorkqueueProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return queueDepth.WithLabelValues(name)
+}
+
+// NewAddsMetric returns the adds counter of the queue
+func (WorkqueueProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return queueAdds.WithLabelValues(name)
+}
+
+// NewLatencyMetric returns the latency histogram of the queue
+func (WorkqueueProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return queueLatency.WithLabelValues(name)
+}
+
+// NewWorkDurationMetric returns the work duration histogram of the queue
+func (WorkqueueProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return queueWorkDuration.WithLabelValues(name)
+}
+
+// NewUnfinishedWorkSecondsMetric returns the unfinished work gauge of the queue
+func (WorkqueueProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return queueUnfinishedWork.WithLabelValues(name)
+}
+
+// NewLongestRunningProcessorSecondsMetric returns the longest running processor gauge of the queue
+func (WorkqueueProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return queueLongestRunningProcessor.WithLabelValues(name)
+}
+
+// NewRetriesMetric returns the retries counter of the queue
+func (WorkqueueProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return queueRetries.WithLabelValues(name)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This code includes GeoLite2 data created by MaxMind, available from
+// https://www.maxmind.com.
+
+package node
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+)
+
+// Reloadable is a provider whose database is read from a file that may be replaced, as the GeoLite2 databases
+// are every week
+type Reloadable interface {
+	// Reload opens the database again if its file has changed, and returns whether it did
+	Reload() (bool, error)
+}
+
+// database is a GeoLite2 database that is opened at the first lookup and kept open. Once its file is replaced,
+// a reload swaps the former database for the new one, and the lookups in progress end with the former one.
+type database struct {
+	path string
+	// mutex guards the reader, the lookups hold it for reading until they end
+	mutex  sync.RWMutex
+	reader *geoip2.Reader
+	// modTime and size tell the version of the file that the reader reads
+	modTime time.Time
+	size    int64
+	// reloading lets a single reload open the file at once
+	reloading sync.Mutex
+}
+
+// lookup calls the function with the reader of the database, which it opens unless it is open already
+func (d *database) lookup(function func(reader *geoip2.Reader) error) error {
+	d.mutex.RLock()
+	opened := d.reader != nil
+	d.mutex.RUnlock()
+	if !opened {
+		if _, err := d.reload(); err != nil {
+			return err
+		}
+	}
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if d.reader == nil {
+		return errors.New(d.path + " is closed")
+	}
+	return function(d.reader)
+}
+
+// reload opens the file unless the reader reads the same version of it, and swaps the readers once the file is opened,
+// so that a file that cannot be opened, such as one being written, leaves the former reader in place
+func (d *database) reload() (bool, error) {
+	d.reloading.Lock()
+	defer d.reloading.Unlock()
+	info, err := os.Stat(d.path)
+	if err != nil {
+		return false, err
+	}
+	d.mutex.RLock()
+	unchanged := d.reader != nil && info.ModTime().Equal(d.modTime) && info.Size() == d.size
+	d.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+	reader, err := geoip2.Open(d.path)
+	if err != nil {
+		return false, err
+	}
+	d.mutex.Lock()
+	former := d.reader
+	d.reader, d.modTime, d.size = reader, info.ModTime(), info.Size()
+	d.mutex.Unlock()
+	// No lookup reads the former reader anymore since the lock is taken
+	if former != nil {
+		former.Close()
+	}
+	return true, nil
+}
+
+// Reload opens the database again if its file has changed, a missing file is not an error since the lookups tell
+// about it already
+func (d *database) Reload() (bool, error) {
+	reloaded, err := d.reload()
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return reloaded, err
+}
+
+// Close closes the database if it is open
+func (d *database) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.reader == nil {
+		return nil
+	}
+	err := d.reader.Close()
+	d.reader = nil
+	return err
+}
+
+// Reload reloads the databases of the providers that have any, and returns whether any of them is reloaded
+func (c ProviderChain) Reload() (bool, error) {
+	reloaded := false
+	var errs []string
+	for _, provider := range c {
+		if reloadable, ok := provider.(Reloadable); ok {
+			providerReloaded, err := reloadable.Reload()
+			if err != nil {
+				errs = append(errs, err.Error())
+			}
+			reloaded = reloaded || providerReloaded
+		}
+	}
+	if len(errs) != 0 {
+		return reloaded, errors.New(strings.Join(errs, "; "))
+	}
+	return reloaded, nil
+}
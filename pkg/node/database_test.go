@@ -0,0 +1,35 @@
+package node
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+)
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "database")
+	util.OK(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "GeoLite2-City.mmdb")
+	provider := NewGeoLiteCityProvider(path)
+	chain := ProviderChain{AnnotationProvider{}, provider, NewGeoLiteCountryProvider(filepath.Join(dir, "GeoLite2-Country.mmdb"))}
+
+	// A database that is not there yet is nothing to reload
+	reloaded, err := chain.Reload()
+	util.OK(t, err)
+	util.Equals(t, false, reloaded)
+
+	// A file being written cannot be opened, and is tried again at the next reload
+	util.OK(t, ioutil.WriteFile(path, []byte("partial"), 0644))
+	reloaded, err = chain.Reload()
+	util.Equals(t, true, err != nil)
+	util.Equals(t, false, reloaded)
+	_, err = provider.Locate(context.TODO(), nil, net.ParseIP("132.227.123.51"))
+	util.Equals(t, true, err != nil)
+	util.OK(t, provider.Close())
+}
@@ -0,0 +1,438 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This code includes GeoLite2 data created by MaxMind, available from
+// https://www.maxmind.com.
+
+package node
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LocationAnnotation holds a location in JSON that overrides the one the node would be looked up at
+const LocationAnnotation = "edge-net.io/location"
+
+// GeolocationSourceLabel tells which provider the geographic labels of the node come from
+const GeolocationSourceLabel = "edge-net.io/geolocation-source"
+
+// LocationDeclaredLabel tells whether the location of the node is declared, by its contributor or an administrator
+// through the annotation, or inferred from its IP address
+const LocationDeclaredLabel = "edge-net.io/location-declared"
+
+// The sources of the locations, one for each provider
+const (
+	SourceAnnotation      = "annotation"
+	SourceGeoLiteCity     = "geolite2-city"
+	SourceGeoLiteCountry  = "geolite2-country"
+	SourcePrivateNetworks = "private-networks"
+)
+
+// geoLabelKeys are the labels that a geolocation sets, a new one replaces all of them
+var geoLabelKeys = []string{geolabel.Continent, geolabel.Country, geolabel.State, geolabel.City,
+	geolabel.Lon, geolabel.Lat, geolabel.TimeZone, GeolocationSourceLabel, LocationDeclaredLabel}
+
+// Geolocation is the location of a node as a provider finds it, the fields that the provider does not know are empty
+type Geolocation struct {
+	Continent string   `json:"continent,omitempty"`
+	Country   string   `json:"country,omitempty"`
+	State     string   `json:"state,omitempty"`
+	City      string   `json:"city,omitempty"`
+	TimeZone  string   `json:"timezone,omitempty"`
+	Lat       *float64 `json:"lat,omitempty"`
+	Lon       *float64 `json:"lon,omitempty"`
+	// Source is the provider that found the location
+	Source string `json:"-"`
+}
+
+// Labels returns the geographic labels of the location, including the one of its source
+func (g *Geolocation) Labels() map[string]string {
+	labels := map[string]string{GeolocationSourceLabel: g.Source, LocationDeclaredLabel: strconv.FormatBool(g.Source == SourceAnnotation)}
+	set := func(key, value string) {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+	set(geolabel.Continent, geolabel.Encode(g.Continent))
+	set(geolabel.Country, geolabel.Encode(g.Country))
+	set(geolabel.State, geolabel.Encode(g.State))
+	if g.State == "" {
+		set(geolabel.State, geolabel.Encode(g.Country))
+	}
+	set(geolabel.City, geolabel.Encode(g.City))
+	set(geolabel.TimeZone, geolabel.EncodeTimeZone(g.TimeZone))
+	if g.Lat != nil && g.Lon != nil {
+		labels[geolabel.Lon], labels[geolabel.Lat] = geolabel.EncodeLon(*g.Lon), geolabel.EncodeLat(*g.Lat)
+	}
+	return labels
+}
+
+// GeolocationProvider looks up the location of a node, by its IP address or else
+type GeolocationProvider interface {
+	Locate(ctx context.Context, nodeObj *corev1.Node, ip net.IP) (*Geolocation, error)
+}
+
+// ProviderChain asks the providers in turn, the first location found wins
+type ProviderChain []GeolocationProvider
+
+// Locate returns the location that the first provider finds, or the errors of all providers if none does
+func (c ProviderChain) Locate(ctx context.Context, nodeObj *corev1.Node, ip net.IP) (*Geolocation, error) {
+	var errs []string
+	for _, provider := range c {
+		geolocation, err := provider.Locate(ctx, nodeObj, ip)
+		if err == nil {
+			return geolocation, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("no location found for %s: %s", ip, strings.Join(errs, "; "))
+}
+
+// AnnotationProvider reads the location that the node is annotated with, which overrides the lookups by IP address
+type AnnotationProvider struct{}
+
+// Locate returns the location of the annotation
+func (AnnotationProvider) Locate(ctx context.Context, nodeObj *corev1.Node, ip net.IP) (*Geolocation, error) {
+	content, ok := "", false
+	if nodeObj != nil {
+		content, ok = nodeObj.Annotations[LocationAnnotation]
+	}
+	if !ok {
+		return nil, fmt.Errorf("no %s annotation", LocationAnnotation)
+	}
+	geolocation := &Geolocation{}
+	if err := json.Unmarshal([]byte(content), geolocation); err != nil {
+		return nil, fmt.Errorf("malformed %s annotation: %s", LocationAnnotation, err)
+	}
+	geolocation.Source = SourceAnnotation
+	return geolocation, nil
+}
+
+// SetLocationAnnotation annotates the node with the location, which overrides the one the node would be looked up at,
+// or removes the annotation if the location is nil
+func SetLocationAnnotation(ctx context.Context, nodeName string, geolocation *Geolocation) error {
+	nodeObj, err := Clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	content := ""
+	if geolocation != nil {
+		contentBytes, err := json.Marshal(geolocation)
+		if err != nil {
+			return err
+		}
+		content = string(contentBytes)
+	}
+	if nodeObj.Annotations[LocationAnnotation] == content {
+		return nil
+	}
+	nodeCopy := nodeObj.DeepCopy()
+	if content == "" {
+		delete(nodeCopy.Annotations, LocationAnnotation)
+	} else {
+		if nodeCopy.Annotations == nil {
+			nodeCopy.Annotations = map[string]string{}
+		}
+		nodeCopy.Annotations[LocationAnnotation] = content
+	}
+	_, err = Clientset.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// DeclareLocation returns the location to annotate the node with out of the one its contributor declares. The provider
+// checks the declared country against the IP address and fills in the continent, as well as the time zone unless
+// the declared city is another one. It returns an error if the provider places the address in another country,
+// and the declaration as it is if the provider cannot locate the address, as there is nothing to check against.
+func DeclareLocation(ctx context.Context, provider GeolocationProvider, location *apps_v1alpha.DeclaredLocation, ip net.IP) (*Geolocation, error) {
+	latitude, longitude := location.Latitude, location.Longitude
+	declared := &Geolocation{Country: location.Country, City: location.City, Lat: &latitude, Lon: &longitude}
+	if ip == nil {
+		return declared, nil
+	}
+	inferred, err := provider.Locate(ctx, nil, ip)
+	if err != nil || inferred.Country == "" {
+		return declared, nil
+	}
+	if declared.Country != "" && !strings.EqualFold(inferred.Country, declared.Country) {
+		return nil, fmt.Errorf("%s places %s in %s rather than %s", inferred.Source, ip, inferred.Country, declared.Country)
+	}
+	declared.Country, declared.Continent = inferred.Country, inferred.Continent
+	if declared.City == "" || strings.EqualFold(declared.City, inferred.City) {
+		declared.TimeZone = inferred.TimeZone
+	}
+	return declared, nil
+}
+
+// GeoLiteProvider looks the IP address up in a GeoLite2 database, which it opens at the first lookup and keeps open
+// until the file is replaced
+type GeoLiteProvider struct {
+	database
+	source string
+}
+
+// NewGeoLiteCityProvider returns a provider of the GeoLite2-City database at the path
+func NewGeoLiteCityProvider(path string) *GeoLiteProvider {
+	return &GeoLiteProvider{database: database{path: path}, source: SourceGeoLiteCity}
+}
+
+// NewGeoLiteCountryProvider returns a provider of the GeoLite2-Country database at the path, whose locations
+// have no city, state, or coordinates
+func NewGeoLiteCountryProvider(path string) *GeoLiteProvider {
+	return &GeoLiteProvider{database: database{path: path}, source: SourceGeoLiteCountry}
+}
+
+// Locate returns the location of the IP address in the database
+func (p *GeoLiteProvider) Locate(ctx context.Context, nodeObj *corev1.Node, ip net.IP) (*Geolocation, error) {
+	geolocation := &Geolocation{Source: p.source}
+	err := p.lookup(func(reader *geoip2.Reader) error {
+		if p.source == SourceGeoLiteCountry {
+			record, err := reader.Country(ip)
+			if err != nil {
+				return err
+			}
+			geolocation.Continent, geolocation.Country = record.Continent.Names["en"], record.Country.IsoCode
+			return nil
+		}
+		record, err := reader.City(ip)
+		if err != nil {
+			return err
+		}
+		// Zero coordinates typically mean there isn't any result meaningful
+		if record.Location.Longitude != 0 || record.Location.Latitude != 0 {
+			geolocation.Lat, geolocation.Lon = &record.Location.Latitude, &record.Location.Longitude
+		}
+		geolocation.Continent, geolocation.Country = record.Continent.Names["en"], record.Country.IsoCode
+		geolocation.City, geolocation.TimeZone = record.City.Names["en"], record.Location.TimeZone
+		if len(record.Subdivisions) > 0 {
+			geolocation.State = record.Subdivisions[0].IsoCode
+		}
+		if geolocation.Lat == nil {
+			return fmt.Errorf("%s has no coordinates for %s", p.source, ip)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if geolocation.Country == "" {
+		return nil, fmt.Errorf("%s has no country for %s", p.source, ip)
+	}
+	return geolocation, nil
+}
+
+// cidrLocation is the location of the addresses of a network
+type cidrLocation struct {
+	network     *net.IPNet
+	geolocation Geolocation
+}
+
+// CIDRProvider locates the addresses of the networks that a static CSV file lists, such as the private networks that
+// the GeoLite2 databases know nothing of. The file is read at the first lookup, and the most specific network wins.
+type CIDRProvider struct {
+	path      string
+	once      sync.Once
+	err       error
+	locations []cidrLocation
+}
+
+// NewCIDRProvider returns a provider of the networks in the CSV file at the path
+func NewCIDRProvider(path string) *CIDRProvider {
+	return &CIDRProvider{path: path}
+}
+
+// Locate returns the location of the most specific network that the IP address is in
+func (p *CIDRProvider) Locate(ctx context.Context, nodeObj *corev1.Node, ip net.IP) (*Geolocation, error) {
+	p.once.Do(func() {
+		var file *os.File
+		if file, p.err = os.Open(p.path); p.err == nil {
+			defer file.Close()
+			p.locations, p.err = parseCIDRLocations(file)
+		}
+	})
+	if p.err != nil {
+		return nil, p.err
+	}
+	var match *cidrLocation
+	for i, location := range p.locations {
+		if !location.network.Contains(ip) {
+			continue
+		}
+		if match == nil || maskSize(location.network) > maskSize(match.network) {
+			match = &p.locations[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no network of %s contains %s", p.path, ip)
+	}
+	geolocation := match.geolocation
+	geolocation.Source = SourcePrivateNetworks
+	return &geolocation, nil
+}
+
+// maskSize returns the number of the leading ones in the mask of the network
+func maskSize(network *net.IPNet) int {
+	ones, _ := network.Mask.Size()
+	return ones
+}
+
+// parseCIDRLocations reads the networks of the CSV content, whose columns are the network in CIDR notation, the continent,
+// the country and the state ISO codes, the city, the latitude, the longitude, and the time zone. The lines starting
+// with # are comments, and the fields after the network may be empty.
+func parseCIDRLocations(content io.Reader) ([]cidrLocation, error) {
+	reader := csv.NewReader(content)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 8
+	reader.TrimLeadingSpace = true
+	var locations []cidrLocation
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return locations, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		_, network, err := net.ParseCIDR(record[0])
+		if err != nil {
+			return nil, err
+		}
+		location := cidrLocation{network: network, geolocation: Geolocation{Continent: record[1], Country: record[2],
+			State: record[3], City: record[4], TimeZone: record[7]}}
+		if record[5] != "" || record[6] != "" {
+			lat, err := strconv.ParseFloat(record[5], 64)
+			if err != nil {
+				return nil, err
+			}
+			lon, err := strconv.ParseFloat(record[6], 64)
+			if err != nil {
+				return nil, err
+			}
+			location.geolocation.Lat, location.geolocation.Lon = &lat, &lon
+		}
+		locations = append(locations, location)
+	}
+}
+
+var (
+	defaultProvider     GeolocationProvider
+	defaultProviderOnce sync.Once
+)
+
+// DefaultGeolocationProvider returns the chain of the annotation, the GeoLite2-City and the GeoLite2-Country
+// databases, and the private networks, whose paths the flags set. The chain is made once, so the databases stay open
+// until their files are replaced.
+func DefaultGeolocationProvider() GeolocationProvider {
+	defaultProviderOnce.Do(func() {
+		defaultProvider = ProviderChain{
+			AnnotationProvider{},
+			NewGeoLiteCityProvider(flagPath("geolite-path", "../../assets/database/GeoLite2-City/GeoLite2-City.mmdb")),
+			NewGeoLiteCountryProvider(flagPath("geolite-country-path", "../../assets/database/GeoLite2-Country/GeoLite2-Country.mmdb")),
+			NewCIDRProvider(flagPath("private-networks-path", "../../assets/database/private-networks.csv")),
+		}
+	})
+	return defaultProvider
+}
+
+// SetGeolocationFlags defines the flags of the paths that the default providers read, in the order of the chain,
+// and the one of the GeoLite2-ASN database
+func SetGeolocationFlags() {
+	flag.String("geolite-path", "", "path of the GeoLite2-City database")
+	flag.String("geolite-country-path", "", "path of the GeoLite2-Country database")
+	flag.String("private-networks-path", "", "path of the CSV file that maps the private networks to their locations")
+	flag.String("geolite-asn-path", "", "path of the GeoLite2-ASN database")
+}
+
+// flagPath returns the value of the flag if it is set, or the path otherwise
+func flagPath(name, path string) string {
+	if flag.Lookup(name) != nil {
+		if value := flag.Lookup(name).Value.(flag.Getter).Get().(string); value != "" {
+			return value
+		}
+	}
+	return path
+}
+
+// Geolocate looks the node up by its external IP address, or else by the internal one, and labels it
+// with the location found along with its source
+func Geolocate(ctx context.Context, provider GeolocationProvider, nodeObj *corev1.Node) (*Geolocation, error) {
+	internalIP, externalIP := GetNodeIPAddresses(nodeObj)
+	var ips []net.IP
+	for _, ipStr := range []string{externalIP, internalIP} {
+		if ipStr != "" {
+			ips = append(ips, net.ParseIP(ipStr))
+		}
+	}
+	if len(ips) == 0 {
+		// The annotation may locate a node that has no address yet
+		ips = append(ips, nil)
+	}
+	var errs []string
+	for _, ip := range ips {
+		geolocation, err := provider.Locate(ctx, nodeObj, ip)
+		if err == nil {
+			return geolocation, SetGeolocation(ctx, nodeObj.GetName(), geolocation)
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("node %s: %s", nodeObj.GetName(), strings.Join(errs, "; "))
+}
+
+// SetGeolocation replaces the geographic labels of the node with those of the location
+func SetGeolocation(ctx context.Context, nodeName string, geolocation *Geolocation) error {
+	return replaceLabels(ctx, nodeName, geoLabelKeys, geolocation.Labels())
+}
+
+// replaceLabels removes the labels of the keys from the node and adds the labels, the node is updated
+// only if its labels change
+func replaceLabels(ctx context.Context, nodeName string, keys []string, labels map[string]string) error {
+	nodeObj, err := Clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	nodeCopy := nodeObj.DeepCopy()
+	if nodeCopy.Labels == nil {
+		nodeCopy.Labels = map[string]string{}
+	}
+	for _, key := range keys {
+		delete(nodeCopy.Labels, key)
+	}
+	for key, value := range labels {
+		nodeCopy.Labels[key] = value
+	}
+	if reflect.DeepEqual(nodeObj.Labels, nodeCopy.Labels) {
+		return nil
+	}
+	_, err = Clientset.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{})
+	return err
+}
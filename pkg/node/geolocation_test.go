@@ -0,0 +1,136 @@
+package node
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const privateNetworks = `# network,continent,country,state,city,latitude,longitude,timezone
+10.0.0.0/8,Europe,FR,,,,,
+10.1.0.0/16,Europe,FR,IDF,Paris,48.8607,2.3281,Europe/Paris
+`
+
+func TestProviderChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geolocation")
+	util.OK(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "private-networks.csv")
+	util.OK(t, ioutil.WriteFile(path, []byte(privateNetworks), 0644))
+	chain := ProviderChain{
+		AnnotationProvider{},
+		NewGeoLiteCityProvider(filepath.Join(dir, "missing.mmdb")),
+		NewCIDRProvider(path),
+	}
+	annotated := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		LocationAnnotation: `{"country":"US","city":"College Park","lat":38.9896,"lon":-76.9457}`}}}
+	lat, lon := 48.8607, 2.3281
+	usLat, usLon := 38.9896, -76.9457
+
+	cases := map[string]struct {
+		node     *corev1.Node
+		ip       string
+		expected *Geolocation
+	}{
+		"annotation": {annotated, "10.1.2.3", &Geolocation{Country: "US", City: "College Park", Lat: &usLat, Lon: &usLon, Source: SourceAnnotation}},
+		"specific network": {&corev1.Node{}, "10.1.2.3", &Geolocation{Continent: "Europe", Country: "FR", State: "IDF", City: "Paris",
+			TimeZone: "Europe/Paris", Lat: &lat, Lon: &lon, Source: SourcePrivateNetworks}},
+		"network":     {&corev1.Node{}, "10.2.2.3", &Geolocation{Continent: "Europe", Country: "FR", Source: SourcePrivateNetworks}},
+		"not located": {&corev1.Node{}, "192.168.1.1", nil},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			geolocation, err := chain.Locate(context.TODO(), tc.node, net.ParseIP(tc.ip))
+			util.Equals(t, tc.expected, geolocation)
+			util.Equals(t, tc.expected == nil, err != nil)
+		})
+	}
+}
+
+func TestGeolocationLabels(t *testing.T) {
+	lat, lon := 38.9896, -76.9457
+	geolocation := Geolocation{Continent: "North America", Country: "US", City: "College Park", TimeZone: "America/New_York",
+		Lat: &lat, Lon: &lon, Source: SourceAnnotation}
+	expected := map[string]string{
+		"edge-net.io/continent":   "North_America",
+		"edge-net.io/country-iso": "US",
+		"edge-net.io/state-iso":   "US",
+		"edge-net.io/city":        "College_Park",
+		"edge-net.io/lat":         "n38.989600",
+		"edge-net.io/lon":         "w-76.945700",
+		"edge-net.io/timezone":    "America.New_York",
+		GeolocationSourceLabel:    SourceAnnotation,
+		LocationDeclaredLabel:     "true",
+	}
+	util.Equals(t, expected, geolocation.Labels())
+}
+
+func TestGeolocate(t *testing.T) {
+	g := testGroup{}
+	g.Init()
+	nodeObj := g.nodeObj.DeepCopy()
+	nodeObj.SetName("ple.edge-net.io")
+	// The labels of the former location go away along with it
+	nodeObj.SetLabels(map[string]string{"kubernetes.io/hostname": "ple.edge-net.io", "edge-net.io/city": "Paris"})
+	nodeObj.SetAnnotations(map[string]string{LocationAnnotation: `{"continent":"Europe","country":"FR"}`})
+	nodeObj.Status.Addresses = []corev1.NodeAddress{{Type: "InternalIP", Address: "10.1.2.3"}}
+	g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj, metav1.CreateOptions{})
+
+	geolocation, err := Geolocate(context.TODO(), ProviderChain{AnnotationProvider{}}, nodeObj)
+	util.OK(t, err)
+	util.Equals(t, SourceAnnotation, geolocation.Source)
+	nodeCopy, _ := g.client.CoreV1().Nodes().Get(context.TODO(), nodeObj.GetName(), metav1.GetOptions{})
+	util.Equals(t, map[string]string{
+		"kubernetes.io/hostname":  "ple.edge-net.io",
+		"edge-net.io/continent":   "Europe",
+		"edge-net.io/country-iso": "FR",
+		"edge-net.io/state-iso":   "FR",
+		GeolocationSourceLabel:    SourceAnnotation,
+		LocationDeclaredLabel:     "true",
+	}, nodeCopy.GetLabels())
+
+	// A lookup error is returned rather than ending the labeler
+	nodeObj.SetAnnotations(nil)
+	_, err = Geolocate(context.TODO(), ProviderChain{AnnotationProvider{}, NewGeoLiteCountryProvider("missing.mmdb")}, nodeObj)
+	util.Equals(t, true, err != nil)
+}
+
+func TestDeclareLocation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "geolocation")
+	util.OK(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "private-networks.csv")
+	util.OK(t, ioutil.WriteFile(path, []byte(privateNetworks), 0644))
+	provider := NewCIDRProvider(path)
+	lat, lon := 48.8566, 2.3522
+
+	cases := map[string]struct {
+		location apps_v1alpha.DeclaredLocation
+		ip       string
+		expected *Geolocation
+	}{
+		"same city": {apps_v1alpha.DeclaredLocation{Latitude: lat, Longitude: lon, City: "Paris", Country: "FR"}, "10.1.2.3",
+			&Geolocation{Continent: "Europe", Country: "FR", City: "Paris", TimeZone: "Europe/Paris", Lat: &lat, Lon: &lon}},
+		"other city": {apps_v1alpha.DeclaredLocation{Latitude: lat, Longitude: lon, City: "Lyon"}, "10.1.2.3",
+			&Geolocation{Continent: "Europe", Country: "FR", City: "Lyon", Lat: &lat, Lon: &lon}},
+		"not located": {apps_v1alpha.DeclaredLocation{Latitude: lat, Longitude: lon, Country: "US"}, "192.168.1.1",
+			&Geolocation{Country: "US", Lat: &lat, Lon: &lon}},
+		"other country": {apps_v1alpha.DeclaredLocation{Latitude: lat, Longitude: lon, Country: "US"}, "10.1.2.3", nil},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			geolocation, err := DeclareLocation(context.TODO(), provider, &tc.location, net.ParseIP(tc.ip))
+			util.Equals(t, tc.expected, geolocation)
+			util.Equals(t, tc.expected == nil, err != nil)
+		})
+	}
+}
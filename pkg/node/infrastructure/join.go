@@ -177,6 +177,31 @@ func SetHostname(client *namecheap.Client, hostRecord namecheap.DomainDNSHost) (
 	return true, ""
 }
 
+// RemoveHostname deletes the host record of the hostname, it does nothing if there is no such record
+func RemoveHostname(client *namecheap.Client, hostname string) (bool, string) {
+	hostList := getHosts(client)
+	hosts := []namecheap.DomainDNSHost{}
+	for _, host := range hostList.Hosts {
+		if host.Name != hostname {
+			hosts = append(hosts, host)
+		}
+	}
+	if len(hosts) == len(hostList.Hosts) {
+		return true, ""
+	}
+
+	setResponse, err := client.DomainDNSSetHosts("edge-net", "io", hosts)
+	if err != nil {
+		log.Println(err.Error())
+		log.Printf("Remove host failed: %s", hostname)
+		return false, "failed"
+	} else if setResponse.IsSuccess == false {
+		log.Printf("Remove host unknown problem: %s", hostname)
+		return false, "unknown"
+	}
+	return true, ""
+}
+
 // encodeTokenSecretData takes the token discovery object and an optional duration and returns the .Data for the Secret
 // now is passed in order to be able to used in unit testing
 func encodeTokenSecretData(token *kubeadmtypes.BootstrapToken, now time.Time) map[string][]byte {
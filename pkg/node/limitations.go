@@ -0,0 +1,177 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The marks of the nodes whose contributions limit them to some authorities, teams, or slices
+const (
+	// LimitedKey is the key of the taint that keeps the pods off a limited node, and of the label that tells it apart
+	LimitedKey = "edge-net.io/limited"
+	// LimitationsAnnotation holds the limitations of the node contribution in JSON
+	LimitationsAnnotation = "edge-net.io/limitations"
+	// DefaultTolerationsAnnotation holds the tolerations that the PodTolerationRestriction admission plugin adds to the
+	// pods of a namespace
+	DefaultTolerationsAnnotation = "scheduler.alpha.kubernetes.io/defaultTolerations"
+)
+
+// limitedTaintValue returns the value of the taint of a limited node, which tells the node apart so that a toleration
+// may let the pods on some of the limited nodes only
+func limitedTaintValue(nodeName string) string {
+	return geolabel.Encode(nodeName)
+}
+
+// SetLimitations taints, labels, and annotates the node according to the limitations of its contribution,
+// or clears the marks if there is none. The pods of the namespaces that the limitations permit need the toleration,
+// which DefaultTolerations gives.
+// The limitations with no field set are left out.
+func SetLimitations(ctx context.Context, nodeName string, limitations []apps_v1alpha.Limitations) error {
+	var set []apps_v1alpha.Limitations
+	for _, limitation := range limitations {
+		if limitation != (apps_v1alpha.Limitations{}) {
+			set = append(set, limitation)
+		}
+	}
+	nodeObj, err := Clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	nodeCopy := nodeObj.DeepCopy()
+	var taints []corev1.Taint
+	for _, taint := range nodeCopy.Spec.Taints {
+		if taint.Key != LimitedKey {
+			taints = append(taints, taint)
+		}
+	}
+	if nodeCopy.Labels == nil {
+		nodeCopy.Labels = map[string]string{}
+	}
+	if nodeCopy.Annotations == nil {
+		nodeCopy.Annotations = map[string]string{}
+	}
+	delete(nodeCopy.Labels, LimitedKey)
+	delete(nodeCopy.Annotations, LimitationsAnnotation)
+	if len(set) != 0 {
+		content, err := json.Marshal(set)
+		if err != nil {
+			return err
+		}
+		taints = append(taints, corev1.Taint{Key: LimitedKey, Value: limitedTaintValue(nodeName), Effect: corev1.TaintEffectNoSchedule})
+		nodeCopy.Labels[LimitedKey] = "true"
+		nodeCopy.Annotations[LimitationsAnnotation] = string(content)
+	}
+	nodeCopy.Spec.Taints = taints
+	if reflect.DeepEqual(nodeObj.Spec.Taints, nodeCopy.Spec.Taints) && nodeObj.Labels[LimitedKey] == nodeCopy.Labels[LimitedKey] &&
+		nodeObj.Annotations[LimitationsAnnotation] == nodeCopy.Annotations[LimitationsAnnotation] {
+		return nil
+	}
+	_, err = Clientset.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{})
+	return err
+}
+
+// GetLimitations returns the limitations that the node is annotated with, a node with none is open to all
+func GetLimitations(nodeObj *corev1.Node) ([]apps_v1alpha.Limitations, error) {
+	content, ok := nodeObj.Annotations[LimitationsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var limitations []apps_v1alpha.Limitations
+	if err := json.Unmarshal([]byte(content), &limitations); err != nil {
+		return nil, fmt.Errorf("node %s has malformed limitations: %s", nodeObj.GetName(), err)
+	}
+	return limitations, nil
+}
+
+// Permits returns whether the limitations let the pods of the namespace run on the node. A limitation permits the
+// namespaces that all of its fields match: those of the authority, those of the team along with its slices, and that
+// of the slice. Any of the limitations is enough, and no limitation at all permits every namespace.
+func Permits(limitations []apps_v1alpha.Limitations, namespace *corev1.Namespace) bool {
+	if len(limitations) == 0 {
+		return true
+	}
+	if namespace == nil {
+		return false
+	}
+	authorityName := namespace.Labels["authority-name"]
+	for _, limitation := range limitations {
+		if limitation.Authority != "" && limitation.Authority != authorityName {
+			continue
+		}
+		if limitation.Team != "" {
+			teamNamespace := fmt.Sprintf("authority-%s-team-%s", authorityName, limitation.Team)
+			if namespace.GetName() != teamNamespace && !strings.HasPrefix(namespace.GetName(), fmt.Sprintf("%s-slice-", teamNamespace)) {
+				continue
+			}
+		}
+		if limitation.Slice != "" && (namespace.Labels["owner"] != "slice" || namespace.Labels["owner-name"] != limitation.Slice) {
+			continue
+		}
+		if limitation != (apps_v1alpha.Limitations{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultTolerations returns the tolerations of the taints of the limited nodes among the nodes that the limitations
+// permit the namespace to use. Each toleration matches the taint of a single node, so the pods are kept off the limited
+// nodes that the namespace may not use.
+func DefaultTolerations(nodes []corev1.Node, namespace *corev1.Namespace) []corev1.Toleration {
+	var tolerations []corev1.Toleration
+	for _, nodeObj := range nodes {
+		if _, limited := nodeObj.Labels[LimitedKey]; !limited {
+			continue
+		}
+		limitations, err := GetLimitations(&nodeObj)
+		if err != nil || len(limitations) == 0 || !Permits(limitations, namespace) {
+			continue
+		}
+		tolerations = append(tolerations, corev1.Toleration{Key: LimitedKey, Operator: corev1.TolerationOpEqual,
+			Value: limitedTaintValue(nodeObj.GetName()), Effect: corev1.TaintEffectNoSchedule})
+	}
+	return tolerations
+}
+
+// SetDefaultTolerations annotates the namespace with the tolerations that its pods get by default, or clears
+// the annotation if there is none
+func SetDefaultTolerations(namespace *corev1.Namespace, tolerations []corev1.Toleration) error {
+	if len(tolerations) == 0 {
+		delete(namespace.Annotations, DefaultTolerationsAnnotation)
+		return nil
+	}
+	content, err := json.Marshal(tolerations)
+	if err != nil {
+		return err
+	}
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	namespace.Annotations[DefaultTolerationsAnnotation] = string(content)
+	return nil
+}
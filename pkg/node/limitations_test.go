@@ -0,0 +1,90 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	apps_v1alpha "github.com/EdgeNet-project/edgenet/pkg/apis/apps/v1alpha"
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPermits(t *testing.T) {
+	namespace := func(name, owner, ownerName string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name,
+			Labels: map[string]string{"owner": owner, "owner-name": ownerName, "authority-name": "edgenet"}}}
+	}
+	authority := namespace("authority-edgenet", "authority", "edgenet")
+	team := namespace("authority-edgenet-team-lab", "team", "lab")
+	teamSlice := namespace("authority-edgenet-team-lab-slice-experiment", "slice", "experiment")
+	slice := namespace("authority-edgenet-slice-experiment", "slice", "experiment")
+	cases := map[string]struct {
+		limitations []apps_v1alpha.Limitations
+		namespace   *corev1.Namespace
+		expected    bool
+	}{
+		"none":                 {nil, nil, true},
+		"authority":            {[]apps_v1alpha.Limitations{{Authority: "edgenet"}}, teamSlice, true},
+		"authority/other":      {[]apps_v1alpha.Limitations{{Authority: "ple"}}, authority, false},
+		"team":                 {[]apps_v1alpha.Limitations{{Authority: "edgenet", Team: "lab"}}, team, true},
+		"team/slice":           {[]apps_v1alpha.Limitations{{Team: "lab"}}, teamSlice, true},
+		"team/authority":       {[]apps_v1alpha.Limitations{{Team: "lab"}}, authority, false},
+		"slice":                {[]apps_v1alpha.Limitations{{Slice: "experiment"}}, slice, true},
+		"slice/team":           {[]apps_v1alpha.Limitations{{Slice: "experiment"}}, team, false},
+		"slice/any limitation": {[]apps_v1alpha.Limitations{{Slice: "other"}, {Slice: "experiment"}}, slice, true},
+		"unknown namespace":    {[]apps_v1alpha.Limitations{{Authority: "edgenet"}}, nil, false},
+		"empty limitation":     {[]apps_v1alpha.Limitations{{}}, authority, false},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			util.Equals(t, tc.expected, Permits(tc.limitations, tc.namespace))
+		})
+	}
+}
+
+func TestSetLimitations(t *testing.T) {
+	g := testGroup{}
+	g.Init()
+	nodeObj := g.nodeObj.DeepCopy()
+	nodeObj.SetName("ple.edge-net.io")
+	g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj, metav1.CreateOptions{})
+
+	limitations := []apps_v1alpha.Limitations{{Authority: "edgenet", Slice: "experiment"}, {}}
+	util.OK(t, SetLimitations(context.TODO(), nodeObj.GetName(), limitations))
+	nodeCopy, _ := g.client.CoreV1().Nodes().Get(context.TODO(), nodeObj.GetName(), metav1.GetOptions{})
+	util.Equals(t, []corev1.Taint{{Key: LimitedKey, Value: "ple.edge-net.io", Effect: corev1.TaintEffectNoSchedule}}, nodeCopy.Spec.Taints)
+	util.Equals(t, "true", nodeCopy.Labels[LimitedKey])
+	storedLimitations, err := GetLimitations(nodeCopy)
+	util.OK(t, err)
+	util.Equals(t, limitations[:1], storedLimitations)
+
+	util.OK(t, SetLimitations(context.TODO(), nodeObj.GetName(), nil))
+	nodeCopy, _ = g.client.CoreV1().Nodes().Get(context.TODO(), nodeObj.GetName(), metav1.GetOptions{})
+	util.Equals(t, 0, len(nodeCopy.Spec.Taints))
+	_, limited := nodeCopy.Labels[LimitedKey]
+	util.Equals(t, false, limited)
+}
+
+func TestDefaultTolerations(t *testing.T) {
+	limitedNode := func(name string, limitations string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{LimitedKey: "true"},
+			Annotations: map[string]string{LimitationsAnnotation: limitations}}}
+	}
+	nodes := []corev1.Node{
+		limitedNode("permitted.edge-net.io", `[{"slice":"experiment"}]`),
+		limitedNode("forbidden.edge-net.io", `[{"slice":"other"}]`),
+		{ObjectMeta: metav1.ObjectMeta{Name: "open.edge-net.io"}},
+	}
+	slice := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "authority-edgenet-slice-experiment",
+		Labels: map[string]string{"owner": "slice", "owner-name": "experiment", "authority-name": "edgenet"}}}
+	tolerations := DefaultTolerations(nodes, slice)
+	util.Equals(t, []corev1.Toleration{{Key: LimitedKey, Operator: corev1.TolerationOpEqual, Value: "permitted.edge-net.io", Effect: corev1.TaintEffectNoSchedule}}, tolerations)
+
+	util.OK(t, SetDefaultTolerations(slice, tolerations))
+	util.Equals(t, `[{"key":"edge-net.io/limited","operator":"Equal","value":"permitted.edge-net.io","effect":"NoSchedule"}]`, slice.Annotations[DefaultTolerationsAnnotation])
+	util.OK(t, SetDefaultTolerations(slice, nil))
+	_, annotated := slice.Annotations[DefaultTolerationsAnnotation]
+	util.Equals(t, false, annotated)
+}
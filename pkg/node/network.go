@@ -0,0 +1,171 @@
+/*
+Copyright 2020 Sorbonne Université
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This code includes GeoLite2 data created by MaxMind, available from
+// https://www.maxmind.com.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The labels of the network that the node is in
+const (
+	// ASNLabel holds the number of the autonomous system that announces the address of the node
+	ASNLabel = "edge-net.io/asn"
+	// ASOrgLabel holds the organization of the autonomous system
+	ASOrgLabel = "edge-net.io/as-org"
+	// IPFamilyLabel tells whether the address of the node is an IPv4 or an IPv6 one
+	IPFamilyLabel = "edge-net.io/ip-family"
+	// PublicIPLabel tells whether the address of the node is reachable from the Internet
+	PublicIPLabel = "edge-net.io/public-ip"
+)
+
+// networkLabelKeys are the labels that the network sets, a new one replaces all of them
+var networkLabelKeys = []string{ASNLabel, ASOrgLabel, IPFamilyLabel, PublicIPLabel}
+
+// nonPublicNetworks are the ranges of the addresses that are not routed on the Internet
+var nonPublicNetworks = parseNetworks("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7")
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, _ := net.ParseCIDR(cidr)
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// IsPublicIP returns whether the IP address is routed on the Internet, that is, neither private, shared, loopback,
+// nor link-local
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsUnspecified() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IPFamily returns ipv4 or ipv6 depending on the IP address
+func IPFamily(ip net.IP) string {
+	if ip.To4() != nil {
+		return "ipv4"
+	}
+	return "ipv6"
+}
+
+// ASNProvider looks the IP address up in a GeoLite2-ASN database, which it opens at the first lookup and keeps open
+// until the file is replaced
+type ASNProvider struct {
+	database
+}
+
+// NewASNProvider returns a provider of the GeoLite2-ASN database at the path
+func NewASNProvider(path string) *ASNProvider {
+	return &ASNProvider{database: database{path: path}}
+}
+
+// Lookup returns the number and the organization of the autonomous system that the IP address belongs to
+func (p *ASNProvider) Lookup(ip net.IP) (uint, string, error) {
+	var record *geoip2.ASN
+	err := p.lookup(func(reader *geoip2.Reader) error {
+		var err error
+		record, err = reader.ASN(ip)
+		return err
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	if record.AutonomousSystemNumber == 0 {
+		return 0, "", fmt.Errorf("no autonomous system found for %s", ip)
+	}
+	return record.AutonomousSystemNumber, record.AutonomousSystemOrganization, nil
+}
+
+var (
+	defaultASNProvider     *ASNProvider
+	defaultASNProviderOnce sync.Once
+)
+
+// DefaultASNProvider returns the provider of the GeoLite2-ASN database whose path the geolite-asn-path flag sets.
+// It is made once, so the database stays open until its file is replaced.
+func DefaultASNProvider() *ASNProvider {
+	defaultASNProviderOnce.Do(func() {
+		defaultASNProvider = NewASNProvider(flagPath("geolite-asn-path", "../../assets/database/GeoLite2-ASN/GeoLite2-ASN.mmdb"))
+	})
+	return defaultASNProvider
+}
+
+// NetworkLabels returns the labels of the network of the node, which go by its external IP address, or else
+// by the internal one. The autonomous system is looked up for a public address only, and the lookup error
+// is returned along with the other labels.
+func NetworkLabels(provider *ASNProvider, nodeObj *corev1.Node) (map[string]string, error) {
+	internalIP, externalIP := GetNodeIPAddresses(nodeObj)
+	ipStr := externalIP
+	if ipStr == "" {
+		ipStr = internalIP
+	}
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return map[string]string{}, fmt.Errorf("node %s has no IP address", nodeObj.GetName())
+	}
+	labels := map[string]string{IPFamilyLabel: IPFamily(ip), PublicIPLabel: strconv.FormatBool(IsPublicIP(ip))}
+	if !IsPublicIP(ip) {
+		return labels, nil
+	}
+	asn, organization, err := provider.Lookup(ip)
+	if err != nil {
+		return labels, fmt.Errorf("node %s: %s", nodeObj.GetName(), err)
+	}
+	labels[ASNLabel] = strconv.FormatUint(uint64(asn), 10)
+	if organization = geolabel.Encode(organization); organization != "" {
+		labels[ASOrgLabel] = organization
+	}
+	return labels, nil
+}
+
+// SetNetworkLabels replaces the network labels of the node with the labels
+func SetNetworkLabels(ctx context.Context, nodeName string, labels map[string]string) error {
+	return replaceLabels(ctx, nodeName, networkLabelKeys, labels)
+}
+
+// ParseASN returns the number of the autonomous system, which may come with the AS prefix as in AS2200
+func ParseASN(value string) (uint, error) {
+	number := strings.TrimSpace(value)
+	if len(number) > 2 && strings.EqualFold(number[:2], "AS") {
+		number = number[2:]
+	}
+	asn, err := strconv.ParseUint(number, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an autonomous system number", value)
+	}
+	return uint(asn), nil
+}
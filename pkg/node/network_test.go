@@ -0,0 +1,65 @@
+package node
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/EdgeNet-project/edgenet/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"132.227.123.51":  true,
+		"10.1.2.3":        false,
+		"172.20.0.1":      false,
+		"192.168.1.1":     false,
+		"100.64.0.1":      false,
+		"127.0.0.1":       false,
+		"169.254.1.1":     false,
+		"2001:660:3302::": true,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	}
+	for ip, expected := range cases {
+		t.Run(ip, func(t *testing.T) {
+			util.Equals(t, expected, IsPublicIP(net.ParseIP(ip)))
+		})
+	}
+}
+
+func TestParseASN(t *testing.T) {
+	for _, value := range []string{"2200", "AS2200", "as2200"} {
+		asn, err := ParseASN(value)
+		util.OK(t, err)
+		util.Equals(t, uint(2200), asn)
+	}
+	_, err := ParseASN("RENATER")
+	util.Equals(t, true, err != nil)
+}
+
+func TestSetNetworkLabels(t *testing.T) {
+	g := testGroup{}
+	g.Init()
+	nodeObj := g.nodeObj.DeepCopy()
+	nodeObj.SetName("ple.edge-net.io")
+	nodeObj.SetLabels(map[string]string{"kubernetes.io/hostname": "ple.edge-net.io", ASNLabel: "2200", ASOrgLabel: "Renater"})
+	nodeObj.Status.Addresses = []corev1.NodeAddress{{Type: "InternalIP", Address: "10.1.2.3"}}
+	g.client.CoreV1().Nodes().Create(context.TODO(), nodeObj, metav1.CreateOptions{})
+
+	// The autonomous system of a private address is not looked up, and that of the former address goes away
+	labels, err := NetworkLabels(NewASNProvider("missing.mmdb"), nodeObj)
+	util.OK(t, err)
+	util.OK(t, SetNetworkLabels(context.TODO(), nodeObj.GetName(), labels))
+	nodeCopy, _ := g.client.CoreV1().Nodes().Get(context.TODO(), nodeObj.GetName(), metav1.GetOptions{})
+	util.Equals(t, map[string]string{"kubernetes.io/hostname": "ple.edge-net.io", IPFamilyLabel: "ipv4", PublicIPLabel: "false"}, nodeCopy.GetLabels())
+
+	// The other labels remain when the database cannot be read
+	nodeObj.Status.Addresses = append(nodeObj.Status.Addresses, corev1.NodeAddress{Type: "ExternalIP", Address: "2001:660:3302::"})
+	labels, err = NetworkLabels(NewASNProvider("missing.mmdb"), nodeObj)
+	util.Equals(t, true, err != nil)
+	util.Equals(t, map[string]string{IPFamilyLabel: "ipv6", PublicIPLabel: "true"}, labels)
+}
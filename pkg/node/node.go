@@ -22,19 +22,17 @@ package node
 import (
 	"context"
 	"encoding/json"
-	"flag"
 	"fmt"
 	"log"
 	"math"
 	"net"
-	"strings"
 	"time"
 
 	"github.com/EdgeNet-project/edgenet/pkg/bootstrap"
+	"github.com/EdgeNet-project/edgenet/pkg/geolabel"
 	"github.com/EdgeNet-project/edgenet/pkg/node/infrastructure"
 
 	namecheap "github.com/billputer/go-namecheap"
-	geoip2 "github.com/oschwald/geoip2-golang"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,11 +41,6 @@ import (
 )
 
 // JSON structure of patch operation
-type patchStringValue struct {
-	Op    string `json:"op"`
-	Path  string `json:"path"`
-	Value string `json:"value"`
-}
 type patchByBoolValue struct {
 	Op    string `json:"op"`
 	Path  string `json:"path"`
@@ -103,8 +96,8 @@ func Boundbox(points [][]float64) []float64 {
 }
 
 // GetKubeletVersion looks at the head node to decide which version of Kubernetes to install
-func GetKubeletVersion() string {
-	nodeRaw, err := Clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/master"})
+func GetKubeletVersion(ctx context.Context) string {
+	nodeRaw, err := Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/master"})
 	if err != nil {
 		log.Println(err.Error())
 	}
@@ -116,7 +109,7 @@ func GetKubeletVersion() string {
 }
 
 // SetOwnerReferences make the references owner of the node
-func SetOwnerReferences(nodeName string, ownerReferences []metav1.OwnerReference) error {
+func SetOwnerReferences(ctx context.Context, nodeName string, ownerReferences []metav1.OwnerReference) error {
 	// Create a patch slice and initialize it to the size of 1
 	// Append the data existing in the label map to the slice
 	nodePatchArr := make([]interface{}, 1)
@@ -128,12 +121,12 @@ func SetOwnerReferences(nodeName string, ownerReferences []metav1.OwnerReference
 	nodePatchJSON, _ := json.Marshal(nodePatchArr)
 	// Patch the nodes with the arguments:
 	// hostname, patch type, and patch data
-	_, err := Clientset.CoreV1().Nodes().Patch(context.TODO(), nodeName, types.JSONPatchType, nodePatchJSON, metav1.PatchOptions{})
+	_, err := Clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.JSONPatchType, nodePatchJSON, metav1.PatchOptions{})
 	return err
 }
 
 // SetNodeScheduling syncs the node with the node contribution
-func SetNodeScheduling(nodeName string, unschedulable bool) error {
+func SetNodeScheduling(ctx context.Context, nodeName string, unschedulable bool) error {
 	// Create a patch slice and initialize it to the size of 1
 	nodePatchArr := make([]interface{}, 1)
 	nodePatch := patchByBoolValue{}
@@ -144,101 +137,37 @@ func SetNodeScheduling(nodeName string, unschedulable bool) error {
 	nodePatchJSON, _ := json.Marshal(nodePatchArr)
 	// Patch the nodes with the arguments:
 	// hostname, patch type, and patch data
-	_, err := Clientset.CoreV1().Nodes().Patch(context.TODO(), nodeName, types.JSONPatchType, nodePatchJSON, metav1.PatchOptions{})
+	_, err := Clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.JSONPatchType, nodePatchJSON, metav1.PatchOptions{})
 	return err
 }
 
-// setNodeLabels uses client-go to patch nodes by processing a labels map
-func setNodeLabels(hostname string, labels map[string]string) bool {
-	// Create a patch slice and initialize it to the label size
-	nodePatchArr := make([]patchStringValue, len(labels))
-	nodePatch := patchStringValue{}
-	row := 0
-	// Append the data existing in the label map to the slice
-	for label, value := range labels {
-		nodePatch.Op = "add"
-		nodePatch.Path = fmt.Sprintf("/metadata/labels/%s", label)
-		nodePatch.Value = value
-		nodePatchArr[row] = nodePatch
-		row++
-	}
-	nodesJSON, _ := json.Marshal(nodePatchArr)
-	// Patch the nodes with the arguments:
-	// hostname, patch type, and patch data
-	_, err := Clientset.CoreV1().Nodes().Patch(context.TODO(), hostname, types.JSONPatchType, nodesJSON, metav1.PatchOptions{})
+// GetGeolocationByIP labels the node with the location that the default providers find for the IP address.
+// It returns false if no provider finds the coordinates of the node, or if the labels cannot be set.
+func GetGeolocationByIP(ctx context.Context, hostname string, ipStr string) bool {
+	nodeObj, err := Clientset.CoreV1().Nodes().Get(ctx, hostname, metav1.GetOptions{})
 	if err != nil {
 		log.Println(err.Error())
-		panic(err.Error())
-	}
-	return true
-}
-
-// GetGeolocationByIP return geolabels by taking advantage of GeoLite database
-func GetGeolocationByIP(hostname string, ipStr string) bool {
-	// Parse IP address
-	ip := net.ParseIP(ipStr)
-	var pathDB string
-	if flag.Lookup("geolite-path") != nil {
-		pathDB = flag.Lookup("geolite-path").Value.(flag.Getter).Get().(string)
-	}
-	if pathDB == "" {
-		pathDB = "../../assets/database/GeoLite2-City/GeoLite2-City.mmdb"
-	}
-	// Open GeoLite database
-	db, err := geoip2.Open(pathDB)
-	if err != nil {
-		log.Fatal(err)
 		return false
 	}
-	// Close the database as a final job
-	defer db.Close()
-	// Get the geolocation information by IP
-	record, err := db.City(ip)
+	geolocation, err := DefaultGeolocationProvider().Locate(ctx, nodeObj, net.ParseIP(ipStr))
 	if err != nil {
-		log.Fatal(err)
+		log.Println(err.Error())
 		return false
 	}
-
-	// Patch for being compatible with Kubernetes alphanumeric characters limitations
-	continent := strings.Replace(record.Continent.Names["en"], " ", "_", -1)
-	country := record.Country.IsoCode
-	state := record.Country.IsoCode
-	city := strings.Replace(record.City.Names["en"], " ", "_", -1)
-	var lon string
-	var lat string
-	if record.Location.Longitude >= 0 {
-		lon = fmt.Sprintf("e%.6f", record.Location.Longitude)
-	} else {
-		lon = fmt.Sprintf("w%.6f", record.Location.Longitude)
-	}
-	if record.Location.Latitude >= 0 {
-		lat = fmt.Sprintf("n%.6f", record.Location.Latitude)
-	} else {
-		lat = fmt.Sprintf("s%.6f", record.Location.Latitude)
-	}
-	if len(record.Subdivisions) > 0 {
-		state = record.Subdivisions[0].IsoCode
-	}
-
-	// Create label map to attach to the node
-	geoLabels := map[string]string{
-		"edge-net.io~1continent":   continent,
-		"edge-net.io~1country-iso": country,
-		"edge-net.io~1state-iso":   state,
-		"edge-net.io~1city":        city,
-		"edge-net.io~1lon":         lon,
-		"edge-net.io~1lat":         lat,
+	if err := SetGeolocation(ctx, hostname, geolocation); err != nil {
+		log.Println(err.Error())
+		return false
 	}
+	return geolocation.Lat != nil && geolocation.Lon != nil
+}
 
-	// Attach geolabels to the node
-	result := setNodeLabels(hostname, geoLabels)
-	// If the result is different than the expected, return false
-	// The expected result is having a different longitude and latitude than zero
-	// Zero value typically means there isn't any result meaningful
-	if record.Location.Longitude == 0 && record.Location.Latitude == 0 {
-		return false
+// GetTimeZone returns the time zone of the node, which the geolocation labels the node with
+func GetTimeZone(nodeObj *corev1.Node) (*time.Location, error) {
+	timezone := nodeObj.Labels[geolabel.TimeZone]
+	if timezone == "" {
+		return nil, fmt.Errorf("node %s has no time zone label", nodeObj.GetName())
 	}
-	return result
+	return time.LoadLocation(geolabel.DecodeTimeZone(timezone))
 }
 
 // CompareIPAddresses makes a comparison between old and new objects of the node
@@ -291,6 +220,17 @@ func SetHostname(hostRecord namecheap.DomainDNSHost) (bool, string) {
 	return result, state
 }
 
+// RemoveHostname deletes the host record of the node from the DNS
+func RemoveHostname(hostname string) (bool, string) {
+	client, err := bootstrap.CreateNamecheapClient()
+	if err != nil {
+		log.Println(err.Error())
+		return false, "Unknown"
+	}
+	result, state := infrastructure.RemoveHostname(client, hostname)
+	return result, state
+}
+
 // CreateJoinToken generates token to be used on adding a node onto the cluster
 func CreateJoinToken(ttl string, hostname string) string {
 	duration, _ := time.ParseDuration(ttl)
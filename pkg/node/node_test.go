@@ -537,6 +537,7 @@ func TestGeolocationByIP(t *testing.T) {
 		"edge-net.io/city":        "Paris",
 		"edge-net.io/lat":         "n48.860700",
 		"edge-net.io/lon":         "e2.328100",
+		"edge-net.io/timezone":    "Europe.Paris",
 	}
 	nodeUS := g.nodeObj
 	nodeUS.ObjectMeta = metav1.ObjectMeta{
@@ -558,6 +559,7 @@ func TestGeolocationByIP(t *testing.T) {
 		"edge-net.io/city":        "College_Park",
 		"edge-net.io/lat":         "n38.989600",
 		"edge-net.io/lon":         "w-76.945700",
+		"edge-net.io/timezone":    "America.New_York",
 	}
 
 	cases := map[string]struct {
@@ -574,10 +576,10 @@ func TestGeolocationByIP(t *testing.T) {
 			internalIP, externalIP := GetNodeIPAddresses(tc.Node.DeepCopy())
 			result := false
 			if externalIP != "" {
-				result = GetGeolocationByIP(tc.Node.GetName(), externalIP)
+				result = GetGeolocationByIP(context.TODO(), tc.Node.GetName(), externalIP)
 			}
 			if internalIP != "" && result == false {
-				GetGeolocationByIP(tc.Node.GetName(), internalIP)
+				GetGeolocationByIP(context.TODO(), tc.Node.GetName(), internalIP)
 			}
 			node, _ := g.client.CoreV1().Nodes().Get(context.TODO(), tc.Node.GetName(), metav1.GetOptions{})
 			if !reflect.DeepEqual(node.Labels, tc.Expected) {
@@ -610,7 +612,7 @@ func TestSetOwnerReferences(t *testing.T) {
 	g.client.CoreV1().Nodes().Create(context.TODO(), node1.DeepCopy(), metav1.CreateOptions{})
 	g.client.CoreV1().Nodes().Create(context.TODO(), node2.DeepCopy(), metav1.CreateOptions{})
 
-	err := SetOwnerReferences(node1.GetName(), ownerReferences)
+	err := SetOwnerReferences(context.TODO(), node1.GetName(), ownerReferences)
 	util.OK(t, err)
 
 	node, err := g.client.CoreV1().Nodes().Get(context.TODO(), node1.GetName(), metav1.GetOptions{})
@@ -637,7 +639,7 @@ func TestSetNodeScheduling(t *testing.T) {
 
 	for k, tc := range cases {
 		t.Run(fmt.Sprintf("%s", k), func(t *testing.T) {
-			err := SetNodeScheduling(node1.GetName(), tc.input)
+			err := SetNodeScheduling(context.TODO(), node1.GetName(), tc.input)
 			util.OK(t, err)
 			node, err := g.client.CoreV1().Nodes().Get(context.TODO(), node1.GetName(), metav1.GetOptions{})
 			util.OK(t, err)
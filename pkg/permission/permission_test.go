@@ -122,7 +122,7 @@ func TestCreateClusterRoles(t *testing.T) {
 	}
 	for k, tc := range cases {
 		t.Run(k, func(t *testing.T) {
-			err := CreateClusterRoles(tc.authority.DeepCopy())
+			err := CreateClusterRoles(context.TODO(), tc.authority.DeepCopy())
 			util.OK(t, err)
 			_, err = g.client.RbacV1().ClusterRoles().Get(context.TODO(), tc.expected, metav1.GetOptions{})
 			util.OK(t, err)
@@ -180,7 +180,7 @@ func TestEstablishPrivateRoleBindings(t *testing.T) {
 	}
 	for k, tc := range cases {
 		t.Run(k, func(t *testing.T) {
-			err := EstablishPrivateRoleBindings(tc.user.DeepCopy())
+			err := EstablishPrivateRoleBindings(context.TODO(), tc.user.DeepCopy())
 			util.OK(t, err)
 			for i, bindingName := range tc.expected {
 				if tc.bindingType[i] == "Role" {
@@ -234,7 +234,7 @@ func TestEstablishRoleBindings(t *testing.T) {
 	}
 	for k, tc := range cases {
 		t.Run(k, func(t *testing.T) {
-			err := EstablishRoleBindings(tc.user.DeepCopy(), tc.user.GetNamespace(), tc.namespaceType)
+			err := EstablishRoleBindings(context.TODO(), tc.user.DeepCopy(), tc.user.GetNamespace(), tc.namespaceType)
 			util.OK(t, err)
 			_, err = g.client.RbacV1().RoleBindings(tc.user.GetNamespace()).Get(context.TODO(), tc.expected, metav1.GetOptions{})
 			util.OK(t, err)
@@ -257,106 +257,106 @@ func TestPermissionSystem(t *testing.T) {
 	user3.Status.Type = "user"
 
 	t.Run("create authority admin role", func(t *testing.T) {
-		err := CreateAuthorityAdminRole()
+		err := CreateAuthorityAdminRole(context.TODO())
 		util.OK(t, err)
 	})
 	t.Run("update existing authority admin role", func(t *testing.T) {
-		err := CreateAuthorityAdminRole()
+		err := CreateAuthorityAdminRole(context.TODO())
 		util.OK(t, err)
 	})
 	t.Run("create authority user role", func(t *testing.T) {
-		err := CreateAuthorityUserRole()
+		err := CreateAuthorityUserRole(context.TODO())
 		util.OK(t, err)
 	})
 	t.Run("update existing authority user role", func(t *testing.T) {
-		err := CreateAuthorityUserRole()
+		err := CreateAuthorityUserRole(context.TODO())
 		util.OK(t, err)
 	})
 	t.Run("create slice roles", func(t *testing.T) {
-		err := CreateSliceRoles()
+		err := CreateSliceRoles(context.TODO())
 		util.OK(t, err)
 	})
 	t.Run("update existing slice roles", func(t *testing.T) {
-		err := CreateSliceRoles()
+		err := CreateSliceRoles(context.TODO())
 		util.OK(t, err)
 	})
 	t.Run("create team roles", func(t *testing.T) {
-		err := CreateTeamRoles()
+		err := CreateTeamRoles(context.TODO())
 		util.OK(t, err)
 	})
 	t.Run("update existing team roles", func(t *testing.T) {
-		err := CreateTeamRoles()
+		err := CreateTeamRoles(context.TODO())
 		util.OK(t, err)
 	})
 
-	err := CreateClusterRoles(g.authorityObj.DeepCopy())
+	err := CreateClusterRoles(context.TODO(), g.authorityObj.DeepCopy())
 	util.OK(t, err)
 
 	t.Run("create user specific role", func(t *testing.T) {
-		err := CreateUserSpecificRole(user1.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
+		err := CreateUserSpecificRole(context.TODO(), user1.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserSpecificRole(user2.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
+		err = CreateUserSpecificRole(context.TODO(), user2.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserSpecificRole(user3.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
+		err = CreateUserSpecificRole(context.TODO(), user3.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
 		util.OK(t, err)
 	})
 	t.Run("update existing user specific role", func(t *testing.T) {
-		err := CreateUserSpecificRole(user1.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
+		err := CreateUserSpecificRole(context.TODO(), user1.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserSpecificRole(user2.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
+		err = CreateUserSpecificRole(context.TODO(), user2.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserSpecificRole(user3.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
+		err = CreateUserSpecificRole(context.TODO(), user3.DeepCopy(), &g.namespace, []metav1.OwnerReference{})
 		util.OK(t, err)
 	})
 	t.Run("create acceptable use policy role", func(t *testing.T) {
-		err := CreateUserAUPRole(user1.DeepCopy(), []metav1.OwnerReference{})
+		err := CreateUserAUPRole(context.TODO(), user1.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserAUPRole(user2.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateUserAUPRole(context.TODO(), user2.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserAUPRole(user3.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateUserAUPRole(context.TODO(), user3.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
 	})
 	t.Run("update acceptable use policy role", func(t *testing.T) {
-		err := CreateUserAUPRole(user1.DeepCopy(), []metav1.OwnerReference{})
+		err := CreateUserAUPRole(context.TODO(), user1.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserAUPRole(user2.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateUserAUPRole(context.TODO(), user2.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateUserAUPRole(user3.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateUserAUPRole(context.TODO(), user3.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
 	})
 	t.Run("create acceptable use policy role binding", func(t *testing.T) {
-		err := CreateAUPRoleBinding(user1.DeepCopy(), []metav1.OwnerReference{})
+		err := CreateAUPRoleBinding(context.TODO(), user1.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateAUPRoleBinding(user2.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateAUPRoleBinding(context.TODO(), user2.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateAUPRoleBinding(user3.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateAUPRoleBinding(context.TODO(), user3.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
 	})
 	t.Run("update acceptable use policy role binding", func(t *testing.T) {
-		err := CreateAUPRoleBinding(user1.DeepCopy(), []metav1.OwnerReference{})
+		err := CreateAUPRoleBinding(context.TODO(), user1.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateAUPRoleBinding(user2.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateAUPRoleBinding(context.TODO(), user2.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
-		err = CreateAUPRoleBinding(user3.DeepCopy(), []metav1.OwnerReference{})
+		err = CreateAUPRoleBinding(context.TODO(), user3.DeepCopy(), []metav1.OwnerReference{})
 		util.OK(t, err)
 	})
 
-	err = EstablishPrivateRoleBindings(user1.DeepCopy())
+	err = EstablishPrivateRoleBindings(context.TODO(), user1.DeepCopy())
 	util.OK(t, err)
-	err = EstablishRoleBindings(user1.DeepCopy(), g.namespace.GetName(), "Authority")
+	err = EstablishRoleBindings(context.TODO(), user1.DeepCopy(), g.namespace.GetName(), "Authority")
 	util.OK(t, err)
-	err = EstablishRoleBindings(user1.DeepCopy(), g.sliceNamespace.GetName(), "Slice")
+	err = EstablishRoleBindings(context.TODO(), user1.DeepCopy(), g.sliceNamespace.GetName(), "Slice")
 	util.OK(t, err)
-	err = EstablishRoleBindings(user1.DeepCopy(), g.teamNamespace.GetName(), "Team")
+	err = EstablishRoleBindings(context.TODO(), user1.DeepCopy(), g.teamNamespace.GetName(), "Team")
 	util.OK(t, err)
 
-	err = EstablishPrivateRoleBindings(user2.DeepCopy())
+	err = EstablishPrivateRoleBindings(context.TODO(), user2.DeepCopy())
 	util.OK(t, err)
-	err = EstablishRoleBindings(user2.DeepCopy(), g.namespace.GetName(), "Authority")
+	err = EstablishRoleBindings(context.TODO(), user2.DeepCopy(), g.namespace.GetName(), "Authority")
 	util.OK(t, err)
-	err = EstablishRoleBindings(user2.DeepCopy(), g.sliceNamespace.GetName(), "Slice")
+	err = EstablishRoleBindings(context.TODO(), user2.DeepCopy(), g.sliceNamespace.GetName(), "Slice")
 	util.OK(t, err)
-	err = EstablishRoleBindings(user2.DeepCopy(), g.teamNamespace.GetName(), "Team")
+	err = EstablishRoleBindings(context.TODO(), user2.DeepCopy(), g.teamNamespace.GetName(), "Team")
 	util.OK(t, err)
 	roleName := "workload-manager"
 	policyRule := []rbacv1.PolicyRule{{APIGroups: []string{"apps.edgenet.io"}, Resources: []string{"slices", "teams"}, Verbs: []string{"create", "update", "patch"}}}
@@ -370,9 +370,9 @@ func TestPermissionSystem(t *testing.T) {
 	_, err = g.client.RbacV1().RoleBindings(g.namespace.GetName()).Create(context.TODO(), roleBind, metav1.CreateOptions{})
 	util.OK(t, err)
 
-	err = EstablishPrivateRoleBindings(user3.DeepCopy())
+	err = EstablishPrivateRoleBindings(context.TODO(), user3.DeepCopy())
 	util.OK(t, err)
-	err = EstablishRoleBindings(user3.DeepCopy(), g.namespace.GetName(), "Authority")
+	err = EstablishRoleBindings(context.TODO(), user3.DeepCopy(), g.namespace.GetName(), "Authority")
 	util.OK(t, err)
 
 	cases := map[string]struct {
@@ -417,7 +417,7 @@ func TestPermissionSystem(t *testing.T) {
 	}
 	for k, tc := range cases {
 		t.Run(k, func(t *testing.T) {
-			authorized := CheckAuthorization(tc.namespace, tc.user.Spec.Email, tc.resource, tc.resourceName)
+			authorized := CheckAuthorization(context.TODO(), tc.namespace, tc.user.Spec.Email, tc.resource, tc.resourceName)
 			util.Equals(t, tc.expected, authorized)
 		})
 	}
@@ -57,7 +57,7 @@ var Clientset kubernetes.Interface
 var dir = "../.."
 
 // MakeUser generates key and certificate and then set user credentials into the config file.
-func MakeUser(authority, username, email string) ([]byte, []byte, error) {
+func MakeUser(ctx context.Context, authority, username, email string) ([]byte, []byte, error) {
 	// The code below inits dir
 	if flag.Lookup("dir") != nil {
 		dir = flag.Lookup("dir").Value.(flag.Getter).Get().(string)
@@ -102,7 +102,7 @@ func MakeUser(authority, username, email string) ([]byte, []byte, error) {
 	CSRObject.Spec.Usages = []certv1.KeyUsage{"client auth"}
 	CSRObject.Spec.Request = csr
 	CSRObject.Spec.SignerName = "kubernetes.io/kube-apiserver-client"
-	CSRCopy, err = Clientset.CertificatesV1().CertificateSigningRequests().Create(context.TODO(), &CSRObject, metav1.CreateOptions{})
+	CSRCopy, err = Clientset.CertificatesV1().CertificateSigningRequests().Create(ctx, &CSRObject, metav1.CreateOptions{})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -114,7 +114,7 @@ func MakeUser(authority, username, email string) ([]byte, []byte, error) {
 		LastUpdateTime: metav1.Now(),
 	})
 
-	_, err = Clientset.CertificatesV1().CertificateSigningRequests().UpdateApproval(context.TODO(), CSRCopy.GetName(), CSRCopy, metav1.UpdateOptions{})
+	_, err = Clientset.CertificatesV1().CertificateSigningRequests().UpdateApproval(ctx, CSRCopy.GetName(), CSRCopy, metav1.UpdateOptions{})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -126,7 +126,7 @@ check:
 		case <-timeout:
 			return nil, nil, err
 		case <-ticker:
-			CSRCopy, err = Clientset.CertificatesV1().CertificateSigningRequests().Get(context.TODO(), CSRCopy.GetName(), metav1.GetOptions{})
+			CSRCopy, err = Clientset.CertificatesV1().CertificateSigningRequests().Get(ctx, CSRCopy.GetName(), metav1.GetOptions{})
 			if err != nil {
 				return nil, nil, err
 			}
@@ -246,12 +246,37 @@ func MakeConfig(authority, username, email string, clientCert, clientKey []byte)
 	return nil
 }
 
+// RemoveUser deletes the certificate signing request, the key and certificate, and the kubeconfig file generated for the user
+func RemoveUser(ctx context.Context, authority, username, email string) error {
+	// The code below inits dir
+	if flag.Lookup("dir") != nil {
+		dir = flag.Lookup("dir").Value.(flag.Getter).Get().(string)
+	}
+	err := Clientset.CertificatesV1().CertificateSigningRequests().Delete(ctx, fmt.Sprintf("%s-%s", authority, username), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		log.Println(err)
+		return err
+	}
+	files := []string{
+		fmt.Sprintf("%s/assets/certs/%s.crt", dir, email),
+		fmt.Sprintf("%s/assets/certs/%s.key", dir, email),
+		fmt.Sprintf("%s/assets/kubeconfigs/%s-%s.cfg", dir, authority, username),
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			log.Println(err)
+			return err
+		}
+	}
+	return nil
+}
+
 // CreateServiceAccount makes a service account to serve for permanent jobs.
-func CreateServiceAccount(userCopy *apps_v1alpha.User, accountType string, ownerReferences []metav1.OwnerReference) (*corev1.ServiceAccount, error) {
+func CreateServiceAccount(ctx context.Context, userCopy *apps_v1alpha.User, accountType string, ownerReferences []metav1.OwnerReference) (*corev1.ServiceAccount, error) {
 	// Set the name of service account according to the type
 	name := userCopy.GetName()
 	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, OwnerReferences: ownerReferences}}
-	serviceAccountCreated, err := Clientset.CoreV1().ServiceAccounts(userCopy.GetNamespace()).Create(context.TODO(), serviceAccount, metav1.CreateOptions{})
+	serviceAccountCreated, err := Clientset.CoreV1().ServiceAccounts(userCopy.GetNamespace()).Create(ctx, serviceAccount, metav1.CreateOptions{})
 	if err != nil {
 		log.Println(err.Error())
 		return nil, err
@@ -262,7 +287,7 @@ func CreateServiceAccount(userCopy *apps_v1alpha.User, accountType string, owner
 // CreateConfig checks serviceaccount of the user and then it gets that secret to use CA and token information.
 // Subsequently, that reads cluster and server info of the current context from the config file to be consumed
 // on the creation of kubeconfig.
-func CreateConfig(serviceAccount *corev1.ServiceAccount) string {
+func CreateConfig(ctx context.Context, serviceAccount *corev1.ServiceAccount) string {
 	// To find out the secret name to use
 	accountSecretName := ""
 	for _, accountSecret := range serviceAccount.Secrets {
@@ -277,7 +302,7 @@ func CreateConfig(serviceAccount *corev1.ServiceAccount) string {
 		log.Printf("Serviceaccount %s in %s doesn't have a token", serviceAccount.GetName(), serviceAccount.GetNamespace())
 		return fmt.Sprintf("Serviceaccount %s doesn't have a token", serviceAccount.GetName())
 	}
-	secret, err := Clientset.CoreV1().Secrets(serviceAccount.GetNamespace()).Get(context.TODO(), accountSecretName, metav1.GetOptions{})
+	secret, err := Clientset.CoreV1().Secrets(serviceAccount.GetNamespace()).Get(ctx, accountSecretName, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		log.Printf("Secret for %s in %s not found", serviceAccount.GetName(), serviceAccount.GetNamespace())
 		return fmt.Sprintf("Secret %s not found", serviceAccount.GetName())
@@ -114,7 +114,7 @@ func TestKubeconfigWithUser(t *testing.T) {
 			}
 		}()
 
-		cert, key, err := MakeUser(g.authorityObj.GetName(), g.userObj.GetName(), g.userObj.Spec.Email)
+		cert, key, err := MakeUser(context.TODO(), g.authorityObj.GetName(), g.userObj.GetName(), g.userObj.Spec.Email)
 		util.OK(t, err)
 
 		t.Run("generate config", func(t *testing.T) {
@@ -128,11 +128,11 @@ func TestKubeconfigWithServiceAccount(t *testing.T) {
 	g := TestGroup{}
 	g.Init()
 	t.Run("create service account", func(t *testing.T) {
-		serviceAccount, err := CreateServiceAccount(g.userObj.DeepCopy(), "User", []metav1.OwnerReference{})
+		serviceAccount, err := CreateServiceAccount(context.TODO(), g.userObj.DeepCopy(), "User", []metav1.OwnerReference{})
 
 		util.OK(t, err)
 		t.Run("generate config without secret", func(t *testing.T) {
-			output := CreateConfig(serviceAccount)
+			output := CreateConfig(context.TODO(), serviceAccount)
 			util.Equals(t, fmt.Sprintf("Serviceaccount %s doesn't have a token", g.userObj.GetName()), output)
 		})
 	})
@@ -160,7 +160,7 @@ func TestKubeconfigWithServiceAccount(t *testing.T) {
 		}
 		_, err := g.client.CoreV1().Secrets(secret.Namespace).Create(context.TODO(), &secret, metav1.CreateOptions{})
 		util.OK(t, err)
-		output := CreateConfig(&serviceAccount)
+		output := CreateConfig(context.TODO(), &serviceAccount)
 
 		list := []string{
 			"certificate-authority-data",